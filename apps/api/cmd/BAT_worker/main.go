@@ -1,5 +1,22 @@
 package main
 
+import (
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/swamphacks/core/apps/api/internal/config"
+	"github.com/swamphacks/core/apps/api/internal/database"
+	"github.com/swamphacks/core/apps/api/internal/database/repository"
+	"github.com/swamphacks/core/apps/api/internal/domains/application"
+	"github.com/swamphacks/core/apps/api/internal/domains/bat"
+	"github.com/swamphacks/core/apps/api/internal/domains/decisions"
+	"github.com/swamphacks/core/apps/api/internal/domains/email"
+	"github.com/swamphacks/core/apps/api/internal/domains/teams"
+	"github.com/swamphacks/core/apps/api/internal/logger"
+	"github.com/swamphacks/core/apps/api/internal/tasks"
+	"github.com/swamphacks/core/apps/api/internal/workers"
+)
+
 /*
                  -.                       .-
               _..-'(                       )`-.._
@@ -15,73 +32,112 @@ V    V         V          }' `\ /' `{          V         V    V
 `    `         `               V               '         '    '
 
 	Entrypoint for the BAT worker which handles hackathon application review
-	and admissions tasks.
+	and admissions tasks, releases decisions, runs the rolling waitlist, and
+	expires unanswered team join requests.
 */
 
 func main() {
-	// logger := logger.New()
-	// cfg := config.LoadConfig()
-
-	// redisOpt, err := asynq.ParseRedisURI(cfg.RedisURL)
-	// if err != nil {
-	// 	logger.Fatal().Msg("failed to parse REDIS_URL")
-	// }
-
-	// srv := asynq.NewServer(
-	// 	redisOpt,
-	// 	asynq.Config{
-	// 		Concurrency: 1,
-	// 		Queues: map[string]int{
-	// 			"bat": 1,
-	// 		},
-	// 		TaskCheckInterval:        10 * time.Second,
-	// 		DelayedTaskCheckInterval: time.Minute,
-	// 		HealthCheckInterval:      2 * time.Minute,
-	// 		JanitorInterval:          time.Hour,
-	// 		JanitorBatchSize:         100,
-	// 	},
-	// )
-
-	// schedulerLocation, err := time.LoadLocation("America/New_York")
-	// if err != nil {
-	// 	panic(err)
-	// }
-	// scheduler := asynq.NewScheduler(
-	// 	redisOpt,
-	// 	&asynq.SchedulerOpts{
-	// 		Location: schedulerLocation,
-	// 	},
-	// )
-
-	// taskQueueClient := asynq.NewClient(redisOpt)
-	// defer taskQueueClient.Close()
-
-	// db := database.NewDB(cfg.DatabaseURL)
-	// defer db.Close()
-
-	// txm := database.NewTransactionManager(db)
-
-	// hackathonRepo := repository.NewHackathonRepository(db)
-	// applicationRepo := repository.NewApplicationRepository(db)
-	// userRepo := repository.NewUserRepository(db)
-	// batRunsRepo := repository.NewBatRunsRepository(db)
-	// eventInterestsRepo := repository.NewEventInterestsRepository(db)
-
-	// sesClient := emailutils.NewSESClient(cfg.AWS.AccessKey, cfg.AWS.AccessKeySecret, cfg.AWS.Region, logger)
-	// emailService := email.NewEmailService(hackathonRepo, userRepo, taskQueueClient, sesClient, nil, logger, cfg)
-	// batService := bat.NewBatService(applicationRepo, hackathonRepo, userRepo, batRunsRepo, emailService, txm, nil, scheduler, cfg, logger)
-	// applicationService := application.NewService(applicationRepo, userRepo, hackathonRepo, txm, nil, nil, scheduler, emailService, batService, cfg, logger)
-	// hackathonService := hackathon.NewService(hackathonRepo, userRepo, eventInterestsRepo, &storage.R2Client{}, nil, logger)
-
-	// BATWorker := workers.NewBATWorker(batService, applicationService, hackathonService, scheduler, taskQueueClient, cfg, logger)
-
-	// mux := asynq.NewServeMux()
-	// mux.HandleFunc(tasks.TypeCalculateAdmissions, BATWorker.HandleCalculateAdmissionsTask)
-	// mux.HandleFunc(tasks.TypeTransitionWaitlist, BATWorker.HandleTransitionWaitlistTask)
-	// mux.HandleFunc(tasks.TypeScheduleTransitionWaitlist, BATWorker.HandleScheduleTransitionWaitlistTask)
-	// mux.HandleFunc(tasks.TypeShutdownScheduler, BATWorker.HandleShutdownScheduler)
-
-	// if err := srv.Run(mux); err != nil {
-	// 	logger.Fatal().Msg("Failed to run BAT worker")
-	// }
+	logger := logger.New()
+	cfg := config.LoadConfig()
+
+	redisOpt, err := asynq.ParseRedisURI(cfg.RedisURL)
+	if err != nil {
+		logger.Fatal().Msg("failed to parse REDIS_URL")
+	}
+
+	srv := asynq.NewServer(
+		redisOpt,
+		asynq.Config{
+			Concurrency: 1,
+			Queues: map[string]int{
+				"bat":   1,
+				"teams": 1,
+			},
+			TaskCheckInterval:        10 * time.Second,
+			DelayedTaskCheckInterval: time.Minute,
+			HealthCheckInterval:      2 * time.Minute,
+			JanitorInterval:          time.Hour,
+			JanitorBatchSize:         100,
+		},
+	)
+
+	taskQueueClient := asynq.NewClient(redisOpt)
+	defer taskQueueClient.Close()
+
+	db := database.NewDB(cfg.DatabaseURL)
+	defer db.Close()
+
+	txm := database.NewTransactionManager(db)
+
+	batService := bat.NewService(db, txm, taskQueueClient, cfg, logger)
+
+	hackathonRepo := repository.NewHackathonRepository(db)
+	userRepo := repository.NewUserRepository(db)
+	emailDeliveryRepo := repository.NewEmailDeliveryRepository(db)
+	emailSuppressionRepo := repository.NewEmailSuppressionRepository(db)
+	emailPreferenceRepo := repository.NewEmailPreferenceRepository(db)
+	emailTemplateRepo := repository.NewEmailTemplateRepository(db)
+
+	// Emails are only queued from here, the email worker sends them.
+	emailService := email.NewEmailService(hackathonRepo, userRepo, emailDeliveryRepo, emailSuppressionRepo, emailPreferenceRepo, emailTemplateRepo, taskQueueClient, nil, nil, logger, cfg)
+
+	// The waitlist scheduler shuts itself down once the event starts, so it only runs
+	// the waitlist rounds.
+	scheduler := asynq.NewScheduler(redisOpt, nil)
+	defer scheduler.Shutdown()
+
+	applicationService := application.NewService(db, txm, nil, &cfg.CoreBuckets, scheduler, emailService, cfg, logger)
+
+	decisionService := decisions.NewService(db, txm, taskQueueClient, emailService, cfg, logger)
+
+	teamService := teams.NewService(db, txm, emailService, cfg, logger)
+
+	BATWorker := workers.NewBATWorker(batService, logger)
+	waitlistWorker := workers.NewWaitlistWorker(applicationService, logger)
+	decisionWorker := workers.NewDecisionWorker(decisionService, logger)
+	teamWorker := workers.NewTeamWorker(teamService, logger)
+
+	mux := asynq.NewServeMux()
+	mux.HandleFunc(tasks.TypeCalculateAdmissions, BATWorker.HandleCalculateAdmissionsTask)
+	mux.HandleFunc(tasks.TypeTransitionWaitlist, waitlistWorker.HandleTransitionWaitlistTask)
+	mux.HandleFunc(tasks.TypeApplyDecisionRelease, decisionWorker.HandleApplyDecisionReleaseTask)
+	mux.HandleFunc(tasks.TypeSendDecisionEmails, decisionWorker.HandleSendDecisionEmailsTask)
+	mux.HandleFunc(tasks.TypeExpireTeamJoinRequests, teamWorker.HandleExpireTeamJoinRequestsTask)
+
+	if cfg.AcceptFromWaitlistPeriod != "" && cfg.AcceptFromWaitlistCount > 0 {
+		task, err := tasks.NewTaskTransitionWaitlist(tasks.TransitionWaitlistPayload{
+			AcceptFromWaitlistCount: cfg.AcceptFromWaitlistCount,
+			MaxAcceptedApplications: cfg.MaxAcceptedApplications,
+		})
+		if err != nil {
+			logger.Fatal().Err(err).Msg("Failed to create waitlist transition task")
+		}
+
+		// Unique keeps replicas of this worker from enqueuing the same round twice.
+		if _, err := scheduler.Register(cfg.AcceptFromWaitlistPeriod, task, asynq.Queue("bat"), asynq.Unique(time.Minute)); err != nil {
+			logger.Fatal().Err(err).Str("period", cfg.AcceptFromWaitlistPeriod).Msg("Failed to schedule waitlist transitions")
+		}
+
+		if err := scheduler.Start(); err != nil {
+			logger.Fatal().Err(err).Msg("Failed to start waitlist scheduler")
+		}
+	}
+
+	// Join requests keep expiring during the event, after the waitlist scheduler stopped.
+	teamScheduler := asynq.NewScheduler(redisOpt, nil)
+	defer teamScheduler.Shutdown()
+
+	if cfg.TeamJoinRequestExpiryPeriod != "" {
+		if _, err := teamScheduler.Register(cfg.TeamJoinRequestExpiryPeriod, tasks.NewTaskExpireTeamJoinRequests(), asynq.Queue("teams"), asynq.Unique(time.Minute)); err != nil {
+			logger.Fatal().Err(err).Str("period", cfg.TeamJoinRequestExpiryPeriod).Msg("Failed to schedule team join request expiry")
+		}
+
+		if err := teamScheduler.Start(); err != nil {
+			logger.Fatal().Err(err).Msg("Failed to start team scheduler")
+		}
+	}
+
+	if err := srv.Run(mux); err != nil {
+		logger.Fatal().Msg("Failed to run BAT worker")
+	}
 }
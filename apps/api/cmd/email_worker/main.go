@@ -25,6 +25,32 @@ func main() {
 		logger.Fatal().Msg("Failed to parse REDIS_URL")
 	}
 
+	taskQueueClient := asynq.NewClient(redisOpt)
+	defer taskQueueClient.Close()
+
+	db := database.NewDB(cfg.DatabaseURL)
+	defer db.Close()
+
+	hackathonRepo := repository.NewHackathonRepository(db)
+	userRepo := repository.NewUserRepository(db)
+	emailCampaignRepo := repository.NewEmailCampaignRepository(db)
+	emailDeliveryRepo := repository.NewEmailDeliveryRepository(db)
+	emailSuppressionRepo := repository.NewEmailSuppressionRepository(db)
+	emailPreferenceRepo := repository.NewEmailPreferenceRepository(db)
+	emailTemplateRepo := repository.NewEmailTemplateRepository(db)
+
+	// SES, SMTP or local capture, depending on EMAIL_BACKEND
+	sender, err := emailutils.NewEmailSender(cfg, logger)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to create email sender")
+	}
+
+	emailService := email.NewEmailService(hackathonRepo, userRepo, emailDeliveryRepo, emailSuppressionRepo, emailPreferenceRepo, emailTemplateRepo, taskQueueClient, sender, nil, logger, cfg)
+	emailWorker := workers.NewEmailWorker(emailService, logger)
+
+	emailCampaignService := email.NewEmailCampaignService(emailCampaignRepo, emailDeliveryRepo, emailSuppressionRepo, emailPreferenceRepo, taskQueueClient, sender, cfg, logger)
+	campaignWorker := workers.NewCampaignWorker(emailCampaignService, logger)
+
 	srv := asynq.NewServer(
 		redisOpt,
 		asynq.Config{
@@ -37,27 +63,33 @@ func main() {
 			HealthCheckInterval:      2 * time.Minute,
 			JanitorInterval:          time.Hour,
 			JanitorBatchSize:         100,
+			// Failed tasks are archived after their last retry instead of dropped.
+			ErrorHandler: asynq.ErrorHandlerFunc(emailWorker.HandleError),
 		},
 	)
 
-	taskQueueClient := asynq.NewClient(redisOpt)
-	defer taskQueueClient.Close()
-
-	db := database.NewDB(cfg.DatabaseURL)
-	defer db.Close()
-
-	hackathonRepo := repository.NewHackathonRepository(db)
-	userRepo := repository.NewUserRepository(db)
+	mux := asynq.NewServeMux()
 
-	// Create ses client
-	sesClient := emailutils.NewSESClient(cfg.AWS.AccessKey, cfg.AWS.AccessKeySecret, cfg.AWS.Region, logger)
+	mux.HandleFunc(tasks.TypeSendTextEmail, emailWorker.HandleSendTextEmailTask)
+	mux.HandleFunc(tasks.TypeSendHtmlEmail, emailWorker.HandleSendHtmlEmailTask)
+	mux.HandleFunc(tasks.TypeSendCampaign, campaignWorker.HandleSendCampaignTask)
+	mux.HandleFunc(tasks.TypeSendCampaignBatch, campaignWorker.HandleSendCampaignBatchTask)
+	mux.HandleFunc(tasks.TypeDispatchCampaigns, campaignWorker.HandleDispatchCampaignsTask)
 
-	emailService := email.NewEmailService(hackathonRepo, userRepo, taskQueueClient, sesClient, nil, logger, cfg)
-	emailWorker := workers.NewEmailWorker(emailService, logger)
+	scheduler := asynq.NewScheduler(redisOpt, nil)
+	defer scheduler.Shutdown()
 
-	mux := asynq.NewServeMux()
+	if cfg.CampaignDispatchPeriod != "" {
+		// Unique keeps replicas of this worker from enqueuing the same dispatch twice. Claiming
+		// due campaigns is safe to run concurrently either way.
+		if _, err := scheduler.Register(cfg.CampaignDispatchPeriod, tasks.NewTaskDispatchCampaigns(), asynq.Queue("email"), asynq.Unique(time.Minute)); err != nil {
+			logger.Fatal().Err(err).Str("period", cfg.CampaignDispatchPeriod).Msg("Failed to schedule email campaign dispatches")
+		}
 
-	mux.HandleFunc(tasks.TypeSendHtmlEmail, emailWorker.HandleSendHtmlEmailTask)
+		if err := scheduler.Start(); err != nil {
+			logger.Fatal().Err(err).Msg("Failed to start email campaign scheduler")
+		}
+	}
 
 	wd, err := os.Getwd()
 	if err != nil {
@@ -19,6 +19,8 @@ import (
 	"github.com/swamphacks/core/apps/api/internal/database/repository"
 	"github.com/swamphacks/core/apps/api/internal/domains/application"
 	"github.com/swamphacks/core/apps/api/internal/domains/auth"
+	"github.com/swamphacks/core/apps/api/internal/domains/bat"
+	"github.com/swamphacks/core/apps/api/internal/domains/decisions"
 	"github.com/swamphacks/core/apps/api/internal/domains/email"
 	"github.com/swamphacks/core/apps/api/internal/domains/hackathon"
 	"github.com/swamphacks/core/apps/api/internal/domains/redeemables"
@@ -50,15 +52,13 @@ func Run() {
 		},
 	}
 
-	// Create SES Client for email service
-	sesClient := emailutils.NewSESClient(config.AWS.AccessKey, config.AWS.AccessKeySecret, config.AWS.Region, logger)
-
 	// Create asynq client
 	redisOpt, err := asynq.ParseRedisURI(config.RedisURL)
 	if err != nil {
 		logger.Fatal().Msg("Failed to parse REDIS_URL")
 	}
 	taskQueueClient := asynq.NewClient(redisOpt)
+	taskInspector := asynq.NewInspector(redisOpt)
 
 	r2Client, err := storage.NewR2Client(config.CF.AccountID, config.CF.AccessKeyId, config.CF.AccessKeySecret, logger)
 	if err != nil {
@@ -114,6 +114,10 @@ func Run() {
 	eventInterestsRepo := repository.NewEventInterestsRepository(db)
 	workshopRepo := repository.NewWorkshopsRepository(db)
 	emailCampaignRepo := repository.NewEmailCampaignRepository(db)
+	emailDeliveryRepo := repository.NewEmailDeliveryRepository(db)
+	emailSuppressionRepo := repository.NewEmailSuppressionRepository(db)
+	emailPreferenceRepo := repository.NewEmailPreferenceRepository(db)
+	emailTemplateRepo := repository.NewEmailTemplateRepository(db)
 
 	mw := mw.NewMiddleware(userRepo, db, logger, config)
 
@@ -130,22 +134,47 @@ func Run() {
 	hackathonHandler := hackathon.NewHandler(hackathonService, config, logger)
 	hackathon.RegisterRoutes(hackathonHandler, huma.NewGroup(api, "/hackathon"), mw)
 
-	emailService := email.NewEmailService(hackathonRepo, userRepo, taskQueueClient, sesClient, r2Client, logger, config)
+	// Emails are only queued from here, the email worker sends them.
+	emailService := email.NewEmailService(hackathonRepo, userRepo, emailDeliveryRepo, emailSuppressionRepo, emailPreferenceRepo, emailTemplateRepo, taskQueueClient, nil, r2Client, logger, config)
 	emailHandler := email.NewHandler(emailService, logger)
 	email.RegisterRoutes(emailHandler, huma.NewGroup(api, "/email"), mw)
 
-	emailCampaignService := email.NewEmailCampaignService(emailCampaignRepo, logger)
+	emailCampaignService := email.NewEmailCampaignService(emailCampaignRepo, emailDeliveryRepo, emailSuppressionRepo, emailPreferenceRepo, taskQueueClient, nil, config, logger)
 	emailCampaignHandler := email.NewCampaignHandler(emailCampaignService, logger)
 	email.RegisterCampaignRoutes(emailCampaignHandler, huma.NewGroup(api, "/email"), mw)
 
-	// batService := bat.NewBatService(applicationRepo, hackathonRepo, userRepo, batRunsRepo, emailService, txm, taskQueueClient, nil, config, logger)
+	emailTemplateService := email.NewEmailTemplateService(emailTemplateRepo, userRepo, txm, config, logger)
+	emailTemplateHandler := email.NewTemplateHandler(emailTemplateService, logger)
+	email.RegisterTemplateRoutes(emailTemplateHandler, huma.NewGroup(api, "/email"), mw)
+
+	emailTaskService := email.NewEmailTaskService(taskInspector, logger)
+	emailTaskHandler := email.NewTaskHandler(emailTaskService, logger)
+	email.RegisterTaskRoutes(emailTaskHandler, huma.NewGroup(api, "/email"), mw)
+
+	emailPreferenceService := email.NewEmailPreferenceService(emailPreferenceRepo, userRepo, txm, config, logger)
+	emailPreferenceHandler := email.NewPreferenceHandler(emailPreferenceService, logger)
+	email.RegisterPreferenceRoutes(emailPreferenceHandler, huma.NewGroup(api, "/email"), mw)
+
+	notificationService := email.NewNotificationService(emailDeliveryRepo, emailSuppressionRepo, emailutils.NewSNSVerifier(httpClient), config, logger)
+	notificationHandler := email.NewNotificationHandler(notificationService, logger)
+	email.RegisterNotificationRoutes(notificationHandler, huma.NewGroup(api, "/email"))
+
+	batService := bat.NewService(db, txm, taskQueueClient, config, logger)
+	batHandler := bat.NewHandler(batService, logger)
+	bat.RegisterRoutes(batHandler, huma.NewGroup(api, "/bat"), mw)
+
+	decisionService := decisions.NewService(db, txm, taskQueueClient, emailService, config, logger)
+	decisionHandler := decisions.NewHandler(decisionService, logger)
+	decisions.RegisterRoutes(decisionHandler, huma.NewGroup(api, "/decisions"), mw)
+
 	applicationService := application.NewService(db, txm, r2Client, &config.CoreBuckets, nil, emailService, config, logger)
 	applicationHandler := application.NewHandler(applicationService, config, logger)
 	application.RegisterRoutes(applicationHandler, huma.NewGroup(api, "/application"), mw)
 
-	teamService := teams.NewService(db, txm, logger)
+	teamService := teams.NewService(db, txm, emailService, config, logger)
 	teamHandler := teams.NewHandler(teamService, logger)
 	teams.RegisterRoutes(teamHandler, huma.NewGroup(api, "/team"), mw)
+	teams.RegisterAdminRoutes(teamHandler, huma.NewGroup(api, "/team"), mw)
 
 	redeemablesService := redeemables.NewService(redeemablesRepo, logger)
 	redeemablesHandler := redeemables.NewHandler(redeemablesService, config, logger)
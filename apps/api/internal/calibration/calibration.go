@@ -0,0 +1,292 @@
+// Package calibration corrects application review ratings for reviewer bias.
+// Harsh and lenient reviewers rate on their own curve; calibration compares every
+// reviewer's ratings to the pooled ratings of all reviewers and maps them back onto
+// the pooled scale, so that a reviewer's "3" means the same as everyone else's.
+package calibration
+
+import (
+	"errors"
+	"math"
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+var ErrUnknownMethod = errors.New("unknown score normalization method")
+
+type Method string
+
+const (
+	// MethodNone uses the raw ratings.
+	MethodNone Method = "none"
+
+	// MethodZScore shifts and scales every reviewer's ratings to the pooled mean
+	// and standard deviation.
+	MethodZScore Method = "zscore"
+
+	// MethodPercentile replaces every rating with the pooled rating at the same
+	// percentile of the reviewer's own ratings.
+	MethodPercentile Method = "percentile"
+)
+
+const (
+	// MinReviews is how many completed reviews a reviewer needs before their
+	// ratings are calibrated or they can be flagged. Fewer say too little about
+	// how they rate.
+	MinReviews = 5
+
+	// OutlierBias is how many pooled standard deviations a reviewer's mean rating
+	// may be away from the pooled mean before they are flagged as an outlier.
+	OutlierBias = 1.0
+
+	// FlatSpread flags reviewers whose ratings spread less than this fraction of the
+	// pooled standard deviation, i.e. who give nearly everyone the same rating.
+	FlatSpread = 0.25
+)
+
+// Validate accepts the known methods. An empty method means MethodNone, which
+// keeps configs stored before normalization existed valid.
+func (m Method) Validate() error {
+	switch m {
+	case "", MethodNone, MethodZScore, MethodPercentile:
+		return nil
+	}
+
+	return ErrUnknownMethod
+}
+
+// Rating is a single completed review.
+type Rating struct {
+	ApplicationID uuid.UUID
+	ReviewerID    uuid.UUID
+	Passion       float64
+	Experience    float64
+}
+
+// Distribution summarizes a set of ratings.
+type Distribution struct {
+	Mean   float64 `json:"mean"`
+	StdDev float64 `json:"stdDev"`
+}
+
+// ReviewerStats describes how a reviewer rates compared to everyone else.
+type ReviewerStats struct {
+	ReviewerID uuid.UUID
+	Reviews    int
+	Passion    Distribution
+	Experience Distribution
+
+	// PassionBias and ExperienceBias are how far the reviewer's mean rating is from
+	// the pooled mean, in pooled standard deviations. Negative means harsh.
+	PassionBias    float64
+	ExperienceBias float64
+
+	// Outlier is set when the reviewer rates noticeably harsher, more leniently or
+	// flatter than everyone else.
+	Outlier bool
+}
+
+// Averages are the mean ratings of a single application.
+type Averages struct {
+	Passion    float64
+	Experience float64
+}
+
+// Stats returns the pooled distribution of all ratings and the stats of every reviewer,
+// in order of their first rating.
+func Stats(ratings []Rating) (pooledPassion, pooledExperience Distribution, reviewers []ReviewerStats) {
+	pooledPassion = distribution(passions(ratings))
+	pooledExperience = distribution(experiences(ratings))
+
+	groups := byReviewer(ratings)
+	seen := make(map[uuid.UUID]bool, len(groups))
+
+	for _, rating := range ratings {
+		if seen[rating.ReviewerID] {
+			continue
+		}
+		seen[rating.ReviewerID] = true
+
+		reviewerRatings := groups[rating.ReviewerID]
+		stats := ReviewerStats{
+			ReviewerID: reviewerRatings[0].ReviewerID,
+			Reviews:    len(reviewerRatings),
+			Passion:    distribution(passions(reviewerRatings)),
+			Experience: distribution(experiences(reviewerRatings)),
+		}
+
+		stats.PassionBias = bias(stats.Passion, pooledPassion)
+		stats.ExperienceBias = bias(stats.Experience, pooledExperience)
+
+		stats.Outlier = stats.Reviews >= MinReviews &&
+			(math.Abs(stats.PassionBias) > OutlierBias ||
+				math.Abs(stats.ExperienceBias) > OutlierBias ||
+				flat(stats.Passion, pooledPassion) ||
+				flat(stats.Experience, pooledExperience))
+
+		reviewers = append(reviewers, stats)
+	}
+
+	return pooledPassion, pooledExperience, reviewers
+}
+
+// Normalize returns the ratings adjusted with the given method. Adjusted ratings stay
+// within the range of the pooled ratings. Ratings of reviewers with fewer than
+// MinReviews reviews are left as they are.
+func Normalize(ratings []Rating, method Method) []Rating {
+	if method == "" || method == MethodNone || len(ratings) == 0 {
+		return slices.Clone(ratings)
+	}
+
+	pooledPassion := passions(ratings)
+	pooledExperience := experiences(ratings)
+	slices.Sort(pooledPassion)
+	slices.Sort(pooledExperience)
+
+	adjust := func(value float64, own, pooled []float64) float64 {
+		var adjusted float64
+
+		switch method {
+		case MethodZScore:
+			ownDist, pooledDist := distribution(own), distribution(pooled)
+			z := 0.0
+			if ownDist.StdDev > 0 {
+				z = (value - ownDist.Mean) / ownDist.StdDev
+			}
+			adjusted = pooledDist.Mean + z*pooledDist.StdDev
+		case MethodPercentile:
+			adjusted = quantile(pooled, percentile(own, value))
+		default:
+			return value
+		}
+
+		return math.Min(math.Max(adjusted, pooled[0]), pooled[len(pooled)-1])
+	}
+
+	reviewerRatings := byReviewer(ratings)
+	normalized := make([]Rating, len(ratings))
+
+	for i, rating := range ratings {
+		normalized[i] = rating
+
+		own := reviewerRatings[rating.ReviewerID]
+		if len(own) < MinReviews {
+			continue
+		}
+
+		normalized[i].Passion = adjust(rating.Passion, passions(own), pooledPassion)
+		normalized[i].Experience = adjust(rating.Experience, experiences(own), pooledExperience)
+	}
+
+	return normalized
+}
+
+// AverageByApplication averages the ratings of every application.
+func AverageByApplication(ratings []Rating) map[uuid.UUID]Averages {
+	sums := make(map[uuid.UUID]Averages)
+	counts := make(map[uuid.UUID]int)
+
+	for _, rating := range ratings {
+		sum := sums[rating.ApplicationID]
+		sum.Passion += rating.Passion
+		sum.Experience += rating.Experience
+		sums[rating.ApplicationID] = sum
+		counts[rating.ApplicationID]++
+	}
+
+	averages := make(map[uuid.UUID]Averages, len(sums))
+	for applicationID, sum := range sums {
+		n := float64(counts[applicationID])
+		averages[applicationID] = Averages{Passion: sum.Passion / n, Experience: sum.Experience / n}
+	}
+
+	return averages
+}
+
+// byReviewer groups ratings by reviewer, keeping each reviewer's ratings in order.
+func byReviewer(ratings []Rating) map[uuid.UUID][]Rating {
+	groups := make(map[uuid.UUID][]Rating)
+	for _, rating := range ratings {
+		groups[rating.ReviewerID] = append(groups[rating.ReviewerID], rating)
+	}
+
+	return groups
+}
+
+func passions(ratings []Rating) []float64 {
+	values := make([]float64, len(ratings))
+	for i, rating := range ratings {
+		values[i] = rating.Passion
+	}
+	return values
+}
+
+func experiences(ratings []Rating) []float64 {
+	values := make([]float64, len(ratings))
+	for i, rating := range ratings {
+		values[i] = rating.Experience
+	}
+	return values
+}
+
+// distribution computes the mean and population standard deviation of the values.
+func distribution(values []float64) Distribution {
+	if len(values) == 0 {
+		return Distribution{}
+	}
+
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	mean := sum / float64(len(values))
+
+	var squares float64
+	for _, v := range values {
+		squares += (v - mean) * (v - mean)
+	}
+
+	return Distribution{Mean: mean, StdDev: math.Sqrt(squares / float64(len(values)))}
+}
+
+func bias(own, pooled Distribution) float64 {
+	if pooled.StdDev == 0 {
+		return 0
+	}
+	return (own.Mean - pooled.Mean) / pooled.StdDev
+}
+
+func flat(own, pooled Distribution) bool {
+	return pooled.StdDev > 0 && own.StdDev < FlatSpread*pooled.StdDev
+}
+
+// percentile returns the mid-rank of value among values, between 0 and 1. Ties
+// share the middle of their ranks so a reviewer who gives everyone the same
+// rating lands on the median.
+func percentile(values []float64, value float64) float64 {
+	below, equal := 0, 0
+	for _, v := range values {
+		switch {
+		case v < value:
+			below++
+		case v == value:
+			equal++
+		}
+	}
+
+	return (float64(below) + float64(equal)/2) / float64(len(values))
+}
+
+// quantile returns the value at fraction p of the sorted values, interpolating
+// between neighbours.
+func quantile(sorted []float64, p float64) float64 {
+	if len(sorted) == 1 {
+		return sorted[0]
+	}
+
+	position := p * float64(len(sorted)-1)
+	lower := int(math.Floor(position))
+	upper := min(lower+1, len(sorted)-1)
+
+	return sorted[lower] + (position-float64(lower))*(sorted[upper]-sorted[lower])
+}
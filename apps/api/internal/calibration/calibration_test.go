@@ -0,0 +1,130 @@
+package calibration
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// reviewer rates one application per passion rating, with the experience rating
+// shifted by offset.
+func reviewer(id uuid.UUID, offset float64, passions ...float64) []Rating {
+	ratings := make([]Rating, len(passions))
+	for i, passion := range passions {
+		ratings[i] = Rating{
+			ApplicationID: uuid.New(),
+			ReviewerID:    id,
+			Passion:       passion,
+			Experience:    passion + offset,
+		}
+	}
+	return ratings
+}
+
+func TestStats(t *testing.T) {
+	harsh, lenient, fair, flat, newcomer := uuid.New(), uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+	var ratings []Rating
+	ratings = append(ratings, reviewer(fair, 0, 1, 2, 3, 4, 5)...)
+	ratings = append(ratings, reviewer(fair, 0, 1, 2, 3, 4, 5)...)
+	ratings = append(ratings, reviewer(harsh, 0, 1, 1, 1, 2, 1)...)
+	ratings = append(ratings, reviewer(lenient, 0, 5, 5, 4, 5, 5)...)
+	ratings = append(ratings, reviewer(flat, 0, 3, 3, 3, 3, 3)...)
+	ratings = append(ratings, reviewer(newcomer, 0, 1, 1)...)
+
+	_, _, stats := Stats(ratings)
+
+	expected := map[uuid.UUID]bool{
+		fair:     false,
+		harsh:    true,
+		lenient:  true,
+		flat:     true,
+		newcomer: false,
+	}
+
+	if len(stats) != len(expected) {
+		t.Fatalf("expected stats for %d reviewers, got %d", len(expected), len(stats))
+	}
+	if stats[0].ReviewerID != fair || stats[0].Reviews != 10 {
+		t.Errorf("expected the fair reviewer first with 10 reviews, got %v with %d", stats[0].ReviewerID, stats[0].Reviews)
+	}
+
+	for _, reviewerStats := range stats {
+		if reviewerStats.Outlier != expected[reviewerStats.ReviewerID] {
+			t.Errorf("reviewer with mean %.2f and spread %.2f: expected outlier %v, got %v",
+				reviewerStats.Passion.Mean, reviewerStats.Passion.StdDev, expected[reviewerStats.ReviewerID], reviewerStats.Outlier)
+		}
+		if reviewerStats.ReviewerID == harsh && reviewerStats.PassionBias >= 0 {
+			t.Errorf("expected the harsh reviewer to have a negative bias, got %.2f", reviewerStats.PassionBias)
+		}
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	harsh, lenient, newcomer := uuid.New(), uuid.New(), uuid.New()
+
+	var ratings []Rating
+	ratings = append(ratings, reviewer(harsh, 0, 1, 1, 2, 2, 3)...)
+	ratings = append(ratings, reviewer(lenient, 0, 3, 3, 4, 4, 5)...)
+	ratings = append(ratings, reviewer(newcomer, 0, 2)...)
+
+	tests := []struct {
+		name   string
+		method Method
+	}{
+		{name: "z-score", method: MethodZScore},
+		{name: "percentile", method: MethodPercentile},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			normalized := Normalize(ratings, tt.method)
+
+			if len(normalized) != len(ratings) {
+				t.Fatalf("expected %d ratings, got %d", len(ratings), len(normalized))
+			}
+
+			// The harsh reviewer's best rating and the lenient reviewer's best rating
+			// mean the same once calibrated.
+			if math.Abs(normalized[4].Passion-normalized[9].Passion) > 1e-9 {
+				t.Errorf("expected the top ratings to match, got %.2f and %.2f", normalized[4].Passion, normalized[9].Passion)
+			}
+
+			for i, rating := range normalized {
+				if rating.Passion < 1 || rating.Passion > 5 {
+					t.Errorf("rating %d: %.2f is outside the pooled range", i, rating.Passion)
+				}
+				if rating.Passion != rating.Experience {
+					t.Errorf("rating %d: expected both ratings to be adjusted alike, got %.2f and %.2f", i, rating.Passion, rating.Experience)
+				}
+			}
+
+			if newcomerRating := normalized[10]; newcomerRating.Passion != 2 {
+				t.Errorf("expected a reviewer with too few reviews to keep their rating, got %.2f", newcomerRating.Passion)
+			}
+		})
+	}
+
+	t.Run("none", func(t *testing.T) {
+		normalized := Normalize(ratings, MethodNone)
+		for i := range ratings {
+			if normalized[i] != ratings[i] {
+				t.Errorf("rating %d: expected raw rating %v, got %v", i, ratings[i], normalized[i])
+			}
+		}
+	})
+}
+
+func TestAverageByApplication(t *testing.T) {
+	application := uuid.New()
+	ratings := []Rating{
+		{ApplicationID: application, ReviewerID: uuid.New(), Passion: 2, Experience: 5},
+		{ApplicationID: application, ReviewerID: uuid.New(), Passion: 4, Experience: 2},
+	}
+
+	averages := AverageByApplication(ratings)[application]
+	if averages.Passion != 3 || averages.Experience != 3.5 {
+		t.Errorf("expected averages 3 and 3.5, got %.2f and %.2f", averages.Passion, averages.Experience)
+	}
+}
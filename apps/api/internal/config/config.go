@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"strings"
+	"time"
 
 	"github.com/caarlos0/env/v11"
 	"github.com/joho/godotenv"
@@ -32,7 +33,8 @@ type CloudflareConfig struct {
 	AccessKeySecret string `env:"ACCESS_KEY_SECRET"`
 }
 
-// TODO: deprecate.
+// SmtpConfig is the server the smtp email backend sends through. Username can be left
+// empty for servers without auth, like MailHog.
 type SmtpConfig struct {
 	Username    string `env:"USERNAME"`
 	Password    string `env:"PASSWORD"`
@@ -45,6 +47,11 @@ type AWSConfig struct {
 	AccessKey       string `env:"ACCESS_KEY"`
 	AccessKeySecret string `env:"ACCESS_KEY_SECRET"`
 	Region          string `env:"REGION"`
+
+	// SNSTopicArn is the topic SES publishes bounces and complaints to. It is required
+	// to process them, notifications from any other topic, or from any topic when it
+	// isn't set, are rejected.
+	SNSTopicArn string `env:"SNS_TOPIC_ARN"`
 }
 
 type CoreBuckets struct {
@@ -69,9 +76,44 @@ type Config struct {
 	AcceptFromWaitlistCount  uint32   `env:"ACCEPT_FROM_WAITLIST_COUNT"`
 	AcceptFromWaitlistPeriod string   `env:"ACCEPT_FROM_WAITLIST_PERIOD"`
 
+	// WaitlistRsvpWindow is how long an applicant accepted off the waitlist
+	// has to confirm before the seat goes back to the waitlist.
+	WaitlistRsvpWindow time.Duration `env:"WAITLIST_RSVP_WINDOW" envDefault:"72h"`
+
+	// DecisionEmailDelay is how long after a decision release is applied its
+	// emails go out. The release can be rolled back until then.
+	DecisionEmailDelay time.Duration `env:"DECISION_EMAIL_DELAY" envDefault:"30m"`
+
+	// CampaignDispatchPeriod is how often the email worker looks for scheduled
+	// email campaigns that are due.
+	CampaignDispatchPeriod string `env:"CAMPAIGN_DISPATCH_PERIOD" envDefault:"@every 1m"`
+
+	// TeamJoinRequestTTL is how long a team owner has to answer a join request before it
+	// expires. TeamJoinRequestExpiryPeriod is how often the BAT worker expires them.
+	TeamJoinRequestTTL          time.Duration `env:"TEAM_JOIN_REQUEST_TTL" envDefault:"72h"`
+	TeamJoinRequestExpiryPeriod string        `env:"TEAM_JOIN_REQUEST_EXPIRY_PERIOD" envDefault:"@every 1h"`
+
+	// EmailTaskRetention is how long sent emails stay in the task queue. Queueing the same
+	// email again within it is rejected as a duplicate.
+	EmailTaskRetention time.Duration `env:"EMAIL_TASK_RETENTION" envDefault:"168h"`
+
+	// EmailBackend picks how emails are sent: "ses", "smtp" or "capture". The capture
+	// backend keeps emails instead of sending them and writes them to
+	// EmailCaptureDirectory as .eml files when it is set.
+	EmailBackend          string `env:"EMAIL_BACKEND" envDefault:"ses"`
+	EmailCaptureDirectory string `env:"EMAIL_CAPTURE_DIRECTORY"`
+	// EmailUnsubscribeAddress gets mailto: unsubscribe requests from the List-Unsubscribe
+	// header of emails people can unsubscribe from.
+	EmailUnsubscribeAddress string `env:"EMAIL_UNSUBSCRIBE_ADDRESS"`
+	// EmailUnsubscribeSecret signs the tokens of unsubscribe links. Without it emails
+	// have no one-click unsubscribe link.
+	EmailUnsubscribeSecret string `env:"EMAIL_UNSUBSCRIBE_SECRET"`
+
 	Auth      AuthConfig   `envPrefix:"AUTH_"`
 	Cookie    CookieConfig `envPrefix:"COOKIE_"`
 	ClientUrl string       `env:"CLIENT_URL"`
+	// ApiUrl is where the API is reachable from outside, used for links in emails.
+	ApiUrl string `env:"API_URL"`
 
 	CF          CloudflareConfig `envPrefix:"CF_"`
 	CoreBuckets CoreBuckets      `envPrefix:"CORE_BUCKETS_"`
@@ -42,6 +42,13 @@ func IsUniqueViolation(err error) bool {
 	return false
 }
 
+func IsForeignKeyViolation(err error) bool {
+	if pgErr, ok := errors.AsType[*pgconn.PgError](err); ok {
+		return pgErr.Code == "23503"
+	}
+	return false
+}
+
 func IsNotFound(err error) bool {
 	return errors.Is(err, pgx.ErrNoRows)
 }
@@ -0,0 +1,29 @@
+-- +goose Up
+create table bat_configs (
+    hackathon_id text not null primary key references hackathons(id) on delete cascade,
+
+    passion_weight double precision not null default 0.6,
+    experience_weight double precision not null default 0.4,
+    weighted_base_constant double precision not null default 0.1,
+
+    team_slots integer not null default 50,
+    uf_early_quota integer not null default 210,
+    uf_late_quota integer not null default 140,
+    other_early_quota integer not null default 90,
+    other_late_quota integer not null default 60,
+
+    created_at timestamptz not null default now(),
+    updated_at timestamptz not null default now(),
+
+    check (passion_weight >= 0 and experience_weight >= 0),
+    check (team_slots >= 0 and uf_early_quota >= 0 and uf_late_quota >= 0 and other_early_quota >= 0 and other_late_quota >= 0)
+);
+
+create trigger bat_configs_updated_at
+before update on bat_configs
+for each row
+execute function update_modified_column();
+
+-- +goose Down
+drop trigger if exists bat_configs_updated_at on bat_configs;
+drop table bat_configs;
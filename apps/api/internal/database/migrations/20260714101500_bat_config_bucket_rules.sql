@@ -0,0 +1,43 @@
+-- +goose Up
+alter table bat_configs add column buckets jsonb not null default '[]'::jsonb;
+
+-- Carry over the fixed UF / other and early / late career quotas as bucket rules.
+update bat_configs set buckets = jsonb_build_array(
+    jsonb_build_object(
+        'name', 'uf_early', 'quota', uf_early_quota, 'rollover', 'uf_late',
+        'conditions', jsonb_build_array(
+            jsonb_build_object('field', 'school', 'operator', 'in', 'values', jsonb_build_array('University of Florida')),
+            jsonb_build_object('field', 'year', 'operator', 'in', 'values', jsonb_build_array('first_year', 'second_year'))
+        )
+    ),
+    jsonb_build_object(
+        'name', 'uf_late', 'quota', uf_late_quota, 'rollover', 'uf_early',
+        'conditions', jsonb_build_array(
+            jsonb_build_object('field', 'school', 'operator', 'in', 'values', jsonb_build_array('University of Florida'))
+        )
+    ),
+    jsonb_build_object(
+        'name', 'other_early', 'quota', other_early_quota, 'rollover', 'other_late',
+        'conditions', jsonb_build_array(
+            jsonb_build_object('field', 'year', 'operator', 'in', 'values', jsonb_build_array('first_year', 'second_year'))
+        )
+    ),
+    jsonb_build_object(
+        'name', 'other_late', 'quota', other_late_quota, 'rollover', 'other_early',
+        'conditions', '[]'::jsonb
+    )
+);
+
+alter table bat_configs drop column uf_early_quota;
+alter table bat_configs drop column uf_late_quota;
+alter table bat_configs drop column other_early_quota;
+alter table bat_configs drop column other_late_quota;
+alter table bat_configs add constraint bat_configs_team_slots_check check (team_slots >= 0);
+
+-- +goose Down
+alter table bat_configs drop constraint if exists bat_configs_team_slots_check;
+alter table bat_configs add column uf_early_quota integer not null default 210;
+alter table bat_configs add column uf_late_quota integer not null default 140;
+alter table bat_configs add column other_early_quota integer not null default 90;
+alter table bat_configs add column other_late_quota integer not null default 60;
+alter table bat_configs drop column buckets;
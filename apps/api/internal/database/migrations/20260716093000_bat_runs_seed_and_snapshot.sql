@@ -0,0 +1,9 @@
+-- +goose Up
+alter table bat_runs add column seed bigint;
+alter table bat_runs add column config jsonb;
+alter table bat_runs add column input_snapshot jsonb;
+
+-- +goose Down
+alter table bat_runs drop column input_snapshot;
+alter table bat_runs drop column config;
+alter table bat_runs drop column seed;
@@ -0,0 +1,29 @@
+-- +goose Up
+create type bat_decision_reason as enum ('accepted_team', 'accepted_individual', 'bucket_full', 'team_not_fit', 'no_bucket');
+
+create table bat_run_decisions (
+    id uuid not null primary key default gen_random_uuid(),
+    run_id uuid not null references bat_runs(id) on delete cascade,
+    application_id uuid not null references applications(id) on delete cascade,
+    user_id uuid not null references users(id) on delete cascade,
+
+    -- Team and bucket are copied as they were when the run happened.
+    team_id uuid,
+    bucket text,
+
+    weighted_score double precision not null,
+    sort_key double precision not null,
+    reason bat_decision_reason not null,
+    quota_state jsonb not null,
+
+    created_at timestamptz not null default now(),
+
+    unique (run_id, application_id)
+);
+
+create index bat_run_decisions_application_id_idx on bat_run_decisions (application_id);
+
+-- +goose Down
+drop index if exists bat_run_decisions_application_id_idx;
+drop table bat_run_decisions;
+drop type bat_decision_reason;
@@ -0,0 +1,11 @@
+-- +goose Up
+alter table applications add waitlist_join_time timestamptz;
+alter table applications add rsvp_due_at timestamptz;
+
+create index applications_waitlist_idx on applications (hackathon_id, waitlist_join_time) where status = 'waitlisted';
+
+-- +goose Down
+drop index if exists applications_waitlist_idx;
+
+alter table applications drop column rsvp_due_at;
+alter table applications drop column waitlist_join_time;
@@ -0,0 +1,44 @@
+-- +goose Up
+create type decision_release_status as enum ('scheduled', 'applied', 'sending', 'sent', 'rolled_back', 'failed');
+
+create table decision_releases (
+    id uuid not null primary key default gen_random_uuid(),
+    hackathon_id text not null references hackathons(id) on delete cascade,
+    run_id uuid not null references bat_runs(id) on delete restrict,
+    status decision_release_status not null default 'scheduled',
+
+    -- How many of the best rejected candidates are waitlisted instead.
+    waitlist_count integer not null default 0 check (waitlist_count >= 0),
+    release_at timestamptz not null,
+
+    -- Filled in once the release is applied.
+    accepted_applicants uuid[] not null default '{}',
+    waitlisted_applicants uuid[] not null default '{}',
+    rejected_applicants uuid[] not null default '{}',
+    previous_statuses jsonb,
+
+    emails_queued integer not null default 0,
+    last_error text,
+
+    created_by uuid references users(id) on delete set null,
+    applied_at timestamptz,
+    sent_at timestamptz,
+    rolled_back_at timestamptz,
+    created_at timestamptz not null default now(),
+    updated_at timestamptz not null default now()
+);
+
+-- Decisions can only be released once per hackathon unless the release is rolled back.
+create unique index decision_releases_one_active_idx on decision_releases (hackathon_id)
+where status in ('scheduled', 'applied', 'sending', 'sent');
+
+create trigger decision_releases_updated_at
+before update on decision_releases
+for each row
+execute function update_modified_column();
+
+-- +goose Down
+drop trigger if exists decision_releases_updated_at on decision_releases;
+drop index if exists decision_releases_one_active_idx;
+drop table decision_releases;
+drop type decision_release_status;
@@ -0,0 +1,17 @@
+-- +goose Up
+create table application_forms (
+    hackathon_id text not null primary key references hackathons(id) on delete cascade,
+    fields jsonb not null,
+
+    created_at timestamptz not null default now(),
+    updated_at timestamptz not null default now()
+);
+
+create trigger application_forms_updated_at
+before update on application_forms
+for each row
+execute function update_modified_column();
+
+-- +goose Down
+drop trigger if exists application_forms_updated_at on application_forms;
+drop table application_forms;
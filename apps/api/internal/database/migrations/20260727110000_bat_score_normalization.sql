@@ -0,0 +1,7 @@
+-- +goose Up
+alter table bat_configs
+    add column score_normalization text not null default 'none'
+    check (score_normalization in ('none', 'zscore', 'percentile'));
+
+-- +goose Down
+alter table bat_configs drop column score_normalization;
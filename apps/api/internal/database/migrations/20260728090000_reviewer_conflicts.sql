@@ -0,0 +1,14 @@
+-- +goose Up
+create table reviewer_conflicts (
+    reviewer_id uuid not null references users(id) on delete cascade,
+    user_id uuid not null references users(id) on delete cascade,
+    reason text,
+
+    created_at timestamptz not null default now(),
+
+    primary key (reviewer_id, user_id),
+    check (reviewer_id <> user_id)
+);
+
+-- +goose Down
+drop table reviewer_conflicts;
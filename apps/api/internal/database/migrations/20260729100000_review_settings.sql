@@ -0,0 +1,20 @@
+-- +goose Up
+create table review_settings (
+    hackathon_id text not null primary key references hackathons(id) on delete cascade,
+    blind_review boolean not null default false,
+    redacted_fields jsonb not null default '[]'::jsonb,
+    resume_access text not null default 'visible'
+        check (resume_access in ('visible', 'after_rating', 'hidden')),
+
+    created_at timestamptz not null default now(),
+    updated_at timestamptz not null default now()
+);
+
+create trigger review_settings_updated_at
+before update on review_settings
+for each row
+execute function update_modified_column();
+
+-- +goose Down
+drop trigger if exists review_settings_updated_at on review_settings;
+drop table review_settings;
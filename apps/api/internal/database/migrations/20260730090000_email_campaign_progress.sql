@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+
+alter table email_campaigns
+    add column recipient_count integer default 0 not null,
+    add column emails_sent integer default 0 not null,
+    add column emails_failed integer default 0 not null;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+alter table email_campaigns
+    drop column if exists emails_failed,
+    drop column if exists emails_sent,
+    drop column if exists recipient_count;
+
+-- +goose StatementEnd
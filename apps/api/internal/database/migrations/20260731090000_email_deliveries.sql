@@ -0,0 +1,54 @@
+-- +goose Up
+-- +goose StatementBegin
+
+create type email_delivery_status as enum (
+    'queued',
+    'sent',
+    'failed',
+    'bounced',
+    'complained'
+);
+
+create table email_deliveries (
+    id uuid default gen_random_uuid() not null primary key,
+    campaign_id uuid references email_campaigns(id) on delete cascade,
+
+    recipient text not null,
+    recipient_name text,
+    subject text not null,
+    template text,
+
+    status email_delivery_status default 'queued'::email_delivery_status not null,
+    ses_message_id text,
+    error text,
+    attempts integer default 0 not null,
+
+    sent_at timestamptz,
+    created_at timestamptz default now() not null,
+    updated_at timestamptz default now() not null
+);
+
+create index idx_email_deliveries_campaign_id
+    on email_deliveries (campaign_id, status);
+
+create unique index idx_email_deliveries_ses_message_id
+    on email_deliveries (ses_message_id);
+
+create index idx_email_deliveries_recipient
+    on email_deliveries (lower(recipient));
+
+create trigger set_updated_at_email_deliveries
+    before update on email_deliveries
+    for each row
+    execute procedure update_modified_column();
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+drop trigger if exists set_updated_at_email_deliveries on email_deliveries;
+drop table if exists email_deliveries;
+drop type if exists email_delivery_status;
+
+-- +goose StatementEnd
@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+
+create type email_suppression_reason as enum (
+    'bounce',
+    'complaint'
+);
+
+-- Addresses are stored lowercased.
+create table email_suppressions (
+    email text not null primary key,
+    reason email_suppression_reason not null,
+    detail text,
+    ses_message_id text,
+    created_at timestamptz default now() not null,
+    updated_at timestamptz default now() not null
+);
+
+create trigger set_updated_at_email_suppressions
+    before update on email_suppressions
+    for each row
+    execute procedure update_modified_column();
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+drop trigger if exists set_updated_at_email_suppressions on email_suppressions;
+drop table if exists email_suppressions;
+drop type if exists email_suppression_reason;
+
+-- +goose StatementEnd
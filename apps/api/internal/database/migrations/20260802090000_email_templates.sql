@@ -0,0 +1,54 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Overrides for the emails the API sends, per hackathon. Keys are the built-in
+-- templates, like application_confirmation. Without a row the template on disk is used.
+create table email_templates (
+    id uuid default gen_random_uuid() not null primary key,
+    hackathon_id text not null references hackathons(id) on delete cascade,
+
+    key text not null,
+    name text not null,
+    description text,
+    current_version int not null,
+
+    created_by_user_id uuid references users(id) on delete set null,
+    updated_by_user_id uuid references users(id) on delete set null,
+    created_at timestamptz default now() not null,
+    updated_at timestamptz default now() not null,
+
+    constraint email_templates_hackathon_key_unique unique (hackathon_id, key)
+);
+
+-- Every edit of a template is a new version. Versions are never changed.
+create table email_template_versions (
+    id uuid default gen_random_uuid() not null primary key,
+    template_id uuid not null references email_templates(id) on delete cascade,
+    version int not null,
+
+    subject text not null,
+    html_body text not null,
+    text_body text,
+    variables text[] default '{}' not null,
+
+    created_by_user_id uuid references users(id) on delete set null,
+    created_at timestamptz default now() not null,
+
+    constraint email_template_versions_template_version_unique unique (template_id, version)
+);
+
+create trigger set_updated_at_email_templates
+    before update on email_templates
+    for each row
+    execute procedure update_modified_column();
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+drop trigger if exists set_updated_at_email_templates on email_templates;
+drop table if exists email_template_versions;
+drop table if exists email_templates;
+
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Where replies to emails sent from a template version go. Without it replies go to
+-- the sender.
+alter table email_template_versions
+    add column reply_to text;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+alter table email_template_versions
+    drop column if exists reply_to;
+
+-- +goose StatementEnd
@@ -0,0 +1,44 @@
+-- +goose Up
+-- +goose StatementBegin
+
+create type email_category as enum (
+    'marketing',
+    'event_updates',
+    'team_notifications',
+    'workshop_reminders'
+);
+
+-- Which categories of email an address gets. Without a row the address is subscribed.
+-- Addresses are stored lowercased, so the preferences of people who aren't users, like
+-- interest subscribers, work the same way.
+create table email_preferences (
+    email text not null,
+    category email_category not null,
+    subscribed boolean not null,
+    created_at timestamptz default now() not null,
+    updated_at timestamptz default now() not null,
+
+    primary key (email, category)
+);
+
+create trigger set_updated_at_email_preferences
+    before update on email_preferences
+    for each row
+    execute procedure update_modified_column();
+
+alter table email_campaigns
+    add column category email_category default 'marketing' not null;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+alter table email_campaigns
+    drop column if exists category;
+
+drop trigger if exists set_updated_at_email_preferences on email_preferences;
+drop table if exists email_preferences;
+drop type if exists email_category;
+
+-- +goose StatementEnd
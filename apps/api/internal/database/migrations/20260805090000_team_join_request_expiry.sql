@@ -0,0 +1,38 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Requests the owner never answered expire, and pending requests are cancelled when
+-- the requester joins a team some other way or withdraws them.
+alter type team_join_request_status add value if not exists 'expired';
+alter type team_join_request_status add value if not exists 'cancelled';
+
+alter table team_join_requests
+    add column expires_at timestamptz;
+
+update team_join_requests
+set expires_at = created_at + interval '3 days';
+
+alter table team_join_requests
+    alter column expires_at set not null;
+
+create index idx_team_join_requests_pending_expires_at
+    on team_join_requests (expires_at)
+    where (status = 'pending'::team_join_request_status);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+-- Postgres can't drop enum values, so expired and cancelled requests become rejected
+-- and the values stay.
+update team_join_requests
+set status = 'rejected'
+where status::text in ('expired', 'cancelled');
+
+drop index if exists idx_team_join_requests_pending_expires_at;
+
+alter table team_join_requests
+    drop column if exists expires_at;
+
+-- +goose StatementEnd
@@ -0,0 +1,56 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Teams opt in to the discovery board by listing themselves for a hackathon. Skills are
+-- what the team is looking for.
+create table team_listings (
+    team_id uuid primary key references teams on delete cascade,
+    hackathon_id text not null references hackathons(id) on delete cascade,
+    description text,
+    skills text[] default '{}' not null,
+    interests text[] default '{}' not null,
+    created_at timestamptz default now() not null,
+    updated_at timestamptz default now() not null
+);
+
+create index idx_team_listings_hackathon_id
+    on team_listings (hackathon_id);
+
+create trigger set_updated_at_team_listings
+    before update on team_listings
+    for each row
+    execute procedure update_modified_column();
+
+-- Hackers without a team opt in to be found by teams. Skills are what they bring.
+create table looking_for_team_profiles (
+    user_id uuid not null references users on delete cascade,
+    hackathon_id text not null references hackathons(id) on delete cascade,
+    bio text,
+    skills text[] default '{}' not null,
+    interests text[] default '{}' not null,
+    created_at timestamptz default now() not null,
+    updated_at timestamptz default now() not null,
+
+    primary key (user_id, hackathon_id)
+);
+
+create index idx_looking_for_team_profiles_hackathon_id
+    on looking_for_team_profiles (hackathon_id);
+
+create trigger set_updated_at_looking_for_team_profiles
+    before update on looking_for_team_profiles
+    for each row
+    execute procedure update_modified_column();
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+drop trigger if exists set_updated_at_looking_for_team_profiles on looking_for_team_profiles;
+drop table if exists looking_for_team_profiles;
+
+drop trigger if exists set_updated_at_team_listings on team_listings;
+drop table if exists team_listings;
+
+-- +goose StatementEnd
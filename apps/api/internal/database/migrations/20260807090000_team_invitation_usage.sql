@@ -0,0 +1,74 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Teams can have any number of invitations. Open invite links can expire or be capped
+-- to a number of uses, invitations sent to a user or an email can be used once by that
+-- person, and either kind can be revoked by the owner.
+alter type team_invitation_status add value if not exists 'revoked';
+
+alter table team_invitations drop constraint unique_team_id;
+
+alter table team_invitations
+    add column max_uses integer,
+    add column use_count integer default 0 not null,
+    add column invited_email text,
+    add column invited_user_id uuid references users on delete cascade,
+    add column status team_invitation_status default 'pending'::team_invitation_status not null;
+
+alter table team_invitations
+    add constraint team_invitations_max_uses_check check (max_uses is null or max_uses > 0);
+
+create index idx_team_invitations_team_id
+    on team_invitations (team_id);
+
+create index idx_team_invitations_invited_user_id
+    on team_invitations (invited_user_id);
+
+create index idx_team_invitations_invited_email
+    on team_invitations (lower(invited_email));
+
+-- Who joined a team through which invitation. Kept when the member leaves the team.
+create table team_invitation_uses (
+    id uuid default gen_random_uuid() not null primary key,
+    invitation_id uuid not null references team_invitations on delete cascade,
+    user_id uuid not null references users on delete cascade,
+    used_at timestamptz default now() not null
+);
+
+create index idx_team_invitation_uses_invitation_id
+    on team_invitation_uses (invitation_id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+drop table if exists team_invitation_uses;
+
+-- Only the first open invite link of each team is kept. Postgres can't drop enum values,
+-- so revoked stays.
+delete from team_invitations
+where id not in (
+    select distinct on (team_id) id
+    from team_invitations
+    where invited_email is null
+        and invited_user_id is null
+        and status = 'pending'::team_invitation_status
+    order by team_id, created_at, id
+);
+
+drop index if exists idx_team_invitations_invited_email;
+drop index if exists idx_team_invitations_invited_user_id;
+drop index if exists idx_team_invitations_team_id;
+
+alter table team_invitations
+    drop constraint if exists team_invitations_max_uses_check,
+    drop column if exists status,
+    drop column if exists invited_user_id,
+    drop column if exists invited_email,
+    drop column if exists use_count,
+    drop column if exists max_uses;
+
+alter table team_invitations add constraint unique_team_id unique (team_id);
+
+-- +goose StatementEnd
@@ -0,0 +1,60 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Deleting the owner's account used to delete their team with it. Now the member who
+-- has been on the team the longest becomes the owner, and the team is only deleted if
+-- the owner was its last member. Invite links the owner created are handed over too,
+-- otherwise they would be deleted with the account.
+create or replace function promote_team_owner()
+returns trigger as $$
+declare
+    owned_team_id uuid;
+    next_owner_id uuid;
+begin
+    select id into owned_team_id
+    from teams
+    where owner_id = OLD.id;
+
+    if owned_team_id is null then
+        return OLD;
+    end if;
+
+    select user_id into next_owner_id
+    from team_members
+    where team_id = owned_team_id
+        and user_id <> OLD.id
+    order by joined_at, user_id
+    limit 1;
+
+    if next_owner_id is null then
+        return OLD;
+    end if;
+
+    update teams
+    set owner_id = next_owner_id
+    where id = owned_team_id;
+
+    update team_invitations
+    set inviter_id = next_owner_id
+    where team_id = owned_team_id
+        and inviter_id = OLD.id;
+
+    return OLD;
+end;
+$$ language plpgsql;
+
+create trigger promote_team_owner_on_user_delete
+    before delete
+    on users
+    for each row
+    execute procedure promote_team_owner();
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+drop trigger if exists promote_team_owner_on_user_delete on users;
+drop function if exists promote_team_owner();
+
+-- +goose StatementEnd
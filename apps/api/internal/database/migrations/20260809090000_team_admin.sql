@@ -0,0 +1,43 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Staff can let a team have more (or fewer) members than the default. Null uses the
+-- default.
+alter table teams
+    add column max_members integer;
+
+alter table teams
+    add constraint teams_max_members_check check (max_members is null or max_members > 0);
+
+-- Every change staff make to teams is recorded. The team id isn't a foreign key so the
+-- entries outlive disbanded and merged teams.
+create type team_audit_action as enum ('rename', 'disband', 'merge', 'move_member', 'set_member_limit');
+
+create table team_audit_logs (
+    id uuid default gen_random_uuid() not null primary key,
+    team_id uuid not null,
+    actor_id uuid references users on delete set null,
+    action team_audit_action not null,
+    details jsonb default '{}'::jsonb not null,
+    created_at timestamptz default now() not null
+);
+
+create index idx_team_audit_logs_team_id_created_at
+    on team_audit_logs (team_id, created_at desc);
+
+create index idx_team_audit_logs_created_at
+    on team_audit_logs (created_at desc);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+drop table if exists team_audit_logs;
+drop type if exists team_audit_action;
+
+alter table teams
+    drop constraint if exists teams_max_members_check,
+    drop column if exists max_members;
+
+-- +goose StatementEnd
@@ -0,0 +1,10 @@
+-- name: GetApplicationFormByHackathonId :one
+SELECT * FROM application_forms
+WHERE hackathon_id = @hackathon_id;
+
+-- name: UpsertApplicationForm :one
+INSERT INTO application_forms (hackathon_id, fields)
+VALUES (@hackathon_id, @fields)
+ON CONFLICT (hackathon_id) DO UPDATE SET
+    fields = EXCLUDED.fields
+RETURNING *;
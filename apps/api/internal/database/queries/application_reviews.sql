@@ -30,11 +30,62 @@ SELECT
     WHERE ar.experience_rating IS NOT NULL AND ar.passion_rating IS NOT NULL
     ) AS completed_count
 FROM application_reviews AS ar
+JOIN applications
+  ON applications.id = ar.application_id
 LEFT JOIN users AS reviewer
   ON reviewer.id = ar.reviewer_id
+WHERE applications.hackathon_id = @hackathon_id
 GROUP BY
   reviewer.id;
 
+-- name: ListEligibleReviewerIds :many
+-- returns the staff and admins, who can be assigned reviews.
+SELECT id FROM users
+WHERE role IN ('staff'::user_role, 'admin'::user_role)
+ORDER BY id;
+
+-- name: ListReviewsByApplicationIds :many
+SELECT application_id, reviewer_id FROM application_reviews
+WHERE application_id = ANY(@application_ids::uuid[]);
+
+-- name: ListReviewerAffiliations :many
+SELECT
+    u.id,
+    t.id AS team_id,
+    COALESCE(latest.school, '')::text AS school
+FROM users u
+LEFT JOIN team_members tm
+    ON tm.user_id = u.id
+LEFT JOIN teams t
+    ON t.id = tm.team_id
+LEFT JOIN LATERAL (
+    SELECT applications.application->>'school' AS school
+    FROM applications
+    WHERE applications.user_id = u.id
+    ORDER BY applications.created_at DESC
+    LIMIT 1
+) AS latest ON true
+WHERE u.id = ANY(@user_ids::uuid[]);
+
+-- name: DeleteIncompleteReviewsByReviewerId :many
+DELETE FROM application_reviews
+WHERE reviewer_id = @reviewer_id
+    AND (passion_rating IS NULL OR experience_rating IS NULL)
+RETURNING application_id;
+
+-- name: ListCompletedReviews :many
+SELECT
+    ar.application_id,
+    ar.reviewer_id,
+    ar.passion_rating::int AS passion_rating,
+    ar.experience_rating::int AS experience_rating
+FROM application_reviews AS ar
+JOIN applications ON applications.id = ar.application_id
+WHERE ar.passion_rating IS NOT NULL
+    AND ar.experience_rating IS NOT NULL
+    AND (sqlc.narg('hackathon_id')::text IS NULL OR applications.hackathon_id = sqlc.narg('hackathon_id'))
+ORDER BY ar.reviewer_id ASC, ar.application_id ASC;
+
 -- name: GetReviewById :one
 SELECT
     ar.*,
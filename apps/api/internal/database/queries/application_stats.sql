@@ -1,57 +1,18 @@
 -- Queries used for statistics, mainly used by overview dashboards etc
 
--- name: GetSubmittedApplicationGenders :one
+-- name: GetSubmittedApplicationFieldCounts :many
 SELECT
-  COUNT(*) FILTER (WHERE application->>'gender' = 'man') AS male,
-  COUNT(*) FILTER (WHERE application->>'gender' = 'woman') AS female,
-  COUNT(*) FILTER (WHERE application->>'gender' = 'non-binary') AS non_binary,
-  COUNT(*) FILTER (WHERE application->>'gender' = '') AS other
-FROM applications
-WHERE status <> 'started' AND status IS NOT NULL;
-
--- name: GetSubmittedApplicationAges :one
-SELECT
-    COUNT(*) FILTER (WHERE (application->>'age') = '<18') AS underage,
-    COUNT(*) FILTER (WHERE (application->>'age') = '18-22') AS between_18_and_22,
-    COUNT(*) FILTER (WHERE (application->>'age') = '>22') AS older_than_22
-FROM applications
-WHERE status <> 'started' AND status IS NOT NULL;
-
--- name: GetSubmittedApplicationRaces :many
-SELECT
-    CASE 
-        WHEN application->>'race' IS NOT NULL AND application->>'race' <> '' THEN application->>'race'
-        WHEN application->>'race-other' IS NOT NULL AND application->>'race-other' <> '' THEN application->>'race-other'
-        ELSE 'prefer_not_to_say'
-    END AS race_group,
-    COUNT(*) AS count
-FROM applications
-WHERE status <> 'started' AND status IS NOT NULL
-GROUP BY 
-    CASE 
-        WHEN application->>'race' IS NOT NULL AND application->>'race' <> '' THEN application->>'race'
-        WHEN application->>'race-other' IS NOT NULL AND application->>'race-other' <> '' THEN application->>'race-other'
-        ELSE 'prefer_not_to_say'
-    END
-ORDER BY count DESC;
-
--- name: GetSubmittedApplicationSchools :many
-SELECT
-    (application->>'school')::text AS school,
-    COUNT(*) AS count
-FROM applications
-WHERE status <> 'started' AND status IS NOT NULL
-GROUP BY (application->>'school')::text
-ORDER BY count DESC;
-
--- name: GetSubmittedApplicationMajors :many
-SELECT
-    trim(major) AS major,
+    COALESCE(NULLIF(trim(answer), ''), 'no_answer')::text AS answer,
     COUNT(*) AS count
 FROM applications,
-LATERAL unnest(string_to_array(application->>'majors', ',')) AS major
-WHERE status <> 'started' AND status IS NOT NULL
-GROUP BY trim(major)
+LATERAL unnest(
+    CASE WHEN @split::boolean
+        THEN COALESCE(string_to_array(application->>@field::text, ','), ARRAY[NULL::text])
+        ELSE ARRAY[application->>@field::text]
+    END
+) AS answer
+WHERE hackathon_id = @hackathon_id AND status <> 'started' AND status IS NOT NULL
+GROUP BY 1
 ORDER BY count DESC;
 
 -- name: GetApplicationStatuses :one
@@ -88,6 +88,20 @@ SELECT id FROM applications
 WHERE status = 'under_review' AND hackathon_id = @hackathon_id
 ORDER BY id ASC;
 
+-- name: ListAssignableApplications :many
+SELECT DISTINCT ON (a.id)
+    a.id,
+    a.user_id,
+    t.id AS team_id,
+    COALESCE(a.application->>'school', '')::text AS school
+FROM applications a
+LEFT JOIN team_members tm
+    ON tm.user_id = a.user_id
+LEFT JOIN teams t
+    ON t.id = tm.team_id
+WHERE a.status = 'under_review' AND a.hackathon_id = @hackathon_id
+ORDER BY a.id ASC, t.id NULLS LAST;
+
 -- name: ListApplicationsUnderReviewWithTeamIds :many
 SELECT 
     a.user_id,
@@ -100,6 +114,26 @@ LEFT JOIN teams t
     ON t.id = tm.team_id
 WHERE a.status = 'under_review';
 
+-- name: ListAdmissionCandidates :many
+SELECT
+    a.id,
+    a.user_id,
+    a.application,
+    tm.team_id,
+    COALESCE(AVG(ar.passion_rating), 0)::float8 AS passion_rating,
+    COALESCE(AVG(ar.experience_rating), 0)::float8 AS experience_rating,
+    COUNT(ar.id) AS rated_review_count
+FROM applications a
+LEFT JOIN application_reviews ar
+    ON ar.application_id = a.id
+    AND ar.passion_rating IS NOT NULL
+    AND ar.experience_rating IS NOT NULL
+LEFT JOIN team_members tm
+    ON tm.user_id = a.user_id
+WHERE a.status = 'under_review' AND a.hackathon_id = @hackathon_id
+GROUP BY a.id, tm.team_id
+ORDER BY a.id ASC;
+
 -- name: WaitlistApplicationById :exec
 UPDATE applications
 SET waitlist_join_time = COALESCE(waitlist_join_time, NOW()),
@@ -129,16 +163,52 @@ WHERE status = 'accepted'
 -- name: AcceptWaitlistedApplications :many
 UPDATE applications
 SET waitlist_join_time = NULL,
+    rsvp_due_at = @rsvp_due_at,
     status = 'accepted'
-WHERE user_id IN (
-  SELECT user_id FROM applications
-  WHERE status = 'waitlisted'
-  ORDER BY waitlist_join_time ASC
-  LIMIT @acceptanceCount::int
+WHERE id IN (
+  SELECT id FROM applications
+  WHERE status = 'waitlisted' AND hackathon_id = @hackathon_id
+    AND id <> ALL(@excluded_ids::uuid[])
+  ORDER BY waitlist_join_time ASC NULLS LAST, id ASC
+  LIMIT @acceptance_count::int
+  FOR UPDATE SKIP LOCKED
 )
-RETURNING user_id;
+RETURNING id, user_id;
+
+-- name: ExpireUnconfirmedAcceptances :many
+UPDATE applications
+SET waitlist_join_time = NOW(),
+    rsvp_due_at = NULL,
+    status = 'waitlisted'
+WHERE hackathon_id = @hackathon_id
+  AND status = 'accepted'
+  AND rsvp_due_at < NOW()
+RETURNING id, user_id;
+
+-- name: CountAdmittedApplications :one
+SELECT COUNT(*) FROM applications
+WHERE hackathon_id = @hackathon_id AND status IN ('accepted', 'confirmed');
 
 -- name: ResetApplicationsToSubmitted :exec
 UPDATE applications 
 SET status = 'submitted'
-WHERE status = 'under_review';
\ No newline at end of file
+WHERE status = 'under_review';
+
+-- name: ListApplicationsByUserIds :many
+SELECT id, user_id, status, waitlist_join_time, rsvp_due_at
+FROM applications
+WHERE hackathon_id = @hackathon_id AND user_id = ANY(@user_ids::uuid[]);
+
+-- name: WaitlistApplicationsInOrder :exec
+UPDATE applications a
+SET status = 'waitlisted',
+    waitlist_join_time = @joined_at::timestamptz + (w.position * interval '1 millisecond')
+FROM unnest(@ids::uuid[]) WITH ORDINALITY AS w(id, position)
+WHERE a.id = w.id;
+
+-- name: RestoreApplicationStatus :execrows
+UPDATE applications
+SET status = @status,
+    waitlist_join_time = @waitlist_join_time,
+    rsvp_due_at = @rsvp_due_at
+WHERE id = @id AND status = @current_status;
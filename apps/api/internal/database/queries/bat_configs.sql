@@ -0,0 +1,22 @@
+-- name: GetBatConfigByHackathonId :one
+SELECT * FROM bat_configs
+WHERE hackathon_id = @hackathon_id;
+
+-- name: UpsertBatConfig :one
+INSERT INTO bat_configs (
+    hackathon_id,
+    passion_weight, experience_weight, weighted_base_constant,
+    team_slots, buckets, score_normalization
+) VALUES (
+    @hackathon_id,
+    @passion_weight, @experience_weight, @weighted_base_constant,
+    @team_slots, @buckets, @score_normalization
+)
+ON CONFLICT (hackathon_id) DO UPDATE SET
+    passion_weight = EXCLUDED.passion_weight,
+    experience_weight = EXCLUDED.experience_weight,
+    weighted_base_constant = EXCLUDED.weighted_base_constant,
+    team_slots = EXCLUDED.team_slots,
+    buckets = EXCLUDED.buckets,
+    score_normalization = EXCLUDED.score_normalization
+RETURNING *;
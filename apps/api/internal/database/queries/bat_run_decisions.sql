@@ -0,0 +1,20 @@
+-- name: CreateBatRunDecision :exec
+INSERT INTO bat_run_decisions (
+    run_id, application_id, user_id, team_id, bucket,
+    weighted_score, sort_key, reason, quota_state
+) VALUES (
+    @run_id, @application_id, @user_id, @team_id, @bucket,
+    @weighted_score, @sort_key, @reason, @quota_state
+);
+
+-- name: ListBatRunDecisionsByApplicationId :many
+SELECT *
+FROM bat_run_decisions
+WHERE application_id = @application_id
+ORDER BY created_at DESC;
+
+-- name: ListBatRunDecisionsByRunId :many
+SELECT *
+FROM bat_run_decisions
+WHERE run_id = @run_id
+ORDER BY created_at ASC, id ASC;
@@ -1,5 +1,5 @@
 -- name: AddBatRun :one
-INSERT INTO bat_runs (hackathon_id) VALUES ($1) RETURNING *;
+INSERT INTO bat_runs (hackathon_id, seed) VALUES (@hackathon_id, @seed) RETURNING *;
 
 -- name: GetBatRunById :one
 SELECT *
@@ -18,7 +18,10 @@ SET
     accepted_applicants = CASE WHEN @accepted_applicants_do_update::boolean THEN @accepted_applicants ELSE accepted_applicants END,
     rejected_applicants = CASE WHEN @rejected_applicants_do_update::boolean THEN @rejected_applicants ELSE rejected_applicants END,
     status = CASE WHEN @status_do_update::boolean THEN @status ELSE status END,
-    created_at = CASE WHEN @created_at_do_update::boolean THEN @created_at ELSE created_at END
+    created_at = CASE WHEN @created_at_do_update::boolean THEN @created_at ELSE created_at END,
+    completed_at = CASE WHEN @completed_at_do_update::boolean THEN @completed_at ELSE completed_at END,
+    config = CASE WHEN @config_do_update::boolean THEN @config ELSE config END,
+    input_snapshot = CASE WHEN @input_snapshot_do_update::boolean THEN @input_snapshot ELSE input_snapshot END
 WHERE
     id = @id::uuid
 RETURNING *;
@@ -0,0 +1,34 @@
+-- name: CreateDecisionRelease :one
+INSERT INTO decision_releases (hackathon_id, run_id, waitlist_count, release_at, created_by)
+VALUES (@hackathon_id, @run_id, @waitlist_count, @release_at, @created_by)
+RETURNING *;
+
+-- name: GetDecisionReleaseById :one
+SELECT * FROM decision_releases
+WHERE id = @id;
+
+-- name: GetDecisionReleaseByIdForUpdate :one
+SELECT * FROM decision_releases
+WHERE id = @id
+FOR UPDATE;
+
+-- name: ListDecisionReleases :many
+SELECT * FROM decision_releases
+WHERE hackathon_id = @hackathon_id
+ORDER BY created_at DESC;
+
+-- name: UpdateDecisionReleaseById :exec
+UPDATE decision_releases
+SET
+    status = CASE WHEN @status_do_update::boolean THEN @status::decision_release_status ELSE status END,
+    accepted_applicants = CASE WHEN @applicants_do_update::boolean THEN @accepted_applicants::uuid[] ELSE accepted_applicants END,
+    waitlisted_applicants = CASE WHEN @applicants_do_update::boolean THEN @waitlisted_applicants::uuid[] ELSE waitlisted_applicants END,
+    rejected_applicants = CASE WHEN @applicants_do_update::boolean THEN @rejected_applicants::uuid[] ELSE rejected_applicants END,
+    previous_statuses = CASE WHEN @previous_statuses_do_update::boolean THEN @previous_statuses ELSE previous_statuses END,
+    emails_queued = CASE WHEN @emails_queued_do_update::boolean THEN @emails_queued::int ELSE emails_queued END,
+    last_error = CASE WHEN @last_error_do_update::boolean THEN @last_error ELSE last_error END,
+    applied_at = CASE WHEN @applied_at_do_update::boolean THEN @applied_at ELSE applied_at END,
+    sent_at = CASE WHEN @sent_at_do_update::boolean THEN @sent_at ELSE sent_at END,
+    rolled_back_at = CASE WHEN @rolled_back_at_do_update::boolean THEN @rolled_back_at ELSE rolled_back_at END
+WHERE
+    id = @id;
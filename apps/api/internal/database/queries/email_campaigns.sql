@@ -1,5 +1,5 @@
 -- name: CreateEmailCampaign :one
--- creates a draft campaign. It stores the title, subject, body, format, recipient groups, and optional schedule time.
+-- creates a draft campaign. It stores the title, subject, body, format, recipient groups, email category, and optional schedule time.
 INSERT INTO email_campaigns (
     hackathon_id,
     title,
@@ -10,7 +10,8 @@ INSERT INTO email_campaigns (
     recipient_types,
     scheduled_at,
     created_by_user_id,
-    updated_by_user_id
+    updated_by_user_id,
+    category
 ) VALUES (
     @hackathon_id,
     @title,
@@ -21,7 +22,8 @@ INSERT INTO email_campaigns (
     sqlc.arg(recipient_types)::text[]::email_recipient_type[],
     sqlc.narg(scheduled_at),
     sqlc.narg(created_by_user_id),
-    sqlc.narg(updated_by_user_id)
+    sqlc.narg(updated_by_user_id),
+    @category::email_category
 )
 RETURNING *;
 
@@ -40,7 +42,7 @@ WHERE hackathon_id = @hackathon_id
 ORDER BY created_at DESC;
 
 -- name: UpdateEmailCampaign :one
--- edits draft-like campaign fields: title, description, subject, body, format, recipients, and scheduled time.
+-- edits draft-like campaign fields: title, description, subject, body, format, recipients, scheduled time, and email category. Returns no rows once the campaign started sending.
 UPDATE email_campaigns
 SET
     title = 
@@ -71,16 +73,21 @@ SET
         CASE WHEN @scheduled_at_do_update::boolean
         THEN sqlc.narg(scheduled_at)
         ELSE scheduled_at END,
+    category =
+        CASE WHEN @category_do_update::boolean
+        THEN sqlc.narg(category)::email_category
+        ELSE category END,
     updated_by_user_id = 
         CASE WHEN @updated_by_user_id_do_update::boolean
         THEN @updated_by_user_id::uuid
         ELSE updated_by_user_id END
 WHERE id = @id::uuid
     AND hackathon_id = @hackathon_id
+    AND status IN ('draft', 'scheduled')
 RETURNING *;
 
 -- name: UpdateEmailCampaignStatus :one
--- changes lifecycle fields like draft -> scheduled, scheduled -> sending, sending -> sent, or sending -> failed.
+-- changes lifecycle fields like draft -> scheduled, scheduled -> sending, sending -> sent, or sending -> failed. If expected_status is set, returns no rows unless the campaign is still in that status.
 UPDATE email_campaigns
 SET
     status = @status::email_campaign_status,
@@ -102,4 +109,117 @@ SET
         ELSE updated_by_user_id END
 WHERE id = @id::uuid
     AND hackathon_id = @hackathon_id
+    AND (
+        sqlc.narg(expected_status)::email_campaign_status IS NULL
+        OR status = sqlc.narg(expected_status)::email_campaign_status
+    )
+RETURNING *;
+
+-- name: StartEmailCampaignSending :one
+-- moves a draft, scheduled, or failed campaign to sending and resets its progress. Returns no rows if the campaign is already sending or sent.
+UPDATE email_campaigns
+SET
+    status = 'sending'::email_campaign_status,
+    last_error = NULL,
+    recipient_count = 0,
+    emails_sent = 0,
+    emails_failed = 0,
+    updated_by_user_id = COALESCE(sqlc.narg(updated_by_user_id)::uuid, updated_by_user_id)
+WHERE id = @id::uuid
+    AND hackathon_id = @hackathon_id
+    AND status IN ('draft', 'scheduled', 'failed')
+RETURNING *;
+
+-- name: ListEmailCampaignRecipients :many
+-- resolves recipient groups into names and contact addresses. Users are only included if they consented to emails, interest subscribers opted in by subscribing. The same address can come up more than once.
+SELECT
+    u.name,
+    COALESCE(NULLIF(u.preferred_email, ''), u.email, '')::text AS email
+FROM users u
+WHERE u.email_consent
+    AND (
+        (u.role = 'admin' AND 'admins' = ANY(@recipient_types::text[]))
+        OR (u.role = 'staff' AND 'staff' = ANY(@recipient_types::text[]))
+        OR (u.role = 'visitor' AND 'visitors' = ANY(@recipient_types::text[]))
+        OR EXISTS (
+            SELECT 1
+            FROM applications a
+            WHERE a.user_id = u.id
+                AND a.hackathon_id = @hackathon_id
+                AND (
+                    (a.status = 'accepted' AND 'accepted_applicants' = ANY(@recipient_types::text[]))
+                    OR (a.status = 'rejected' AND 'rejected_applicants' = ANY(@recipient_types::text[]))
+                    OR (a.status = 'waitlisted' AND 'waitlisted_applicants' = ANY(@recipient_types::text[]))
+                )
+        )
+    )
+UNION ALL
+SELECT
+    ''::text AS name,
+    i.email
+FROM interest_submissions i
+WHERE i.hackathon_id = @hackathon_id
+    AND 'interest_subscribers' = ANY(@recipient_types::text[]);
+
+-- name: UpdateEmailCampaignRecipientCount :exec
+-- stores how many emails a sending campaign goes out to.
+UPDATE email_campaigns
+SET recipient_count = @recipient_count::integer
+WHERE id = @id::uuid;
+
+-- name: RecordEmailCampaignBatch :one
+-- adds the outcome of a sent batch. Once every recipient was tried the campaign is sent, or failed if no email went out.
+UPDATE email_campaigns
+SET
+    emails_sent = emails_sent + @sent::integer,
+    emails_failed = emails_failed + @failed::integer,
+    last_error = COALESCE(sqlc.narg(last_error)::text, last_error),
+    status =
+        CASE WHEN emails_sent + emails_failed + @sent::integer + @failed::integer < recipient_count
+        THEN status
+        WHEN emails_sent + @sent::integer > 0
+        THEN 'sent'::email_campaign_status
+        ELSE 'failed'::email_campaign_status END,
+    sent_at =
+        CASE WHEN emails_sent + emails_failed + @sent::integer + @failed::integer >= recipient_count
+            AND emails_sent + @sent::integer > 0
+        THEN now()
+        ELSE sent_at END
+WHERE id = @id::uuid
+    AND status = 'sending'::email_campaign_status
+RETURNING *;
+
+-- name: ClaimDueEmailCampaigns :many
+-- moves scheduled campaigns that are due to sending. Campaigns locked by another worker are skipped, so every campaign is claimed once.
+UPDATE email_campaigns
+SET
+    status = 'sending'::email_campaign_status,
+    last_error = NULL,
+    recipient_count = 0,
+    emails_sent = 0,
+    emails_failed = 0
+WHERE id IN (
+    SELECT id
+    FROM email_campaigns
+    WHERE status = 'scheduled'::email_campaign_status
+        AND scheduled_at <= now()
+    ORDER BY scheduled_at
+    LIMIT @max_campaigns::integer
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: StartEmailCampaignResend :one
+-- moves a sent or failed campaign back to sending to retry its failed deliveries. Returns no rows if the campaign is sending or was never sent.
+UPDATE email_campaigns
+SET
+    status = 'sending'::email_campaign_status,
+    last_error = NULL,
+    recipient_count = @recipient_count::integer,
+    emails_sent = 0,
+    emails_failed = 0,
+    updated_by_user_id = COALESCE(sqlc.narg(updated_by_user_id)::uuid, updated_by_user_id)
+WHERE id = @id::uuid
+    AND hackathon_id = @hackathon_id
+    AND status IN ('sent', 'failed')
 RETURNING *;
@@ -0,0 +1,107 @@
+-- name: CreateEmailDelivery :one
+-- records an email that is about to be queued.
+INSERT INTO email_deliveries (
+    campaign_id,
+    recipient,
+    recipient_name,
+    subject,
+    template
+) VALUES (
+    sqlc.narg(campaign_id),
+    @recipient,
+    sqlc.narg(recipient_name),
+    @subject,
+    sqlc.narg(template)
+)
+RETURNING *;
+
+-- name: DeleteQueuedEmailDelivery :exec
+-- drops a delivery whose email turned out to be queued already.
+DELETE FROM email_deliveries
+WHERE id = @id::uuid
+    AND status = 'queued';
+
+-- name: DeleteUnsentCampaignDeliveries :exec
+-- drops the queued and failed deliveries of a campaign that is sent again from scratch.
+DELETE FROM email_deliveries
+WHERE campaign_id = @campaign_id::uuid
+    AND status IN ('queued', 'failed');
+
+-- name: CreateCampaignDeliveries :many
+-- records a campaign email for every recipient, skipping recipients an earlier attempt already reached.
+INSERT INTO email_deliveries (
+    campaign_id,
+    recipient,
+    recipient_name,
+    subject
+)
+SELECT
+    @campaign_id::uuid,
+    r.recipient,
+    NULLIF(r.recipient_name, ''),
+    @subject
+FROM unnest(@recipients::text[], @recipient_names::text[]) AS r(recipient, recipient_name)
+WHERE NOT EXISTS (
+    SELECT 1
+    FROM email_deliveries d
+    WHERE d.campaign_id = @campaign_id::uuid
+        AND lower(d.recipient) = lower(r.recipient)
+)
+RETURNING id, recipient, recipient_name;
+
+-- name: MarkEmailDeliverySent :exec
+UPDATE email_deliveries
+SET
+    status = 'sent'::email_delivery_status,
+    ses_message_id = @ses_message_id,
+    error = NULL,
+    attempts = attempts + 1,
+    sent_at = now()
+WHERE id = @id::uuid;
+
+-- name: MarkEmailDeliveryFailed :exec
+UPDATE email_deliveries
+SET
+    status = 'failed'::email_delivery_status,
+    error = @error,
+    attempts = attempts + 1
+WHERE id = @id::uuid;
+
+-- name: ListEmailDeliveryCountsByCampaignIds :many
+-- counts the deliveries of every campaign by status.
+SELECT
+    campaign_id::uuid AS campaign_id,
+    count(*) FILTER (WHERE status = 'queued') AS queued,
+    count(*) FILTER (WHERE status = 'sent') AS sent,
+    count(*) FILTER (WHERE status = 'failed') AS failed,
+    count(*) FILTER (WHERE status = 'bounced') AS bounced,
+    count(*) FILTER (WHERE status = 'complained') AS complained
+FROM email_deliveries
+WHERE campaign_id = ANY(@campaign_ids::uuid[])
+GROUP BY campaign_id;
+
+-- name: ListSentEmailDeliveryIds :many
+-- returns which of the given deliveries were already sent.
+SELECT id
+FROM email_deliveries
+WHERE id = ANY(@ids::uuid[])
+    AND status = 'sent'::email_delivery_status;
+
+-- name: RequeueFailedCampaignDeliveries :many
+-- moves the failed deliveries of a campaign back to queued so they can be sent again.
+UPDATE email_deliveries
+SET
+    status = 'queued'::email_delivery_status,
+    error = NULL
+WHERE campaign_id = @campaign_id::uuid
+    AND status = 'failed'::email_delivery_status
+RETURNING id, recipient, recipient_name;
+
+-- name: UpdateEmailDeliveryStatusBySesMessageId :exec
+-- records a bounce or complaint SES reported for a sent email. A complaint is never overwritten.
+UPDATE email_deliveries
+SET
+    status = @status::email_delivery_status,
+    error = sqlc.narg(error)
+WHERE ses_message_id = @ses_message_id
+    AND status <> 'complained'::email_delivery_status;
@@ -0,0 +1,27 @@
+-- name: UpsertEmailPreference :exec
+INSERT INTO email_preferences (
+    email,
+    category,
+    subscribed
+) VALUES (
+    lower(@email::text),
+    @category::email_category,
+    @subscribed
+)
+ON CONFLICT (email, category) DO UPDATE
+SET subscribed = EXCLUDED.subscribed;
+
+-- name: ListEmailPreferences :many
+-- returns the categories an address changed. Categories without a row are subscribed.
+SELECT *
+FROM email_preferences
+WHERE email = lower(@email::text)
+ORDER BY category ASC;
+
+-- name: ListUnsubscribedEmails :many
+-- returns which of the given lowercased addresses unsubscribed from a category.
+SELECT email
+FROM email_preferences
+WHERE category = @category::email_category
+    AND NOT subscribed
+    AND email = ANY(@emails::text[]);
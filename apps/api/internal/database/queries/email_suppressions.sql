@@ -0,0 +1,24 @@
+-- name: UpsertEmailSuppression :exec
+-- stops sending to an address. A complaint replaces an earlier bounce, never the other way around.
+INSERT INTO email_suppressions (
+    email,
+    reason,
+    detail,
+    ses_message_id
+) VALUES (
+    lower(@email::text),
+    @reason::email_suppression_reason,
+    sqlc.narg(detail),
+    sqlc.narg(ses_message_id)
+)
+ON CONFLICT (email) DO UPDATE
+SET
+    reason = CASE WHEN email_suppressions.reason = 'complaint' THEN email_suppressions.reason ELSE EXCLUDED.reason END,
+    detail = EXCLUDED.detail,
+    ses_message_id = EXCLUDED.ses_message_id;
+
+-- name: ListSuppressedEmails :many
+-- returns which of the given lowercased addresses are suppressed.
+SELECT email
+FROM email_suppressions
+WHERE email = ANY(@emails::text[]);
@@ -0,0 +1,96 @@
+-- name: CreateEmailTemplate :one
+-- creates a template override. Its first version is created in the same transaction.
+INSERT INTO email_templates (
+    hackathon_id,
+    key,
+    name,
+    description,
+    current_version,
+    created_by_user_id,
+    updated_by_user_id
+) VALUES (
+    @hackathon_id,
+    @key,
+    @name,
+    sqlc.narg(description),
+    1,
+    sqlc.narg(created_by_user_id),
+    sqlc.narg(created_by_user_id)
+)
+RETURNING *;
+
+-- name: CreateEmailTemplateVersion :one
+INSERT INTO email_template_versions (
+    template_id,
+    version,
+    subject,
+    html_body,
+    text_body,
+    variables,
+    reply_to,
+    created_by_user_id
+) VALUES (
+    @template_id,
+    @version,
+    @subject,
+    @html_body,
+    sqlc.narg(text_body),
+    @variables::text[],
+    sqlc.narg(reply_to),
+    sqlc.narg(created_by_user_id)
+)
+RETURNING *;
+
+-- name: IncrementEmailTemplateVersion :one
+-- bumps current_version before its version is created. The row lock keeps concurrent edits apart.
+UPDATE email_templates
+SET
+    current_version = current_version + 1,
+    updated_by_user_id = sqlc.narg(updated_by_user_id)
+WHERE id = @id
+    AND hackathon_id = @hackathon_id
+RETURNING *;
+
+-- name: GetEmailTemplateByID :one
+SELECT *
+FROM email_templates
+WHERE id = @id
+    AND hackathon_id = @hackathon_id;
+
+-- name: ListEmailTemplates :many
+SELECT *
+FROM email_templates
+WHERE hackathon_id = @hackathon_id
+ORDER BY key ASC;
+
+-- name: DeleteEmailTemplate :execrows
+DELETE FROM email_templates
+WHERE id = @id
+    AND hackathon_id = @hackathon_id;
+
+-- name: GetEmailTemplateVersion :one
+SELECT *
+FROM email_template_versions
+WHERE template_id = @template_id
+    AND version = @version;
+
+-- name: GetEmailTemplateVersionByID :one
+SELECT *
+FROM email_template_versions
+WHERE id = @id;
+
+-- name: ListEmailTemplateVersions :many
+SELECT *
+FROM email_template_versions
+WHERE template_id = @template_id
+ORDER BY version DESC;
+
+-- name: GetCurrentEmailTemplateVersionByKey :one
+-- returns the version of a hackathon's template override that is used to send emails.
+SELECT v.*
+FROM email_template_versions v
+JOIN email_templates t
+    ON t.id = v.template_id
+    AND t.current_version = v.version
+WHERE t.hackathon_id = @hackathon_id
+    AND t.key = @key;
@@ -0,0 +1,12 @@
+-- name: GetReviewSettingsByHackathonId :one
+SELECT * FROM review_settings
+WHERE hackathon_id = @hackathon_id;
+
+-- name: UpsertReviewSettings :one
+INSERT INTO review_settings (hackathon_id, blind_review, redacted_fields, resume_access)
+VALUES (@hackathon_id, @blind_review, @redacted_fields, @resume_access)
+ON CONFLICT (hackathon_id) DO UPDATE SET
+    blind_review = EXCLUDED.blind_review,
+    redacted_fields = EXCLUDED.redacted_fields,
+    resume_access = EXCLUDED.resume_access
+RETURNING *;
@@ -0,0 +1,24 @@
+-- name: CreateReviewerConflict :one
+INSERT INTO reviewer_conflicts (reviewer_id, user_id, reason)
+VALUES (@reviewer_id, @user_id, @reason)
+ON CONFLICT (reviewer_id, user_id) DO UPDATE SET
+    reason = EXCLUDED.reason
+RETURNING *;
+
+-- name: DeleteReviewerConflict :exec
+DELETE FROM reviewer_conflicts
+WHERE reviewer_id = @reviewer_id AND user_id = @user_id;
+
+-- name: ListReviewerConflictsByReviewerId :many
+SELECT
+    rc.*,
+    users.name AS user_name,
+    users.image AS user_image
+FROM reviewer_conflicts AS rc
+JOIN users ON users.id = rc.user_id
+WHERE rc.reviewer_id = @reviewer_id
+ORDER BY rc.created_at DESC;
+
+-- name: ListReviewerConflictsByReviewerIds :many
+SELECT reviewer_id, user_id FROM reviewer_conflicts
+WHERE reviewer_id = ANY(@reviewer_ids::uuid[]);
@@ -0,0 +1,28 @@
+-- name: CreateTeamAuditLog :exec
+INSERT INTO team_audit_logs (
+    team_id,
+    actor_id,
+    action,
+    details
+) VALUES (
+    @team_id,
+    @actor_id,
+    @action,
+    @details
+);
+
+-- name: ListTeamAuditLogs :many
+-- returns the audit log of every team, or of one team, newest first.
+SELECT
+    l.id,
+    l.team_id,
+    l.actor_id,
+    u.name AS actor_name,
+    l.action,
+    l.details,
+    l.created_at
+FROM team_audit_logs l
+LEFT JOIN users u ON u.id = l.actor_id
+WHERE sqlc.narg('team_id')::uuid IS NULL OR l.team_id = sqlc.narg('team_id')
+ORDER BY l.created_at DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
@@ -0,0 +1,126 @@
+-- name: UpsertTeamListing :one
+INSERT INTO team_listings (
+    team_id,
+    hackathon_id,
+    description,
+    skills,
+    interests
+) VALUES (
+    @team_id,
+    @hackathon_id,
+    @description,
+    @skills::text[],
+    @interests::text[]
+)
+ON CONFLICT (team_id) DO UPDATE
+SET
+    hackathon_id = EXCLUDED.hackathon_id,
+    description = EXCLUDED.description,
+    skills = EXCLUDED.skills,
+    interests = EXCLUDED.interests
+RETURNING *;
+
+-- name: GetTeamListing :one
+SELECT *
+FROM team_listings
+WHERE team_id = @team_id
+    AND hackathon_id = @hackathon_id;
+
+-- name: DeleteTeamListing :execrows
+DELETE FROM team_listings
+WHERE team_id = @team_id;
+
+-- name: ListTeamListings :many
+-- returns the teams listed for a hackathon that have room for at least @min_open_slots
+-- more members, most recently updated first.
+SELECT
+    t.id,
+    t.name,
+    t.owner_id,
+    t.max_members,
+    l.description,
+    l.skills,
+    l.interests,
+    count(tm.user_id) AS member_count,
+    COALESCE(
+        json_agg(
+            json_build_object(
+                'id', u.id,
+                'name', u.name,
+                'image', u.image
+            )
+        ) FILTER (WHERE u.id IS NOT NULL),
+        '[]'
+    )::jsonb AS members,
+    l.updated_at
+FROM team_listings l
+JOIN teams t ON t.id = l.team_id
+LEFT JOIN team_members tm ON tm.team_id = t.id
+LEFT JOIN users u ON u.id = tm.user_id
+WHERE l.hackathon_id = @hackathon_id
+    AND (LOWER(t.name) LIKE LOWER('%' || COALESCE(sqlc.narg('search'), '') || '%')
+        OR LOWER(COALESCE(l.description, '')) LIKE LOWER('%' || COALESCE(sqlc.narg('search'), '') || '%'))
+    AND (cardinality(@skills::text[]) = 0 OR l.skills && @skills::text[])
+    AND (cardinality(@interests::text[]) = 0 OR l.interests && @interests::text[])
+GROUP BY t.id, l.team_id
+HAVING count(tm.user_id) <= COALESCE(t.max_members, @max_members::bigint) - @min_open_slots::bigint
+ORDER BY l.updated_at DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: UpsertLookingForTeamProfile :one
+INSERT INTO looking_for_team_profiles (
+    user_id,
+    hackathon_id,
+    bio,
+    skills,
+    interests
+) VALUES (
+    @user_id,
+    @hackathon_id,
+    @bio,
+    @skills::text[],
+    @interests::text[]
+)
+ON CONFLICT (user_id, hackathon_id) DO UPDATE
+SET
+    bio = EXCLUDED.bio,
+    skills = EXCLUDED.skills,
+    interests = EXCLUDED.interests
+RETURNING *;
+
+-- name: GetLookingForTeamProfile :one
+SELECT *
+FROM looking_for_team_profiles
+WHERE user_id = @user_id
+    AND hackathon_id = @hackathon_id;
+
+-- name: DeleteLookingForTeamProfile :execrows
+DELETE FROM looking_for_team_profiles
+WHERE user_id = @user_id
+    AND hackathon_id = @hackathon_id;
+
+-- name: ListLookingForTeamProfiles :many
+-- returns the profiles of a hackathon whose users aren't on a team yet, most recently
+-- updated first.
+SELECT
+    p.user_id,
+    u.name,
+    u.image,
+    p.bio,
+    p.skills,
+    p.interests,
+    p.updated_at
+FROM looking_for_team_profiles p
+JOIN users u ON u.id = p.user_id
+WHERE p.hackathon_id = @hackathon_id
+    AND NOT EXISTS (
+        SELECT 1
+        FROM team_members tm
+        WHERE tm.user_id = p.user_id
+    )
+    AND (LOWER(u.name) LIKE LOWER('%' || COALESCE(sqlc.narg('search'), '') || '%')
+        OR LOWER(COALESCE(p.bio, '')) LIKE LOWER('%' || COALESCE(sqlc.narg('search'), '') || '%'))
+    AND (cardinality(@skills::text[]) = 0 OR p.skills && @skills::text[])
+    AND (cardinality(@interests::text[]) = 0 OR p.interests && @interests::text[])
+ORDER BY p.updated_at DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
@@ -0,0 +1,124 @@
+-- name: CreateInvitation :one
+INSERT INTO team_invitations (
+    team_id,
+    inviter_id,
+    expires_at,
+    max_uses,
+    invited_email,
+    invited_user_id
+) VALUES (
+    @team_id,
+    @inviter_id,
+    @expires_at,
+    @max_uses,
+    @invited_email,
+    @invited_user_id
+)
+RETURNING *;
+
+-- name: GetInvitationByID :one
+SELECT * FROM team_invitations WHERE id = @id;
+
+-- name: GetActiveInviteLinkByTeamID :one
+-- returns the newest open invite link of a team that can still be used.
+SELECT *
+FROM team_invitations
+WHERE team_id = @team_id
+    AND invited_email IS NULL
+    AND invited_user_id IS NULL
+    AND status = 'pending'
+    AND (expires_at IS NULL OR expires_at > now())
+    AND (max_uses IS NULL OR use_count < max_uses)
+ORDER BY created_at DESC
+LIMIT 1;
+
+-- name: GetPendingInvitationTo :one
+-- returns an unexpired invitation of a team sent to a user or an email that wasn't used yet.
+SELECT *
+FROM team_invitations
+WHERE team_id = @team_id
+    AND (invited_user_id = sqlc.narg('user_id') OR LOWER(invited_email) = LOWER(sqlc.narg('email')))
+    AND status = 'pending'
+    AND (expires_at IS NULL OR expires_at > now())
+LIMIT 1;
+
+-- name: ListTeamInvitations :many
+SELECT
+    ti.id,
+    ti.inviter_id,
+    ti.invited_email,
+    ti.invited_user_id,
+    ti.status,
+    ti.expires_at,
+    ti.max_uses,
+    ti.use_count,
+    ti.created_at,
+    COALESCE(
+        (
+            SELECT json_agg(
+                json_build_object(
+                    'userId', u.id,
+                    'name', u.name,
+                    'image', u.image,
+                    'usedAt', tiu.used_at
+                )
+                ORDER BY tiu.used_at
+            )
+            FROM team_invitation_uses tiu
+            JOIN users u ON u.id = tiu.user_id
+            WHERE tiu.invitation_id = ti.id
+        ),
+        '[]'
+    )::jsonb AS uses
+FROM team_invitations ti
+WHERE ti.team_id = @team_id
+ORDER BY ti.created_at DESC;
+
+-- name: ListPendingInvitationsForUser :many
+-- returns the unexpired invitations sent to a user, either to their account or to their
+-- email before they had one.
+SELECT
+    ti.id,
+    ti.team_id,
+    t.name AS team_name,
+    u.name AS inviter_name,
+    ti.expires_at,
+    ti.created_at
+FROM team_invitations ti
+JOIN teams t ON t.id = ti.team_id
+JOIN users u ON u.id = ti.inviter_id
+WHERE (ti.invited_user_id = @user_id::uuid OR LOWER(ti.invited_email) = LOWER(@email::text))
+    AND ti.status = 'pending'
+    AND (ti.expires_at IS NULL OR ti.expires_at > now())
+ORDER BY ti.created_at DESC;
+
+-- name: UseInvitation :one
+-- counts a use of an invitation that is still valid. Invitations sent to a person are
+-- accepted by their first use. Returns no rows if the invitation can't be used.
+UPDATE team_invitations
+SET
+    use_count = use_count + 1,
+    status = CASE
+        WHEN invited_email IS NOT NULL OR invited_user_id IS NOT NULL THEN 'accepted'::team_invitation_status
+        ELSE status
+    END
+WHERE id = @id
+    AND status = 'pending'
+    AND (expires_at IS NULL OR expires_at > now())
+    AND (max_uses IS NULL OR use_count < max_uses)
+RETURNING *;
+
+-- name: CreateInvitationUse :exec
+INSERT INTO team_invitation_uses (invitation_id, user_id)
+VALUES (@invitation_id, @user_id);
+
+-- name: UpdateInvitationStatus :one
+-- closes a pending invitation. Returns no rows if it was already closed.
+UPDATE team_invitations
+SET status = @status
+WHERE id = @id
+    AND status = 'pending'
+RETURNING *;
+
+-- name: DeleteInvitation :exec
+DELETE FROM team_invitations WHERE id = @id;
@@ -0,0 +1,86 @@
+-- name: CreateTeamJoinRequest :one
+-- creates a pending request. A pending request for the same team that already expired
+-- but wasn't swept yet is renewed instead; one that is still open returns no rows.
+INSERT INTO team_join_requests (
+    team_id,
+    user_id,
+    request_message,
+    expires_at
+) VALUES (
+    @team_id,
+    @user_id,
+    @request_message,
+    @expires_at
+)
+ON CONFLICT (team_id, user_id) WHERE status = 'pending'::team_join_request_status DO UPDATE
+SET
+    request_message = EXCLUDED.request_message,
+    expires_at = EXCLUDED.expires_at,
+    created_at = now()
+WHERE team_join_requests.expires_at <= now()
+RETURNING *;
+
+-- name: GetTeamJoinRequestById :one
+SELECT *
+FROM team_join_requests
+WHERE id = @id;
+
+-- name: ListPendingTeamJoinRequestsByTeam :many
+SELECT
+    r.id,
+    r.user_id,
+    u.name,
+    u.image,
+    r.request_message,
+    r.created_at,
+    r.expires_at
+FROM team_join_requests r
+JOIN users u ON u.id = r.user_id
+WHERE r.team_id = @team_id
+    AND r.status = 'pending'
+    AND r.expires_at > now()
+ORDER BY r.created_at ASC;
+
+-- name: ListPendingTeamJoinRequestsByUser :many
+SELECT
+    r.id,
+    r.team_id,
+    t.name AS team_name,
+    r.request_message,
+    r.created_at,
+    r.expires_at
+FROM team_join_requests r
+JOIN teams t ON t.id = r.team_id
+WHERE r.user_id = @user_id
+    AND r.status = 'pending'
+    AND r.expires_at > now()
+ORDER BY r.created_at DESC;
+
+-- name: UpdateTeamJoinRequestStatus :one
+-- closes a request that is still open. Returns no rows if it was already answered,
+-- cancelled or expired.
+UPDATE team_join_requests
+SET
+    status = @status,
+    processed_by_user_id = @processed_by_user_id,
+    processed_at = now()
+WHERE id = @id
+    AND status = 'pending'
+    AND expires_at > now()
+RETURNING *;
+
+-- name: CancelPendingTeamJoinRequestsByUser :exec
+UPDATE team_join_requests
+SET
+    status = 'cancelled',
+    processed_at = now()
+WHERE user_id = @user_id
+    AND status = 'pending';
+
+-- name: ExpireTeamJoinRequests :execrows
+UPDATE team_join_requests
+SET
+    status = 'expired',
+    processed_at = now()
+WHERE status = 'pending'
+    AND expires_at <= now();
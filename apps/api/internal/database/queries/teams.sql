@@ -61,28 +61,73 @@ RETURNING *;
 DELETE FROM teams WHERE id = @id;
 
 -- name: ListTeamsWithMembers :many
+-- returns every team with its members and their application status for the hackathon,
+-- newest first. @search matches the team name or the name or email of a member.
 SELECT
     t.id,
     t.name,
     t.owner_id,
-    (COALESCE(
+    t.max_members,
+    t.created_at,
+    count(tm.user_id) AS member_count,
+    COALESCE(
         json_agg(
             json_build_object(
-                'user_id', u.id,
+                'id', u.id,
                 'name', u.name,
                 'email', u.email,
                 'image', u.image,
-                'joined_at', tm.joined_at
-            )
+                'joinedAt', tm.joined_at,
+                'applicationStatus', a.status
+            ) ORDER BY tm.joined_at
         ) FILTER (WHERE u.id IS NOT NULL),
-        '[]'::json
-    ))::jsonb AS members -- <--- Explicitly cast to jsonb
+        '[]'
+    )::jsonb AS members
 FROM teams t
 LEFT JOIN team_members tm ON t.id = tm.team_id
 LEFT JOIN users u ON tm.user_id = u.id
+LEFT JOIN applications a ON a.user_id = u.id AND a.hackathon_id = @hackathon_id
+WHERE LOWER(t.name) LIKE LOWER('%' || COALESCE(sqlc.narg('search'), '') || '%')
+    OR EXISTS (
+        SELECT 1
+        FROM team_members stm
+        JOIN users su ON su.id = stm.user_id
+        WHERE stm.team_id = t.id
+            AND (LOWER(su.name) LIKE LOWER('%' || COALESCE(sqlc.narg('search'), '') || '%')
+                OR LOWER(COALESCE(su.email, '')) LIKE LOWER('%' || COALESCE(sqlc.narg('search'), '') || '%'))
+    )
 GROUP BY t.id
 ORDER BY t.created_at DESC
-LIMIT $1 OFFSET $2;
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: GetTeamWithMembers :one
+-- returns a team with its members and their application status for the hackathon.
+SELECT
+    t.id,
+    t.name,
+    t.owner_id,
+    t.max_members,
+    t.created_at,
+    count(tm.user_id) AS member_count,
+    COALESCE(
+        json_agg(
+            json_build_object(
+                'id', u.id,
+                'name', u.name,
+                'email', u.email,
+                'image', u.image,
+                'joinedAt', tm.joined_at,
+                'applicationStatus', a.status
+            ) ORDER BY tm.joined_at
+        ) FILTER (WHERE u.id IS NOT NULL),
+        '[]'
+    )::jsonb AS members
+FROM teams t
+LEFT JOIN team_members tm ON t.id = tm.team_id
+LEFT JOIN users u ON tm.user_id = u.id
+LEFT JOIN applications a ON a.user_id = u.id AND a.hackathon_id = @hackathon_id
+WHERE t.id = @id
+GROUP BY t.id;
 
 -- name: AddUserToTeam :one
 INSERT INTO team_members (team_id, user_id) VALUES (@team_id, @user_id) RETURNING *;
@@ -90,19 +135,41 @@ INSERT INTO team_members (team_id, user_id) VALUES (@team_id, @user_id) RETURNIN
 -- name: RemoveUserFromTeam :exec
 DELETE FROM team_members WHERE user_id = @user_id AND team_id = @team_id;
 
--- name: CreateInvitation :one
-INSERT INTO team_invitations (
-    team_id, inviter_id, expires_at
-) VALUES (
-    @team_id, @inviter_id, @expires_at
-)
-RETURNING *;
+-- name: GetTeamByIdForUpdate :one
+-- locks the team so members are added one at a time and the member limit holds.
+SELECT *
+FROM teams
+WHERE id = @id
+FOR UPDATE;
+
+-- name: CountTeamMembers :one
+SELECT count(*)
+FROM team_members
+WHERE team_id = @team_id;
 
--- name: GetInvitationByID :one
-SELECT * FROM team_invitations WHERE id = @id;
+-- name: GetLongestTenuredTeamMember :one
+-- returns the member who has been on the team the longest, other than the given user.
+SELECT user_id
+FROM team_members
+WHERE team_id = @team_id
+    AND user_id <> @user_id
+ORDER BY joined_at, user_id
+LIMIT 1;
+
+-- name: SetTeamMaxMembers :one
+UPDATE teams
+SET max_members = sqlc.narg('max_members')
+WHERE id = @id
+RETURNING *;
 
--- name: GetInvitationByTeamID :one
-SELECT * FROM team_invitations WHERE team_id = @team_id;
+-- name: MoveTeamMember :execrows
+-- the member's tenure starts over on the new team.
+UPDATE team_members
+SET team_id = @to_team_id, joined_at = now()
+WHERE user_id = @user_id
+    AND team_id = @from_team_id;
 
--- name: DeleteInvitation :exec
-DELETE FROM team_invitations WHERE id = @id;
\ No newline at end of file
+-- name: MoveAllTeamMembers :execrows
+UPDATE team_members
+SET team_id = @to_team_id, joined_at = now()
+WHERE team_id = @from_team_id;
@@ -21,7 +21,9 @@ func NewBatRunsRepository(db *database.DB) *BatRunsRepository {
 }
 
 func (r *BatRunsRepository) AddRun(ctx context.Context, hackathonID string) (*sqlc.BatRun, error) {
-	run, err := r.db.Query.AddBatRun(ctx, hackathonID)
+	run, err := r.db.Query.AddBatRun(ctx, sqlc.AddBatRunParams{
+		HackathonID: hackathonID,
+	})
 	if err != nil {
 		if database.IsUniqueViolation(err) {
 			return nil, database.ErrDuplicateRun
@@ -88,3 +88,66 @@ func (r *EmailCampaignRepository) UpdateEmailCampaignStatus(
 	}
 	return &campaign, nil
 }
+
+func (r *EmailCampaignRepository) StartEmailCampaignSending(
+	ctx context.Context,
+	params sqlc.StartEmailCampaignSendingParams,
+) (*sqlc.EmailCampaign, error) {
+	campaign, err := r.db.Query.StartEmailCampaignSending(ctx, params)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrEmailCampaignNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &campaign, nil
+}
+
+func (r *EmailCampaignRepository) ListEmailCampaignRecipients(
+	ctx context.Context,
+	params sqlc.ListEmailCampaignRecipientsParams,
+) ([]sqlc.ListEmailCampaignRecipientsRow, error) {
+	return r.db.Query.ListEmailCampaignRecipients(ctx, params)
+}
+
+func (r *EmailCampaignRepository) UpdateEmailCampaignRecipientCount(
+	ctx context.Context,
+	params sqlc.UpdateEmailCampaignRecipientCountParams,
+) error {
+	return r.db.Query.UpdateEmailCampaignRecipientCount(ctx, params)
+}
+
+func (r *EmailCampaignRepository) RecordEmailCampaignBatch(
+	ctx context.Context,
+	params sqlc.RecordEmailCampaignBatchParams,
+) (*sqlc.EmailCampaign, error) {
+	campaign, err := r.db.Query.RecordEmailCampaignBatch(ctx, params)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrEmailCampaignNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &campaign, nil
+}
+
+func (r *EmailCampaignRepository) ClaimDueEmailCampaigns(
+	ctx context.Context,
+	maxCampaigns int32,
+) ([]sqlc.EmailCampaign, error) {
+	return r.db.Query.ClaimDueEmailCampaigns(ctx, maxCampaigns)
+}
+
+func (r *EmailCampaignRepository) StartEmailCampaignResend(
+	ctx context.Context,
+	params sqlc.StartEmailCampaignResendParams,
+) (*sqlc.EmailCampaign, error) {
+	campaign, err := r.db.Query.StartEmailCampaignResend(ctx, params)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrEmailCampaignNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &campaign, nil
+}
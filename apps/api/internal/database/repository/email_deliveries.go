@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/swamphacks/core/apps/api/internal/database"
+	"github.com/swamphacks/core/apps/api/internal/database/sqlc"
+)
+
+type EmailDeliveryRepository struct {
+	db *database.DB
+}
+
+func (r *EmailDeliveryRepository) NewTx(tx pgx.Tx) *EmailDeliveryRepository {
+	txDB := &database.DB{
+		Pool:  r.db.Pool,
+		Query: sqlc.New(tx),
+	}
+	return &EmailDeliveryRepository{db: txDB}
+}
+
+func NewEmailDeliveryRepository(db *database.DB) *EmailDeliveryRepository {
+	return &EmailDeliveryRepository{db: db}
+}
+
+func (r *EmailDeliveryRepository) CreateEmailDelivery(
+	ctx context.Context,
+	params sqlc.CreateEmailDeliveryParams,
+) (*sqlc.EmailDelivery, error) {
+	delivery, err := r.db.Query.CreateEmailDelivery(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+func (r *EmailDeliveryRepository) CreateCampaignDeliveries(
+	ctx context.Context,
+	params sqlc.CreateCampaignDeliveriesParams,
+) ([]sqlc.CreateCampaignDeliveriesRow, error) {
+	return r.db.Query.CreateCampaignDeliveries(ctx, params)
+}
+
+func (r *EmailDeliveryRepository) DeleteQueuedEmailDelivery(ctx context.Context, id uuid.UUID) error {
+	return r.db.Query.DeleteQueuedEmailDelivery(ctx, id)
+}
+
+func (r *EmailDeliveryRepository) DeleteUnsentCampaignDeliveries(ctx context.Context, campaignID uuid.UUID) error {
+	return r.db.Query.DeleteUnsentCampaignDeliveries(ctx, campaignID)
+}
+
+func (r *EmailDeliveryRepository) MarkEmailDeliverySent(
+	ctx context.Context,
+	params sqlc.MarkEmailDeliverySentParams,
+) error {
+	return r.db.Query.MarkEmailDeliverySent(ctx, params)
+}
+
+func (r *EmailDeliveryRepository) MarkEmailDeliveryFailed(
+	ctx context.Context,
+	params sqlc.MarkEmailDeliveryFailedParams,
+) error {
+	return r.db.Query.MarkEmailDeliveryFailed(ctx, params)
+}
+
+func (r *EmailDeliveryRepository) ListEmailDeliveryCountsByCampaignIds(
+	ctx context.Context,
+	campaignIDs []uuid.UUID,
+) ([]sqlc.ListEmailDeliveryCountsByCampaignIdsRow, error) {
+	return r.db.Query.ListEmailDeliveryCountsByCampaignIds(ctx, campaignIDs)
+}
+
+func (r *EmailDeliveryRepository) ListSentEmailDeliveryIds(ctx context.Context, ids []uuid.UUID) ([]uuid.UUID, error) {
+	return r.db.Query.ListSentEmailDeliveryIds(ctx, ids)
+}
+
+func (r *EmailDeliveryRepository) RequeueFailedCampaignDeliveries(
+	ctx context.Context,
+	campaignID uuid.UUID,
+) ([]sqlc.RequeueFailedCampaignDeliveriesRow, error) {
+	return r.db.Query.RequeueFailedCampaignDeliveries(ctx, campaignID)
+}
+
+func (r *EmailDeliveryRepository) UpdateEmailDeliveryStatusBySesMessageId(
+	ctx context.Context,
+	params sqlc.UpdateEmailDeliveryStatusBySesMessageIdParams,
+) error {
+	return r.db.Query.UpdateEmailDeliveryStatusBySesMessageId(ctx, params)
+}
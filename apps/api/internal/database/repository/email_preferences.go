@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/swamphacks/core/apps/api/internal/database"
+	"github.com/swamphacks/core/apps/api/internal/database/sqlc"
+)
+
+type EmailPreferenceRepository struct {
+	db *database.DB
+}
+
+func (r *EmailPreferenceRepository) NewTx(tx pgx.Tx) *EmailPreferenceRepository {
+	txDB := &database.DB{
+		Pool:  r.db.Pool,
+		Query: sqlc.New(tx),
+	}
+	return &EmailPreferenceRepository{db: txDB}
+}
+
+func NewEmailPreferenceRepository(db *database.DB) *EmailPreferenceRepository {
+	return &EmailPreferenceRepository{db: db}
+}
+
+func (r *EmailPreferenceRepository) UpsertEmailPreference(
+	ctx context.Context,
+	params sqlc.UpsertEmailPreferenceParams,
+) error {
+	return r.db.Query.UpsertEmailPreference(ctx, params)
+}
+
+// ListEmailPreferences returns the categories an address changed. Categories without
+// a row are subscribed.
+func (r *EmailPreferenceRepository) ListEmailPreferences(
+	ctx context.Context,
+	email string,
+) ([]sqlc.EmailPreference, error) {
+	return r.db.Query.ListEmailPreferences(ctx, email)
+}
+
+// ListUnsubscribedEmails returns which of the given lowercased addresses unsubscribed
+// from a category.
+func (r *EmailPreferenceRepository) ListUnsubscribedEmails(
+	ctx context.Context,
+	params sqlc.ListUnsubscribedEmailsParams,
+) ([]string, error) {
+	return r.db.Query.ListUnsubscribedEmails(ctx, params)
+}
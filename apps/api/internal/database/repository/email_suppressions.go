@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/swamphacks/core/apps/api/internal/database"
+	"github.com/swamphacks/core/apps/api/internal/database/sqlc"
+)
+
+type EmailSuppressionRepository struct {
+	db *database.DB
+}
+
+func (r *EmailSuppressionRepository) NewTx(tx pgx.Tx) *EmailSuppressionRepository {
+	txDB := &database.DB{
+		Pool:  r.db.Pool,
+		Query: sqlc.New(tx),
+	}
+	return &EmailSuppressionRepository{db: txDB}
+}
+
+func NewEmailSuppressionRepository(db *database.DB) *EmailSuppressionRepository {
+	return &EmailSuppressionRepository{db: db}
+}
+
+func (r *EmailSuppressionRepository) UpsertEmailSuppression(
+	ctx context.Context,
+	params sqlc.UpsertEmailSuppressionParams,
+) error {
+	return r.db.Query.UpsertEmailSuppression(ctx, params)
+}
+
+// ListSuppressedEmails returns which of the given lowercased addresses are suppressed.
+func (r *EmailSuppressionRepository) ListSuppressedEmails(
+	ctx context.Context,
+	emails []string,
+) ([]string, error) {
+	return r.db.Query.ListSuppressedEmails(ctx, emails)
+}
@@ -0,0 +1,157 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/swamphacks/core/apps/api/internal/database"
+	"github.com/swamphacks/core/apps/api/internal/database/sqlc"
+)
+
+var (
+	ErrEmailTemplateNotFound = errors.New("email template not found")
+	ErrEmailTemplateExists   = errors.New("hackathon already overrides this email template")
+)
+
+type EmailTemplateRepository struct {
+	db *database.DB
+}
+
+func (r *EmailTemplateRepository) NewTx(tx pgx.Tx) *EmailTemplateRepository {
+	txDB := &database.DB{
+		Pool:  r.db.Pool,
+		Query: sqlc.New(tx),
+	}
+	return &EmailTemplateRepository{db: txDB}
+}
+
+func NewEmailTemplateRepository(db *database.DB) *EmailTemplateRepository {
+	return &EmailTemplateRepository{db: db}
+}
+
+func (r *EmailTemplateRepository) CreateEmailTemplate(
+	ctx context.Context,
+	params sqlc.CreateEmailTemplateParams,
+) (*sqlc.EmailTemplate, error) {
+	template, err := r.db.Query.CreateEmailTemplate(ctx, params)
+	if err != nil {
+		if database.IsUniqueViolation(err) {
+			return nil, ErrEmailTemplateExists
+		}
+		return nil, err
+	}
+	return &template, nil
+}
+
+func (r *EmailTemplateRepository) CreateEmailTemplateVersion(
+	ctx context.Context,
+	params sqlc.CreateEmailTemplateVersionParams,
+) (*sqlc.EmailTemplateVersion, error) {
+	version, err := r.db.Query.CreateEmailTemplateVersion(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return &version, nil
+}
+
+func (r *EmailTemplateRepository) IncrementEmailTemplateVersion(
+	ctx context.Context,
+	params sqlc.IncrementEmailTemplateVersionParams,
+) (*sqlc.EmailTemplate, error) {
+	template, err := r.db.Query.IncrementEmailTemplateVersion(ctx, params)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrEmailTemplateNotFound
+		}
+		return nil, err
+	}
+	return &template, nil
+}
+
+func (r *EmailTemplateRepository) GetEmailTemplateByID(
+	ctx context.Context,
+	params sqlc.GetEmailTemplateByIDParams,
+) (*sqlc.EmailTemplate, error) {
+	template, err := r.db.Query.GetEmailTemplateByID(ctx, params)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrEmailTemplateNotFound
+		}
+		return nil, err
+	}
+	return &template, nil
+}
+
+func (r *EmailTemplateRepository) ListEmailTemplates(
+	ctx context.Context,
+	hackathonID string,
+) ([]sqlc.EmailTemplate, error) {
+	return r.db.Query.ListEmailTemplates(ctx, hackathonID)
+}
+
+func (r *EmailTemplateRepository) DeleteEmailTemplate(
+	ctx context.Context,
+	params sqlc.DeleteEmailTemplateParams,
+) error {
+	rows, err := r.db.Query.DeleteEmailTemplate(ctx, params)
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrEmailTemplateNotFound
+	}
+	return nil
+}
+
+func (r *EmailTemplateRepository) GetEmailTemplateVersion(
+	ctx context.Context,
+	params sqlc.GetEmailTemplateVersionParams,
+) (*sqlc.EmailTemplateVersion, error) {
+	version, err := r.db.Query.GetEmailTemplateVersion(ctx, params)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrEmailTemplateNotFound
+		}
+		return nil, err
+	}
+	return &version, nil
+}
+
+func (r *EmailTemplateRepository) GetEmailTemplateVersionByID(
+	ctx context.Context,
+	id uuid.UUID,
+) (*sqlc.EmailTemplateVersion, error) {
+	version, err := r.db.Query.GetEmailTemplateVersionByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrEmailTemplateNotFound
+		}
+		return nil, err
+	}
+	return &version, nil
+}
+
+func (r *EmailTemplateRepository) ListEmailTemplateVersions(
+	ctx context.Context,
+	templateID uuid.UUID,
+) ([]sqlc.EmailTemplateVersion, error) {
+	return r.db.Query.ListEmailTemplateVersions(ctx, templateID)
+}
+
+// GetCurrentEmailTemplateVersionByKey returns ErrEmailTemplateNotFound when the hackathon
+// doesn't override the template.
+func (r *EmailTemplateRepository) GetCurrentEmailTemplateVersionByKey(
+	ctx context.Context,
+	params sqlc.GetCurrentEmailTemplateVersionByKeyParams,
+) (*sqlc.EmailTemplateVersion, error) {
+	version, err := r.db.Query.GetCurrentEmailTemplateVersionByKey(ctx, params)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrEmailTemplateNotFound
+		}
+		return nil, err
+	}
+	return &version, nil
+}
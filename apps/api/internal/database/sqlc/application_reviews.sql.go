@@ -65,6 +65,33 @@ func (q *Queries) DeleteAutoDecisionRequest(ctx context.Context, arg DeleteAutoD
 	return err
 }
 
+const deleteIncompleteReviewsByReviewerId = `-- name: DeleteIncompleteReviewsByReviewerId :many
+DELETE FROM application_reviews
+WHERE reviewer_id = $1
+    AND (passion_rating IS NULL OR experience_rating IS NULL)
+RETURNING application_id
+`
+
+func (q *Queries) DeleteIncompleteReviewsByReviewerId(ctx context.Context, reviewerID uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := q.db.Query(ctx, deleteIncompleteReviewsByReviewerId, reviewerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []uuid.UUID{}
+	for rows.Next() {
+		var application_id uuid.UUID
+		if err := rows.Scan(&application_id); err != nil {
+			return nil, err
+		}
+		items = append(items, application_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getAutoDecisionRequestsCount = `-- name: GetAutoDecisionRequestsCount :one
 SELECT COUNT(*) FROM application_auto_decision_requests
 `
@@ -244,6 +271,125 @@ func (q *Queries) ListAutoDecisionRequests(ctx context.Context) ([]ListAutoDecis
 	return items, nil
 }
 
+const listCompletedReviews = `-- name: ListCompletedReviews :many
+SELECT
+    ar.application_id,
+    ar.reviewer_id,
+    ar.passion_rating::int AS passion_rating,
+    ar.experience_rating::int AS experience_rating
+FROM application_reviews AS ar
+JOIN applications ON applications.id = ar.application_id
+WHERE ar.passion_rating IS NOT NULL
+    AND ar.experience_rating IS NOT NULL
+    AND ($1::text IS NULL OR applications.hackathon_id = $1)
+ORDER BY ar.reviewer_id ASC, ar.application_id ASC
+`
+
+type ListCompletedReviewsRow struct {
+	ApplicationID    uuid.UUID `json:"application_id"`
+	ReviewerID       uuid.UUID `json:"reviewer_id"`
+	PassionRating    int32     `json:"passion_rating"`
+	ExperienceRating int32     `json:"experience_rating"`
+}
+
+func (q *Queries) ListCompletedReviews(ctx context.Context, hackathonID *string) ([]ListCompletedReviewsRow, error) {
+	rows, err := q.db.Query(ctx, listCompletedReviews, hackathonID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListCompletedReviewsRow{}
+	for rows.Next() {
+		var i ListCompletedReviewsRow
+		if err := rows.Scan(
+			&i.ApplicationID,
+			&i.ReviewerID,
+			&i.PassionRating,
+			&i.ExperienceRating,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEligibleReviewerIds = `-- name: ListEligibleReviewerIds :many
+SELECT id FROM users
+WHERE role IN ('staff'::user_role, 'admin'::user_role)
+ORDER BY id
+`
+
+// returns the staff and admins, who can be assigned reviews.
+func (q *Queries) ListEligibleReviewerIds(ctx context.Context) ([]uuid.UUID, error) {
+	rows, err := q.db.Query(ctx, listEligibleReviewerIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []uuid.UUID{}
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listReviewerAffiliations = `-- name: ListReviewerAffiliations :many
+SELECT
+    u.id,
+    t.id AS team_id,
+    COALESCE(latest.school, '')::text AS school
+FROM users u
+LEFT JOIN team_members tm
+    ON tm.user_id = u.id
+LEFT JOIN teams t
+    ON t.id = tm.team_id
+LEFT JOIN LATERAL (
+    SELECT applications.application->>'school' AS school
+    FROM applications
+    WHERE applications.user_id = u.id
+    ORDER BY applications.created_at DESC
+    LIMIT 1
+) AS latest ON true
+WHERE u.id = ANY($1::uuid[])
+`
+
+type ListReviewerAffiliationsRow struct {
+	ID     uuid.UUID  `json:"id"`
+	TeamID *uuid.UUID `json:"team_id"`
+	School string     `json:"school"`
+}
+
+func (q *Queries) ListReviewerAffiliations(ctx context.Context, userIds []uuid.UUID) ([]ListReviewerAffiliationsRow, error) {
+	rows, err := q.db.Query(ctx, listReviewerAffiliations, userIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListReviewerAffiliationsRow{}
+	for rows.Next() {
+		var i ListReviewerAffiliationsRow
+		if err := rows.Scan(&i.ID, &i.TeamID, &i.School); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listReviewersAndProgress = `-- name: ListReviewersAndProgress :many
 SELECT
     reviewer.id,
@@ -254,8 +400,11 @@ SELECT
     WHERE ar.experience_rating IS NOT NULL AND ar.passion_rating IS NOT NULL
     ) AS completed_count
 FROM application_reviews AS ar
+JOIN applications
+  ON applications.id = ar.application_id
 LEFT JOIN users AS reviewer
   ON reviewer.id = ar.reviewer_id
+WHERE applications.hackathon_id = $1
 GROUP BY
   reviewer.id
 `
@@ -268,8 +417,8 @@ type ListReviewersAndProgressRow struct {
 	CompletedCount int64      `json:"completed_count"`
 }
 
-func (q *Queries) ListReviewersAndProgress(ctx context.Context) ([]ListReviewersAndProgressRow, error) {
-	rows, err := q.db.Query(ctx, listReviewersAndProgress)
+func (q *Queries) ListReviewersAndProgress(ctx context.Context, hackathonID string) ([]ListReviewersAndProgressRow, error) {
+	rows, err := q.db.Query(ctx, listReviewersAndProgress, hackathonID)
 	if err != nil {
 		return nil, err
 	}
@@ -294,6 +443,36 @@ func (q *Queries) ListReviewersAndProgress(ctx context.Context) ([]ListReviewers
 	return items, nil
 }
 
+const listReviewsByApplicationIds = `-- name: ListReviewsByApplicationIds :many
+SELECT application_id, reviewer_id FROM application_reviews
+WHERE application_id = ANY($1::uuid[])
+`
+
+type ListReviewsByApplicationIdsRow struct {
+	ApplicationID uuid.UUID `json:"application_id"`
+	ReviewerID    uuid.UUID `json:"reviewer_id"`
+}
+
+func (q *Queries) ListReviewsByApplicationIds(ctx context.Context, applicationIds []uuid.UUID) ([]ListReviewsByApplicationIdsRow, error) {
+	rows, err := q.db.Query(ctx, listReviewsByApplicationIds, applicationIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListReviewsByApplicationIdsRow{}
+	for rows.Next() {
+		var i ListReviewsByApplicationIdsRow
+		if err := rows.Scan(&i.ApplicationID, &i.ReviewerID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listReviewsByReviewerId = `-- name: ListReviewsByReviewerId :many
 SELECT 
     ar.id, ar.application_id, ar.reviewer_id, ar.experience_rating, ar.passion_rating, ar.notes, ar.updated_by, ar.created_at, ar.updated_at,
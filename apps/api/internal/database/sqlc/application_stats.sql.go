@@ -83,164 +83,43 @@ func (q *Queries) GetSubmissionTimes(ctx context.Context) ([]GetSubmissionTimesR
 	return items, nil
 }
 
-const getSubmittedApplicationAges = `-- name: GetSubmittedApplicationAges :one
+const getSubmittedApplicationFieldCounts = `-- name: GetSubmittedApplicationFieldCounts :many
 SELECT
-    COUNT(*) FILTER (WHERE (application->>'age') = '<18') AS underage,
-    COUNT(*) FILTER (WHERE (application->>'age') = '18-22') AS between_18_and_22,
-    COUNT(*) FILTER (WHERE (application->>'age') = '>22') AS older_than_22
-FROM applications
-WHERE status <> 'started' AND status IS NOT NULL
-`
-
-type GetSubmittedApplicationAgesRow struct {
-	Underage       int64 `json:"underage"`
-	Between18And22 int64 `json:"between_18_and_22"`
-	OlderThan22    int64 `json:"older_than_22"`
-}
-
-func (q *Queries) GetSubmittedApplicationAges(ctx context.Context) (GetSubmittedApplicationAgesRow, error) {
-	row := q.db.QueryRow(ctx, getSubmittedApplicationAges)
-	var i GetSubmittedApplicationAgesRow
-	err := row.Scan(&i.Underage, &i.Between18And22, &i.OlderThan22)
-	return i, err
-}
-
-const getSubmittedApplicationGenders = `-- name: GetSubmittedApplicationGenders :one
-
-SELECT
-  COUNT(*) FILTER (WHERE application->>'gender' = 'man') AS male,
-  COUNT(*) FILTER (WHERE application->>'gender' = 'woman') AS female,
-  COUNT(*) FILTER (WHERE application->>'gender' = 'non-binary') AS non_binary,
-  COUNT(*) FILTER (WHERE application->>'gender' = '') AS other
-FROM applications
-WHERE status <> 'started' AND status IS NOT NULL
-`
-
-type GetSubmittedApplicationGendersRow struct {
-	Male      int64 `json:"male"`
-	Female    int64 `json:"female"`
-	NonBinary int64 `json:"non_binary"`
-	Other     int64 `json:"other"`
-}
-
-// Queries used for statistics, mainly used by overview dashboards etc
-func (q *Queries) GetSubmittedApplicationGenders(ctx context.Context) (GetSubmittedApplicationGendersRow, error) {
-	row := q.db.QueryRow(ctx, getSubmittedApplicationGenders)
-	var i GetSubmittedApplicationGendersRow
-	err := row.Scan(
-		&i.Male,
-		&i.Female,
-		&i.NonBinary,
-		&i.Other,
-	)
-	return i, err
-}
-
-const getSubmittedApplicationMajors = `-- name: GetSubmittedApplicationMajors :many
-SELECT
-    trim(major) AS major,
+    COALESCE(NULLIF(trim(answer), ''), 'no_answer')::text AS answer,
     COUNT(*) AS count
 FROM applications,
-LATERAL unnest(string_to_array(application->>'majors', ',')) AS major
-WHERE status <> 'started' AND status IS NOT NULL
-GROUP BY trim(major)
-ORDER BY count DESC
-`
-
-type GetSubmittedApplicationMajorsRow struct {
-	Major string `json:"major"`
-	Count int64  `json:"count"`
-}
-
-func (q *Queries) GetSubmittedApplicationMajors(ctx context.Context) ([]GetSubmittedApplicationMajorsRow, error) {
-	rows, err := q.db.Query(ctx, getSubmittedApplicationMajors)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	items := []GetSubmittedApplicationMajorsRow{}
-	for rows.Next() {
-		var i GetSubmittedApplicationMajorsRow
-		if err := rows.Scan(&i.Major, &i.Count); err != nil {
-			return nil, err
-		}
-		items = append(items, i)
-	}
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
-	return items, nil
-}
-
-const getSubmittedApplicationRaces = `-- name: GetSubmittedApplicationRaces :many
-SELECT
-    CASE 
-        WHEN application->>'race' IS NOT NULL AND application->>'race' <> '' THEN application->>'race'
-        WHEN application->>'race-other' IS NOT NULL AND application->>'race-other' <> '' THEN application->>'race-other'
-        ELSE 'prefer_not_to_say'
-    END AS race_group,
-    COUNT(*) AS count
-FROM applications
-WHERE status <> 'started' AND status IS NOT NULL
-GROUP BY 
-    CASE 
-        WHEN application->>'race' IS NOT NULL AND application->>'race' <> '' THEN application->>'race'
-        WHEN application->>'race-other' IS NOT NULL AND application->>'race-other' <> '' THEN application->>'race-other'
-        ELSE 'prefer_not_to_say'
+LATERAL unnest(
+    CASE WHEN $1::boolean
+        THEN COALESCE(string_to_array(application->>$2::text, ','), ARRAY[NULL::text])
+        ELSE ARRAY[application->>$2::text]
     END
+) AS answer
+WHERE hackathon_id = $3 AND status <> 'started' AND status IS NOT NULL
+GROUP BY 1
 ORDER BY count DESC
 `
 
-type GetSubmittedApplicationRacesRow struct {
-	RaceGroup string `json:"race_group"`
-	Count     int64  `json:"count"`
-}
-
-func (q *Queries) GetSubmittedApplicationRaces(ctx context.Context) ([]GetSubmittedApplicationRacesRow, error) {
-	rows, err := q.db.Query(ctx, getSubmittedApplicationRaces)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	items := []GetSubmittedApplicationRacesRow{}
-	for rows.Next() {
-		var i GetSubmittedApplicationRacesRow
-		if err := rows.Scan(&i.RaceGroup, &i.Count); err != nil {
-			return nil, err
-		}
-		items = append(items, i)
-	}
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
-	return items, nil
+type GetSubmittedApplicationFieldCountsParams struct {
+	Split       bool   `json:"split"`
+	Field       string `json:"field"`
+	HackathonID string `json:"hackathon_id"`
 }
 
-const getSubmittedApplicationSchools = `-- name: GetSubmittedApplicationSchools :many
-SELECT
-    (application->>'school')::text AS school,
-    COUNT(*) AS count
-FROM applications
-WHERE status <> 'started' AND status IS NOT NULL
-GROUP BY (application->>'school')::text
-ORDER BY count DESC
-`
-
-type GetSubmittedApplicationSchoolsRow struct {
-	School string `json:"school"`
+type GetSubmittedApplicationFieldCountsRow struct {
+	Answer string `json:"answer"`
 	Count  int64  `json:"count"`
 }
 
-func (q *Queries) GetSubmittedApplicationSchools(ctx context.Context) ([]GetSubmittedApplicationSchoolsRow, error) {
-	rows, err := q.db.Query(ctx, getSubmittedApplicationSchools)
+func (q *Queries) GetSubmittedApplicationFieldCounts(ctx context.Context, arg GetSubmittedApplicationFieldCountsParams) ([]GetSubmittedApplicationFieldCountsRow, error) {
+	rows, err := q.db.Query(ctx, getSubmittedApplicationFieldCounts, arg.Split, arg.Field, arg.HackathonID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []GetSubmittedApplicationSchoolsRow{}
+	items := []GetSubmittedApplicationFieldCountsRow{}
 	for rows.Next() {
-		var i GetSubmittedApplicationSchoolsRow
-		if err := rows.Scan(&i.School, &i.Count); err != nil {
+		var i GetSubmittedApplicationFieldCountsRow
+		if err := rows.Scan(&i.Answer, &i.Count); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -15,29 +15,49 @@ import (
 const acceptWaitlistedApplications = `-- name: AcceptWaitlistedApplications :many
 UPDATE applications
 SET waitlist_join_time = NULL,
+    rsvp_due_at = $1,
     status = 'accepted'
-WHERE user_id IN (
-  SELECT user_id FROM applications
-  WHERE status = 'waitlisted'
-  ORDER BY waitlist_join_time ASC
-  LIMIT $1::int
+WHERE id IN (
+  SELECT id FROM applications
+  WHERE status = 'waitlisted' AND hackathon_id = $2
+    AND id <> ALL($3::uuid[])
+  ORDER BY waitlist_join_time ASC NULLS LAST, id ASC
+  LIMIT $4::int
+  FOR UPDATE SKIP LOCKED
 )
-RETURNING user_id
+RETURNING id, user_id
 `
 
-func (q *Queries) AcceptWaitlistedApplications(ctx context.Context, acceptancecount int32) ([]uuid.UUID, error) {
-	rows, err := q.db.Query(ctx, acceptWaitlistedApplications, acceptancecount)
+type AcceptWaitlistedApplicationsParams struct {
+	RsvpDueAt       *time.Time  `json:"rsvp_due_at"`
+	HackathonID     string      `json:"hackathon_id"`
+	ExcludedIds     []uuid.UUID `json:"excluded_ids"`
+	AcceptanceCount int32       `json:"acceptance_count"`
+}
+
+type AcceptWaitlistedApplicationsRow struct {
+	ID     uuid.UUID `json:"id"`
+	UserID uuid.UUID `json:"user_id"`
+}
+
+func (q *Queries) AcceptWaitlistedApplications(ctx context.Context, arg AcceptWaitlistedApplicationsParams) ([]AcceptWaitlistedApplicationsRow, error) {
+	rows, err := q.db.Query(ctx, acceptWaitlistedApplications,
+		arg.RsvpDueAt,
+		arg.HackathonID,
+		arg.ExcludedIds,
+		arg.AcceptanceCount,
+	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []uuid.UUID{}
+	items := []AcceptWaitlistedApplicationsRow{}
 	for rows.Next() {
-		var user_id uuid.UUID
-		if err := rows.Scan(&user_id); err != nil {
+		var i AcceptWaitlistedApplicationsRow
+		if err := rows.Scan(&i.ID, &i.UserID); err != nil {
 			return nil, err
 		}
-		items = append(items, user_id)
+		items = append(items, i)
 	}
 	if err := rows.Err(); err != nil {
 		return nil, err
@@ -45,8 +65,20 @@ func (q *Queries) AcceptWaitlistedApplications(ctx context.Context, acceptanceco
 	return items, nil
 }
 
+const countAdmittedApplications = `-- name: CountAdmittedApplications :one
+SELECT COUNT(*) FROM applications
+WHERE hackathon_id = $1 AND status IN ('accepted', 'confirmed')
+`
+
+func (q *Queries) CountAdmittedApplications(ctx context.Context, hackathonID string) (int64, error) {
+	row := q.db.QueryRow(ctx, countAdmittedApplications, hackathonID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createApplication = `-- name: CreateApplication :one
-INSERT INTO applications (user_id, hackathon_id, is_early) VALUES ($1, $2, $3) RETURNING user_id, status, application, created_at, saved_at, updated_at, submitted_at, hackathon_id, is_early, id
+INSERT INTO applications (user_id, hackathon_id, is_early) VALUES ($1, $2, $3) RETURNING user_id, status, application, created_at, saved_at, updated_at, submitted_at, hackathon_id, is_early, id, waitlist_join_time, rsvp_due_at
 `
 
 type CreateApplicationParams struct {
@@ -69,6 +101,8 @@ func (q *Queries) CreateApplication(ctx context.Context, arg CreateApplicationPa
 		&i.HackathonID,
 		&i.IsEarly,
 		&i.ID,
+		&i.WaitlistJoinTime,
+		&i.RsvpDueAt,
 	)
 	return i, err
 }
@@ -82,8 +116,44 @@ func (q *Queries) DeleteApplicationById(ctx context.Context, id uuid.UUID) error
 	return err
 }
 
+const expireUnconfirmedAcceptances = `-- name: ExpireUnconfirmedAcceptances :many
+UPDATE applications
+SET waitlist_join_time = NOW(),
+    rsvp_due_at = NULL,
+    status = 'waitlisted'
+WHERE hackathon_id = $1
+  AND status = 'accepted'
+  AND rsvp_due_at < NOW()
+RETURNING id, user_id
+`
+
+type ExpireUnconfirmedAcceptancesRow struct {
+	ID     uuid.UUID `json:"id"`
+	UserID uuid.UUID `json:"user_id"`
+}
+
+func (q *Queries) ExpireUnconfirmedAcceptances(ctx context.Context, hackathonID string) ([]ExpireUnconfirmedAcceptancesRow, error) {
+	rows, err := q.db.Query(ctx, expireUnconfirmedAcceptances, hackathonID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ExpireUnconfirmedAcceptancesRow{}
+	for rows.Next() {
+		var i ExpireUnconfirmedAcceptancesRow
+		if err := rows.Scan(&i.ID, &i.UserID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getApplicationById = `-- name: GetApplicationById :one
-SELECT user_id, status, application, created_at, saved_at, updated_at, submitted_at, hackathon_id, is_early, id FROM applications WHERE id = $1
+SELECT user_id, status, application, created_at, saved_at, updated_at, submitted_at, hackathon_id, is_early, id, waitlist_join_time, rsvp_due_at FROM applications WHERE id = $1
 `
 
 func (q *Queries) GetApplicationById(ctx context.Context, id uuid.UUID) (Application, error) {
@@ -100,12 +170,14 @@ func (q *Queries) GetApplicationById(ctx context.Context, id uuid.UUID) (Applica
 		&i.HackathonID,
 		&i.IsEarly,
 		&i.ID,
+		&i.WaitlistJoinTime,
+		&i.RsvpDueAt,
 	)
 	return i, err
 }
 
 const getApplicationByUserId = `-- name: GetApplicationByUserId :one
-SELECT user_id, status, application, created_at, saved_at, updated_at, submitted_at, hackathon_id, is_early, id FROM applications WHERE user_id = $1
+SELECT user_id, status, application, created_at, saved_at, updated_at, submitted_at, hackathon_id, is_early, id, waitlist_join_time, rsvp_due_at FROM applications WHERE user_id = $1
 `
 
 func (q *Queries) GetApplicationByUserId(ctx context.Context, userID uuid.UUID) (Application, error) {
@@ -122,6 +194,8 @@ func (q *Queries) GetApplicationByUserId(ctx context.Context, userID uuid.UUID)
 		&i.HackathonID,
 		&i.IsEarly,
 		&i.ID,
+		&i.WaitlistJoinTime,
+		&i.RsvpDueAt,
 	)
 	return i, err
 }
@@ -139,7 +213,7 @@ func (q *Queries) GetApplicationsCount(ctx context.Context, hackathonID string)
 
 const getExtendedApplicationById = `-- name: GetExtendedApplicationById :one
 SELECT 
-    a.user_id, a.status, a.application, a.created_at, a.saved_at, a.updated_at, a.submitted_at, a.hackathon_id, a.is_early, a.id,
+    a.user_id, a.status, a.application, a.created_at, a.saved_at, a.updated_at, a.submitted_at, a.hackathon_id, a.is_early, a.id, a.waitlist_join_time, a.rsvp_due_at,
     ar.id AS review_id,
     ar.experience_rating, 
     ar.passion_rating, 
@@ -177,6 +251,8 @@ type GetExtendedApplicationByIdRow struct {
 	HackathonID              string                          `json:"hackathon_id"`
 	IsEarly                  bool                            `json:"is_early"`
 	ID                       uuid.UUID                       `json:"id"`
+	WaitlistJoinTime         *time.Time                      `json:"waitlist_join_time"`
+	RsvpDueAt                *time.Time                      `json:"rsvp_due_at"`
 	ReviewID                 *uuid.UUID                      `json:"review_id"`
 	ExperienceRating         *int32                          `json:"experience_rating"`
 	PassionRating            *int32                          `json:"passion_rating"`
@@ -211,6 +287,8 @@ func (q *Queries) GetExtendedApplicationById(ctx context.Context, id uuid.UUID)
 		&i.HackathonID,
 		&i.IsEarly,
 		&i.ID,
+		&i.WaitlistJoinTime,
+		&i.RsvpDueAt,
 		&i.ReviewID,
 		&i.ExperienceRating,
 		&i.PassionRating,
@@ -233,6 +311,110 @@ func (q *Queries) GetExtendedApplicationById(ctx context.Context, id uuid.UUID)
 	return i, err
 }
 
+const listAdmissionCandidates = `-- name: ListAdmissionCandidates :many
+SELECT
+    a.id,
+    a.user_id,
+    a.application,
+    tm.team_id,
+    COALESCE(AVG(ar.passion_rating), 0)::float8 AS passion_rating,
+    COALESCE(AVG(ar.experience_rating), 0)::float8 AS experience_rating,
+    COUNT(ar.id) AS rated_review_count
+FROM applications a
+LEFT JOIN application_reviews ar
+    ON ar.application_id = a.id
+    AND ar.passion_rating IS NOT NULL
+    AND ar.experience_rating IS NOT NULL
+LEFT JOIN team_members tm
+    ON tm.user_id = a.user_id
+WHERE a.status = 'under_review' AND a.hackathon_id = $1
+GROUP BY a.id, tm.team_id
+ORDER BY a.id ASC
+`
+
+type ListAdmissionCandidatesRow struct {
+	ID               uuid.UUID  `json:"id"`
+	UserID           uuid.UUID  `json:"user_id"`
+	Application      []byte     `json:"application"`
+	TeamID           *uuid.UUID `json:"team_id"`
+	PassionRating    float64    `json:"passion_rating"`
+	ExperienceRating float64    `json:"experience_rating"`
+	RatedReviewCount int64      `json:"rated_review_count"`
+}
+
+func (q *Queries) ListAdmissionCandidates(ctx context.Context, hackathonID string) ([]ListAdmissionCandidatesRow, error) {
+	rows, err := q.db.Query(ctx, listAdmissionCandidates, hackathonID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListAdmissionCandidatesRow{}
+	for rows.Next() {
+		var i ListAdmissionCandidatesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Application,
+			&i.TeamID,
+			&i.PassionRating,
+			&i.ExperienceRating,
+			&i.RatedReviewCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listApplicationsByUserIds = `-- name: ListApplicationsByUserIds :many
+SELECT id, user_id, status, waitlist_join_time, rsvp_due_at
+FROM applications
+WHERE hackathon_id = $1 AND user_id = ANY($2::uuid[])
+`
+
+type ListApplicationsByUserIdsParams struct {
+	HackathonID string      `json:"hackathon_id"`
+	UserIds     []uuid.UUID `json:"user_ids"`
+}
+
+type ListApplicationsByUserIdsRow struct {
+	ID               uuid.UUID         `json:"id"`
+	UserID           uuid.UUID         `json:"user_id"`
+	Status           ApplicationStatus `json:"status"`
+	WaitlistJoinTime *time.Time        `json:"waitlist_join_time"`
+	RsvpDueAt        *time.Time        `json:"rsvp_due_at"`
+}
+
+func (q *Queries) ListApplicationsByUserIds(ctx context.Context, arg ListApplicationsByUserIdsParams) ([]ListApplicationsByUserIdsRow, error) {
+	rows, err := q.db.Query(ctx, listApplicationsByUserIds, arg.HackathonID, arg.UserIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListApplicationsByUserIdsRow{}
+	for rows.Next() {
+		var i ListApplicationsByUserIdsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Status,
+			&i.WaitlistJoinTime,
+			&i.RsvpDueAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listApplicationsUnderReviewWithTeamIds = `-- name: ListApplicationsUnderReviewWithTeamIds :many
 SELECT 
     a.user_id,
@@ -272,6 +454,53 @@ func (q *Queries) ListApplicationsUnderReviewWithTeamIds(ctx context.Context) ([
 	return items, nil
 }
 
+const listAssignableApplications = `-- name: ListAssignableApplications :many
+SELECT DISTINCT ON (a.id)
+    a.id,
+    a.user_id,
+    t.id AS team_id,
+    COALESCE(a.application->>'school', '')::text AS school
+FROM applications a
+LEFT JOIN team_members tm
+    ON tm.user_id = a.user_id
+LEFT JOIN teams t
+    ON t.id = tm.team_id
+WHERE a.status = 'under_review' AND a.hackathon_id = $1
+ORDER BY a.id ASC, t.id NULLS LAST
+`
+
+type ListAssignableApplicationsRow struct {
+	ID     uuid.UUID  `json:"id"`
+	UserID uuid.UUID  `json:"user_id"`
+	TeamID *uuid.UUID `json:"team_id"`
+	School string     `json:"school"`
+}
+
+func (q *Queries) ListAssignableApplications(ctx context.Context, hackathonID string) ([]ListAssignableApplicationsRow, error) {
+	rows, err := q.db.Query(ctx, listAssignableApplications, hackathonID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListAssignableApplicationsRow{}
+	for rows.Next() {
+		var i ListAssignableApplicationsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.TeamID,
+			&i.School,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listUnderReviewApplicationIds = `-- name: ListUnderReviewApplicationIds :many
 SELECT id FROM applications
 WHERE status = 'under_review' AND hackathon_id = $1
@@ -320,6 +549,36 @@ func (q *Queries) ResetApplicationsToSubmitted(ctx context.Context) error {
 	return err
 }
 
+const restoreApplicationStatus = `-- name: RestoreApplicationStatus :execrows
+UPDATE applications
+SET status = $1,
+    waitlist_join_time = $2,
+    rsvp_due_at = $3
+WHERE id = $4 AND status = $5
+`
+
+type RestoreApplicationStatusParams struct {
+	Status           ApplicationStatus `json:"status"`
+	WaitlistJoinTime *time.Time        `json:"waitlist_join_time"`
+	RsvpDueAt        *time.Time        `json:"rsvp_due_at"`
+	ID               uuid.UUID         `json:"id"`
+	CurrentStatus    ApplicationStatus `json:"current_status"`
+}
+
+func (q *Queries) RestoreApplicationStatus(ctx context.Context, arg RestoreApplicationStatusParams) (int64, error) {
+	result, err := q.db.Exec(ctx, restoreApplicationStatus,
+		arg.Status,
+		arg.WaitlistJoinTime,
+		arg.RsvpDueAt,
+		arg.ID,
+		arg.CurrentStatus,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const searchApplicationsWithUserInfo = `-- name: SearchApplicationsWithUserInfo :many
 SELECT 
     a.id, 
@@ -526,3 +785,21 @@ func (q *Queries) WaitlistApplicationById(ctx context.Context, id uuid.UUID) err
 	_, err := q.db.Exec(ctx, waitlistApplicationById, id)
 	return err
 }
+
+const waitlistApplicationsInOrder = `-- name: WaitlistApplicationsInOrder :exec
+UPDATE applications a
+SET status = 'waitlisted',
+    waitlist_join_time = $1::timestamptz + (w.position * interval '1 millisecond')
+FROM unnest($2::uuid[]) WITH ORDINALITY AS w(id, position)
+WHERE a.id = w.id
+`
+
+type WaitlistApplicationsInOrderParams struct {
+	JoinedAt time.Time   `json:"joined_at"`
+	Ids      []uuid.UUID `json:"ids"`
+}
+
+func (q *Queries) WaitlistApplicationsInOrder(ctx context.Context, arg WaitlistApplicationsInOrderParams) error {
+	_, err := q.db.Exec(ctx, waitlistApplicationsInOrder, arg.JoinedAt, arg.Ids)
+	return err
+}
@@ -13,11 +13,16 @@ import (
 )
 
 const addBatRun = `-- name: AddBatRun :one
-INSERT INTO bat_runs (hackathon_id) VALUES ($1) RETURNING id, accepted_applicants, rejected_applicants, status, created_at, completed_at, hackathon_id
+INSERT INTO bat_runs (hackathon_id, seed) VALUES ($1, $2) RETURNING id, accepted_applicants, rejected_applicants, status, created_at, completed_at, hackathon_id, seed, config, input_snapshot
 `
 
-func (q *Queries) AddBatRun(ctx context.Context, hackathonID string) (BatRun, error) {
-	row := q.db.QueryRow(ctx, addBatRun, hackathonID)
+type AddBatRunParams struct {
+	HackathonID string `json:"hackathon_id"`
+	Seed        *int64 `json:"seed"`
+}
+
+func (q *Queries) AddBatRun(ctx context.Context, arg AddBatRunParams) (BatRun, error) {
+	row := q.db.QueryRow(ctx, addBatRun, arg.HackathonID, arg.Seed)
 	var i BatRun
 	err := row.Scan(
 		&i.ID,
@@ -27,6 +32,9 @@ func (q *Queries) AddBatRun(ctx context.Context, hackathonID string) (BatRun, er
 		&i.CreatedAt,
 		&i.CompletedAt,
 		&i.HackathonID,
+		&i.Seed,
+		&i.Config,
+		&i.InputSnapshot,
 	)
 	return i, err
 }
@@ -45,7 +53,7 @@ func (q *Queries) DeleteBatRunById(ctx context.Context, id uuid.UUID) (int64, er
 }
 
 const getBatRunById = `-- name: GetBatRunById :one
-SELECT id, accepted_applicants, rejected_applicants, status, created_at, completed_at, hackathon_id
+SELECT id, accepted_applicants, rejected_applicants, status, created_at, completed_at, hackathon_id, seed, config, input_snapshot
 FROM bat_runs
 WHERE id = $1
 `
@@ -61,13 +69,16 @@ func (q *Queries) GetBatRunById(ctx context.Context, id uuid.UUID) (BatRun, erro
 		&i.CreatedAt,
 		&i.CompletedAt,
 		&i.HackathonID,
+		&i.Seed,
+		&i.Config,
+		&i.InputSnapshot,
 	)
 	return i, err
 }
 
 const getBatRuns = `-- name: GetBatRuns :many
 SELECT
-    id, accepted_applicants, rejected_applicants, status, created_at, completed_at, hackathon_id
+    id, accepted_applicants, rejected_applicants, status, created_at, completed_at, hackathon_id, seed, config, input_snapshot
 FROM bat_runs
 ORDER BY created_at DESC
 `
@@ -89,6 +100,9 @@ func (q *Queries) GetBatRuns(ctx context.Context) ([]BatRun, error) {
 			&i.CreatedAt,
 			&i.CompletedAt,
 			&i.HackathonID,
+			&i.Seed,
+			&i.Config,
+			&i.InputSnapshot,
 		); err != nil {
 			return nil, err
 		}
@@ -106,10 +120,13 @@ SET
     accepted_applicants = CASE WHEN $1::boolean THEN $2 ELSE accepted_applicants END,
     rejected_applicants = CASE WHEN $3::boolean THEN $4 ELSE rejected_applicants END,
     status = CASE WHEN $5::boolean THEN $6 ELSE status END,
-    created_at = CASE WHEN $7::boolean THEN $8 ELSE created_at END
+    created_at = CASE WHEN $7::boolean THEN $8 ELSE created_at END,
+    completed_at = CASE WHEN $9::boolean THEN $10 ELSE completed_at END,
+    config = CASE WHEN $11::boolean THEN $12 ELSE config END,
+    input_snapshot = CASE WHEN $13::boolean THEN $14 ELSE input_snapshot END
 WHERE
-    id = $9::uuid
-RETURNING id, accepted_applicants, rejected_applicants, status, created_at, completed_at, hackathon_id
+    id = $15::uuid
+RETURNING id, accepted_applicants, rejected_applicants, status, created_at, completed_at, hackathon_id, seed, config, input_snapshot
 `
 
 type UpdateBatRunByIdParams struct {
@@ -121,6 +138,12 @@ type UpdateBatRunByIdParams struct {
 	Status                     BatRunStatus `json:"status"`
 	CreatedAtDoUpdate          bool         `json:"created_at_do_update"`
 	CreatedAt                  time.Time    `json:"created_at"`
+	CompletedAtDoUpdate        bool         `json:"completed_at_do_update"`
+	CompletedAt                *time.Time   `json:"completed_at"`
+	ConfigDoUpdate             bool         `json:"config_do_update"`
+	Config                     []byte       `json:"config"`
+	InputSnapshotDoUpdate      bool         `json:"input_snapshot_do_update"`
+	InputSnapshot              []byte       `json:"input_snapshot"`
 	ID                         uuid.UUID    `json:"id"`
 }
 
@@ -134,6 +157,12 @@ func (q *Queries) UpdateBatRunById(ctx context.Context, arg UpdateBatRunByIdPara
 		arg.Status,
 		arg.CreatedAtDoUpdate,
 		arg.CreatedAt,
+		arg.CompletedAtDoUpdate,
+		arg.CompletedAt,
+		arg.ConfigDoUpdate,
+		arg.Config,
+		arg.InputSnapshotDoUpdate,
+		arg.InputSnapshot,
 		arg.ID,
 	)
 	return err
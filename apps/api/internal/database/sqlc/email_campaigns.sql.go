@@ -12,6 +12,68 @@ import (
 	"github.com/google/uuid"
 )
 
+const claimDueEmailCampaigns = `-- name: ClaimDueEmailCampaigns :many
+UPDATE email_campaigns
+SET
+    status = 'sending'::email_campaign_status,
+    last_error = NULL,
+    recipient_count = 0,
+    emails_sent = 0,
+    emails_failed = 0
+WHERE id IN (
+    SELECT id
+    FROM email_campaigns
+    WHERE status = 'scheduled'::email_campaign_status
+        AND scheduled_at <= now()
+    ORDER BY scheduled_at
+    LIMIT $1::integer
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING id, hackathon_id, title, description, subject, body, format, recipient_types, status, scheduled_at, sent_at, last_error, created_by_user_id, updated_by_user_id, created_at, updated_at, recipient_count, emails_sent, emails_failed, category
+`
+
+// moves scheduled campaigns that are due to sending. Campaigns locked by another worker are skipped, so every campaign is claimed once.
+func (q *Queries) ClaimDueEmailCampaigns(ctx context.Context, maxCampaigns int32) ([]EmailCampaign, error) {
+	rows, err := q.db.Query(ctx, claimDueEmailCampaigns, maxCampaigns)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []EmailCampaign{}
+	for rows.Next() {
+		var i EmailCampaign
+		if err := rows.Scan(
+			&i.ID,
+			&i.HackathonID,
+			&i.Title,
+			&i.Description,
+			&i.Subject,
+			&i.Body,
+			&i.Format,
+			&i.RecipientTypes,
+			&i.Status,
+			&i.ScheduledAt,
+			&i.SentAt,
+			&i.LastError,
+			&i.CreatedByUserID,
+			&i.UpdatedByUserID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.RecipientCount,
+			&i.EmailsSent,
+			&i.EmailsFailed,
+			&i.Category,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const createEmailCampaign = `-- name: CreateEmailCampaign :one
 INSERT INTO email_campaigns (
     hackathon_id,
@@ -23,7 +85,8 @@ INSERT INTO email_campaigns (
     recipient_types,
     scheduled_at,
     created_by_user_id,
-    updated_by_user_id
+    updated_by_user_id,
+    category
 ) VALUES (
     $1,
     $2,
@@ -34,9 +97,10 @@ INSERT INTO email_campaigns (
     $7::text[]::email_recipient_type[],
     $8,
     $9,
-    $10
+    $10,
+    $11::email_category
 )
-RETURNING id, hackathon_id, title, description, subject, body, format, recipient_types, status, scheduled_at, sent_at, last_error, created_by_user_id, updated_by_user_id, created_at, updated_at
+RETURNING id, hackathon_id, title, description, subject, body, format, recipient_types, status, scheduled_at, sent_at, last_error, created_by_user_id, updated_by_user_id, created_at, updated_at, recipient_count, emails_sent, emails_failed, category
 `
 
 type CreateEmailCampaignParams struct {
@@ -50,9 +114,10 @@ type CreateEmailCampaignParams struct {
 	ScheduledAt     *time.Time          `json:"scheduled_at"`
 	CreatedByUserID *uuid.UUID          `json:"created_by_user_id"`
 	UpdatedByUserID *uuid.UUID          `json:"updated_by_user_id"`
+	Category        EmailCategory       `json:"category"`
 }
 
-// creates a draft campaign. It stores the title, subject, body, format, recipient groups, and optional schedule time.
+// creates a draft campaign. It stores the title, subject, body, format, recipient groups, email category, and optional schedule time.
 func (q *Queries) CreateEmailCampaign(ctx context.Context, arg CreateEmailCampaignParams) (EmailCampaign, error) {
 	row := q.db.QueryRow(ctx, createEmailCampaign,
 		arg.HackathonID,
@@ -65,6 +130,7 @@ func (q *Queries) CreateEmailCampaign(ctx context.Context, arg CreateEmailCampai
 		arg.ScheduledAt,
 		arg.CreatedByUserID,
 		arg.UpdatedByUserID,
+		arg.Category,
 	)
 	var i EmailCampaign
 	err := row.Scan(
@@ -84,12 +150,16 @@ func (q *Queries) CreateEmailCampaign(ctx context.Context, arg CreateEmailCampai
 		&i.UpdatedByUserID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.RecipientCount,
+		&i.EmailsSent,
+		&i.EmailsFailed,
+		&i.Category,
 	)
 	return i, err
 }
 
 const getEmailCampaignByID = `-- name: GetEmailCampaignByID :one
-SELECT id, hackathon_id, title, description, subject, body, format, recipient_types, status, scheduled_at, sent_at, last_error, created_by_user_id, updated_by_user_id, created_at, updated_at
+SELECT id, hackathon_id, title, description, subject, body, format, recipient_types, status, scheduled_at, sent_at, last_error, created_by_user_id, updated_by_user_id, created_at, updated_at, recipient_count, emails_sent, emails_failed, category
 FROM email_campaigns
 WHERE id = $1::uuid
     AND hackathon_id = $2
@@ -121,12 +191,78 @@ func (q *Queries) GetEmailCampaignByID(ctx context.Context, arg GetEmailCampaign
 		&i.UpdatedByUserID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.RecipientCount,
+		&i.EmailsSent,
+		&i.EmailsFailed,
+		&i.Category,
 	)
 	return i, err
 }
 
+const listEmailCampaignRecipients = `-- name: ListEmailCampaignRecipients :many
+SELECT
+    u.name,
+    COALESCE(NULLIF(u.preferred_email, ''), u.email, '')::text AS email
+FROM users u
+WHERE u.email_consent
+    AND (
+        (u.role = 'admin' AND 'admins' = ANY($1::text[]))
+        OR (u.role = 'staff' AND 'staff' = ANY($1::text[]))
+        OR (u.role = 'visitor' AND 'visitors' = ANY($1::text[]))
+        OR EXISTS (
+            SELECT 1
+            FROM applications a
+            WHERE a.user_id = u.id
+                AND a.hackathon_id = $2
+                AND (
+                    (a.status = 'accepted' AND 'accepted_applicants' = ANY($1::text[]))
+                    OR (a.status = 'rejected' AND 'rejected_applicants' = ANY($1::text[]))
+                    OR (a.status = 'waitlisted' AND 'waitlisted_applicants' = ANY($1::text[]))
+                )
+        )
+    )
+UNION ALL
+SELECT
+    ''::text AS name,
+    i.email
+FROM interest_submissions i
+WHERE i.hackathon_id = $2
+    AND 'interest_subscribers' = ANY($1::text[])
+`
+
+type ListEmailCampaignRecipientsParams struct {
+	RecipientTypes []string `json:"recipient_types"`
+	HackathonID    string   `json:"hackathon_id"`
+}
+
+type ListEmailCampaignRecipientsRow struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+// resolves recipient groups into names and contact addresses. Users are only included if they consented to emails, interest subscribers opted in by subscribing. The same address can come up more than once.
+func (q *Queries) ListEmailCampaignRecipients(ctx context.Context, arg ListEmailCampaignRecipientsParams) ([]ListEmailCampaignRecipientsRow, error) {
+	rows, err := q.db.Query(ctx, listEmailCampaignRecipients, arg.RecipientTypes, arg.HackathonID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListEmailCampaignRecipientsRow{}
+	for rows.Next() {
+		var i ListEmailCampaignRecipientsRow
+		if err := rows.Scan(&i.Name, &i.Email); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listEmailCampaigns = `-- name: ListEmailCampaigns :many
-SELECT id, hackathon_id, title, description, subject, body, format, recipient_types, status, scheduled_at, sent_at, last_error, created_by_user_id, updated_by_user_id, created_at, updated_at
+SELECT id, hackathon_id, title, description, subject, body, format, recipient_types, status, scheduled_at, sent_at, last_error, created_by_user_id, updated_by_user_id, created_at, updated_at, recipient_count, emails_sent, emails_failed, category
 FROM email_campaigns
 WHERE hackathon_id = $1
 ORDER BY created_at DESC
@@ -159,6 +295,10 @@ func (q *Queries) ListEmailCampaigns(ctx context.Context, hackathonID string) ([
 			&i.UpdatedByUserID,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.RecipientCount,
+			&i.EmailsSent,
+			&i.EmailsFailed,
+			&i.Category,
 		); err != nil {
 			return nil, err
 		}
@@ -170,6 +310,175 @@ func (q *Queries) ListEmailCampaigns(ctx context.Context, hackathonID string) ([
 	return items, nil
 }
 
+const recordEmailCampaignBatch = `-- name: RecordEmailCampaignBatch :one
+UPDATE email_campaigns
+SET
+    emails_sent = emails_sent + $1::integer,
+    emails_failed = emails_failed + $2::integer,
+    last_error = COALESCE($3::text, last_error),
+    status =
+        CASE WHEN emails_sent + emails_failed + $1::integer + $2::integer < recipient_count
+        THEN status
+        WHEN emails_sent + $1::integer > 0
+        THEN 'sent'::email_campaign_status
+        ELSE 'failed'::email_campaign_status END,
+    sent_at =
+        CASE WHEN emails_sent + emails_failed + $1::integer + $2::integer >= recipient_count
+            AND emails_sent + $1::integer > 0
+        THEN now()
+        ELSE sent_at END
+WHERE id = $4::uuid
+    AND status = 'sending'::email_campaign_status
+RETURNING id, hackathon_id, title, description, subject, body, format, recipient_types, status, scheduled_at, sent_at, last_error, created_by_user_id, updated_by_user_id, created_at, updated_at, recipient_count, emails_sent, emails_failed, category
+`
+
+type RecordEmailCampaignBatchParams struct {
+	Sent      int32     `json:"sent"`
+	Failed    int32     `json:"failed"`
+	LastError *string   `json:"last_error"`
+	ID        uuid.UUID `json:"id"`
+}
+
+// adds the outcome of a sent batch. Once every recipient was tried the campaign is sent, or failed if no email went out.
+func (q *Queries) RecordEmailCampaignBatch(ctx context.Context, arg RecordEmailCampaignBatchParams) (EmailCampaign, error) {
+	row := q.db.QueryRow(ctx, recordEmailCampaignBatch,
+		arg.Sent,
+		arg.Failed,
+		arg.LastError,
+		arg.ID,
+	)
+	var i EmailCampaign
+	err := row.Scan(
+		&i.ID,
+		&i.HackathonID,
+		&i.Title,
+		&i.Description,
+		&i.Subject,
+		&i.Body,
+		&i.Format,
+		&i.RecipientTypes,
+		&i.Status,
+		&i.ScheduledAt,
+		&i.SentAt,
+		&i.LastError,
+		&i.CreatedByUserID,
+		&i.UpdatedByUserID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.RecipientCount,
+		&i.EmailsSent,
+		&i.EmailsFailed,
+		&i.Category,
+	)
+	return i, err
+}
+
+const startEmailCampaignResend = `-- name: StartEmailCampaignResend :one
+UPDATE email_campaigns
+SET
+    status = 'sending'::email_campaign_status,
+    last_error = NULL,
+    recipient_count = $1::integer,
+    emails_sent = 0,
+    emails_failed = 0,
+    updated_by_user_id = COALESCE($2::uuid, updated_by_user_id)
+WHERE id = $3::uuid
+    AND hackathon_id = $4
+    AND status IN ('sent', 'failed')
+RETURNING id, hackathon_id, title, description, subject, body, format, recipient_types, status, scheduled_at, sent_at, last_error, created_by_user_id, updated_by_user_id, created_at, updated_at, recipient_count, emails_sent, emails_failed, category
+`
+
+type StartEmailCampaignResendParams struct {
+	RecipientCount  int32      `json:"recipient_count"`
+	UpdatedByUserID *uuid.UUID `json:"updated_by_user_id"`
+	ID              uuid.UUID  `json:"id"`
+	HackathonID     string     `json:"hackathon_id"`
+}
+
+// moves a sent or failed campaign back to sending to retry its failed deliveries. Returns no rows if the campaign is sending or was never sent.
+func (q *Queries) StartEmailCampaignResend(ctx context.Context, arg StartEmailCampaignResendParams) (EmailCampaign, error) {
+	row := q.db.QueryRow(ctx, startEmailCampaignResend,
+		arg.RecipientCount,
+		arg.UpdatedByUserID,
+		arg.ID,
+		arg.HackathonID,
+	)
+	var i EmailCampaign
+	err := row.Scan(
+		&i.ID,
+		&i.HackathonID,
+		&i.Title,
+		&i.Description,
+		&i.Subject,
+		&i.Body,
+		&i.Format,
+		&i.RecipientTypes,
+		&i.Status,
+		&i.ScheduledAt,
+		&i.SentAt,
+		&i.LastError,
+		&i.CreatedByUserID,
+		&i.UpdatedByUserID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.RecipientCount,
+		&i.EmailsSent,
+		&i.EmailsFailed,
+		&i.Category,
+	)
+	return i, err
+}
+
+const startEmailCampaignSending = `-- name: StartEmailCampaignSending :one
+UPDATE email_campaigns
+SET
+    status = 'sending'::email_campaign_status,
+    last_error = NULL,
+    recipient_count = 0,
+    emails_sent = 0,
+    emails_failed = 0,
+    updated_by_user_id = COALESCE($1::uuid, updated_by_user_id)
+WHERE id = $2::uuid
+    AND hackathon_id = $3
+    AND status IN ('draft', 'scheduled', 'failed')
+RETURNING id, hackathon_id, title, description, subject, body, format, recipient_types, status, scheduled_at, sent_at, last_error, created_by_user_id, updated_by_user_id, created_at, updated_at, recipient_count, emails_sent, emails_failed, category
+`
+
+type StartEmailCampaignSendingParams struct {
+	UpdatedByUserID *uuid.UUID `json:"updated_by_user_id"`
+	ID              uuid.UUID  `json:"id"`
+	HackathonID     string     `json:"hackathon_id"`
+}
+
+// moves a draft, scheduled, or failed campaign to sending and resets its progress. Returns no rows if the campaign is already sending or sent.
+func (q *Queries) StartEmailCampaignSending(ctx context.Context, arg StartEmailCampaignSendingParams) (EmailCampaign, error) {
+	row := q.db.QueryRow(ctx, startEmailCampaignSending, arg.UpdatedByUserID, arg.ID, arg.HackathonID)
+	var i EmailCampaign
+	err := row.Scan(
+		&i.ID,
+		&i.HackathonID,
+		&i.Title,
+		&i.Description,
+		&i.Subject,
+		&i.Body,
+		&i.Format,
+		&i.RecipientTypes,
+		&i.Status,
+		&i.ScheduledAt,
+		&i.SentAt,
+		&i.LastError,
+		&i.CreatedByUserID,
+		&i.UpdatedByUserID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.RecipientCount,
+		&i.EmailsSent,
+		&i.EmailsFailed,
+		&i.Category,
+	)
+	return i, err
+}
+
 const updateEmailCampaign = `-- name: UpdateEmailCampaign :one
 UPDATE email_campaigns
 SET
@@ -201,13 +510,18 @@ SET
         CASE WHEN $13::boolean
         THEN $14
         ELSE scheduled_at END,
-    updated_by_user_id = 
+    category =
         CASE WHEN $15::boolean
-        THEN $16::uuid
+        THEN $16::email_category
+        ELSE category END,
+    updated_by_user_id = 
+        CASE WHEN $17::boolean
+        THEN $18::uuid
         ELSE updated_by_user_id END
-WHERE id = $17::uuid
-    AND hackathon_id = $18
-RETURNING id, hackathon_id, title, description, subject, body, format, recipient_types, status, scheduled_at, sent_at, last_error, created_by_user_id, updated_by_user_id, created_at, updated_at
+WHERE id = $19::uuid
+    AND hackathon_id = $20
+    AND status IN ('draft', 'scheduled')
+RETURNING id, hackathon_id, title, description, subject, body, format, recipient_types, status, scheduled_at, sent_at, last_error, created_by_user_id, updated_by_user_id, created_at, updated_at, recipient_count, emails_sent, emails_failed, category
 `
 
 type UpdateEmailCampaignParams struct {
@@ -225,13 +539,15 @@ type UpdateEmailCampaignParams struct {
 	RecipientTypes          []string                `json:"recipient_types"`
 	ScheduledAtDoUpdate     bool                    `json:"scheduled_at_do_update"`
 	ScheduledAt             *time.Time              `json:"scheduled_at"`
+	CategoryDoUpdate        bool                    `json:"category_do_update"`
+	Category                NullEmailCategory       `json:"category"`
 	UpdatedByUserIDDoUpdate bool                    `json:"updated_by_user_id_do_update"`
 	UpdatedByUserID         uuid.UUID               `json:"updated_by_user_id"`
 	ID                      uuid.UUID               `json:"id"`
 	HackathonID             string                  `json:"hackathon_id"`
 }
 
-// edits draft-like campaign fields: title, description, subject, body, format, recipients, and scheduled time.
+// edits draft-like campaign fields: title, description, subject, body, format, recipients, scheduled time, and email category. Returns no rows once the campaign started sending.
 func (q *Queries) UpdateEmailCampaign(ctx context.Context, arg UpdateEmailCampaignParams) (EmailCampaign, error) {
 	row := q.db.QueryRow(ctx, updateEmailCampaign,
 		arg.TitleDoUpdate,
@@ -248,6 +564,8 @@ func (q *Queries) UpdateEmailCampaign(ctx context.Context, arg UpdateEmailCampai
 		arg.RecipientTypes,
 		arg.ScheduledAtDoUpdate,
 		arg.ScheduledAt,
+		arg.CategoryDoUpdate,
+		arg.Category,
 		arg.UpdatedByUserIDDoUpdate,
 		arg.UpdatedByUserID,
 		arg.ID,
@@ -271,10 +589,31 @@ func (q *Queries) UpdateEmailCampaign(ctx context.Context, arg UpdateEmailCampai
 		&i.UpdatedByUserID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.RecipientCount,
+		&i.EmailsSent,
+		&i.EmailsFailed,
+		&i.Category,
 	)
 	return i, err
 }
 
+const updateEmailCampaignRecipientCount = `-- name: UpdateEmailCampaignRecipientCount :exec
+UPDATE email_campaigns
+SET recipient_count = $1::integer
+WHERE id = $2::uuid
+`
+
+type UpdateEmailCampaignRecipientCountParams struct {
+	RecipientCount int32     `json:"recipient_count"`
+	ID             uuid.UUID `json:"id"`
+}
+
+// stores how many emails a sending campaign goes out to.
+func (q *Queries) UpdateEmailCampaignRecipientCount(ctx context.Context, arg UpdateEmailCampaignRecipientCountParams) error {
+	_, err := q.db.Exec(ctx, updateEmailCampaignRecipientCount, arg.RecipientCount, arg.ID)
+	return err
+}
+
 const updateEmailCampaignStatus = `-- name: UpdateEmailCampaignStatus :one
 UPDATE email_campaigns
 SET
@@ -297,24 +636,29 @@ SET
         ELSE updated_by_user_id END
 WHERE id = $10::uuid
     AND hackathon_id = $11
-RETURNING id, hackathon_id, title, description, subject, body, format, recipient_types, status, scheduled_at, sent_at, last_error, created_by_user_id, updated_by_user_id, created_at, updated_at
+    AND (
+        $12::email_campaign_status IS NULL
+        OR status = $12::email_campaign_status
+    )
+RETURNING id, hackathon_id, title, description, subject, body, format, recipient_types, status, scheduled_at, sent_at, last_error, created_by_user_id, updated_by_user_id, created_at, updated_at, recipient_count, emails_sent, emails_failed, category
 `
 
 type UpdateEmailCampaignStatusParams struct {
-	Status                  EmailCampaignStatus `json:"status"`
-	ScheduledAtDoUpdate     bool                `json:"scheduled_at_do_update"`
-	ScheduledAt             *time.Time          `json:"scheduled_at"`
-	SentAtDoUpdate          bool                `json:"sent_at_do_update"`
-	SentAt                  *time.Time          `json:"sent_at"`
-	LastErrorDoUpdate       bool                `json:"last_error_do_update"`
-	LastError               *string             `json:"last_error"`
-	UpdatedByUserIDDoUpdate bool                `json:"updated_by_user_id_do_update"`
-	UpdatedByUserID         uuid.UUID           `json:"updated_by_user_id"`
-	ID                      uuid.UUID           `json:"id"`
-	HackathonID             string              `json:"hackathon_id"`
+	Status                  EmailCampaignStatus     `json:"status"`
+	ScheduledAtDoUpdate     bool                    `json:"scheduled_at_do_update"`
+	ScheduledAt             *time.Time              `json:"scheduled_at"`
+	SentAtDoUpdate          bool                    `json:"sent_at_do_update"`
+	SentAt                  *time.Time              `json:"sent_at"`
+	LastErrorDoUpdate       bool                    `json:"last_error_do_update"`
+	LastError               *string                 `json:"last_error"`
+	UpdatedByUserIDDoUpdate bool                    `json:"updated_by_user_id_do_update"`
+	UpdatedByUserID         uuid.UUID               `json:"updated_by_user_id"`
+	ID                      uuid.UUID               `json:"id"`
+	HackathonID             string                  `json:"hackathon_id"`
+	ExpectedStatus          NullEmailCampaignStatus `json:"expected_status"`
 }
 
-// changes lifecycle fields like draft -> scheduled, scheduled -> sending, sending -> sent, or sending -> failed.
+// changes lifecycle fields like draft -> scheduled, scheduled -> sending, sending -> sent, or sending -> failed. If expected_status is set, returns no rows unless the campaign is still in that status.
 func (q *Queries) UpdateEmailCampaignStatus(ctx context.Context, arg UpdateEmailCampaignStatusParams) (EmailCampaign, error) {
 	row := q.db.QueryRow(ctx, updateEmailCampaignStatus,
 		arg.Status,
@@ -328,6 +672,7 @@ func (q *Queries) UpdateEmailCampaignStatus(ctx context.Context, arg UpdateEmail
 		arg.UpdatedByUserID,
 		arg.ID,
 		arg.HackathonID,
+		arg.ExpectedStatus,
 	)
 	var i EmailCampaign
 	err := row.Scan(
@@ -347,6 +692,10 @@ func (q *Queries) UpdateEmailCampaignStatus(ctx context.Context, arg UpdateEmail
 		&i.UpdatedByUserID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.RecipientCount,
+		&i.EmailsSent,
+		&i.EmailsFailed,
+		&i.Category,
 	)
 	return i, err
 }
@@ -102,6 +102,51 @@ func (ns NullApplicationStatus) Value() (driver.Value, error) {
 	return string(ns.ApplicationStatus), nil
 }
 
+type BatDecisionReason string
+
+const (
+	BatDecisionReasonAcceptedTeam       BatDecisionReason = "accepted_team"
+	BatDecisionReasonAcceptedIndividual BatDecisionReason = "accepted_individual"
+	BatDecisionReasonBucketFull         BatDecisionReason = "bucket_full"
+	BatDecisionReasonTeamNotFit         BatDecisionReason = "team_not_fit"
+	BatDecisionReasonNoBucket           BatDecisionReason = "no_bucket"
+)
+
+func (e *BatDecisionReason) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = BatDecisionReason(s)
+	case string:
+		*e = BatDecisionReason(s)
+	default:
+		return fmt.Errorf("unsupported scan type for BatDecisionReason: %T", src)
+	}
+	return nil
+}
+
+type NullBatDecisionReason struct {
+	BatDecisionReason BatDecisionReason `json:"bat_decision_reason"`
+	Valid             bool              `json:"valid"` // Valid is true if BatDecisionReason is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullBatDecisionReason) Scan(value interface{}) error {
+	if value == nil {
+		ns.BatDecisionReason, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.BatDecisionReason.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullBatDecisionReason) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.BatDecisionReason), nil
+}
+
 type BatRunStatus string
 
 const (
@@ -145,6 +190,52 @@ func (ns NullBatRunStatus) Value() (driver.Value, error) {
 	return string(ns.BatRunStatus), nil
 }
 
+type DecisionReleaseStatus string
+
+const (
+	DecisionReleaseStatusScheduled  DecisionReleaseStatus = "scheduled"
+	DecisionReleaseStatusApplied    DecisionReleaseStatus = "applied"
+	DecisionReleaseStatusSending    DecisionReleaseStatus = "sending"
+	DecisionReleaseStatusSent       DecisionReleaseStatus = "sent"
+	DecisionReleaseStatusRolledBack DecisionReleaseStatus = "rolled_back"
+	DecisionReleaseStatusFailed     DecisionReleaseStatus = "failed"
+)
+
+func (e *DecisionReleaseStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = DecisionReleaseStatus(s)
+	case string:
+		*e = DecisionReleaseStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for DecisionReleaseStatus: %T", src)
+	}
+	return nil
+}
+
+type NullDecisionReleaseStatus struct {
+	DecisionReleaseStatus DecisionReleaseStatus `json:"decision_release_status"`
+	Valid                 bool                  `json:"valid"` // Valid is true if DecisionReleaseStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullDecisionReleaseStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.DecisionReleaseStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.DecisionReleaseStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullDecisionReleaseStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.DecisionReleaseStatus), nil
+}
+
 type EmailCampaignFormat string
 
 const (
@@ -232,6 +323,95 @@ func (ns NullEmailCampaignStatus) Value() (driver.Value, error) {
 	return string(ns.EmailCampaignStatus), nil
 }
 
+type EmailCategory string
+
+const (
+	EmailCategoryMarketing         EmailCategory = "marketing"
+	EmailCategoryEventUpdates      EmailCategory = "event_updates"
+	EmailCategoryTeamNotifications EmailCategory = "team_notifications"
+	EmailCategoryWorkshopReminders EmailCategory = "workshop_reminders"
+)
+
+func (e *EmailCategory) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = EmailCategory(s)
+	case string:
+		*e = EmailCategory(s)
+	default:
+		return fmt.Errorf("unsupported scan type for EmailCategory: %T", src)
+	}
+	return nil
+}
+
+type NullEmailCategory struct {
+	EmailCategory EmailCategory `json:"email_category"`
+	Valid         bool          `json:"valid"` // Valid is true if EmailCategory is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullEmailCategory) Scan(value interface{}) error {
+	if value == nil {
+		ns.EmailCategory, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.EmailCategory.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullEmailCategory) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.EmailCategory), nil
+}
+
+type EmailDeliveryStatus string
+
+const (
+	EmailDeliveryStatusQueued     EmailDeliveryStatus = "queued"
+	EmailDeliveryStatusSent       EmailDeliveryStatus = "sent"
+	EmailDeliveryStatusFailed     EmailDeliveryStatus = "failed"
+	EmailDeliveryStatusBounced    EmailDeliveryStatus = "bounced"
+	EmailDeliveryStatusComplained EmailDeliveryStatus = "complained"
+)
+
+func (e *EmailDeliveryStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = EmailDeliveryStatus(s)
+	case string:
+		*e = EmailDeliveryStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for EmailDeliveryStatus: %T", src)
+	}
+	return nil
+}
+
+type NullEmailDeliveryStatus struct {
+	EmailDeliveryStatus EmailDeliveryStatus `json:"email_delivery_status"`
+	Valid               bool                `json:"valid"` // Valid is true if EmailDeliveryStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullEmailDeliveryStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.EmailDeliveryStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.EmailDeliveryStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullEmailDeliveryStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.EmailDeliveryStatus), nil
+}
+
 type EmailRecipientType string
 
 const (
@@ -279,6 +459,93 @@ func (ns NullEmailRecipientType) Value() (driver.Value, error) {
 	return string(ns.EmailRecipientType), nil
 }
 
+type EmailSuppressionReason string
+
+const (
+	EmailSuppressionReasonBounce    EmailSuppressionReason = "bounce"
+	EmailSuppressionReasonComplaint EmailSuppressionReason = "complaint"
+)
+
+func (e *EmailSuppressionReason) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = EmailSuppressionReason(s)
+	case string:
+		*e = EmailSuppressionReason(s)
+	default:
+		return fmt.Errorf("unsupported scan type for EmailSuppressionReason: %T", src)
+	}
+	return nil
+}
+
+type NullEmailSuppressionReason struct {
+	EmailSuppressionReason EmailSuppressionReason `json:"email_suppression_reason"`
+	Valid                  bool                   `json:"valid"` // Valid is true if EmailSuppressionReason is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullEmailSuppressionReason) Scan(value interface{}) error {
+	if value == nil {
+		ns.EmailSuppressionReason, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.EmailSuppressionReason.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullEmailSuppressionReason) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.EmailSuppressionReason), nil
+}
+
+type TeamAuditAction string
+
+const (
+	TeamAuditActionRename         TeamAuditAction = "rename"
+	TeamAuditActionDisband        TeamAuditAction = "disband"
+	TeamAuditActionMerge          TeamAuditAction = "merge"
+	TeamAuditActionMoveMember     TeamAuditAction = "move_member"
+	TeamAuditActionSetMemberLimit TeamAuditAction = "set_member_limit"
+)
+
+func (e *TeamAuditAction) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = TeamAuditAction(s)
+	case string:
+		*e = TeamAuditAction(s)
+	default:
+		return fmt.Errorf("unsupported scan type for TeamAuditAction: %T", src)
+	}
+	return nil
+}
+
+type NullTeamAuditAction struct {
+	TeamAuditAction TeamAuditAction `json:"team_audit_action"`
+	Valid           bool            `json:"valid"` // Valid is true if TeamAuditAction is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullTeamAuditAction) Scan(value interface{}) error {
+	if value == nil {
+		ns.TeamAuditAction, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.TeamAuditAction.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullTeamAuditAction) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.TeamAuditAction), nil
+}
+
 type TeamInvitationStatus string
 
 const (
@@ -286,6 +553,7 @@ const (
 	TeamInvitationStatusAccepted TeamInvitationStatus = "accepted"
 	TeamInvitationStatusExpired  TeamInvitationStatus = "expired"
 	TeamInvitationStatusRejected TeamInvitationStatus = "rejected"
+	TeamInvitationStatusRevoked  TeamInvitationStatus = "revoked"
 )
 
 func (e *TeamInvitationStatus) Scan(src interface{}) error {
@@ -326,9 +594,11 @@ func (ns NullTeamInvitationStatus) Value() (driver.Value, error) {
 type TeamJoinRequestStatus string
 
 const (
-	TeamJoinRequestStatusPending  TeamJoinRequestStatus = "pending"
-	TeamJoinRequestStatusApproved TeamJoinRequestStatus = "approved"
-	TeamJoinRequestStatusRejected TeamJoinRequestStatus = "rejected"
+	TeamJoinRequestStatusPending   TeamJoinRequestStatus = "pending"
+	TeamJoinRequestStatusApproved  TeamJoinRequestStatus = "approved"
+	TeamJoinRequestStatusRejected  TeamJoinRequestStatus = "rejected"
+	TeamJoinRequestStatusExpired   TeamJoinRequestStatus = "expired"
+	TeamJoinRequestStatusCancelled TeamJoinRequestStatus = "cancelled"
 )
 
 func (e *TeamJoinRequestStatus) Scan(src interface{}) error {
@@ -428,16 +698,18 @@ type Account struct {
 }
 
 type Application struct {
-	UserID      uuid.UUID         `json:"user_id"`
-	Status      ApplicationStatus `json:"status"`
-	Application []byte            `json:"application"`
-	CreatedAt   time.Time         `json:"created_at"`
-	SavedAt     time.Time         `json:"saved_at"`
-	UpdatedAt   time.Time         `json:"updated_at"`
-	SubmittedAt *time.Time        `json:"submitted_at"`
-	HackathonID string            `json:"hackathon_id"`
-	IsEarly     bool              `json:"is_early"`
-	ID          uuid.UUID         `json:"id"`
+	UserID           uuid.UUID         `json:"user_id"`
+	Status           ApplicationStatus `json:"status"`
+	Application      []byte            `json:"application"`
+	CreatedAt        time.Time         `json:"created_at"`
+	SavedAt          time.Time         `json:"saved_at"`
+	UpdatedAt        time.Time         `json:"updated_at"`
+	SubmittedAt      *time.Time        `json:"submitted_at"`
+	HackathonID      string            `json:"hackathon_id"`
+	IsEarly          bool              `json:"is_early"`
+	ID               uuid.UUID         `json:"id"`
+	WaitlistJoinTime *time.Time        `json:"waitlist_join_time"`
+	RsvpDueAt        *time.Time        `json:"rsvp_due_at"`
 }
 
 type ApplicationAutoDecisionRequest struct {
@@ -452,6 +724,13 @@ type ApplicationAutoDecisionRequest struct {
 	CreatedAt         time.Time                   `json:"created_at"`
 }
 
+type ApplicationForm struct {
+	HackathonID string    `json:"hackathon_id"`
+	Fields      []byte    `json:"fields"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
 type ApplicationReview struct {
 	ID               uuid.UUID  `json:"id"`
 	ApplicationID    uuid.UUID  `json:"application_id"`
@@ -464,6 +743,18 @@ type ApplicationReview struct {
 	UpdatedAt        time.Time  `json:"updated_at"`
 }
 
+type BatConfig struct {
+	HackathonID          string    `json:"hackathon_id"`
+	PassionWeight        float64   `json:"passion_weight"`
+	ExperienceWeight     float64   `json:"experience_weight"`
+	WeightedBaseConstant float64   `json:"weighted_base_constant"`
+	TeamSlots            int32     `json:"team_slots"`
+	CreatedAt            time.Time `json:"created_at"`
+	UpdatedAt            time.Time `json:"updated_at"`
+	Buckets              []byte    `json:"buckets"`
+	ScoreNormalization   string    `json:"score_normalization"`
+}
+
 type BatRun struct {
 	ID                 uuid.UUID    `json:"id"`
 	AcceptedApplicants []uuid.UUID  `json:"accepted_applicants"`
@@ -472,6 +763,44 @@ type BatRun struct {
 	CreatedAt          time.Time    `json:"created_at"`
 	CompletedAt        *time.Time   `json:"completed_at"`
 	HackathonID        string       `json:"hackathon_id"`
+	Seed               *int64       `json:"seed"`
+	Config             []byte       `json:"config"`
+	InputSnapshot      []byte       `json:"input_snapshot"`
+}
+
+type BatRunDecision struct {
+	ID            uuid.UUID         `json:"id"`
+	RunID         uuid.UUID         `json:"run_id"`
+	ApplicationID uuid.UUID         `json:"application_id"`
+	UserID        uuid.UUID         `json:"user_id"`
+	TeamID        *uuid.UUID        `json:"team_id"`
+	Bucket        *string           `json:"bucket"`
+	WeightedScore float64           `json:"weighted_score"`
+	SortKey       float64           `json:"sort_key"`
+	Reason        BatDecisionReason `json:"reason"`
+	QuotaState    []byte            `json:"quota_state"`
+	CreatedAt     time.Time         `json:"created_at"`
+}
+
+type DecisionRelease struct {
+	ID                   uuid.UUID             `json:"id"`
+	HackathonID          string                `json:"hackathon_id"`
+	RunID                uuid.UUID             `json:"run_id"`
+	Status               DecisionReleaseStatus `json:"status"`
+	WaitlistCount        int32                 `json:"waitlist_count"`
+	ReleaseAt            time.Time             `json:"release_at"`
+	AcceptedApplicants   []uuid.UUID           `json:"accepted_applicants"`
+	WaitlistedApplicants []uuid.UUID           `json:"waitlisted_applicants"`
+	RejectedApplicants   []uuid.UUID           `json:"rejected_applicants"`
+	PreviousStatuses     []byte                `json:"previous_statuses"`
+	EmailsQueued         int32                 `json:"emails_queued"`
+	LastError            *string               `json:"last_error"`
+	CreatedBy            *uuid.UUID            `json:"created_by"`
+	AppliedAt            *time.Time            `json:"applied_at"`
+	SentAt               *time.Time            `json:"sent_at"`
+	RolledBackAt         *time.Time            `json:"rolled_back_at"`
+	CreatedAt            time.Time             `json:"created_at"`
+	UpdatedAt            time.Time             `json:"updated_at"`
 }
 
 type EmailCampaign struct {
@@ -491,6 +820,69 @@ type EmailCampaign struct {
 	UpdatedByUserID *uuid.UUID           `json:"updated_by_user_id"`
 	CreatedAt       time.Time            `json:"created_at"`
 	UpdatedAt       time.Time            `json:"updated_at"`
+	RecipientCount  int32                `json:"recipient_count"`
+	EmailsSent      int32                `json:"emails_sent"`
+	EmailsFailed    int32                `json:"emails_failed"`
+	Category        EmailCategory        `json:"category"`
+}
+
+type EmailDelivery struct {
+	ID            uuid.UUID           `json:"id"`
+	CampaignID    *uuid.UUID          `json:"campaign_id"`
+	Recipient     string              `json:"recipient"`
+	RecipientName *string             `json:"recipient_name"`
+	Subject       string              `json:"subject"`
+	Template      *string             `json:"template"`
+	Status        EmailDeliveryStatus `json:"status"`
+	SesMessageID  *string             `json:"ses_message_id"`
+	Error         *string             `json:"error"`
+	Attempts      int32               `json:"attempts"`
+	SentAt        *time.Time          `json:"sent_at"`
+	CreatedAt     time.Time           `json:"created_at"`
+	UpdatedAt     time.Time           `json:"updated_at"`
+}
+
+type EmailPreference struct {
+	Email      string        `json:"email"`
+	Category   EmailCategory `json:"category"`
+	Subscribed bool          `json:"subscribed"`
+	CreatedAt  time.Time     `json:"created_at"`
+	UpdatedAt  time.Time     `json:"updated_at"`
+}
+
+type EmailSuppression struct {
+	Email        string                 `json:"email"`
+	Reason       EmailSuppressionReason `json:"reason"`
+	Detail       *string                `json:"detail"`
+	SesMessageID *string                `json:"ses_message_id"`
+	CreatedAt    time.Time              `json:"created_at"`
+	UpdatedAt    time.Time              `json:"updated_at"`
+}
+
+type EmailTemplate struct {
+	ID              uuid.UUID  `json:"id"`
+	HackathonID     string     `json:"hackathon_id"`
+	Key             string     `json:"key"`
+	Name            string     `json:"name"`
+	Description     *string    `json:"description"`
+	CurrentVersion  int32      `json:"current_version"`
+	CreatedByUserID *uuid.UUID `json:"created_by_user_id"`
+	UpdatedByUserID *uuid.UUID `json:"updated_by_user_id"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+type EmailTemplateVersion struct {
+	ID              uuid.UUID  `json:"id"`
+	TemplateID      uuid.UUID  `json:"template_id"`
+	Version         int32      `json:"version"`
+	Subject         string     `json:"subject"`
+	HtmlBody        string     `json:"html_body"`
+	TextBody        *string    `json:"text_body"`
+	Variables       []string   `json:"variables"`
+	CreatedByUserID *uuid.UUID `json:"created_by_user_id"`
+	CreatedAt       time.Time  `json:"created_at"`
+	ReplyTo         *string    `json:"reply_to"`
 }
 
 type Hackathon struct {
@@ -524,6 +916,16 @@ type InterestSubmission struct {
 	HackathonID string    `json:"hackathon_id"`
 }
 
+type LookingForTeamProfile struct {
+	UserID      uuid.UUID `json:"user_id"`
+	HackathonID string    `json:"hackathon_id"`
+	Bio         *string   `json:"bio"`
+	Skills      []string  `json:"skills"`
+	Interests   []string  `json:"interests"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
 type Redeemable struct {
 	ID            uuid.UUID `json:"id"`
 	Name          string    `json:"name"`
@@ -534,6 +936,22 @@ type Redeemable struct {
 	HackathonID   string    `json:"hackathon_id"`
 }
 
+type ReviewSetting struct {
+	HackathonID    string    `json:"hackathon_id"`
+	BlindReview    bool      `json:"blind_review"`
+	RedactedFields []byte    `json:"redacted_fields"`
+	ResumeAccess   string    `json:"resume_access"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+type ReviewerConflict struct {
+	ReviewerID uuid.UUID `json:"reviewer_id"`
+	UserID     uuid.UUID `json:"user_id"`
+	Reason     *string   `json:"reason"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
 type Session struct {
 	ID         uuid.UUID `json:"id"`
 	UserID     uuid.UUID `json:"user_id"`
@@ -546,20 +964,42 @@ type Session struct {
 }
 
 type Team struct {
-	ID        uuid.UUID `json:"id"`
-	Name      string    `json:"name"`
-	OwnerID   uuid.UUID `json:"owner_id"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID         uuid.UUID `json:"id"`
+	Name       string    `json:"name"`
+	OwnerID    uuid.UUID `json:"owner_id"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+	MaxMembers *int32    `json:"max_members"`
+}
+
+type TeamAuditLog struct {
+	ID        uuid.UUID       `json:"id"`
+	TeamID    uuid.UUID       `json:"team_id"`
+	ActorID   *uuid.UUID      `json:"actor_id"`
+	Action    TeamAuditAction `json:"action"`
+	Details   []byte          `json:"details"`
+	CreatedAt time.Time       `json:"created_at"`
 }
 
 type TeamInvitation struct {
-	ID        uuid.UUID  `json:"id"`
-	TeamID    uuid.UUID  `json:"team_id"`
-	InviterID uuid.UUID  `json:"inviter_id"`
-	ExpiresAt *time.Time `json:"expires_at"`
-	CreatedAt time.Time  `json:"created_at"`
-	UpdatedAt time.Time  `json:"updated_at"`
+	ID            uuid.UUID            `json:"id"`
+	TeamID        uuid.UUID            `json:"team_id"`
+	InviterID     uuid.UUID            `json:"inviter_id"`
+	ExpiresAt     *time.Time           `json:"expires_at"`
+	CreatedAt     time.Time            `json:"created_at"`
+	UpdatedAt     time.Time            `json:"updated_at"`
+	MaxUses       *int32               `json:"max_uses"`
+	UseCount      int32                `json:"use_count"`
+	InvitedEmail  *string              `json:"invited_email"`
+	InvitedUserID *uuid.UUID           `json:"invited_user_id"`
+	Status        TeamInvitationStatus `json:"status"`
+}
+
+type TeamInvitationUse struct {
+	ID           uuid.UUID `json:"id"`
+	InvitationID uuid.UUID `json:"invitation_id"`
+	UserID       uuid.UUID `json:"user_id"`
+	UsedAt       time.Time `json:"used_at"`
 }
 
 type TeamJoinRequest struct {
@@ -572,6 +1012,17 @@ type TeamJoinRequest struct {
 	ProcessedAt       *time.Time            `json:"processed_at"`
 	CreatedAt         time.Time             `json:"created_at"`
 	UpdatedAt         time.Time             `json:"updated_at"`
+	ExpiresAt         time.Time             `json:"expires_at"`
+}
+
+type TeamListing struct {
+	TeamID      uuid.UUID `json:"team_id"`
+	HackathonID string    `json:"hackathon_id"`
+	Description *string   `json:"description"`
+	Skills      []string  `json:"skills"`
+	Interests   []string  `json:"interests"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
 }
 
 type TeamMember struct {
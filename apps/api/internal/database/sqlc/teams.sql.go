@@ -28,37 +28,21 @@ func (q *Queries) AddUserToTeam(ctx context.Context, arg AddUserToTeamParams) (T
 	return i, err
 }
 
-const createInvitation = `-- name: CreateInvitation :one
-INSERT INTO team_invitations (
-    team_id, inviter_id, expires_at
-) VALUES (
-    $1, $2, $3
-)
-RETURNING id, team_id, inviter_id, expires_at, created_at, updated_at
+const countTeamMembers = `-- name: CountTeamMembers :one
+SELECT count(*)
+FROM team_members
+WHERE team_id = $1
 `
 
-type CreateInvitationParams struct {
-	TeamID    uuid.UUID  `json:"team_id"`
-	InviterID uuid.UUID  `json:"inviter_id"`
-	ExpiresAt *time.Time `json:"expires_at"`
-}
-
-func (q *Queries) CreateInvitation(ctx context.Context, arg CreateInvitationParams) (TeamInvitation, error) {
-	row := q.db.QueryRow(ctx, createInvitation, arg.TeamID, arg.InviterID, arg.ExpiresAt)
-	var i TeamInvitation
-	err := row.Scan(
-		&i.ID,
-		&i.TeamID,
-		&i.InviterID,
-		&i.ExpiresAt,
-		&i.CreatedAt,
-		&i.UpdatedAt,
-	)
-	return i, err
+func (q *Queries) CountTeamMembers(ctx context.Context, teamID uuid.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, countTeamMembers, teamID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
 }
 
 const createTeam = `-- name: CreateTeam :one
-INSERT INTO teams (name, owner_id) VALUES ($1, $2) RETURNING id, name, owner_id, created_at, updated_at
+INSERT INTO teams (name, owner_id) VALUES ($1, $2) RETURNING id, name, owner_id, created_at, updated_at, max_members
 `
 
 type CreateTeamParams struct {
@@ -75,19 +59,11 @@ func (q *Queries) CreateTeam(ctx context.Context, arg CreateTeamParams) (Team, e
 		&i.OwnerID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.MaxMembers,
 	)
 	return i, err
 }
 
-const deleteInvitation = `-- name: DeleteInvitation :exec
-DELETE FROM team_invitations WHERE id = $1
-`
-
-func (q *Queries) DeleteInvitation(ctx context.Context, id uuid.UUID) error {
-	_, err := q.db.Exec(ctx, deleteInvitation, id)
-	return err
-}
-
 const deleteTeamById = `-- name: DeleteTeamById :exec
 DELETE FROM teams WHERE id = $1
 `
@@ -97,50 +73,58 @@ func (q *Queries) DeleteTeamById(ctx context.Context, id uuid.UUID) error {
 	return err
 }
 
-const getInvitationByID = `-- name: GetInvitationByID :one
-SELECT id, team_id, inviter_id, expires_at, created_at, updated_at FROM team_invitations WHERE id = $1
+const getLongestTenuredTeamMember = `-- name: GetLongestTenuredTeamMember :one
+SELECT user_id
+FROM team_members
+WHERE team_id = $1
+    AND user_id <> $2
+ORDER BY joined_at, user_id
+LIMIT 1
 `
 
-func (q *Queries) GetInvitationByID(ctx context.Context, id uuid.UUID) (TeamInvitation, error) {
-	row := q.db.QueryRow(ctx, getInvitationByID, id)
-	var i TeamInvitation
-	err := row.Scan(
-		&i.ID,
-		&i.TeamID,
-		&i.InviterID,
-		&i.ExpiresAt,
-		&i.CreatedAt,
-		&i.UpdatedAt,
-	)
-	return i, err
+type GetLongestTenuredTeamMemberParams struct {
+	TeamID uuid.UUID `json:"team_id"`
+	UserID uuid.UUID `json:"user_id"`
+}
+
+// returns the member who has been on the team the longest, other than the given user.
+func (q *Queries) GetLongestTenuredTeamMember(ctx context.Context, arg GetLongestTenuredTeamMemberParams) (uuid.UUID, error) {
+	row := q.db.QueryRow(ctx, getLongestTenuredTeamMember, arg.TeamID, arg.UserID)
+	var user_id uuid.UUID
+	err := row.Scan(&user_id)
+	return user_id, err
 }
 
-const getInvitationByTeamID = `-- name: GetInvitationByTeamID :one
-SELECT id, team_id, inviter_id, expires_at, created_at, updated_at FROM team_invitations WHERE team_id = $1
+const getTeamById = `-- name: GetTeamById :one
+SELECT id, name, owner_id, created_at, updated_at, max_members
+FROM teams
+WHERE id = $1
 `
 
-func (q *Queries) GetInvitationByTeamID(ctx context.Context, teamID uuid.UUID) (TeamInvitation, error) {
-	row := q.db.QueryRow(ctx, getInvitationByTeamID, teamID)
-	var i TeamInvitation
+func (q *Queries) GetTeamById(ctx context.Context, id uuid.UUID) (Team, error) {
+	row := q.db.QueryRow(ctx, getTeamById, id)
+	var i Team
 	err := row.Scan(
 		&i.ID,
-		&i.TeamID,
-		&i.InviterID,
-		&i.ExpiresAt,
+		&i.Name,
+		&i.OwnerID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.MaxMembers,
 	)
 	return i, err
 }
 
-const getTeamById = `-- name: GetTeamById :one
-SELECT id, name, owner_id, created_at, updated_at
+const getTeamByIdForUpdate = `-- name: GetTeamByIdForUpdate :one
+SELECT id, name, owner_id, created_at, updated_at, max_members
 FROM teams
 WHERE id = $1
+FOR UPDATE
 `
 
-func (q *Queries) GetTeamById(ctx context.Context, id uuid.UUID) (Team, error) {
-	row := q.db.QueryRow(ctx, getTeamById, id)
+// locks the team so members are added one at a time and the member limit holds.
+func (q *Queries) GetTeamByIdForUpdate(ctx context.Context, id uuid.UUID) (Team, error) {
+	row := q.db.QueryRow(ctx, getTeamByIdForUpdate, id)
 	var i Team
 	err := row.Scan(
 		&i.ID,
@@ -148,13 +132,14 @@ func (q *Queries) GetTeamById(ctx context.Context, id uuid.UUID) (Team, error) {
 		&i.OwnerID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.MaxMembers,
 	)
 	return i, err
 }
 
 const getTeamByInvitationId = `-- name: GetTeamByInvitationId :one
 SELECT
-    t.id, t.name, t.owner_id, t.created_at, t.updated_at
+    t.id, t.name, t.owner_id, t.created_at, t.updated_at, t.max_members
 FROM teams t
 JOIN team_invitations ti ON ti.team_id = t.id
 WHERE ti.id = $1
@@ -169,6 +154,7 @@ func (q *Queries) GetTeamByInvitationId(ctx context.Context, invitationID uuid.U
 		&i.OwnerID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.MaxMembers,
 	)
 	return i, err
 }
@@ -198,7 +184,7 @@ func (q *Queries) GetTeamByUserId(ctx context.Context, userID uuid.UUID) (GetTea
 
 const getTeamDetails = `-- name: GetTeamDetails :one
 SELECT 
-    t.id, t.name, t.owner_id, t.created_at, t.updated_at, 
+    t.id, t.name, t.owner_id, t.created_at, t.updated_at, t.max_members, 
     COALESCE(
         json_agg(
             json_build_object(
@@ -218,12 +204,13 @@ GROUP BY t.id
 `
 
 type GetTeamDetailsRow struct {
-	ID        uuid.UUID `json:"id"`
-	Name      string    `json:"name"`
-	OwnerID   uuid.UUID `json:"owner_id"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
-	Members   []byte    `json:"members"`
+	ID         uuid.UUID `json:"id"`
+	Name       string    `json:"name"`
+	OwnerID    uuid.UUID `json:"owner_id"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+	MaxMembers *int32    `json:"max_members"`
+	Members    []byte    `json:"members"`
 }
 
 func (q *Queries) GetTeamDetails(ctx context.Context, id uuid.UUID) (GetTeamDetailsRow, error) {
@@ -235,6 +222,7 @@ func (q *Queries) GetTeamDetails(ctx context.Context, id uuid.UUID) (GetTeamDeta
 		&i.OwnerID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.MaxMembers,
 		&i.Members,
 	)
 	return i, err
@@ -273,45 +261,131 @@ func (q *Queries) GetTeamMembers(ctx context.Context, teamID uuid.UUID) ([]GetTe
 	return items, nil
 }
 
+const getTeamWithMembers = `-- name: GetTeamWithMembers :one
+SELECT
+    t.id,
+    t.name,
+    t.owner_id,
+    t.max_members,
+    t.created_at,
+    count(tm.user_id) AS member_count,
+    COALESCE(
+        json_agg(
+            json_build_object(
+                'id', u.id,
+                'name', u.name,
+                'email', u.email,
+                'image', u.image,
+                'joinedAt', tm.joined_at,
+                'applicationStatus', a.status
+            ) ORDER BY tm.joined_at
+        ) FILTER (WHERE u.id IS NOT NULL),
+        '[]'
+    )::jsonb AS members
+FROM teams t
+LEFT JOIN team_members tm ON t.id = tm.team_id
+LEFT JOIN users u ON tm.user_id = u.id
+LEFT JOIN applications a ON a.user_id = u.id AND a.hackathon_id = $1
+WHERE t.id = $2
+GROUP BY t.id
+`
+
+type GetTeamWithMembersParams struct {
+	HackathonID string    `json:"hackathon_id"`
+	ID          uuid.UUID `json:"id"`
+}
+
+type GetTeamWithMembersRow struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	OwnerID     uuid.UUID `json:"owner_id"`
+	MaxMembers  *int32    `json:"max_members"`
+	CreatedAt   time.Time `json:"created_at"`
+	MemberCount int64     `json:"member_count"`
+	Members     []byte    `json:"members"`
+}
+
+// returns a team with its members and their application status for the hackathon.
+func (q *Queries) GetTeamWithMembers(ctx context.Context, arg GetTeamWithMembersParams) (GetTeamWithMembersRow, error) {
+	row := q.db.QueryRow(ctx, getTeamWithMembers, arg.HackathonID, arg.ID)
+	var i GetTeamWithMembersRow
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.OwnerID,
+		&i.MaxMembers,
+		&i.CreatedAt,
+		&i.MemberCount,
+		&i.Members,
+	)
+	return i, err
+}
+
 const listTeamsWithMembers = `-- name: ListTeamsWithMembers :many
 SELECT
     t.id,
     t.name,
     t.owner_id,
-    (COALESCE(
+    t.max_members,
+    t.created_at,
+    count(tm.user_id) AS member_count,
+    COALESCE(
         json_agg(
             json_build_object(
-                'user_id', u.id,
+                'id', u.id,
                 'name', u.name,
                 'email', u.email,
                 'image', u.image,
-                'joined_at', tm.joined_at
-            )
+                'joinedAt', tm.joined_at,
+                'applicationStatus', a.status
+            ) ORDER BY tm.joined_at
         ) FILTER (WHERE u.id IS NOT NULL),
-        '[]'::json
-    ))::jsonb AS members -- <--- Explicitly cast to jsonb
+        '[]'
+    )::jsonb AS members
 FROM teams t
 LEFT JOIN team_members tm ON t.id = tm.team_id
 LEFT JOIN users u ON tm.user_id = u.id
+LEFT JOIN applications a ON a.user_id = u.id AND a.hackathon_id = $1
+WHERE LOWER(t.name) LIKE LOWER('%' || COALESCE($2, '') || '%')
+    OR EXISTS (
+        SELECT 1
+        FROM team_members stm
+        JOIN users su ON su.id = stm.user_id
+        WHERE stm.team_id = t.id
+            AND (LOWER(su.name) LIKE LOWER('%' || COALESCE($2, '') || '%')
+                OR LOWER(COALESCE(su.email, '')) LIKE LOWER('%' || COALESCE($2, '') || '%'))
+    )
 GROUP BY t.id
 ORDER BY t.created_at DESC
-LIMIT $1 OFFSET $2
+LIMIT $3 OFFSET $4
 `
 
 type ListTeamsWithMembersParams struct {
-	Limit  int32 `json:"limit"`
-	Offset int32 `json:"offset"`
+	HackathonID string  `json:"hackathon_id"`
+	Search      *string `json:"search"`
+	Limit       int32   `json:"limit"`
+	Offset      int32   `json:"offset"`
 }
 
 type ListTeamsWithMembersRow struct {
-	ID      uuid.UUID `json:"id"`
-	Name    string    `json:"name"`
-	OwnerID uuid.UUID `json:"owner_id"`
-	Members []byte    `json:"members"`
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	OwnerID     uuid.UUID `json:"owner_id"`
+	MaxMembers  *int32    `json:"max_members"`
+	CreatedAt   time.Time `json:"created_at"`
+	MemberCount int64     `json:"member_count"`
+	Members     []byte    `json:"members"`
 }
 
+// returns every team with its members and their application status for the hackathon,
+// newest first. @search matches the team name or the name or email of a member.
 func (q *Queries) ListTeamsWithMembers(ctx context.Context, arg ListTeamsWithMembersParams) ([]ListTeamsWithMembersRow, error) {
-	rows, err := q.db.Query(ctx, listTeamsWithMembers, arg.Limit, arg.Offset)
+	rows, err := q.db.Query(ctx, listTeamsWithMembers,
+		arg.HackathonID,
+		arg.Search,
+		arg.Limit,
+		arg.Offset,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -323,6 +397,9 @@ func (q *Queries) ListTeamsWithMembers(ctx context.Context, arg ListTeamsWithMem
 			&i.ID,
 			&i.Name,
 			&i.OwnerID,
+			&i.MaxMembers,
+			&i.CreatedAt,
+			&i.MemberCount,
 			&i.Members,
 		); err != nil {
 			return nil, err
@@ -335,6 +412,47 @@ func (q *Queries) ListTeamsWithMembers(ctx context.Context, arg ListTeamsWithMem
 	return items, nil
 }
 
+const moveAllTeamMembers = `-- name: MoveAllTeamMembers :execrows
+UPDATE team_members
+SET team_id = $1, joined_at = now()
+WHERE team_id = $2
+`
+
+type MoveAllTeamMembersParams struct {
+	ToTeamID   uuid.UUID `json:"to_team_id"`
+	FromTeamID uuid.UUID `json:"from_team_id"`
+}
+
+func (q *Queries) MoveAllTeamMembers(ctx context.Context, arg MoveAllTeamMembersParams) (int64, error) {
+	result, err := q.db.Exec(ctx, moveAllTeamMembers, arg.ToTeamID, arg.FromTeamID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const moveTeamMember = `-- name: MoveTeamMember :execrows
+UPDATE team_members
+SET team_id = $1, joined_at = now()
+WHERE user_id = $2
+    AND team_id = $3
+`
+
+type MoveTeamMemberParams struct {
+	ToTeamID   uuid.UUID `json:"to_team_id"`
+	UserID     uuid.UUID `json:"user_id"`
+	FromTeamID uuid.UUID `json:"from_team_id"`
+}
+
+// the member's tenure starts over on the new team.
+func (q *Queries) MoveTeamMember(ctx context.Context, arg MoveTeamMemberParams) (int64, error) {
+	result, err := q.db.Exec(ctx, moveTeamMember, arg.ToTeamID, arg.UserID, arg.FromTeamID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const removeUserFromTeam = `-- name: RemoveUserFromTeam :exec
 DELETE FROM team_members WHERE user_id = $1 AND team_id = $2
 `
@@ -349,6 +467,32 @@ func (q *Queries) RemoveUserFromTeam(ctx context.Context, arg RemoveUserFromTeam
 	return err
 }
 
+const setTeamMaxMembers = `-- name: SetTeamMaxMembers :one
+UPDATE teams
+SET max_members = $1
+WHERE id = $2
+RETURNING id, name, owner_id, created_at, updated_at, max_members
+`
+
+type SetTeamMaxMembersParams struct {
+	MaxMembers *int32    `json:"max_members"`
+	ID         uuid.UUID `json:"id"`
+}
+
+func (q *Queries) SetTeamMaxMembers(ctx context.Context, arg SetTeamMaxMembersParams) (Team, error) {
+	row := q.db.QueryRow(ctx, setTeamMaxMembers, arg.MaxMembers, arg.ID)
+	var i Team
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.OwnerID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.MaxMembers,
+	)
+	return i, err
+}
+
 const updateTeamById = `-- name: UpdateTeamById :one
 UPDATE teams
 SET
@@ -356,7 +500,7 @@ SET
     name = CASE WHEN $3::boolean THEN $4 ELSE name END
 WHERE
     id = $5
-RETURNING id, name, owner_id, created_at, updated_at
+RETURNING id, name, owner_id, created_at, updated_at, max_members
 `
 
 type UpdateTeamByIdParams struct {
@@ -382,6 +526,7 @@ func (q *Queries) UpdateTeamById(ctx context.Context, arg UpdateTeamByIdParams)
 		&i.OwnerID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.MaxMembers,
 	)
 	return i, err
 }
@@ -0,0 +1,174 @@
+package application
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// assignableApplication is an application under review and what it takes to decide
+// which reviewers may not see it.
+type assignableApplication struct {
+	ID     uuid.UUID
+	UserID uuid.UUID
+	TeamID *uuid.UUID
+	School string
+}
+
+// reviewerAffiliation is what ties a reviewer to applicants: the teams they are on in
+// the hackathon, the school from their own most recent application, and the users
+// they flagged as a conflict of interest.
+type reviewerAffiliation struct {
+	TeamIDs map[uuid.UUID]bool
+	School  string
+	Flagged map[uuid.UUID]bool
+}
+
+// reviewConflicts decides which reviewers are kept away from which applications.
+type reviewConflicts struct {
+	reviewers map[uuid.UUID]reviewerAffiliation
+
+	// assigned holds the reviewers already reviewing an application, by application.
+	assigned map[uuid.UUID]map[uuid.UUID]bool
+}
+
+// excludes reports whether the reviewer may not review the application: it is their
+// own, they already review it, the applicant is a teammate or goes to the same school,
+// or the reviewer flagged the applicant.
+func (c reviewConflicts) excludes(reviewerID uuid.UUID, app assignableApplication) bool {
+	if reviewerID == app.UserID || c.assigned[app.ID][reviewerID] {
+		return true
+	}
+
+	affiliation, ok := c.reviewers[reviewerID]
+	if !ok {
+		return false
+	}
+
+	if app.TeamID != nil && affiliation.TeamIDs[*app.TeamID] {
+		return true
+	}
+
+	if affiliation.School != "" && strings.EqualFold(strings.TrimSpace(affiliation.School), strings.TrimSpace(app.School)) {
+		return true
+	}
+
+	return affiliation.Flagged[app.UserID]
+}
+
+// reviewerSlot is a reviewer taking part in an allocation.
+type reviewerSlot struct {
+	ID uuid.UUID
+
+	// Share is how many reviews the reviewer should end up with, counting Load.
+	// Fixed reviewers never get more than their share; the others take over whatever
+	// fixed reviewers can't.
+	Share int
+	Fixed bool
+
+	// Load is how many unfinished reviews the reviewer already has.
+	Load int
+}
+
+// reviewerShares turns the requested assignments into reviewer slots for the given number
+// of reviews. Reviewers with an amount get exactly that many; the rest split the remaining
+// reviews evenly.
+func reviewerShares(assignments []ReviewerAssignmentRequestDto, totalReviews int) ([]reviewerSlot, error) {
+	var slots []reviewerSlot
+	var autoIndexes []int
+	var totalFixed int
+
+	for _, assignment := range assignments {
+		if assignment.Amount == nil {
+			autoIndexes = append(autoIndexes, len(slots))
+			slots = append(slots, reviewerSlot{ID: assignment.ID})
+			continue
+		}
+
+		if *assignment.Amount <= 0 {
+			return nil, ErrInvalidAssignmentAmount
+		}
+
+		slots = append(slots, reviewerSlot{ID: assignment.ID, Share: *assignment.Amount, Fixed: true})
+		totalFixed += *assignment.Amount
+	}
+
+	if totalFixed > totalReviews {
+		return nil, ErrAssignmentExceedsApplications
+	}
+
+	if totalReviews > totalFixed && len(autoIndexes) == 0 {
+		return nil, ErrApplicationsLeftUnassigned
+	}
+
+	remaining := totalReviews - totalFixed
+	for i, index := range autoIndexes {
+		slots[index].Share = remaining / len(autoIndexes)
+		if i < remaining%len(autoIndexes) {
+			slots[index].Share++
+		}
+	}
+
+	return slots, nil
+}
+
+// allocateReviews gives every application reviewsPerApplication distinct reviewers. Applications
+// with the fewest eligible reviewers are placed first, and each goes to the eligible reviewers
+// furthest below their share, so the load stays balanced. It returns the application IDs each
+// reviewer gets, by reviewer.
+func allocateReviews(applications []assignableApplication, reviewers []reviewerSlot, reviewsPerApplication int, conflicts reviewConflicts) (map[uuid.UUID][]uuid.UUID, error) {
+	type candidate struct {
+		app      assignableApplication
+		eligible []int
+	}
+
+	candidates := make([]candidate, len(applications))
+	for i, app := range applications {
+		candidates[i].app = app
+		for j, reviewer := range reviewers {
+			if !conflicts.excludes(reviewer.ID, app) {
+				candidates[i].eligible = append(candidates[i].eligible, j)
+			}
+		}
+	}
+
+	slices.SortStableFunc(candidates, func(a, b candidate) int {
+		return len(a.eligible) - len(b.eligible)
+	})
+
+	assigned := make([]int, len(reviewers))
+	remaining := func(j int) int {
+		return reviewers[j].Share - reviewers[j].Load - assigned[j]
+	}
+
+	allocations := make(map[uuid.UUID][]uuid.UUID)
+
+	for _, c := range candidates {
+		var available []int
+		for _, j := range c.eligible {
+			if !reviewers[j].Fixed || remaining(j) > 0 {
+				available = append(available, j)
+			}
+		}
+
+		if len(available) < reviewsPerApplication {
+			return nil, fmt.Errorf("%w: application %s", ErrNotEnoughEligibleReviewers, c.app.ID)
+		}
+
+		slices.SortStableFunc(available, func(a, b int) int {
+			if remaining(a) != remaining(b) {
+				return remaining(b) - remaining(a)
+			}
+			return (reviewers[a].Load + assigned[a]) - (reviewers[b].Load + assigned[b])
+		})
+
+		for _, j := range available[:reviewsPerApplication] {
+			assigned[j]++
+			allocations[reviewers[j].ID] = append(allocations[reviewers[j].ID], c.app.ID)
+		}
+	}
+
+	return allocations, nil
+}
@@ -0,0 +1,157 @@
+package application
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestReviewerShares(t *testing.T) {
+	amount := func(n int) *int { return &n }
+
+	tests := []struct {
+		name          string
+		assignments   []ReviewerAssignmentRequestDto
+		totalReviews  int
+		expected      []int
+		expectedError error
+	}{
+		{
+			name:         "auto reviewers split evenly",
+			assignments:  []ReviewerAssignmentRequestDto{{ID: uuid.New()}, {ID: uuid.New()}, {ID: uuid.New()}},
+			totalReviews: 10,
+			expected:     []int{4, 3, 3},
+		},
+		{
+			name:         "fixed amounts come first",
+			assignments:  []ReviewerAssignmentRequestDto{{ID: uuid.New()}, {ID: uuid.New(), Amount: amount(6)}},
+			totalReviews: 10,
+			expected:     []int{4, 6},
+		},
+		{
+			name:          "non positive amount",
+			assignments:   []ReviewerAssignmentRequestDto{{ID: uuid.New(), Amount: amount(0)}},
+			totalReviews:  10,
+			expectedError: ErrInvalidAssignmentAmount,
+		},
+		{
+			name:          "fixed amounts exceed reviews",
+			assignments:   []ReviewerAssignmentRequestDto{{ID: uuid.New(), Amount: amount(11)}},
+			totalReviews:  10,
+			expectedError: ErrAssignmentExceedsApplications,
+		},
+		{
+			name:          "fixed amounts leave reviews unassigned",
+			assignments:   []ReviewerAssignmentRequestDto{{ID: uuid.New(), Amount: amount(9)}},
+			totalReviews:  10,
+			expectedError: ErrApplicationsLeftUnassigned,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slots, err := reviewerShares(tt.assignments, tt.totalReviews)
+			if !errors.Is(err, tt.expectedError) {
+				t.Fatalf("expected error %v, got %v", tt.expectedError, err)
+			}
+
+			for i, share := range tt.expected {
+				if slots[i].Share != share {
+					t.Errorf("reviewer %d: expected share %d, got %d", i, share, slots[i].Share)
+				}
+			}
+		})
+	}
+}
+
+func TestAllocateReviews(t *testing.T) {
+	alice, bob, carol, dave := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	team := uuid.New()
+
+	applications := make([]assignableApplication, 6)
+	for i := range applications {
+		applications[i] = assignableApplication{ID: uuid.New(), UserID: uuid.New(), School: "University of Florida"}
+	}
+	// Alice's teammate, an applicant Bob flagged who goes to Carol's school, and Carol herself.
+	applications[0].TeamID = &team
+	applications[1].School = "Florida State University"
+	applications[2].UserID = carol
+
+	conflicts := reviewConflicts{
+		reviewers: map[uuid.UUID]reviewerAffiliation{
+			alice: {TeamIDs: map[uuid.UUID]bool{team: true}},
+			bob:   {Flagged: map[uuid.UUID]bool{applications[1].UserID: true}},
+			carol: {School: " florida state university"},
+		},
+	}
+
+	t.Run("two reviews per application", func(t *testing.T) {
+		reviewers := []reviewerSlot{{ID: alice, Share: 3}, {ID: bob, Share: 3}, {ID: carol, Share: 3}, {ID: dave, Share: 3}}
+
+		allocations, err := allocateReviews(applications, reviewers, 2, conflicts)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		reviewersOf := make(map[uuid.UUID]map[uuid.UUID]bool)
+		for reviewerID, applicationIDs := range allocations {
+			for _, applicationID := range applicationIDs {
+				if reviewersOf[applicationID] == nil {
+					reviewersOf[applicationID] = make(map[uuid.UUID]bool)
+				}
+				reviewersOf[applicationID][reviewerID] = true
+			}
+		}
+
+		for i, app := range applications {
+			if len(reviewersOf[app.ID]) != 2 {
+				t.Errorf("application %d: expected 2 distinct reviewers, got %d", i, len(reviewersOf[app.ID]))
+			}
+			for reviewerID := range reviewersOf[app.ID] {
+				if conflicts.excludes(reviewerID, app) {
+					t.Errorf("application %d: assigned to a conflicted reviewer", i)
+				}
+			}
+		}
+
+		for _, reviewer := range reviewers {
+			if len(allocations[reviewer.ID]) != 3 {
+				t.Errorf("expected every reviewer to get 3 reviews, got %d", len(allocations[reviewer.ID]))
+			}
+		}
+	})
+
+	t.Run("fixed reviewers stay within their share", func(t *testing.T) {
+		reviewers := []reviewerSlot{{ID: alice, Share: 1, Fixed: true}, {ID: bob, Share: 2}}
+
+		allocations, err := allocateReviews(applications[3:], reviewers, 1, reviewConflicts{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(allocations[alice]) != 1 || len(allocations[bob]) != 2 {
+			t.Errorf("expected 1 and 2 reviews, got %d and %d", len(allocations[alice]), len(allocations[bob]))
+		}
+	})
+
+	t.Run("reassignment balances outstanding load", func(t *testing.T) {
+		reviewers := []reviewerSlot{{ID: alice, Load: 5}, {ID: bob, Load: 1}}
+
+		allocations, err := allocateReviews(applications[3:], reviewers, 1, reviewConflicts{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(allocations[bob]) != 3 {
+			t.Errorf("expected the least loaded reviewer to take all 3 reviews, got %d", len(allocations[bob]))
+		}
+	})
+
+	t.Run("not enough eligible reviewers", func(t *testing.T) {
+		reviewers := []reviewerSlot{{ID: bob, Share: 6}, {ID: carol, Share: 6}}
+
+		_, err := allocateReviews(applications, reviewers, 2, conflicts)
+		if !errors.Is(err, ErrNotEnoughEligibleReviewers) {
+			t.Errorf("expected %v, got %v", ErrNotEnoughEligibleReviewers, err)
+		}
+	})
+}
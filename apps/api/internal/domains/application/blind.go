@@ -0,0 +1,143 @@
+package application
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrRedactedFieldNameRequired = errors.New("every redacted field needs a name")
+	ErrDuplicateRedactedField    = errors.New("redacted field names must be unique")
+	ErrInvalidRedactionMode      = errors.New("redaction mode must be strip or mask")
+	ErrInvalidResumeAccess       = errors.New("resume access must be visible, after_rating or hidden")
+)
+
+type RedactionMode string
+
+const (
+	// RedactionStrip removes the answer from the review payload.
+	RedactionStrip RedactionMode = "strip"
+
+	// RedactionMask keeps the field but replaces the answer, so reviewers can tell
+	// it was answered without seeing it.
+	RedactionMask RedactionMode = "mask"
+)
+
+// maskedAnswer replaces masked answers.
+const maskedAnswer = "[redacted]"
+
+type ResumeAccess string
+
+const (
+	ResumeVisible ResumeAccess = "visible"
+
+	// ResumeAfterRating shows the resume once the reviewer has rated the application,
+	// so it can't sway the ratings.
+	ResumeAfterRating ResumeAccess = "after_rating"
+
+	ResumeHidden ResumeAccess = "hidden"
+)
+
+// RedactedField is an application answer hidden from reviewers in blind review. The
+// field's "-other" free text answer is redacted along with it.
+type RedactedField struct {
+	Name string        `json:"name" minLength:"1"`
+	Mode RedactionMode `json:"mode" enum:"strip,mask"`
+}
+
+// ReviewSettings controls what reviewers see of an application. Redacted fields and
+// resume access only apply while blind review is on.
+type ReviewSettings struct {
+	BlindReview    bool            `json:"blindReview"`
+	RedactedFields []RedactedField `json:"redactedFields" nullable:"false"`
+	ResumeAccess   ResumeAccess    `json:"resumeAccess" enum:"visible,after_rating,hidden"`
+}
+
+// DefaultReviewSettings are used by hackathons that haven't saved review settings yet.
+// Blind review is off, but turning it on hides the answers of the default form that
+// identify the applicant or could bias a reviewer.
+func DefaultReviewSettings() ReviewSettings {
+	strip := func(names ...string) []RedactedField {
+		fields := make([]RedactedField, len(names))
+		for i, name := range names {
+			fields[i] = RedactedField{Name: name, Mode: RedactionStrip}
+		}
+		return fields
+	}
+
+	return ReviewSettings{
+		BlindReview: false,
+		RedactedFields: strip(
+			"firstName", "lastName", "phone", "preferredEmail", "universityEmail", "linkedin",
+			"age", "gender", "pronouns", "race", "orientation", "country", "school",
+		),
+		ResumeAccess: ResumeAfterRating,
+	}
+}
+
+func validateReviewSettings(settings ReviewSettings) error {
+	switch settings.ResumeAccess {
+	case ResumeVisible, ResumeAfterRating, ResumeHidden:
+	default:
+		return ErrInvalidResumeAccess
+	}
+
+	names := make(map[string]bool, len(settings.RedactedFields))
+	for _, field := range settings.RedactedFields {
+		if field.Name == "" {
+			return ErrRedactedFieldNameRequired
+		}
+		if names[field.Name] {
+			return fmt.Errorf("%w: %s", ErrDuplicateRedactedField, field.Name)
+		}
+		names[field.Name] = true
+
+		if field.Mode != RedactionStrip && field.Mode != RedactionMask {
+			return fmt.Errorf("%w: %s", ErrInvalidRedactionMode, field.Name)
+		}
+	}
+
+	return nil
+}
+
+// redactApplication returns the application JSON with the redacted fields stripped or masked.
+func redactApplication(application []byte, fields []RedactedField) ([]byte, error) {
+	var answers map[string]any
+	if err := json.Unmarshal(application, &answers); err != nil {
+		return nil, err
+	}
+
+	for _, field := range fields {
+		for _, name := range []string{field.Name, otherFieldName(field.Name)} {
+			if _, ok := answers[name]; !ok {
+				continue
+			}
+
+			if field.Mode == RedactionMask {
+				answers[name] = maskedAnswer
+			} else {
+				delete(answers, name)
+			}
+		}
+	}
+
+	return json.Marshal(answers)
+}
+
+// resumeVisible reports whether a reviewer may see the resume of an application they
+// have or haven't rated yet.
+func (s ReviewSettings) resumeVisible(rated bool) bool {
+	if !s.BlindReview {
+		return true
+	}
+
+	switch s.ResumeAccess {
+	case ResumeVisible:
+		return true
+	case ResumeAfterRating:
+		return rated
+	}
+
+	return false
+}
@@ -0,0 +1,113 @@
+package application
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestRedactApplication(t *testing.T) {
+	application := []byte(`{"firstName":"Albert","gender":"other","gender-other":"agender","school":"University of Florida","essay1":"Gators","ageCertification":true}`)
+
+	redacted, err := redactApplication(application, []RedactedField{
+		{Name: "firstName", Mode: RedactionStrip},
+		{Name: "gender", Mode: RedactionStrip},
+		{Name: "school", Mode: RedactionMask},
+		{Name: "ageCertification", Mode: RedactionMask},
+		{Name: "linkedin", Mode: RedactionStrip},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var answers map[string]any
+	if err := json.Unmarshal(redacted, &answers); err != nil {
+		t.Fatalf("redacted application is not valid JSON: %v", err)
+	}
+
+	expected := map[string]any{
+		"school":           maskedAnswer,
+		"essay1":           "Gators",
+		"ageCertification": maskedAnswer,
+	}
+	if !reflect.DeepEqual(answers, expected) {
+		t.Errorf("expected %v, got %v", expected, answers)
+	}
+}
+
+func TestValidateReviewSettings(t *testing.T) {
+	tests := []struct {
+		name          string
+		mutate        func(settings *ReviewSettings)
+		expectedError error
+	}{
+		{
+			name:          "default settings",
+			mutate:        func(settings *ReviewSettings) {},
+			expectedError: nil,
+		},
+		{
+			name: "unknown resume access",
+			mutate: func(settings *ReviewSettings) {
+				settings.ResumeAccess = "redacted"
+			},
+			expectedError: ErrInvalidResumeAccess,
+		},
+		{
+			name: "missing field name",
+			mutate: func(settings *ReviewSettings) {
+				settings.RedactedFields = append(settings.RedactedFields, RedactedField{Mode: RedactionMask})
+			},
+			expectedError: ErrRedactedFieldNameRequired,
+		},
+		{
+			name: "duplicate field",
+			mutate: func(settings *ReviewSettings) {
+				settings.RedactedFields = append(settings.RedactedFields, RedactedField{Name: "school", Mode: RedactionMask})
+			},
+			expectedError: ErrDuplicateRedactedField,
+		},
+		{
+			name: "unknown mode",
+			mutate: func(settings *ReviewSettings) {
+				settings.RedactedFields = append(settings.RedactedFields, RedactedField{Name: "essay1", Mode: "blur"})
+			},
+			expectedError: ErrInvalidRedactionMode,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := DefaultReviewSettings()
+			tt.mutate(&settings)
+
+			if err := validateReviewSettings(settings); !errors.Is(err, tt.expectedError) {
+				t.Errorf("expected error %v, got %v", tt.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestResumeVisible(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings ReviewSettings
+		rated    bool
+		expected bool
+	}{
+		{name: "blind review off", settings: ReviewSettings{ResumeAccess: ResumeHidden}, expected: true},
+		{name: "visible", settings: ReviewSettings{BlindReview: true, ResumeAccess: ResumeVisible}, expected: true},
+		{name: "before rating", settings: ReviewSettings{BlindReview: true, ResumeAccess: ResumeAfterRating}, expected: false},
+		{name: "after rating", settings: ReviewSettings{BlindReview: true, ResumeAccess: ResumeAfterRating}, rated: true, expected: true},
+		{name: "hidden", settings: ReviewSettings{BlindReview: true, ResumeAccess: ResumeHidden}, rated: true, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if visible := tt.settings.resumeVisible(tt.rated); visible != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, visible)
+			}
+		})
+	}
+}
@@ -0,0 +1,424 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	ErrNoFormFields          = errors.New("the form needs at least one field")
+	ErrFormFieldNameRequired = errors.New("every form field needs a name")
+	ErrDuplicateFormField    = errors.New("form field names must be unique")
+	ErrInvalidFormField      = errors.New("form field is invalid")
+	ErrInvalidApplication    = errors.New("application has invalid fields")
+)
+
+type FormFieldType string
+
+const (
+	FormFieldText        FormFieldType = "text"
+	FormFieldParagraph   FormFieldType = "paragraph"
+	FormFieldEmail       FormFieldType = "email"
+	FormFieldURL         FormFieldType = "url"
+	FormFieldSelect      FormFieldType = "select"
+	FormFieldMultiSelect FormFieldType = "multiselect"
+	FormFieldBoolean     FormFieldType = "boolean"
+
+	// FormFieldFile fields are uploaded next to the application JSON and are
+	// not part of it.
+	FormFieldFile FormFieldType = "file"
+)
+
+// otherOption is the option that asks for a free text answer in the field's
+// "-other" companion field, e.g. "gender-other" for "gender".
+const otherOption = "other"
+
+// maxOtherLength bounds the "-other" free text answer of fields without a MaxLength.
+const maxOtherLength = 100
+
+// FormField describes a single question of an application form. Options restrict
+// select and multiselect answers; fields whose options come from a long list on
+// the client, like schools, leave them empty to accept any answer. Multiselect
+// answers are stored comma separated.
+type FormField struct {
+	Name        string        `json:"name" minLength:"1"`
+	Type        FormFieldType `json:"type" enum:"text,paragraph,email,url,select,multiselect,boolean,file"`
+	Required    bool          `json:"required,omitempty" required:"false"`
+	MinLength   int           `json:"minLength,omitempty" required:"false" minimum:"0"`
+	MaxLength   int           `json:"maxLength,omitempty" required:"false" minimum:"0"`
+	MinWords    int           `json:"minWords,omitempty" required:"false" minimum:"0"`
+	MaxWords    int           `json:"maxWords,omitempty" required:"false" minimum:"0"`
+	Pattern     string        `json:"pattern,omitempty" required:"false"`
+	Options     []string      `json:"options,omitempty" required:"false"`
+	HasOther    bool          `json:"hasOther,omitempty" required:"false" doc:"Picking the \"other\" option requires a free text answer in the \"<name>-other\" field"`
+	Demographic bool          `json:"demographic,omitempty" required:"false" doc:"Include the field's answers in the application statistics"`
+}
+
+// FieldError is a problem with a single answer of an application.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// FormValidationError lists every invalid answer of an application.
+type FormValidationError struct {
+	Fields []FieldError
+}
+
+func (e *FormValidationError) Error() string {
+	return fmt.Sprintf("%v: %d invalid field(s)", ErrInvalidApplication, len(e.Fields))
+}
+
+func (e *FormValidationError) Unwrap() error {
+	return ErrInvalidApplication
+}
+
+func otherFieldName(name string) string {
+	return name + "-" + otherOption
+}
+
+// DefaultFormFields is the SwampHacks application form, used by hackathons that
+// haven't saved a form of their own.
+func DefaultFormFields() []FormField {
+	agree := []string{"agree"}
+
+	return []FormField{
+		{Name: "firstName", Type: FormFieldText, Required: true, MaxLength: 50},
+		{Name: "lastName", Type: FormFieldText, Required: true, MaxLength: 50},
+		{Name: "age", Type: FormFieldSelect, Required: true, Options: []string{"<18", "18-22", ">22"}, Demographic: true},
+		{Name: "phone", Type: FormFieldText, Required: true, MinLength: 10, MaxLength: 10},
+		{Name: "preferredEmail", Type: FormFieldEmail, Required: true},
+		{Name: "universityEmail", Type: FormFieldEmail, Required: true},
+		{Name: "country", Type: FormFieldSelect, Required: true},
+		{Name: "gender", Type: FormFieldSelect, Required: true, HasOther: true, Demographic: true,
+			Options: []string{"man", "woman", "non-binary", "other", "no-answer"}},
+		{Name: "pronouns", Type: FormFieldSelect, Required: true,
+			Options: []string{"she/her", "he/him", "they/them", "she/they", "he/they", "not-represented", "no-answer"}},
+		{Name: "race", Type: FormFieldSelect, Required: true, HasOther: true, Demographic: true,
+			Options: []string{"native-american-alaska-native", "asian-pacific-islander", "black-african-american", "hispanic-latino", "white", "middle-eastern", "multiracial", "other", "no-answer"}},
+		{Name: "orientation", Type: FormFieldSelect, Required: true,
+			Options: []string{"heterosexual", "homosexual", "bisexual", "not-represented", "no-answer"}},
+		{Name: "linkedin", Type: FormFieldURL, Required: true},
+		{Name: "ageCertification", Type: FormFieldBoolean, Required: true},
+		{Name: "school", Type: FormFieldSelect, Required: true, Demographic: true},
+		{Name: "level", Type: FormFieldSelect, Required: true, HasOther: true,
+			Options: []string{"undergrad_two_year", "undergrad_three_plus_year", "graduate", "post_doctorate", "bootcamp", "other_program", "not_student", "prefer_no_answer", "other"}},
+		{Name: "year", Type: FormFieldSelect, Required: true, HasOther: true,
+			Options: []string{"first_year", "second_year", "third_year", "fourth_year", "graduate", "other"}},
+		{Name: "graduationYear", Type: FormFieldSelect, Required: true},
+		{Name: "majors", Type: FormFieldMultiSelect, Required: true, Demographic: true},
+		{Name: "minors", Type: FormFieldText},
+		{Name: "experience", Type: FormFieldSelect, Required: true, Options: []string{"first_time", "one", "two", "three", "four_or_more"}},
+		{Name: "ufHackathonExp", Type: FormFieldSelect, Required: true, Options: []string{"yes", "no"}},
+		{Name: "projectExperience", Type: FormFieldSelect, Required: true, Options: []string{"no_experience", "course_experience", "independent_project"}},
+		{Name: "shirtSize", Type: FormFieldSelect, Required: true, Options: []string{"S", "M", "L", "XL", "XXL"}},
+		{Name: "diet", Type: FormFieldMultiSelect,
+			Options: []string{"vegetarian", "vegan", "celiac-disease", "allergies", "kosher", "halal", "other"}},
+		{Name: "resume", Type: FormFieldFile, Required: true},
+		{Name: "essay1", Type: FormFieldParagraph, Required: true, MinWords: 100, MaxWords: 250},
+		{Name: "essay2", Type: FormFieldParagraph, Required: true, MinWords: 50, MaxWords: 250},
+		{Name: "essay3", Type: FormFieldParagraph, MaxWords: 250},
+		{Name: "referral", Type: FormFieldMultiSelect, Required: true,
+			Options: []string{"instagram", "discord", "linkedin", "word_of_mouth", "website", "class_shoutout", "other_fl_hackathon", "other"}},
+		{Name: "pictureConsent", Type: FormFieldSelect, Required: true, Options: agree},
+		{Name: "inpersonAcknowledgement", Type: FormFieldSelect, Required: true, Options: agree},
+		{Name: "agreeToConduct", Type: FormFieldSelect, Required: true, Options: agree},
+		{Name: "infoShareAuthorization", Type: FormFieldSelect, Required: true, Options: agree},
+		{Name: "agreeToMLHEmails", Type: FormFieldSelect, Options: agree},
+	}
+}
+
+func validateFormFields(fields []FormField) error {
+	if len(fields) == 0 {
+		return ErrNoFormFields
+	}
+
+	names := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		if strings.TrimSpace(field.Name) == "" {
+			return ErrFormFieldNameRequired
+		}
+		if names[field.Name] {
+			return fmt.Errorf("%w: %s", ErrDuplicateFormField, field.Name)
+		}
+		names[field.Name] = true
+
+		if err := field.validate(); err != nil {
+			return fmt.Errorf("%w: %s", err, field.Name)
+		}
+	}
+
+	// The free text answer of a field with an "other" option can't clash with a real field.
+	for _, field := range fields {
+		if field.HasOther && names[otherFieldName(field.Name)] {
+			return fmt.Errorf("%w: %s", ErrDuplicateFormField, otherFieldName(field.Name))
+		}
+	}
+
+	return nil
+}
+
+func (f FormField) validate() error {
+	switch f.Type {
+	case FormFieldText, FormFieldParagraph, FormFieldEmail, FormFieldURL:
+		if len(f.Options) > 0 || f.HasOther {
+			return ErrInvalidFormField
+		}
+	case FormFieldSelect, FormFieldMultiSelect:
+		if f.HasOther && len(f.Options) > 0 && !slices.Contains(f.Options, otherOption) {
+			return ErrInvalidFormField
+		}
+	case FormFieldBoolean, FormFieldFile:
+		if len(f.Options) > 0 || f.HasOther {
+			return ErrInvalidFormField
+		}
+	default:
+		return ErrInvalidFormField
+	}
+
+	if f.MinLength < 0 || f.MaxLength < 0 || f.MinWords < 0 || f.MaxWords < 0 {
+		return ErrInvalidFormField
+	}
+	if f.MaxLength > 0 && f.MinLength > f.MaxLength {
+		return ErrInvalidFormField
+	}
+	if f.MaxWords > 0 && f.MinWords > f.MaxWords {
+		return ErrInvalidFormField
+	}
+
+	if f.Pattern != "" {
+		if _, err := regexp.Compile(f.Pattern); err != nil {
+			return ErrInvalidFormField
+		}
+	}
+
+	return nil
+}
+
+// validateApplication checks application answers against the form fields and returns
+// the answers that belong to the form, normalized to how they are stored: booleans as
+// JSON booleans and everything else as strings. Answers to unknown fields are dropped.
+// A partial application, i.e. a saved draft, may leave required fields empty.
+func validateApplication(fields []FormField, answers map[string]any, partial bool) (map[string]any, []FieldError) {
+	application := make(map[string]any, len(fields))
+	var fieldErrors []FieldError
+
+	fail := func(field, message string) {
+		fieldErrors = append(fieldErrors, FieldError{Field: field, Message: message})
+	}
+
+	for _, field := range fields {
+		if field.Type == FormFieldFile {
+			continue
+		}
+
+		raw, present := answers[field.Name]
+
+		if field.Type == FormFieldBoolean {
+			value, ok := parseBoolAnswer(raw)
+			if present && !ok {
+				fail(field.Name, "must be true or false")
+				continue
+			}
+			if field.Required && !value && !partial {
+				fail(field.Name, "is required")
+				continue
+			}
+			if present {
+				application[field.Name] = value
+			}
+			continue
+		}
+
+		values, ok := stringAnswers(raw, field.Type == FormFieldMultiSelect)
+		if !ok {
+			fail(field.Name, "has an invalid value")
+			continue
+		}
+
+		if len(values) == 0 {
+			if field.Required && !partial {
+				fail(field.Name, "is required")
+				continue
+			}
+			if present {
+				application[field.Name] = ""
+			}
+			continue
+		}
+
+		if message := field.check(values, partial); message != "" {
+			fail(field.Name, message)
+			continue
+		}
+
+		application[field.Name] = strings.Join(values, ",")
+
+		if field.HasOther && slices.Contains(values, otherOption) {
+			otherName := otherFieldName(field.Name)
+			other, ok := stringAnswers(answers[otherName], false)
+
+			switch {
+			case !ok || len(other) > 1:
+				fail(otherName, "has an invalid value")
+			case len(other) == 0:
+				if !partial {
+					fail(otherName, "is required")
+				}
+			case utf8.RuneCountInString(other[0]) > field.otherMaxLength():
+				fail(otherName, fmt.Sprintf("must be at most %d characters", field.otherMaxLength()))
+			default:
+				application[otherName] = other[0]
+			}
+		}
+	}
+
+	return application, fieldErrors
+}
+
+// check returns why non-empty answers don't fit the field, or an empty string if they do.
+// Drafts are only held to the upper limits, since their answers may still be half typed.
+func (f FormField) check(values []string, partial bool) string {
+	if f.Type != FormFieldMultiSelect && len(values) > 1 {
+		return "must be a single answer"
+	}
+
+	for _, value := range values {
+		if message := f.checkValue(value, partial); message != "" {
+			return message
+		}
+	}
+
+	return ""
+}
+
+func (f FormField) checkValue(value string, partial bool) string {
+	if len(f.Options) > 0 && !slices.Contains(f.Options, value) {
+		return fmt.Sprintf("%q is not one of the options", value)
+	}
+
+	length := utf8.RuneCountInString(value)
+	if f.MaxLength > 0 && length > f.MaxLength {
+		return fmt.Sprintf("must be at most %d characters", f.MaxLength)
+	}
+
+	words := len(strings.Fields(value))
+	if f.MaxWords > 0 && words > f.MaxWords {
+		return fmt.Sprintf("must be at most %d words", f.MaxWords)
+	}
+
+	if partial {
+		return ""
+	}
+
+	switch f.Type {
+	case FormFieldEmail:
+		if address, err := mail.ParseAddress(value); err != nil || address.Address != value {
+			return "must be an email address"
+		}
+	case FormFieldURL:
+		if !isURL(value) {
+			return "must be a URL"
+		}
+	}
+
+	if f.MinLength > 0 && length < f.MinLength {
+		return fmt.Sprintf("must be at least %d characters", f.MinLength)
+	}
+	if f.MinWords > 0 && words < f.MinWords {
+		return fmt.Sprintf("must be at least %d words", f.MinWords)
+	}
+
+	if f.Pattern != "" {
+		if matched, err := regexp.MatchString(f.Pattern, value); err != nil || !matched {
+			return "has an invalid format"
+		}
+	}
+
+	return ""
+}
+
+func (f FormField) otherMaxLength() int {
+	if f.MaxLength > 0 {
+		return f.MaxLength
+	}
+	return maxOtherLength
+}
+
+// stringAnswers returns the non-empty, trimmed answers of a field. Answers may come in as
+// a JSON list, like the ["agree"] of a labeled checkbox, and multi-valued answers from
+// multipart forms as a comma separated string.
+func stringAnswers(raw any, multiple bool) ([]string, bool) {
+	var values []string
+
+	switch val := raw.(type) {
+	case nil:
+		return nil, true
+	case string:
+		if multiple {
+			values = strings.Split(val, ",")
+		} else {
+			values = []string{val}
+		}
+	case []any:
+		for _, item := range val {
+			s, ok := item.(string)
+			if !ok {
+				return nil, false
+			}
+			values = append(values, s)
+		}
+	case []string:
+		values = val
+	default:
+		return nil, false
+	}
+
+	answers := make([]string, 0, len(values))
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			answers = append(answers, value)
+		}
+	}
+
+	return answers, true
+}
+
+func parseBoolAnswer(raw any) (bool, bool) {
+	switch val := raw.(type) {
+	case nil:
+		return false, true
+	case bool:
+		return val, true
+	case string:
+		if val == "" {
+			return false, true
+		}
+		b, err := strconv.ParseBool(val)
+		return b, err == nil
+	}
+
+	return false, false
+}
+
+// isURL accepts absolute http(s) URLs as well as ones that leave out the scheme,
+// like "linkedin.com/in/albert".
+func isURL(value string) bool {
+	if !strings.Contains(value, "://") {
+		value = "https://" + value
+	}
+
+	parsed, err := url.ParseRequestURI(value)
+	if err != nil {
+		return false
+	}
+
+	return (parsed.Scheme == "http" || parsed.Scheme == "https") && strings.Contains(parsed.Host, ".")
+}
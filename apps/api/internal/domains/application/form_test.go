@@ -0,0 +1,209 @@
+package application
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestValidateApplication(t *testing.T) {
+	fields := []FormField{
+		{Name: "firstName", Type: FormFieldText, Required: true, MaxLength: 5},
+		{Name: "email", Type: FormFieldEmail, Required: true},
+		{Name: "gender", Type: FormFieldSelect, HasOther: true, Options: []string{"man", "woman", "other"}},
+		{Name: "diet", Type: FormFieldMultiSelect, Options: []string{"vegan", "halal"}},
+		{Name: "essay", Type: FormFieldParagraph, MinWords: 2, MaxWords: 3},
+		{Name: "over18", Type: FormFieldBoolean, Required: true},
+		{Name: "resume", Type: FormFieldFile, Required: true},
+		{Name: "consent", Type: FormFieldSelect, Options: []string{"agree"}},
+	}
+
+	tests := []struct {
+		name          string
+		answers       map[string]any
+		partial       bool
+		expected      map[string]any
+		invalidFields []string
+	}{
+		{
+			name: "valid multipart answers are normalized",
+			answers: map[string]any{
+				"firstName":    " Al ",
+				"email":        "al@ufl.edu",
+				"gender":       "other",
+				"gender-other": "agender",
+				"diet":         "vegan,halal",
+				"essay":        "two words",
+				"over18":       "true",
+				"unknown":      "dropped",
+			},
+			expected: map[string]any{
+				"firstName":    "Al",
+				"email":        "al@ufl.edu",
+				"gender":       "other",
+				"gender-other": "agender",
+				"diet":         "vegan,halal",
+				"essay":        "two words",
+				"over18":       true,
+			},
+		},
+		{
+			name:    "multiselect answers may be a JSON list",
+			answers: map[string]any{"firstName": "Al", "email": "al@ufl.edu", "diet": []any{"halal"}, "over18": true},
+			expected: map[string]any{
+				"firstName": "Al",
+				"email":     "al@ufl.edu",
+				"diet":      "halal",
+				"over18":    true,
+			},
+		},
+		{
+			name:    "labeled checkboxes send a one answer JSON list",
+			answers: map[string]any{"firstName": "Al", "email": "al@ufl.edu", "over18": true, "consent": []any{"agree"}},
+			expected: map[string]any{
+				"firstName": "Al",
+				"email":     "al@ufl.edu",
+				"over18":    true,
+				"consent":   "agree",
+			},
+		},
+		{
+			name: "single answer fields reject lists and long other answers",
+			answers: map[string]any{
+				"firstName": []any{"Al", "Alberta"},
+				"email":     "al@ufl.edu",
+				"gender":    []any{"man", "woman"},
+				"over18":    true,
+				"consent":   []any{"agree", "pizza"},
+			},
+			invalidFields: []string{"firstName", "gender", "consent"},
+		},
+		{
+			name: "other answers are bounded",
+			answers: map[string]any{
+				"firstName":    "Al",
+				"email":        "al@ufl.edu",
+				"gender":       "other",
+				"gender-other": strings.Repeat("a", maxOtherLength+1),
+				"over18":       true,
+			},
+			invalidFields: []string{"gender-other"},
+		},
+		{
+			name: "every invalid answer is reported",
+			answers: map[string]any{
+				"firstName": "Alberta",
+				"email":     "not an email",
+				"gender":    "other",
+				"diet":      "pizza",
+				"essay":     "one",
+				"over18":    "false",
+			},
+			invalidFields: []string{"firstName", "email", "gender-other", "diet", "essay", "over18"},
+		},
+		{
+			name:          "missing required answers",
+			answers:       map[string]any{},
+			invalidFields: []string{"firstName", "email", "over18"},
+		},
+		{
+			name:     "drafts may be incomplete and half typed",
+			answers:  map[string]any{"email": "al@", "gender": "other", "essay": "one"},
+			partial:  true,
+			expected: map[string]any{"email": "al@", "gender": "other", "essay": "one"},
+		},
+		{
+			name:          "drafts still respect upper limits",
+			answers:       map[string]any{"firstName": "Alberta", "essay": "one two three four"},
+			partial:       true,
+			invalidFields: []string{"firstName", "essay"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			application, fieldErrors := validateApplication(fields, test.answers, test.partial)
+
+			var invalidFields []string
+			for _, fieldError := range fieldErrors {
+				invalidFields = append(invalidFields, fieldError.Field)
+			}
+
+			if !slices.Equal(invalidFields, test.invalidFields) {
+				t.Fatalf("expected invalid fields %v, got %v", test.invalidFields, invalidFields)
+			}
+
+			if test.invalidFields != nil {
+				return
+			}
+
+			if len(application) != len(test.expected) {
+				t.Fatalf("expected %v, got %v", test.expected, application)
+			}
+			for name, value := range test.expected {
+				if application[name] != value {
+					t.Fatalf("expected %s to be %v, got %v", name, value, application[name])
+				}
+			}
+		})
+	}
+}
+
+func TestValidateFormFields(t *testing.T) {
+	tests := []struct {
+		name     string
+		fields   []FormField
+		expected error
+	}{
+		{
+			name:   "default form is valid",
+			fields: DefaultFormFields(),
+		},
+		{
+			name:     "empty form",
+			fields:   []FormField{},
+			expected: ErrNoFormFields,
+		},
+		{
+			name:     "duplicate field",
+			fields:   []FormField{{Name: "a", Type: FormFieldText}, {Name: "a", Type: FormFieldText}},
+			expected: ErrDuplicateFormField,
+		},
+		{
+			name:     "other answer clashes with a field",
+			fields:   []FormField{{Name: "a", Type: FormFieldSelect, HasOther: true}, {Name: "a-other", Type: FormFieldText}},
+			expected: ErrDuplicateFormField,
+		},
+		{
+			name:     "unknown type",
+			fields:   []FormField{{Name: "a", Type: "slider"}},
+			expected: ErrInvalidFormField,
+		},
+		{
+			name:     "options on a text field",
+			fields:   []FormField{{Name: "a", Type: FormFieldText, Options: []string{"b"}}},
+			expected: ErrInvalidFormField,
+		},
+		{
+			name:     "min length above max length",
+			fields:   []FormField{{Name: "a", Type: FormFieldText, MinLength: 5, MaxLength: 2}},
+			expected: ErrInvalidFormField,
+		},
+		{
+			name:     "bad pattern",
+			fields:   []FormField{{Name: "a", Type: FormFieldText, Pattern: "("}},
+			expected: ErrInvalidFormField,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateFormFields(test.fields)
+
+			if !errors.Is(err, test.expected) {
+				t.Fatalf("expected %v, got %v", test.expected, err)
+			}
+		})
+	}
+}
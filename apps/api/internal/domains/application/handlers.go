@@ -8,7 +8,6 @@ import (
 	"net/http"
 
 	"github.com/danielgtaylor/huma/v2"
-	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
 	"github.com/swamphacks/core/apps/api/internal/api/middleware"
 	"github.com/swamphacks/core/apps/api/internal/ctxutils"
@@ -206,7 +205,7 @@ type SaveApplicationOutput struct {
 }
 
 func (h *handler) handleSaveApplication(ctx context.Context, input *struct {
-	Body any
+	Body map[string]any
 }) (*SaveApplicationOutput, error) {
 	userCtx := ctxutils.GetUserFromCtx(ctx)
 
@@ -214,10 +213,13 @@ func (h *handler) handleSaveApplication(ctx context.Context, input *struct {
 		return nil, huma.Error400BadRequest("Failed to get current user info")
 	}
 
-	// TODO: validate input.Body, make sure that the data is the application
 	err := h.applicationService.SaveApplication(ctx, input.Body, userCtx.UserID)
 
 	if err != nil {
+		var fieldErr *FormValidationError
+		if errors.As(err, &fieldErr) {
+			return nil, toFieldErrorResponse(fieldErr)
+		}
 		return nil, huma.Error500InternalServerError(err.Error())
 	}
 
@@ -245,53 +247,13 @@ func (h *handler) handleSubmitApplication(ctx context.Context, input *struct{})
 		return nil, huma.Error400BadRequest("Failed to parse form")
 	}
 
-	var submission ApplicationSubmissionFields
-
-	// Map form values
-	submission.FirstName = r.FormValue("firstName")
-	submission.LastName = r.FormValue("lastName")
-
-	submission.Phone = r.FormValue("phone")
-	submission.PreferredEmail = r.FormValue("preferredEmail")
-	submission.UniversityEmail = r.FormValue("universityEmail")
-	submission.Age = r.FormValue("age")
-	submission.Country = r.FormValue("country")
-	submission.Gender = r.FormValue("gender")
-	submission.GenderOther = r.FormValue("gender-other")
-	submission.Pronouns = r.FormValue("pronouns")
-	submission.Race = r.FormValue("race")
-	submission.RaceOther = r.FormValue("race-other")
-	submission.Orientation = r.FormValue("orientation")
-
-	submission.Linkedin = r.FormValue("linkedin")
-	// submission.Github = r.FormValue("github")
-
-	if ageCertStr := r.FormValue("ageCertification"); ageCertStr != "" {
-		submission.AgeCertification = (ageCertStr == "true" || ageCertStr == "1")
-	}
-
-	submission.School = r.FormValue("school")
-	submission.Level = r.FormValue("level")
-	submission.LevelOther = r.FormValue("level-other")
-	submission.Year = r.FormValue("year")
-	submission.YearOther = r.FormValue("year-other")
-	submission.GraduationYear = r.FormValue("graduationYear")
-	submission.Majors = r.FormValue("majors")
-	submission.Minors = r.FormValue("minors")
-	submission.Experience = r.FormValue("experience")
-	submission.UfHackathonExp = r.FormValue("ufHackathonExp")
-	submission.ProjectExperience = r.FormValue("projectExperience")
-	submission.ShirtSize = r.FormValue("shirtSize")
-	submission.Diet = r.FormValue("diet")
-	submission.Essay1 = r.FormValue("essay1")
-	submission.Essay2 = r.FormValue("essay2")
-	submission.Essay3 = r.FormValue("essay3")
-	submission.Referral = r.FormValue("referral")
-	submission.PictureConsent = r.FormValue("pictureConsent")
-	submission.InPersonAcknowledgement = r.FormValue("inpersonAcknowledgement")
-	submission.AgreeToConduct = r.FormValue("agreeToConduct")
-	submission.InfoShareAuthorization = r.FormValue("infoShareAuthorization")
-	submission.AgreeToMLHEmails = r.FormValue("agreeToMLHEmails")
+	// Every answer arrives as a string, multiselect answers comma separated.
+	answers := make(map[string]any, len(r.MultipartForm.Value))
+	for name, values := range r.MultipartForm.Value {
+		if len(values) > 0 {
+			answers[name] = values[0]
+		}
+	}
 
 	resumeFile, _, err := r.FormFile("resume[]")
 	if err != nil {
@@ -306,17 +268,16 @@ func (h *handler) handleSubmitApplication(ctx context.Context, input *struct{})
 		return nil, huma.Error500InternalServerError("Error while parsing resume")
 	}
 
-	validate := validator.New()
-	if err := validate.Struct(submission); err != nil {
-		return nil, huma.Error400BadRequest("Unable to parse application submission")
-	}
-
-	submittedAt, err := h.applicationService.SubmitApplication(r.Context(), submission, resumeFileBuffer.Bytes(), userCtx.UserID)
+	submittedAt, err := h.applicationService.SubmitApplication(r.Context(), answers, resumeFileBuffer.Bytes(), userCtx.UserID)
 
 	if err != nil {
 		if errors.Is(err, ErrApplicationNotOpened) {
 			return nil, huma.Error400BadRequest(err.Error())
 		}
+		var fieldErr *FormValidationError
+		if errors.As(err, &fieldErr) {
+			return nil, toFieldErrorResponse(fieldErr)
+		}
 
 		return nil, huma.Error500InternalServerError(err.Error())
 	}
@@ -397,6 +358,51 @@ func (h *handler) handleReplaceResume(ctx context.Context, input *struct {
 	return &ReplaceResumeOutput{Status: http.StatusNoContent}, nil
 }
 
+type FormOutput struct {
+	Body []FormField `nullable:"false"`
+}
+
+func (h *handler) handleGetForm(ctx context.Context, input *struct{}) (*FormOutput, error) {
+	fields, err := h.applicationService.GetFormFields(ctx)
+	if err != nil {
+		return nil, huma.Error500InternalServerError("Failed to get application form")
+	}
+
+	return &FormOutput{Body: fields}, nil
+}
+
+func (h *handler) handleUpdateForm(ctx context.Context, input *struct {
+	Body []FormField
+}) (*FormOutput, error) {
+	fields, err := h.applicationService.UpdateFormFields(ctx, input.Body)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrNoFormFields),
+			errors.Is(err, ErrFormFieldNameRequired),
+			errors.Is(err, ErrDuplicateFormField),
+			errors.Is(err, ErrInvalidFormField):
+			return nil, huma.Error400BadRequest(err.Error())
+		}
+		return nil, huma.Error500InternalServerError("Failed to update application form")
+	}
+
+	return &FormOutput{Body: fields}, nil
+}
+
+// toFieldErrorResponse reports every invalid answer of an application as an error
+// detail located at the answer's field.
+func toFieldErrorResponse(err *FormValidationError) huma.StatusError {
+	details := make([]error, len(err.Fields))
+	for i, field := range err.Fields {
+		details[i] = &huma.ErrorDetail{
+			Message:  field.Message,
+			Location: "body." + field.Field,
+		}
+	}
+
+	return huma.Error422UnprocessableEntity("Some answers of the application are invalid", details...)
+}
+
 type GetApplicationStatisticsOutput struct {
 	Body ApplicationStatisticsDto
 }
@@ -436,12 +442,26 @@ type GetApplicationReviewDetailsOutput struct {
 func (h *handler) handleGetReviewById(ctx context.Context, input *struct {
 	ID uuid.UUID `path:"reviewId"`
 }) (*GetApplicationReviewDetailsOutput, error) {
-	review, resume, err := h.applicationService.GetReviewById(ctx, input.ID)
+	userCtx := ctxutils.GetUserFromCtx(ctx)
+
+	if userCtx == nil {
+		return nil, huma.Error400BadRequest("Failed to get current user info")
+	}
+
+	details, err := h.applicationService.GetReviewById(ctx, input.ID, userCtx.UserID, userCtx.Role)
 
 	if err != nil {
+		switch {
+		case errors.Is(err, ErrReviewNotFound):
+			return nil, huma.Error404NotFound(err.Error())
+		case errors.Is(err, ErrReviewNotAssigned):
+			return nil, huma.Error403Forbidden(err.Error())
+		}
 		return nil, huma.Error500InternalServerError(err.Error())
 	}
 
+	review := details.Review
+
 	var autoDecisionRequest *AutoDecisionRequestDto
 	if review.DecisionRequestID != nil && review.RequestedDecision.Valid {
 		autoDecisionRequest = &AutoDecisionRequestDto{
@@ -461,11 +481,75 @@ func (h *handler) handleGetReviewById(ctx context.Context, input *struct {
 		ExperienceRating:    review.ExperienceRating,
 		Notes:               review.Notes,
 		Application:         review.Application,
-		ResumeURL:           resume.URL,
+		ResumeURL:           details.ResumeURL,
+		Blind:               details.Blind,
 		AutoDecisionRequest: autoDecisionRequest,
 	}}, nil
 }
 
+type GetReviewResumeOutput struct {
+	ContentType string `header:"Content-Type"`
+	Body        []byte
+}
+
+func (h *handler) handleGetReviewResume(ctx context.Context, input *struct {
+	ID uuid.UUID `path:"reviewId"`
+}) (*GetReviewResumeOutput, error) {
+	userCtx := ctxutils.GetUserFromCtx(ctx)
+
+	if userCtx == nil {
+		return nil, huma.Error400BadRequest("Failed to get current user info")
+	}
+
+	resume, err := h.applicationService.GetReviewResume(ctx, input.ID, userCtx.UserID, userCtx.Role)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrReviewNotFound):
+			return nil, huma.Error404NotFound(err.Error())
+		case errors.Is(err, ErrReviewNotAssigned),
+			errors.Is(err, ErrResumeHidden):
+			return nil, huma.Error403Forbidden(err.Error())
+		}
+		return nil, huma.Error500InternalServerError(err.Error())
+	}
+
+	return &GetReviewResumeOutput{ContentType: "application/pdf", Body: resume}, nil
+}
+
+type ReviewSettingsOutput struct {
+	Body ReviewSettings
+}
+
+func (h *handler) handleGetReviewSettings(ctx context.Context, input *struct{}) (*ReviewSettingsOutput, error) {
+	settings, err := h.applicationService.GetReviewSettings(ctx)
+
+	if err != nil {
+		return nil, huma.Error500InternalServerError(err.Error())
+	}
+
+	return &ReviewSettingsOutput{Body: *settings}, nil
+}
+
+func (h *handler) handleUpdateReviewSettings(ctx context.Context, input *struct {
+	Body ReviewSettings
+}) (*ReviewSettingsOutput, error) {
+	settings, err := h.applicationService.UpdateReviewSettings(ctx, input.Body)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrRedactedFieldNameRequired),
+			errors.Is(err, ErrDuplicateRedactedField),
+			errors.Is(err, ErrInvalidRedactionMode),
+			errors.Is(err, ErrInvalidResumeAccess):
+			return nil, huma.Error400BadRequest(err.Error())
+		}
+		return nil, huma.Error500InternalServerError(err.Error())
+	}
+
+	return &ReviewSettingsOutput{Body: *settings}, nil
+}
+
 type SubmitApplicationReviewOutput struct {
 	Status int
 }
@@ -550,23 +634,132 @@ type AssignApplicationReviewersOutput struct {
 }
 
 func (h *handler) handleAssignApplicationReviewers(ctx context.Context, input *struct {
-	Body []ReviewerAssignmentRequestDto
+	ReviewsPerApplication int `query:"reviewsPerApplication" default:"1" minimum:"1" doc:"Number of distinct reviewers every application gets"`
+	Body                  []ReviewerAssignmentRequestDto
 }) (*AssignApplicationReviewersOutput, error) {
-	err := h.applicationService.AssignReviewersToApplications(ctx, input.Body)
+	err := h.applicationService.AssignReviewersToApplications(ctx, input.Body, input.ReviewsPerApplication)
 
 	if err != nil {
+		switch {
+		case errors.Is(err, ErrApplicationReviewNotStarted),
+			errors.Is(err, ErrInvalidAssignmentAmount),
+			errors.Is(err, ErrAssignmentExceedsApplications),
+			errors.Is(err, ErrApplicationsLeftUnassigned),
+			errors.Is(err, ErrNotEnoughEligibleReviewers):
+			return nil, huma.Error400BadRequest(err.Error())
+		}
 		return nil, huma.Error500InternalServerError(err.Error())
 	}
 
 	return &AssignApplicationReviewersOutput{Status: http.StatusOK}, nil
 }
 
+type ReassignReviewsOutput struct {
+	Body ReassignReviewsResponseDto
+}
+
+func (h *handler) handleReassignReviews(ctx context.Context, input *struct {
+	Body ReassignReviewsRequestDto
+}) (*ReassignReviewsOutput, error) {
+	reassigned, err := h.applicationService.ReassignReviews(ctx, input.Body)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrApplicationReviewNotStarted),
+			errors.Is(err, ErrNotEnoughEligibleReviewers):
+			return nil, huma.Error400BadRequest(err.Error())
+		}
+		return nil, huma.Error500InternalServerError(err.Error())
+	}
+
+	return &ReassignReviewsOutput{Body: ReassignReviewsResponseDto{Reassigned: reassigned}}, nil
+}
+
+type GetReviewerConflictsOutput struct {
+	Body []ReviewerConflictDto `nullable:"false"`
+}
+
+func (h *handler) handleGetReviewerConflicts(ctx context.Context, input *struct{}) (*GetReviewerConflictsOutput, error) {
+	userCtx := ctxutils.GetUserFromCtx(ctx)
+
+	if userCtx == nil {
+		return nil, huma.Error400BadRequest("Failed to get current user info")
+	}
+
+	conflicts, err := h.applicationService.GetReviewerConflicts(ctx, userCtx.UserID)
+
+	if err != nil {
+		return nil, huma.Error500InternalServerError(err.Error())
+	}
+
+	conflictDtos := make([]ReviewerConflictDto, len(conflicts))
+	for i, conflict := range conflicts {
+		conflictDtos[i] = ReviewerConflictDto{
+			User: AppUser{
+				ID:       conflict.UserID,
+				UserName: conflict.UserName,
+				Image:    conflict.UserImage,
+			},
+			Reason:    conflict.Reason,
+			CreatedAt: conflict.CreatedAt,
+		}
+	}
+
+	return &GetReviewerConflictsOutput{Body: conflictDtos}, nil
+}
+
+type FlagReviewerConflictOutput struct {
+	Status int
+}
+
+func (h *handler) handleFlagReviewerConflict(ctx context.Context, input *struct {
+	Body FlagReviewerConflictRequestDto
+}) (*FlagReviewerConflictOutput, error) {
+	userCtx := ctxutils.GetUserFromCtx(ctx)
+
+	if userCtx == nil {
+		return nil, huma.Error400BadRequest("Failed to get current user info")
+	}
+
+	if _, err := h.applicationService.FlagReviewerConflict(ctx, userCtx.UserID, input.Body); err != nil {
+		switch {
+		case errors.Is(err, ErrFlagOwnConflict):
+			return nil, huma.Error400BadRequest(err.Error())
+		case errors.Is(err, ErrConflictUserNotFound):
+			return nil, huma.Error404NotFound(err.Error())
+		}
+		return nil, huma.Error500InternalServerError(err.Error())
+	}
+
+	return &FlagReviewerConflictOutput{Status: http.StatusCreated}, nil
+}
+
+type RemoveReviewerConflictOutput struct {
+	Status int
+}
+
+func (h *handler) handleRemoveReviewerConflict(ctx context.Context, input *struct {
+	UserID uuid.UUID `path:"userId"`
+}) (*RemoveReviewerConflictOutput, error) {
+	userCtx := ctxutils.GetUserFromCtx(ctx)
+
+	if userCtx == nil {
+		return nil, huma.Error400BadRequest("Failed to get current user info")
+	}
+
+	if err := h.applicationService.RemoveReviewerConflict(ctx, userCtx.UserID, input.UserID); err != nil {
+		return nil, huma.Error500InternalServerError(err.Error())
+	}
+
+	return &RemoveReviewerConflictOutput{Status: http.StatusNoContent}, nil
+}
+
 type GetAllReviewersAndProgressOutput struct {
 	Body []ReviewerProgressResponseDto
 }
 
 func (h *handler) handleGetAllReviewersAndProgress(ctx context.Context, input *struct{}) (*GetAllReviewersAndProgressOutput, error) {
-	results, err := h.applicationService.GetAllReviewersAndProgress(ctx)
+	results, stats, err := h.applicationService.GetAllReviewersAndProgress(ctx)
 
 	if err != nil {
 		return nil, huma.Error500InternalServerError(err.Error())
@@ -582,6 +775,20 @@ func (h *handler) handleGetAllReviewersAndProgress(ctx context.Context, input *s
 			TotalAssigned:  val.TotalAssigned,
 			CompletedCount: val.CompletedCount,
 		}
+
+		if val.ID == nil {
+			continue
+		}
+
+		if reviewerStats, ok := stats[*val.ID]; ok {
+			reviewersProgress[i].Outlier = reviewerStats.Outlier
+			reviewersProgress[i].Calibration = &ReviewerCalibrationDto{
+				Passion:        reviewerStats.Passion,
+				Experience:     reviewerStats.Experience,
+				PassionBias:    reviewerStats.PassionBias,
+				ExperienceBias: reviewerStats.ExperienceBias,
+			}
+		}
 	}
 
 	return &GetAllReviewersAndProgressOutput{
@@ -826,12 +1033,32 @@ func (h *handler) handleConfirmAttendance(ctx context.Context, input *struct{})
 	err := h.applicationService.ConfirmAttendance(ctx, userCtx.UserID)
 
 	if err != nil {
+		if errors.Is(err, ErrRsvpDeadlinePassed) {
+			return nil, huma.Error409Conflict(err.Error())
+		}
 		return nil, huma.Error500InternalServerError(err.Error())
 	}
 
 	return &ConfirmAttendanceOutput{Status: http.StatusOK}, nil
 }
 
+type TransitionWaitlistedApplicationsOutput struct {
+	Status int
+}
+
+func (h *handler) handleTransitionWaitlistedApplications(ctx context.Context, input *struct{}) (*TransitionWaitlistedApplicationsOutput, error) {
+	err := h.applicationService.TransitionWaitlistedApplications(ctx, h.config.AcceptFromWaitlistCount, h.config.MaxAcceptedApplications)
+
+	if err != nil {
+		if errors.Is(err, ErrEventAlreadyStarted) {
+			return nil, huma.Error409Conflict(err.Error())
+		}
+		return nil, huma.Error500InternalServerError("Fail to transition waitlisted applications")
+	}
+
+	return &TransitionWaitlistedApplicationsOutput{Status: http.StatusOK}, nil
+}
+
 // type GetApplicationByUserIdOutput struct {
 // 	Body Application
 // }
@@ -941,23 +1168,6 @@ func (h *handler) handleConfirmAttendance(ctx context.Context, input *struct{})
 // 	return &WithdrawAcceptanceOutput{Status: http.StatusOK}, nil
 // }
 
-// type TransitionWaitlistedApplicationsOutput struct {
-// 	Status int
-// }
-
-// func (h *handler) handleTransitionWaitlistedApplications(ctx context.Context, input *struct{}) (*TransitionWaitlistedApplicationsOutput, error) {
-// 	// TODO: move these numbers elsewhere to a config file or something
-// 	var acceptanceCount uint32 = 50
-// 	var acceptanceQuota uint32 = 500
-// 	err := h.applicationService.TransitionWaitlistedApplications(ctx, acceptanceCount, acceptanceQuota)
-
-// 	if err != nil {
-// 		return nil, huma.Error500InternalServerError("Fail to transition waitlisted applications")
-// 	}
-
-// 	return &TransitionWaitlistedApplicationsOutput{Status: http.StatusOK}, nil
-// }
-
 // type CalculateAdmissionsRequestOutput struct {
 // 	Status int
 // }
@@ -971,26 +1181,3 @@ func (h *handler) handleConfirmAttendance(ctx context.Context, input *struct{})
 
 // 	return &CalculateAdmissionsRequestOutput{Status: http.StatusOK}, nil
 // }
-
-// type ReleaseDecisionsOutput struct {
-// 	Status int
-// }
-
-// func (h *handler) handleReleaseDecisions(ctx context.Context, input *struct {
-// 	RunId string `path:"runId"`
-// }) (*ReleaseDecisionsOutput, error) {
-// 	runId, err := uuid.Parse(input.RunId)
-
-// 	if err != nil {
-// 		return nil, huma.Error400BadRequest("Invalid Run Id")
-// 	}
-
-// 	err = h.applicationService.ReleaseDecisions(ctx, runId)
-
-// 	if err != nil {
-// 		return nil, err
-// 	}
-
-// 	return &ReleaseDecisionsOutput{Status: http.StatusOK}, nil
-
-// }
@@ -105,7 +105,7 @@ func RegisterRoutes(applicationHandler *handler, group huma.API, mw *middleware.
 		OperationID:   "get-application-statistics",
 		Method:        http.MethodGet,
 		Summary:       "Get Application Statistics",
-		Description:   "Aggregates applications by race, gender, age, majors, and schools",
+		Description:   "Aggregates submitted applications by every field the application form marks as demographic",
 		Tags:          []string{"Application"},
 		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma, mw.Auth.RequireStaffHuma},
 		Path:          "/stats",
@@ -114,6 +114,32 @@ func RegisterRoutes(applicationHandler *handler, group huma.API, mw *middleware.
 		DefaultStatus: http.StatusOK,
 	}, applicationHandler.handleGetApplicationStatistics)
 
+	huma.Register(group, huma.Operation{
+		OperationID:   "get-application-form",
+		Method:        http.MethodGet,
+		Summary:       "Get Application Form",
+		Description:   "Returns the fields of the current hackathon's application form",
+		Tags:          []string{"Application"},
+		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma},
+		Path:          "/form",
+		Errors:        []int{http.StatusUnauthorized, http.StatusInternalServerError},
+		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
+		DefaultStatus: http.StatusOK,
+	}, applicationHandler.handleGetForm)
+
+	huma.Register(group, huma.Operation{
+		OperationID:   "update-application-form",
+		Method:        http.MethodPut,
+		Summary:       "Update Application Form",
+		Description:   "Replaces the fields of the current hackathon's application form",
+		Tags:          []string{"Application"},
+		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma, mw.Auth.RequireAdminHuma},
+		Path:          "/form",
+		Errors:        []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusInternalServerError},
+		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
+		DefaultStatus: http.StatusOK,
+	}, applicationHandler.handleUpdateForm)
+
 	huma.Register(group, huma.Operation{
 		OperationID:   "save-application",
 		Method:        http.MethodPost,
@@ -122,7 +148,7 @@ func RegisterRoutes(applicationHandler *handler, group huma.API, mw *middleware.
 		Tags:          []string{"Application"},
 		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma},
 		Path:          "/save",
-		Errors:        []int{http.StatusUnauthorized, http.StatusInternalServerError},
+		Errors:        []int{http.StatusUnauthorized, http.StatusUnprocessableEntity, http.StatusInternalServerError},
 		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
 		DefaultStatus: http.StatusOK,
 	}, applicationHandler.handleSaveApplication)
@@ -131,11 +157,11 @@ func RegisterRoutes(applicationHandler *handler, group huma.API, mw *middleware.
 		OperationID:   "submit-application",
 		Method:        http.MethodPost,
 		Summary:       "Submit Application",
-		Description:   "Submit the application",
+		Description:   "Submit the application. Answers are validated against the hackathon's application form.",
 		Tags:          []string{"Application"},
 		Middlewares:   huma.Middlewares{mw.Auth.RawHTTPMiddlewareHuma, mw.Auth.RequireAuthHuma},
 		Path:          "/submit",
-		Errors:        []int{http.StatusUnauthorized, http.StatusInternalServerError},
+		Errors:        []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusUnprocessableEntity, http.StatusInternalServerError},
 		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
 		DefaultStatus: http.StatusOK,
 	}, applicationHandler.handleSubmitApplication)
@@ -161,7 +187,7 @@ func RegisterRoutes(applicationHandler *handler, group huma.API, mw *middleware.
 		Tags:          []string{"Application"},
 		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma},
 		Path:          "/confirm",
-		Errors:        []int{http.StatusUnauthorized, http.StatusInternalServerError},
+		Errors:        []int{http.StatusUnauthorized, http.StatusConflict, http.StatusInternalServerError},
 		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
 		DefaultStatus: http.StatusOK,
 	}, applicationHandler.handleConfirmAttendance)
@@ -189,11 +215,50 @@ func RegisterRoutes(applicationHandler *handler, group huma.API, mw *middleware.
 		Tags:          []string{"Application Review"},
 		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma, mw.Auth.RequireStaffHuma},
 		Path:          "/review/{reviewId}",
-		Errors:        []int{http.StatusUnauthorized, http.StatusInternalServerError},
+		Errors:        []int{http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound, http.StatusInternalServerError},
 		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
 		DefaultStatus: http.StatusOK,
 	}, applicationHandler.handleGetReviewById)
 
+	huma.Register(group, huma.Operation{
+		OperationID:   "get-review-resume",
+		Method:        http.MethodGet,
+		Summary:       "Get Review Resume",
+		Description:   "Serves the resume of a reviewed application during blind review, so reviewers never see the storage key. The resume stays hidden until the review settings allow it.",
+		Tags:          []string{"Application Review"},
+		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma, mw.Auth.RequireStaffHuma},
+		Path:          "/review/{reviewId}/resume",
+		Errors:        []int{http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound, http.StatusInternalServerError},
+		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
+		DefaultStatus: http.StatusOK,
+	}, applicationHandler.handleGetReviewResume)
+
+	huma.Register(group, huma.Operation{
+		OperationID:   "get-review-settings",
+		Method:        http.MethodGet,
+		Summary:       "Get Review Settings",
+		Description:   "Get the blind review settings of the current hackathon",
+		Tags:          []string{"Application Review"},
+		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma, mw.Auth.RequireAdminHuma},
+		Path:          "/review/settings",
+		Errors:        []int{http.StatusUnauthorized, http.StatusInternalServerError},
+		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
+		DefaultStatus: http.StatusOK,
+	}, applicationHandler.handleGetReviewSettings)
+
+	huma.Register(group, huma.Operation{
+		OperationID:   "update-review-settings",
+		Method:        http.MethodPut,
+		Summary:       "Update Review Settings",
+		Description:   "Turn blind review on or off and choose which answers are stripped or masked and when reviewers see the resume",
+		Tags:          []string{"Application Review"},
+		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma, mw.Auth.RequireAdminHuma},
+		Path:          "/review/settings",
+		Errors:        []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusInternalServerError},
+		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
+		DefaultStatus: http.StatusOK,
+	}, applicationHandler.handleUpdateReviewSettings)
+
 	huma.Register(group, huma.Operation{
 		OperationID:   "get-reviewers-and-progress",
 		Method:        http.MethodGet,
@@ -250,15 +315,67 @@ func RegisterRoutes(applicationHandler *handler, group huma.API, mw *middleware.
 		OperationID:   "assign-application-reviewers",
 		Method:        http.MethodPost,
 		Summary:       "Assign Application Reviewers",
-		Description:   "Assigns applications to reviewers for the application review process.",
+		Description:   "Assigns applications to reviewers for the application review process. Every application gets the requested number of distinct reviewers, and reviewers are kept away from their own application, their teammates, applicants from their school and users they flagged.",
 		Tags:          []string{"Application Review"},
 		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma, mw.Auth.RequireAdminHuma},
 		Path:          "/review/assign",
-		Errors:        []int{http.StatusUnauthorized, http.StatusInternalServerError},
+		Errors:        []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusInternalServerError},
 		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
 		DefaultStatus: http.StatusOK,
 	}, applicationHandler.handleAssignApplicationReviewers)
 
+	huma.Register(group, huma.Operation{
+		OperationID:   "reassign-application-reviews",
+		Method:        http.MethodPost,
+		Summary:       "Reassign Application Reviews",
+		Description:   "Hands the unfinished reviews of a reviewer who dropped out to other reviewers, balancing their outstanding work.",
+		Tags:          []string{"Application Review"},
+		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma, mw.Auth.RequireAdminHuma},
+		Path:          "/review/reassign",
+		Errors:        []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusInternalServerError},
+		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
+		DefaultStatus: http.StatusOK,
+	}, applicationHandler.handleReassignReviews)
+
+	huma.Register(group, huma.Operation{
+		OperationID:   "get-reviewer-conflicts",
+		Method:        http.MethodGet,
+		Summary:       "Get Reviewer Conflicts",
+		Description:   "Get the users the current reviewer flagged as a conflict of interest",
+		Tags:          []string{"Application Review"},
+		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma, mw.Auth.RequireStaffHuma},
+		Path:          "/review/conflicts",
+		Errors:        []int{http.StatusUnauthorized, http.StatusInternalServerError},
+		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
+		DefaultStatus: http.StatusOK,
+	}, applicationHandler.handleGetReviewerConflicts)
+
+	huma.Register(group, huma.Operation{
+		OperationID:   "flag-reviewer-conflict",
+		Method:        http.MethodPost,
+		Summary:       "Flag Reviewer Conflict",
+		Description:   "Flag a user as a conflict of interest so the current reviewer is never assigned their application",
+		Tags:          []string{"Application Review"},
+		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma, mw.Auth.RequireStaffHuma},
+		Path:          "/review/conflicts",
+		Errors:        []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusNotFound, http.StatusInternalServerError},
+		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
+		DefaultStatus: http.StatusCreated,
+	}, applicationHandler.handleFlagReviewerConflict)
+
+	huma.Register(group, huma.Operation{
+		OperationID:   "remove-reviewer-conflict",
+		Method:        http.MethodDelete,
+		Summary:       "Remove Reviewer Conflict",
+		Description:   "Remove a conflict of interest flagged by the current reviewer",
+		Tags:          []string{"Application Review"},
+		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma, mw.Auth.RequireStaffHuma},
+		Path:          "/review/conflicts/{userId}",
+		Errors:        []int{http.StatusUnauthorized, http.StatusInternalServerError},
+		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
+		DefaultStatus: http.StatusNoContent,
+	}, applicationHandler.handleRemoveReviewerConflict)
+
 	huma.Register(group, huma.Operation{
 		OperationID:   "reset-application-reviews",
 		Method:        http.MethodPost,
@@ -363,18 +480,18 @@ func RegisterRoutes(applicationHandler *handler, group huma.API, mw *middleware.
 	// 	DefaultStatus: http.StatusOK,
 	// }, applicationHandler.handleWithdrawAcceptance)
 
-	// huma.Register(group, huma.Operation{
-	// 	OperationID:   "transition-waitlist",
-	// 	Method:        http.MethodPatch,
-	// 	Summary:       "Transition Waitlisted Applications",
-	// 	Description:   "Transitions all accepted users to waitlist, and accepts 50 from the waitlist. Sets application status from accepted to rejected.",
-	// 	Tags:          []string{"Application"},
-	// 	Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma},
-	// 	Path:          "/transition-waitlisted-applications",
-	// 	Errors:        []int{http.StatusUnauthorized, http.StatusInternalServerError},
-	// 	Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
-	// 	DefaultStatus: http.StatusOK,
-	// }, applicationHandler.handleTransitionWaitlistedApplications)
+	huma.Register(group, huma.Operation{
+		OperationID:   "transition-waitlist",
+		Method:        http.MethodPost,
+		Summary:       "Transition Waitlisted Applications",
+		Description:   "Runs one round of the rolling waitlist right away. Accepted waitlisters past their RSVP deadline go back to the waitlist, then the longest waiting applicants are accepted into the free seats.",
+		Tags:          []string{"Application"},
+		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma, mw.Auth.RequireAdminHuma},
+		Path:          "/waitlist/transition",
+		Errors:        []int{http.StatusUnauthorized, http.StatusConflict, http.StatusInternalServerError},
+		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
+		DefaultStatus: http.StatusOK,
+	}, applicationHandler.handleTransitionWaitlistedApplications)
 
 	// huma.Register(group, huma.Operation{
 	// 	OperationID:   "calculate-admissions-request",
@@ -388,19 +505,6 @@ func RegisterRoutes(applicationHandler *handler, group huma.API, mw *middleware.
 	// 	Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
 	// 	DefaultStatus: http.StatusOK,
 	// }, applicationHandler.handleCalculateAdmissionsRequest)
-
-	// huma.Register(group, huma.Operation{
-	// 	OperationID:   "release-decisions",
-	// 	Method:        http.MethodPost,
-	// 	Summary:       "Release Decisions",
-	// 	Description:   "Releases decisions that were calculated by the worker from a specific run id",
-	// 	Tags:          []string{"Application"},
-	// 	Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma, mw.Auth.RequireAdminHuma},
-	// 	Path:          "/release-decisions/{runId}",
-	// 	Errors:        []int{http.StatusUnauthorized, http.StatusInternalServerError},
-	// 	Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
-	// 	DefaultStatus: http.StatusOK,
-	// }, applicationHandler.handleReleaseDecisions)
 }
 
 type handler struct {
@@ -12,6 +12,7 @@ import (
 	"github.com/hibiken/asynq"
 	"github.com/jackc/pgx/v5"
 	"github.com/rs/zerolog"
+	"github.com/swamphacks/core/apps/api/internal/calibration"
 	"github.com/swamphacks/core/apps/api/internal/config"
 	"github.com/swamphacks/core/apps/api/internal/database"
 	"github.com/swamphacks/core/apps/api/internal/database/sqlc"
@@ -174,7 +175,9 @@ func (s *ApplicationService) SearchApplications(ctx context.Context, limit, offs
 	return &applicationsCount, applications, nil
 }
 
-func (s *ApplicationService) SubmitApplication(ctx context.Context, data ApplicationSubmissionFields, resume []byte, userID uuid.UUID) (*time.Time, error) {
+// SubmitApplication validates the answers against the hackathon's form and submits them.
+// Invalid answers are reported as a *FormValidationError.
+func (s *ApplicationService) SubmitApplication(ctx context.Context, answers map[string]any, resume []byte, userID uuid.UUID) (*time.Time, error) {
 	hackathon, err := s.db.Query.GetHackathon(ctx)
 
 	if err != nil {
@@ -193,6 +196,16 @@ func (s *ApplicationService) SubmitApplication(ctx context.Context, data Applica
 		return nil, ErrApplicationNotOpened
 	}
 
+	fields, err := s.getFormFields(ctx, hackathon.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	data, fieldErrors := validateApplication(fields, answers, false)
+	if len(fieldErrors) > 0 {
+		return nil, &FormValidationError{Fields: fieldErrors}
+	}
+
 	dataJSON, err := json.Marshal(data)
 
 	if err != nil {
@@ -247,7 +260,10 @@ func (s *ApplicationService) SubmitApplication(ctx context.Context, data Applica
 		return nil, ErrSubmitApplication
 	}
 
-	err = s.emailService.QueueApplicationConfirmationEmail(data.PreferredEmail, data.FirstName)
+	recipient, name, err := s.confirmationRecipient(ctx, data, userID)
+	if err == nil {
+		err = s.emailService.QueueApplicationConfirmationEmail(ctx, recipient, name)
+	}
 
 	// Non-blocking error
 	if err != nil {
@@ -257,7 +273,39 @@ func (s *ApplicationService) SubmitApplication(ctx context.Context, data Applica
 	return &now, nil
 }
 
-func (s *ApplicationService) SaveApplication(ctx context.Context, data any, userID uuid.UUID) error {
+// confirmationRecipient prefers the email and first name given in the application,
+// and falls back to the account's for forms that don't ask for them.
+func (s *ApplicationService) confirmationRecipient(ctx context.Context, application map[string]any, userID uuid.UUID) (string, string, error) {
+	recipient, _ := application["preferredEmail"].(string)
+	name, _ := application["firstName"].(string)
+
+	if recipient != "" && name != "" {
+		return recipient, name, nil
+	}
+
+	user, err := s.db.Query.GetUserEmailInfoById(ctx, userID)
+	if err != nil {
+		return "", "", err
+	}
+
+	if recipient == "" {
+		contactEmail, ok := user.ContactEmail.(string)
+		if !ok || contactEmail == "" {
+			return "", "", fmt.Errorf("user %s has no email to send the confirmation to", userID)
+		}
+		recipient = contactEmail
+	}
+
+	if name == "" {
+		name = user.Name
+	}
+
+	return recipient, name, nil
+}
+
+// SaveApplication stores a draft of the application. Drafts may leave questions
+// unanswered, but answers that can never be valid are reported as a *FormValidationError.
+func (s *ApplicationService) SaveApplication(ctx context.Context, answers map[string]any, userID uuid.UUID) error {
 	// Guard clauses to ensure application can be saved
 	// 1) Check if applications are open for the event
 	// 2) Ensure application status is "started" (Reject all other statuses)
@@ -279,6 +327,16 @@ func (s *ApplicationService) SaveApplication(ctx context.Context, data any, user
 		return ErrApplicationAlreadySubmitted
 	}
 
+	fields, err := s.getFormFields(ctx, application.HackathonID)
+	if err != nil {
+		return err
+	}
+
+	data, fieldErrors := validateApplication(fields, answers, true)
+	if len(fieldErrors) > 0 {
+		return &FormValidationError{Fields: fieldErrors}
+	}
+
 	dataJSON, err := json.Marshal(data)
 
 	if err != nil {
@@ -359,45 +417,43 @@ func (s *ApplicationService) ReplaceResume(ctx context.Context, userID uuid.UUID
 	return nil
 }
 
+// GetApplicationStatistics counts the answers to every field the hackathon's form marks
+// as demographic, next to the number of applications in each status.
 func (s *ApplicationService) GetApplicationStatistics(ctx context.Context) (*ApplicationStatisticsDto, error) {
-	g, ctx := errgroup.WithContext(ctx)
-
-	var genderStats sqlc.GetSubmittedApplicationGendersRow
-	var ageStats sqlc.GetSubmittedApplicationAgesRow
-	var raceStats []sqlc.GetSubmittedApplicationRacesRow
-	var majorStats []sqlc.GetSubmittedApplicationMajorsRow
-	var schoolStats []sqlc.GetSubmittedApplicationSchoolsRow
-	var statusStats sqlc.GetApplicationStatusesRow
+	hackathon, err := s.db.Query.GetHackathon(ctx)
+	if err != nil {
+		s.logger.Err(err).Msg("GetApplicationStatistics fail because can't retrieve hackathon")
+		return nil, ErrGetHackathon
+	}
 
-	g.Go(func() error {
-		var err error
-		genderStats, err = s.db.Query.GetSubmittedApplicationGenders(ctx)
-		return err
-	})
+	fields, err := s.getFormFields(ctx, hackathon.ID)
+	if err != nil {
+		return nil, err
+	}
 
-	g.Go(func() error {
-		var err error
-		ageStats, err = s.db.Query.GetSubmittedApplicationAges(ctx)
-		return err
-	})
+	var demographicFields []FormField
+	for _, field := range fields {
+		if field.Demographic && field.Type != FormFieldFile {
+			demographicFields = append(demographicFields, field)
+		}
+	}
 
-	g.Go(func() error {
-		var err error
-		majorStats, err = s.db.Query.GetSubmittedApplicationMajors(ctx)
-		return err
-	})
+	g, ctx := errgroup.WithContext(ctx)
 
-	g.Go(func() error {
-		var err error
-		raceStats, err = s.db.Query.GetSubmittedApplicationRaces(ctx)
-		return err
-	})
+	demographicStats := make([]FieldStatisticsDto, len(demographicFields))
+	var statusStats sqlc.GetApplicationStatusesRow
 
-	g.Go(func() error {
-		var err error
-		schoolStats, err = s.db.Query.GetSubmittedApplicationSchools(ctx)
-		return err
-	})
+	for i, field := range demographicFields {
+		g.Go(func() error {
+			counts, err := s.db.Query.GetSubmittedApplicationFieldCounts(ctx, sqlc.GetSubmittedApplicationFieldCountsParams{
+				Split:       field.Type == FormFieldMultiSelect,
+				Field:       field.Name,
+				HackathonID: hackathon.ID,
+			})
+			demographicStats[i] = FieldStatisticsDto{Field: field.Name, Counts: counts}
+			return err
+		})
+	}
 
 	g.Go(func() error {
 		var err error
@@ -411,14 +467,75 @@ func (s *ApplicationService) GetApplicationStatistics(ctx context.Context) (*App
 	}
 
 	return &ApplicationStatisticsDto{
-		GenderStatistics: genderStats,
-		AgeStatistics:    ageStats,
-		RaceStatistics:   raceStats,
-		MajorStatistics:  majorStats,
-		SchoolStatistics: schoolStats,
-		StatusStatistics: statusStats,
+		DemographicStatistics: demographicStats,
+		StatusStatistics:      statusStats,
 	}, nil
+}
 
+// GetFormFields returns the application form of the current hackathon.
+func (s *ApplicationService) GetFormFields(ctx context.Context) ([]FormField, error) {
+	hackathon, err := s.db.Query.GetHackathon(ctx)
+	if err != nil {
+		s.logger.Err(err).Msg("GetFormFields fail because can't retrieve hackathon")
+		return nil, ErrGetHackathon
+	}
+
+	return s.getFormFields(ctx, hackathon.ID)
+}
+
+// UpdateFormFields replaces the application form of the current hackathon. Answers
+// already saved or submitted are kept as they are.
+func (s *ApplicationService) UpdateFormFields(ctx context.Context, fields []FormField) ([]FormField, error) {
+	if err := validateFormFields(fields); err != nil {
+		return nil, err
+	}
+
+	hackathon, err := s.db.Query.GetHackathon(ctx)
+	if err != nil {
+		s.logger.Err(err).Msg("UpdateFormFields fail because can't retrieve hackathon")
+		return nil, ErrGetHackathon
+	}
+
+	fieldsJSON, err := json.Marshal(fields)
+	if err != nil {
+		return nil, ErrUpdateForm
+	}
+
+	row, err := s.db.Query.UpsertApplicationForm(ctx, sqlc.UpsertApplicationFormParams{
+		HackathonID: hackathon.ID,
+		Fields:      fieldsJSON,
+	})
+	if err != nil {
+		s.logger.Err(err).Msg("UpdateFormFields fail")
+		return nil, ErrUpdateForm
+	}
+
+	return s.toFormFields(row)
+}
+
+// getFormFields loads the stored application form of a hackathon, falling back to
+// the default form when none has been saved yet.
+func (s *ApplicationService) getFormFields(ctx context.Context, hackathonID string) ([]FormField, error) {
+	row, err := s.db.Query.GetApplicationFormByHackathonId(ctx, hackathonID)
+	if err != nil {
+		if database.IsNotFound(err) {
+			return DefaultFormFields(), nil
+		}
+		s.logger.Err(err).Msg("Failed to get application form")
+		return nil, ErrGetForm
+	}
+
+	return s.toFormFields(row)
+}
+
+func (s *ApplicationService) toFormFields(row sqlc.ApplicationForm) ([]FormField, error) {
+	var fields []FormField
+	if err := json.Unmarshal(row.Fields, &fields); err != nil {
+		s.logger.Err(err).Str("HackathonID", row.HackathonID).Msg("Failed to parse stored application form")
+		return nil, ErrGetForm
+	}
+
+	return fields, nil
 }
 
 // func (s *ApplicationService) JoinWaitlist(ctx context.Context, userID uuid.UUID) error {
@@ -475,6 +592,10 @@ func (s *ApplicationService) ConfirmAttendance(ctx context.Context, userID uuid.
 			return err
 		}
 
+		if application.RsvpDueAt != nil && time.Now().After(*application.RsvpDueAt) {
+			return ErrRsvpDeadlinePassed
+		}
+
 		if err := txDB.Query.UpdateApplicationByUserId(ctx, sqlc.UpdateApplicationByUserIdParams{
 			UserID:         userID,
 			StatusDoUpdate: true,
@@ -493,11 +614,120 @@ func (s *ApplicationService) ConfirmAttendance(ctx context.Context, userID uuid.
 
 	if err != nil {
 		s.logger.Err(err).Str("userID", userID.String()).Msg("ConfirmAttendance fail")
+		if errors.Is(err, ErrRsvpDeadlinePassed) {
+			return err
+		}
 		return ErrConfirmAttendance
 	}
 	return nil
 }
 
+// TransitionWaitlistedApplications runs one round of the rolling waitlist. Applicants
+// accepted off the waitlist who missed their RSVP deadline are sent back to the end
+// of the waitlist first, then up to acceptanceCount of the longest waiting applicants
+// are accepted into the free seats and emailed. Applicants sent back in this round
+// aren't accepted again until the next one. Seats are capped by the hackathon's
+// max attendees, or acceptanceQuota if it has none.
+//
+// Once the event has started the waitlist is closed and the scheduler, if this
+// service runs one, is shut down.
+func (s *ApplicationService) TransitionWaitlistedApplications(ctx context.Context, acceptanceCount uint32, acceptanceQuota uint32) error {
+	hackathon, err := s.db.Query.GetHackathon(ctx)
+	if err != nil {
+		s.logger.Err(err).Msg("TransitionWaitlistedApplications fail, unable to get hackathon")
+		return ErrGetHackathon
+	}
+
+	now := time.Now()
+	if !now.Before(hackathon.StartTime) {
+		s.logger.Info().Msg("The event has started, shutting down the waitlist transition scheduler.")
+		if s.scheduler != nil {
+			// The API also uses this service without a scheduler, so it has to be checked.
+			s.scheduler.Shutdown()
+		}
+		return ErrEventAlreadyStarted
+	}
+
+	capacity := int64(acceptanceQuota)
+	if hackathon.MaxAttendees != nil {
+		capacity = int64(*hackathon.MaxAttendees)
+	}
+
+	// Nobody should be asked to confirm after the event has started.
+	rsvpDueAt := now.Add(s.config.WaitlistRsvpWindow)
+	if rsvpDueAt.After(hackathon.StartTime) {
+		rsvpDueAt = hackathon.StartTime
+	}
+
+	var expired []sqlc.ExpireUnconfirmedAcceptancesRow
+	var accepted []sqlc.AcceptWaitlistedApplicationsRow
+
+	err = s.txm.WithTx(ctx, func(tx pgx.Tx) error {
+		txDB := s.db.NewTX(tx)
+
+		expired, err = txDB.Query.ExpireUnconfirmedAcceptances(ctx, hackathon.ID)
+		if err != nil {
+			return err
+		}
+
+		admittedCount, err := txDB.Query.CountAdmittedApplications(ctx, hackathon.ID)
+		if err != nil {
+			return err
+		}
+
+		seats := min(int64(acceptanceCount), capacity-admittedCount)
+		if seats <= 0 {
+			return nil
+		}
+
+		// The expired applicants are at the end of the waitlist, but with a short waitlist
+		// they'd be accepted again right away with a new deadline.
+		expiredIDs := make([]uuid.UUID, len(expired))
+		for i, application := range expired {
+			expiredIDs[i] = application.ID
+		}
+
+		accepted, err = txDB.Query.AcceptWaitlistedApplications(ctx, sqlc.AcceptWaitlistedApplicationsParams{
+			RsvpDueAt:       &rsvpDueAt,
+			HackathonID:     hackathon.ID,
+			ExcludedIds:     expiredIDs,
+			AcceptanceCount: int32(seats),
+		})
+		return err
+	})
+	if err != nil {
+		s.logger.Err(err).Msg("TransitionWaitlistedApplications fail")
+		return ErrTransitionWaitlist
+	}
+
+	s.logger.Info().
+		Int("Expired", len(expired)).
+		Int("Accepted", len(accepted)).
+		Time("RsvpDueAt", rsvpDueAt).
+		Msg("Transitioned waitlisted applications")
+
+	// The acceptances are already committed, so a failed email shouldn't stop the others.
+	for _, application := range accepted {
+		userContactInfo, err := s.db.Query.GetUserEmailInfoById(ctx, application.UserID)
+		if err != nil {
+			s.logger.Err(err).Str("userID", application.UserID.String()).Msg("Failed to get contact info for waitlist acceptance email")
+			continue
+		}
+
+		contactEmail, ok := userContactInfo.ContactEmail.(string)
+		if !ok {
+			s.logger.Error().Str("userID", application.UserID.String()).Msg("Failed to get contact email for waitlist acceptance email")
+			continue
+		}
+
+		if err := s.emailService.QueueWaitlistAcceptanceEmail(ctx, contactEmail, userContactInfo.Name); err != nil {
+			s.logger.Err(err).Str("userID", application.UserID.String()).Msg("Failed to queue waitlist acceptance email")
+		}
+	}
+
+	return nil
+}
+
 // ============================== APPLICATION REVIEW FUNCTIONS ==============================
 
 func (s *ApplicationService) UpdateApplicationReviewStatusForHackathon(ctx context.Context, started bool) error {
@@ -536,13 +766,11 @@ func (s *ApplicationService) UpdateApplicationReviewStatusForHackathon(ctx conte
 	return nil
 }
 
-func (s *ApplicationService) AssignReviewersToApplications(ctx context.Context, assignments []ReviewerAssignmentRequestDto) error {
+// AssignReviewersToApplications replaces all review assignments of the hackathon. Every application
+// under review gets reviewsPerApplication distinct reviewers, keeping reviewers away from their own
+// application, their teammates, applicants from their school and users they flagged.
+func (s *ApplicationService) AssignReviewersToApplications(ctx context.Context, assignments []ReviewerAssignmentRequestDto, reviewsPerApplication int) error {
 	// TODO: Must check if applications are closed, if we havent released decisions, and more.
-	type ReviewerAllocation struct {
-		ReviewerID             uuid.UUID   `json:"reviewerIdd"`
-		AssignedApplicationIDs []uuid.UUID `json:"assignedApplicationIds"`
-	}
-
 	hackathon, err := s.db.Query.GetHackathon(ctx)
 
 	if err != nil {
@@ -554,156 +782,300 @@ func (s *ApplicationService) AssignReviewersToApplications(ctx context.Context,
 		return ErrApplicationReviewNotStarted
 	}
 
-	var fixedAssignments []ReviewerAssignmentRequestDto
-	var autoAssignments []ReviewerAssignmentRequestDto
-	var totalFixedAmount int
-
-	for _, assignment := range assignments {
-		if assignment.Amount != nil {
-			// Guard negative/zero amounts
-			if *assignment.Amount <= 0 {
-				return errors.New("assignment amount must be positive")
-			}
-			fixedAssignments = append(fixedAssignments, assignment)
-			totalFixedAmount += *assignment.Amount
-		} else {
-			autoAssignments = append(autoAssignments, assignment)
-		}
-	}
-
-	availableApplications, err := s.db.Query.ListUnderReviewApplicationIds(ctx, hackathon.ID)
+	applications, err := s.listAssignableApplications(ctx, hackathon.ID)
 	if err != nil {
 		return ErrGetApplicationsUnderReview
 	}
 
-	totalAvailable := len(availableApplications)
-	if totalAvailable == 0 {
+	if len(applications) == 0 {
 		s.logger.Info().Msg("no available applications to assign")
 		return nil
 	}
 
-	if totalFixedAmount > totalAvailable {
-		return errors.New("total fixed assignment amount exceeds available applications")
+	reviewers, err := reviewerShares(assignments, len(applications)*reviewsPerApplication)
+	if err != nil {
+		return err
 	}
 
-	if totalAvailable > totalFixedAmount && len(autoAssignments) == 0 {
-		return errors.New("not enough assignment slots: some applications would remain unassigned and no auto assignments provided")
+	conflicts, err := s.getReviewConflicts(ctx, reviewers, nil)
+	if err != nil {
+		return ErrAssignReviewers
 	}
 
-	// helper to take a slice safely
-	take := func(start, count int) ([]uuid.UUID, error) {
-		if count == 0 {
-			return []uuid.UUID{}, nil
+	allocations, err := allocateReviews(applications, reviewers, reviewsPerApplication, conflicts)
+	if err != nil {
+		return err
+	}
+
+	for _, reviewer := range reviewers {
+		s.logger.Info().Str("ReviewerID", reviewer.ID.String()).Int("AssignedCount", len(allocations[reviewer.ID])).Msg("Reviewer assigned applications")
+	}
+
+	return s.txm.WithTx(ctx, func(tx pgx.Tx) error {
+		txDB := s.db.NewTX(tx)
+
+		err = txDB.Query.DeleteAllApplicationReviews(ctx)
+
+		if err != nil {
+			s.logger.Err(err).Msg("unable to reset all application reviews before assigning")
+			return ErrAssignReviewers
 		}
-		if start < 0 || count < 0 || start+count > totalAvailable {
-			return nil, errors.New("allocation out of bounds")
+
+		err = txDB.Query.DeleteAllAutoDecisionRequests(ctx)
+
+		if err != nil {
+			s.logger.Err(err).Msg("unable to delete all decision requests before assigning")
+			return ErrAssignReviewers
 		}
-		// copy to avoid referencing the backing array
-		out := make([]uuid.UUID, count)
-		copy(out, availableApplications[start:start+count])
-		return out, nil
+
+		return s.insertAllocations(ctx, txDB, reviewers, allocations)
+	})
+}
+
+// ReassignReviews hands the unfinished reviews of a reviewer who dropped out to other reviewers,
+// balancing their outstanding work. Completed reviews stay with the original reviewer. When no
+// reviewers are given, the reviews go to every other staff member and admin.
+func (s *ApplicationService) ReassignReviews(ctx context.Context, req ReassignReviewsRequestDto) (int, error) {
+	hackathon, err := s.db.Query.GetHackathon(ctx)
+	if err != nil {
+		s.logger.Err(err).Msg("ReassignReviews fail because can't retrieve hackathon")
+		return 0, ErrGetHackathon
 	}
 
-	var appIndex int
-	var finalAllocations []ReviewerAllocation
+	if !hackathon.ApplicationReviewStarted {
+		return 0, ErrApplicationReviewNotStarted
+	}
 
-	// Assign fixed amounts
-	for _, assignment := range fixedAssignments {
-		amountToAssign := *assignment.Amount
-		if appIndex+amountToAssign > totalAvailable {
-			return errors.New("not enough available applications to satisfy fixed assignments")
+	progress, err := s.db.Query.ListReviewersAndProgress(ctx, hackathon.ID)
+	if err != nil {
+		s.logger.Err(err).Msg("ReassignReviews fail because reviewer progress could not be listed")
+		return 0, ErrReassignReviews
+	}
+
+	outstanding := make(map[uuid.UUID]int, len(progress))
+	for _, reviewer := range progress {
+		if reviewer.ID != nil {
+			outstanding[*reviewer.ID] = int(reviewer.TotalAssigned - reviewer.CompletedCount)
 		}
+	}
 
-		assignedSlice, err := take(appIndex, amountToAssign)
+	targets := req.ToReviewers
+	if len(targets) == 0 {
+		targets, err = s.db.Query.ListEligibleReviewerIds(ctx)
 		if err != nil {
-			return err
+			s.logger.Err(err).Msg("ReassignReviews fail because eligible reviewers could not be listed")
+			return 0, ErrReassignReviews
 		}
+	}
 
-		finalAllocations = append(finalAllocations, ReviewerAllocation{
-			ReviewerID:             assignment.ID,
-			AssignedApplicationIDs: assignedSlice,
-		})
-		appIndex += amountToAssign
+	var reviewers []reviewerSlot
+	for _, id := range targets {
+		if id != req.ReviewerID {
+			reviewers = append(reviewers, reviewerSlot{ID: id, Load: outstanding[id]})
+		}
 	}
 
-	// Auto-assign remaining applications evenly
-	remainingApps := totalAvailable - appIndex
-	if remainingApps > 0 && len(autoAssignments) > 0 {
-		baseShare := remainingApps / len(autoAssignments)
-		remainder := remainingApps % len(autoAssignments)
+	if len(reviewers) == 0 {
+		return 0, ErrNotEnoughEligibleReviewers
+	}
 
-		for i, assignment := range autoAssignments {
-			assignCount := baseShare
-			if i < remainder {
-				assignCount++
-			}
+	applications, err := s.listAssignableApplications(ctx, hackathon.ID)
+	if err != nil {
+		return 0, ErrGetApplicationsUnderReview
+	}
 
-			if assignCount == 0 {
-				// nothing to assign to this reviewer
-				finalAllocations = append(finalAllocations, ReviewerAllocation{
-					ReviewerID:             assignment.ID,
-					AssignedApplicationIDs: []uuid.UUID{},
-				})
-				continue
-			}
+	var reassigned int
+
+	err = s.txm.WithTx(ctx, func(tx pgx.Tx) error {
+		txDB := s.db.NewTX(tx)
+
+		applicationIDs, err := txDB.Query.DeleteIncompleteReviewsByReviewerId(ctx, req.ReviewerID)
+		if err != nil {
+			s.logger.Err(err).Msg("ReassignReviews fail because unfinished reviews could not be removed")
+			return ErrReassignReviews
+		}
+
+		if len(applicationIDs) == 0 {
+			return nil
+		}
 
-			if appIndex+assignCount > totalAvailable {
-				return errors.New("auto assignment would exceed available applications")
+		orphaned := make([]assignableApplication, 0, len(applicationIDs))
+		for _, app := range applications {
+			if slices.Contains(applicationIDs, app.ID) {
+				orphaned = append(orphaned, app)
 			}
+		}
+
+		existing, err := txDB.Query.ListReviewsByApplicationIds(ctx, applicationIDs)
+		if err != nil {
+			s.logger.Err(err).Msg("ReassignReviews fail because existing reviews could not be listed")
+			return ErrReassignReviews
+		}
 
-			assignedSlice, err := take(appIndex, assignCount)
-			if err != nil {
-				return err
+		assigned := make(map[uuid.UUID]map[uuid.UUID]bool, len(applicationIDs))
+		for _, review := range existing {
+			if assigned[review.ApplicationID] == nil {
+				assigned[review.ApplicationID] = make(map[uuid.UUID]bool)
 			}
+			assigned[review.ApplicationID][review.ReviewerID] = true
+		}
 
-			finalAllocations = append(finalAllocations, ReviewerAllocation{
-				ReviewerID:             assignment.ID,
-				AssignedApplicationIDs: assignedSlice,
-			})
+		conflicts, err := s.getReviewConflicts(ctx, reviewers, assigned)
+		if err != nil {
+			return ErrReassignReviews
+		}
 
-			appIndex += assignCount
+		allocations, err := allocateReviews(orphaned, reviewers, 1, conflicts)
+		if err != nil {
+			return err
 		}
-	}
 
-	for _, allocation := range finalAllocations {
-		s.logger.Info().Str("ReviewerID", allocation.ReviewerID.String()).Int("AssignedCount", len(allocation.AssignedApplicationIDs)).Msg("Reviewer assigned applications")
+		reassigned = len(orphaned)
+
+		return s.insertAllocations(ctx, txDB, reviewers, allocations)
+	})
+
+	if err != nil {
+		return 0, err
 	}
 
-	return s.txm.WithTx(ctx, func(tx pgx.Tx) error {
-		txDB := s.db.NewTX(tx)
+	s.logger.Info().Str("ReviewerID", req.ReviewerID.String()).Int("ReassignedCount", reassigned).Msg("Reassigned unfinished reviews")
 
-		err = txDB.Query.DeleteAllApplicationReviews(ctx)
+	return reassigned, nil
+}
 
-		if err != nil {
-			s.logger.Err(err).Msg("unable to reset all application reviews before assigning")
-			return ErrAssignReviewers
+func (s *ApplicationService) insertAllocations(ctx context.Context, txDB *database.DB, reviewers []reviewerSlot, allocations map[uuid.UUID][]uuid.UUID) error {
+	for _, reviewer := range reviewers {
+		if len(allocations[reviewer.ID]) == 0 {
+			continue
 		}
 
-		err = txDB.Query.DeleteAllAutoDecisionRequests(ctx)
+		err := txDB.Query.AssignReviewerToApplications(ctx, sqlc.AssignReviewerToApplicationsParams{
+			ReviewerID:     reviewer.ID,
+			ApplicationIds: allocations[reviewer.ID],
+		})
 
 		if err != nil {
-			s.logger.Err(err).Msg("unable to delete all decision requests before assigning")
+			s.logger.Err(err).Msg("assign application to reviewer failed while allocating")
 			return ErrAssignReviewers
 		}
+	}
 
-		for _, allocation := range finalAllocations {
-			if len(allocation.AssignedApplicationIDs) == 0 {
-				continue
-			}
+	return nil
+}
 
-			err := txDB.Query.AssignReviewerToApplications(ctx, sqlc.AssignReviewerToApplicationsParams{
-				ReviewerID:     allocation.ReviewerID,
-				ApplicationIds: allocation.AssignedApplicationIDs,
-			})
+func (s *ApplicationService) listAssignableApplications(ctx context.Context, hackathonID string) ([]assignableApplication, error) {
+	rows, err := s.db.Query.ListAssignableApplications(ctx, hackathonID)
+	if err != nil {
+		s.logger.Err(err).Msg("Failed to list applications under review")
+		return nil, err
+	}
 
-			if err != nil {
-				s.logger.Err(err).Msg("assign application to reviewer failed while allocating")
-				return ErrAssignReviewers
-			}
+	applications := make([]assignableApplication, len(rows))
+	for i, row := range rows {
+		applications[i] = assignableApplication{
+			ID:     row.ID,
+			UserID: row.UserID,
+			TeamID: row.TeamID,
+			School: row.School,
 		}
+	}
 
-		return nil
+	return applications, nil
+}
+
+// getReviewConflicts loads the teams, schools and flagged users of the reviewers.
+func (s *ApplicationService) getReviewConflicts(ctx context.Context, reviewers []reviewerSlot, assigned map[uuid.UUID]map[uuid.UUID]bool) (reviewConflicts, error) {
+	reviewerIDs := make([]uuid.UUID, len(reviewers))
+	for i, reviewer := range reviewers {
+		reviewerIDs[i] = reviewer.ID
+	}
+
+	affiliations, err := s.db.Query.ListReviewerAffiliations(ctx, reviewerIDs)
+	if err != nil {
+		s.logger.Err(err).Msg("Failed to list reviewer affiliations")
+		return reviewConflicts{}, err
+	}
+
+	flags, err := s.db.Query.ListReviewerConflictsByReviewerIds(ctx, reviewerIDs)
+	if err != nil {
+		s.logger.Err(err).Msg("Failed to list reviewer conflicts")
+		return reviewConflicts{}, err
+	}
+
+	conflicts := reviewConflicts{
+		reviewers: make(map[uuid.UUID]reviewerAffiliation, len(reviewers)),
+		assigned:  assigned,
+	}
+
+	for _, id := range reviewerIDs {
+		conflicts.reviewers[id] = reviewerAffiliation{
+			TeamIDs: make(map[uuid.UUID]bool),
+			Flagged: make(map[uuid.UUID]bool),
+		}
+	}
+
+	for _, row := range affiliations {
+		affiliation := conflicts.reviewers[row.ID]
+		affiliation.School = row.School
+		if row.TeamID != nil {
+			affiliation.TeamIDs[*row.TeamID] = true
+		}
+		conflicts.reviewers[row.ID] = affiliation
+	}
+
+	for _, flag := range flags {
+		conflicts.reviewers[flag.ReviewerID].Flagged[flag.UserID] = true
+	}
+
+	return conflicts, nil
+}
+
+// GetReviewerConflicts returns the users a reviewer flagged as a conflict of interest.
+func (s *ApplicationService) GetReviewerConflicts(ctx context.Context, reviewerID uuid.UUID) ([]sqlc.ListReviewerConflictsByReviewerIdRow, error) {
+	conflicts, err := s.db.Query.ListReviewerConflictsByReviewerId(ctx, reviewerID)
+	if err != nil {
+		s.logger.Err(err).Msg("GetReviewerConflicts fail")
+		return nil, ErrGetReviewerConflicts
+	}
+
+	return conflicts, nil
+}
+
+// FlagReviewerConflict keeps the reviewer away from the user's application in future
+// assignments and reassignments.
+func (s *ApplicationService) FlagReviewerConflict(ctx context.Context, reviewerID uuid.UUID, req FlagReviewerConflictRequestDto) (*sqlc.ReviewerConflict, error) {
+	if req.UserID == reviewerID {
+		return nil, ErrFlagOwnConflict
+	}
+
+	conflict, err := s.db.Query.CreateReviewerConflict(ctx, sqlc.CreateReviewerConflictParams{
+		ReviewerID: reviewerID,
+		UserID:     req.UserID,
+		Reason:     req.Reason,
 	})
+	if err != nil {
+		if database.IsForeignKeyViolation(err) {
+			return nil, ErrConflictUserNotFound
+		}
+		s.logger.Err(err).Msg("FlagReviewerConflict fail")
+		return nil, ErrFlagReviewerConflict
+	}
+
+	return &conflict, nil
+}
+
+func (s *ApplicationService) RemoveReviewerConflict(ctx context.Context, reviewerID, userID uuid.UUID) error {
+	err := s.db.Query.DeleteReviewerConflict(ctx, sqlc.DeleteReviewerConflictParams{
+		ReviewerID: reviewerID,
+		UserID:     userID,
+	})
+	if err != nil {
+		s.logger.Err(err).Msg("RemoveReviewerConflict fail")
+		return ErrFlagReviewerConflict
+	}
+
+	return nil
 }
 
 func (s *ApplicationService) DeleteAllApplicationReviews(ctx context.Context) error {
@@ -738,33 +1110,216 @@ func (s *ApplicationService) GetReviewsForReviewer(ctx context.Context, reviewer
 	return reviews, nil
 }
 
-func (s *ApplicationService) GetAllReviewersAndProgress(ctx context.Context) ([]sqlc.ListReviewersAndProgressRow, error) {
-	results, err := s.db.Query.ListReviewersAndProgress(ctx)
+// GetAllReviewersAndProgress returns the review progress of every reviewer of the active hackathon,
+// along with how each reviewer's completed ratings compare to everyone else's.
+func (s *ApplicationService) GetAllReviewersAndProgress(ctx context.Context) ([]sqlc.ListReviewersAndProgressRow, map[uuid.UUID]calibration.ReviewerStats, error) {
+	hackathon, err := s.db.Query.GetHackathon(ctx)
+	if err != nil {
+		s.logger.Err(err).Msg("GetAllReviewersAndProgress fail because can't retrieve hackathon")
+		return nil, nil, ErrGetHackathon
+	}
+
+	results, err := s.db.Query.ListReviewersAndProgress(ctx, hackathon.ID)
 
 	if err != nil {
 		s.logger.Err(err).Msg("GetAllReviewersAndProgress fail")
-		return nil, ErrGetReviewers
+		return nil, nil, ErrGetReviewers
+	}
+
+	// Only the active hackathon's reviews are compared, as when its admissions are calibrated.
+	reviews, err := s.db.Query.ListCompletedReviews(ctx, &hackathon.ID)
+	if err != nil {
+		s.logger.Err(err).Msg("GetAllReviewersAndProgress fail because completed reviews could not be listed")
+		return nil, nil, ErrGetReviewers
+	}
+
+	ratings := make([]calibration.Rating, len(reviews))
+	for i, review := range reviews {
+		ratings[i] = calibration.Rating{
+			ApplicationID: review.ApplicationID,
+			ReviewerID:    review.ReviewerID,
+			Passion:       float64(review.PassionRating),
+			Experience:    float64(review.ExperienceRating),
+		}
+	}
+
+	_, _, reviewerStats := calibration.Stats(ratings)
+
+	stats := make(map[uuid.UUID]calibration.ReviewerStats, len(reviewerStats))
+	for _, reviewer := range reviewerStats {
+		stats[reviewer.ReviewerID] = reviewer
+	}
+
+	return results, stats, nil
+}
+
+// GetReviewById returns a review for its reviewer, or for an admin. When blind review is on and
+// the viewer is the assigned reviewer, the configured answers are redacted and the resume is
+// served through the API instead of a presigned storage URL, whose key names the applicant.
+// The resume URL is empty when the reviewer may not see the resume yet.
+func (s *ApplicationService) GetReviewById(ctx context.Context, reviewId, viewerID uuid.UUID, viewerRole sqlc.UserRole) (*ReviewDetails, error) {
+	review, settings, err := s.getReviewForViewer(ctx, reviewId, viewerID, viewerRole)
+	if err != nil {
+		return nil, err
+	}
+
+	details := &ReviewDetails{Review: *review}
+
+	if !settings.BlindReview || review.ReviewerID != viewerID {
+		resumeRequest, err := s.GetApplicationResumeURL(ctx, review.UserID, 600)
+
+		if err != nil {
+			s.logger.Err(err).Msg("GetReviewById fail, unable to get resume")
+			return nil, ErrGetResume
+		}
+
+		details.ResumeURL = resumeRequest.URL
+		return details, nil
+	}
+
+	details.Blind = true
+
+	details.Review.Application, err = redactApplication(review.Application, settings.RedactedFields)
+	if err != nil {
+		s.logger.Err(err).Str("ReviewID", reviewId.String()).Msg("GetReviewById fail, unable to redact application")
+		return nil, ErrGetReviews
+	}
+
+	if settings.resumeVisible(review.PassionRating != nil && review.ExperienceRating != nil) {
+		details.ResumeURL = fmt.Sprintf("application/review/%s/resume", review.ID)
+	}
+
+	return details, nil
+}
+
+// GetReviewResume returns the resume of a reviewed application for blind review, hiding it
+// until the reviewer may see it.
+func (s *ApplicationService) GetReviewResume(ctx context.Context, reviewId, viewerID uuid.UUID, viewerRole sqlc.UserRole) ([]byte, error) {
+	review, settings, err := s.getReviewForViewer(ctx, reviewId, viewerID, viewerRole)
+	if err != nil {
+		return nil, err
+	}
+
+	if review.ReviewerID == viewerID && !settings.resumeVisible(review.PassionRating != nil && review.ExperienceRating != nil) {
+		return nil, ErrResumeHidden
+	}
+
+	hackathon, err := s.db.Query.GetHackathon(ctx)
+	if err != nil {
+		s.logger.Err(err).Msg("GetReviewResume fail because can't retrieve hackathon")
+		return nil, ErrGetHackathon
+	}
+
+	resume, err := s.storage.Retrieve(ctx, s.buckets.ApplicationResumes, hackathon.ID+"/"+review.UserID.String())
+	if err != nil {
+		s.logger.Err(err).Str("ReviewID", reviewId.String()).Msg("GetReviewResume fail, unable to retrieve resume")
+		return nil, ErrGetResume
 	}
 
-	return results, nil
+	return resume, nil
 }
 
-func (s *ApplicationService) GetReviewById(ctx context.Context, reviewId uuid.UUID) (*sqlc.GetReviewByIdRow, *storage.PresignedRequest, error) {
+// getReviewForViewer loads a review and the review settings of the hackathon. Only the assigned
+// reviewer and admins may see a review.
+func (s *ApplicationService) getReviewForViewer(ctx context.Context, reviewId, viewerID uuid.UUID, viewerRole sqlc.UserRole) (*sqlc.GetReviewByIdRow, *ReviewSettings, error) {
 	review, err := s.db.Query.GetReviewById(ctx, reviewId)
 
 	if err != nil {
+		if database.IsNotFound(err) {
+			return nil, nil, ErrReviewNotFound
+		}
 		s.logger.Err(err).Msg("GetReviewById fail, unable to get review")
 		return nil, nil, ErrGetReviews
 	}
 
-	resumeRequest, err := s.GetApplicationResumeURL(ctx, review.UserID, 600)
+	if review.ReviewerID != viewerID && viewerRole != sqlc.UserRoleAdmin {
+		return nil, nil, ErrReviewNotAssigned
+	}
+
+	hackathon, err := s.db.Query.GetHackathon(ctx)
+	if err != nil {
+		s.logger.Err(err).Msg("GetReviewById fail because can't retrieve hackathon")
+		return nil, nil, ErrGetHackathon
+	}
+
+	settings, err := s.getReviewSettings(ctx, hackathon.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &review, settings, nil
+}
 
+func (s *ApplicationService) GetReviewSettings(ctx context.Context) (*ReviewSettings, error) {
+	hackathon, err := s.db.Query.GetHackathon(ctx)
 	if err != nil {
-		s.logger.Err(err).Msg("GetReviewById fail, unable to get resume")
-		return nil, nil, ErrGetResume
+		s.logger.Err(err).Msg("GetReviewSettings fail because can't retrieve hackathon")
+		return nil, ErrGetHackathon
 	}
 
-	return &review, resumeRequest, nil
+	return s.getReviewSettings(ctx, hackathon.ID)
+}
+
+// UpdateReviewSettings replaces the review settings of the current hackathon. They apply to
+// every review opened afterwards.
+func (s *ApplicationService) UpdateReviewSettings(ctx context.Context, settings ReviewSettings) (*ReviewSettings, error) {
+	if err := validateReviewSettings(settings); err != nil {
+		return nil, err
+	}
+
+	hackathon, err := s.db.Query.GetHackathon(ctx)
+	if err != nil {
+		s.logger.Err(err).Msg("UpdateReviewSettings fail because can't retrieve hackathon")
+		return nil, ErrGetHackathon
+	}
+
+	redactedFields, err := json.Marshal(settings.RedactedFields)
+	if err != nil {
+		return nil, ErrUpdateReviewSettings
+	}
+
+	row, err := s.db.Query.UpsertReviewSettings(ctx, sqlc.UpsertReviewSettingsParams{
+		HackathonID:    hackathon.ID,
+		BlindReview:    settings.BlindReview,
+		RedactedFields: redactedFields,
+		ResumeAccess:   string(settings.ResumeAccess),
+	})
+	if err != nil {
+		s.logger.Err(err).Msg("UpdateReviewSettings fail")
+		return nil, ErrUpdateReviewSettings
+	}
+
+	return s.toReviewSettings(row)
+}
+
+// getReviewSettings loads the stored review settings of a hackathon, falling back to
+// the defaults when none have been saved yet.
+func (s *ApplicationService) getReviewSettings(ctx context.Context, hackathonID string) (*ReviewSettings, error) {
+	row, err := s.db.Query.GetReviewSettingsByHackathonId(ctx, hackathonID)
+	if err != nil {
+		if database.IsNotFound(err) {
+			settings := DefaultReviewSettings()
+			return &settings, nil
+		}
+		s.logger.Err(err).Msg("Failed to get review settings")
+		return nil, ErrGetReviewSettings
+	}
+
+	return s.toReviewSettings(row)
+}
+
+func (s *ApplicationService) toReviewSettings(row sqlc.ReviewSetting) (*ReviewSettings, error) {
+	redactedFields := []RedactedField{}
+	if err := json.Unmarshal(row.RedactedFields, &redactedFields); err != nil {
+		s.logger.Err(err).Str("HackathonID", row.HackathonID).Msg("Failed to parse stored redacted fields")
+		return nil, ErrGetReviewSettings
+	}
+
+	return &ReviewSettings{
+		BlindReview:    row.BlindReview,
+		RedactedFields: redactedFields,
+		ResumeAccess:   ResumeAccess(row.ResumeAccess),
+	}, nil
 }
 
 func (s *ApplicationService) SaveApplicationReview(ctx context.Context, req SaveReviewRequestDto, reviewerId uuid.UUID, reviewerRole sqlc.UserRole) error {
@@ -1029,119 +1584,3 @@ func (s *ApplicationService) isApplicationOpen(ctx context.Context) error {
 
 // 	return request, nil
 // }
-
-// func (s *ApplicationService) TransitionWaitlistedApplications(ctx context.Context, acceptanceCount uint32, acceptanceQuota uint32) error {
-// 	var acceptedUserIds []uuid.UUID
-
-// 	ErrEventAlreadyStarted := errors.New("the event has already started")
-// 	ErrFailedToGetContactEmail := errors.New("Failed to get contact email")
-
-// 	hackathon, err := s.db.Query.GetHackathon(ctx)
-// 	if err != nil {
-// 		s.logger.Err(err).Msg("TransitionWaitlistedApplications fail, unable to get hackathon")
-// 		return err
-// 	}
-// 	currentTime := time.Now()
-// 	if currentTime.After(hackathon.StartTime) {
-// 		s.logger.Err(ErrEventAlreadyStarted).Msg("Could not transition waitlisted applications: the event has already started.")
-// 		return ErrEventAlreadyStarted
-// 	}
-
-// 	err = s.txm.WithTx(ctx, func(tx pgx.Tx) error {
-// 		txDB := s.db.NewTX(tx)
-
-// 		err := txDB.Query.TransitionAcceptedApplicationsToWaitlist(ctx)
-// 		if err != nil {
-// 			s.logger.Err(err).Msg(err.Error())
-// 			return err
-// 		}
-
-// 		attendeeCount, err := s.db.Query.GetAttendeeCount(ctx)
-// 		if err != nil {
-// 			s.logger.Err(err).Msg("Failed to get total accepted application amount.")
-// 		}
-// 		if (acceptanceQuota - attendeeCount) <= acceptanceCount {
-// 			s.logger.Info().Msgf("Acceptance quota is close, shutting down waitlist transition scheduler. Remaining acceptances: %v - %v <= %v", acceptanceQuota, attendeeCount, acceptanceCount)
-// 			if s.scheduler != nil {
-// 				// The API also uses this file, and this function can be run from an endpoint so we have to check that the scheduler exists.
-// 				// Technically the task should be removed from the scheduler via an scheduler ENTRY_ID. However the scheduler is only running for this task.
-// 				s.scheduler.Shutdown()
-// 			}
-// 			acceptanceCount = acceptanceQuota - attendeeCount
-// 		}
-
-// 		s.logger.Info().Msgf("Acceptance count: %v", acceptanceCount)
-// 		acceptedUserIds, err = txDB.Query.TransitionWaitlistedApplicationsToAccepted(ctx, int32(acceptanceCount))
-// 		if err != nil {
-// 			s.logger.Err(err).Msg(err.Error())
-// 			return err
-// 		}
-
-// 		s.logger.Debug().Msgf("Statuses transitioned: %s", acceptedUserIds)
-// 		return nil
-// 	})
-
-// 	if err != nil {
-// 		s.logger.Err(err).Msg(err.Error())
-// 		return err
-// 	}
-
-// 	for _, userID := range acceptedUserIds {
-// 		userContactInfo, err := s.db.Query.GetUserEmailInfoById(ctx, userID)
-// 		if err != nil {
-// 			s.logger.Err(err).Msg(err.Error())
-// 			return err
-// 		}
-
-// 		contactEmail, ok := userContactInfo.ContactEmail.(string)
-// 		if !ok {
-// 			return ErrFailedToGetContactEmail
-// 		}
-
-// 		err = s.emailService.QueueWaitlistAcceptanceEmail(contactEmail, userContactInfo.Name)
-// 		if err != nil {
-// 			s.logger.Err(err).Msg(err.Error())
-// 			return err
-// 		}
-// 	}
-
-// 	return nil
-// }
-
-// func (s *ApplicationService) ReleaseDecisions(ctx context.Context, batRunId uuid.UUID) error {
-// 	batRun, err := s.batService.GetRunById(ctx, batRunId)
-
-// 	if batRun.Status != sqlc.BatRunStatusCompleted {
-// 		return errors.New("This run status is not valid for this action.")
-// 	}
-
-// 	if len(batRun.AcceptedApplicants) == 0 {
-// 		return errors.New("No applicants marked as accepted.")
-// 	}
-
-// 	err = s.txm.WithTx(ctx, func(tx pgx.Tx) error {
-// 		txDB := s.db.NewTX(tx)
-
-// 		err := txDB.Query.UpdateApplicationStatusForMultipleUserIds(ctx, sqlc.UpdateApplicationStatusForMultipleUserIdsParams{
-// 			Status:  sqlc.ApplicationStatusAccepted,
-// 			UserIds: batRun.AcceptedApplicants,
-// 		})
-// 		if err != nil {
-// 			return err
-// 		}
-// 		return txDB.Query.UpdateApplicationStatusForMultipleUserIds(ctx, sqlc.UpdateApplicationStatusForMultipleUserIdsParams{
-// 			Status:  sqlc.ApplicationStatusRejected,
-// 			UserIds: batRun.RejectedApplicants,
-// 		})
-// 	})
-// 	if err != nil {
-// 		return err
-// 	}
-
-// 	err = s.emailService.SendDecisionEmails(ctx, batRun)
-// 	if err != nil {
-// 		return errors.New("Failed to send decision emails")
-// 	}
-
-// 	return nil
-// }
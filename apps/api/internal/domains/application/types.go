@@ -5,31 +5,51 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/swamphacks/core/apps/api/internal/calibration"
 	"github.com/swamphacks/core/apps/api/internal/database/sqlc"
 )
 
 var (
-	ErrGetApplication              = errors.New("fail to get application")
-	ErrSearchApplications          = errors.New("fail to search applications")
-	ErrSubmitApplication           = errors.New("fail to submit application")
-	ErrApplicationNotOpened        = errors.New("application not opened")
-	ErrCreateApplication           = errors.New("failed to create application")
-	ErrGetHackathon                = errors.New("failed to get hackathon information")
-	ErrReplaceResume               = errors.New("fail to replace resume")
-	ErrParseApplicationData        = errors.New("fail to parse application data")
-	ErrApplicationNotSubmitted     = errors.New("application is not submitted")
-	ErrApplicationAlreadySubmitted = errors.New("application has already been submitted")
-	ErrSaveApplication             = errors.New("fail to save application")
-	ErrGetResume                   = errors.New("fail to get application resume")
-	ErrGetApplicationStats         = errors.New("fail to get application stats")
-	ErrWithdrawApplication         = errors.New("fail to withdraw application")
-	ErrConfirmAttendance           = errors.New("fail to confirm attendance")
-	ErrUpdateHackathonReview       = errors.New("fail to update hackathon review status")
-	ErrAssignReviewers             = errors.New("fail to assign applications to reviewer(s)")
-	ErrApplicationReviewNotStarted = errors.New("application review has not started")
-	ErrGetApplicationsUnderReview  = errors.New("fail to get applications under review")
-	ErrGetReviews                  = errors.New("fail to get reviews")
-	ErrGetReviewers                = errors.New("fail to get reviewers")
+	ErrGetApplication                = errors.New("fail to get application")
+	ErrSearchApplications            = errors.New("fail to search applications")
+	ErrSubmitApplication             = errors.New("fail to submit application")
+	ErrApplicationNotOpened          = errors.New("application not opened")
+	ErrCreateApplication             = errors.New("failed to create application")
+	ErrGetHackathon                  = errors.New("failed to get hackathon information")
+	ErrReplaceResume                 = errors.New("fail to replace resume")
+	ErrParseApplicationData          = errors.New("fail to parse application data")
+	ErrApplicationNotSubmitted       = errors.New("application is not submitted")
+	ErrApplicationAlreadySubmitted   = errors.New("application has already been submitted")
+	ErrSaveApplication               = errors.New("fail to save application")
+	ErrGetForm                       = errors.New("fail to get application form")
+	ErrUpdateForm                    = errors.New("fail to update application form")
+	ErrGetResume                     = errors.New("fail to get application resume")
+	ErrGetApplicationStats           = errors.New("fail to get application stats")
+	ErrWithdrawApplication           = errors.New("fail to withdraw application")
+	ErrConfirmAttendance             = errors.New("fail to confirm attendance")
+	ErrRsvpDeadlinePassed            = errors.New("the deadline to confirm attendance has passed")
+	ErrEventAlreadyStarted           = errors.New("the event has already started")
+	ErrTransitionWaitlist            = errors.New("fail to transition waitlisted applications")
+	ErrUpdateHackathonReview         = errors.New("fail to update hackathon review status")
+	ErrAssignReviewers               = errors.New("fail to assign applications to reviewer(s)")
+	ErrApplicationReviewNotStarted   = errors.New("application review has not started")
+	ErrGetApplicationsUnderReview    = errors.New("fail to get applications under review")
+	ErrGetReviews                    = errors.New("fail to get reviews")
+	ErrGetReviewers                  = errors.New("fail to get reviewers")
+	ErrInvalidAssignmentAmount       = errors.New("assignment amount must be positive")
+	ErrAssignmentExceedsApplications = errors.New("total fixed assignment amount exceeds the reviews needed")
+	ErrApplicationsLeftUnassigned    = errors.New("not enough assignment slots: some applications would remain unassigned and no auto assignments provided")
+	ErrNotEnoughEligibleReviewers    = errors.New("not enough eligible reviewers")
+	ErrReassignReviews               = errors.New("fail to reassign reviews")
+	ErrGetReviewerConflicts          = errors.New("fail to get reviewer conflicts")
+	ErrFlagReviewerConflict          = errors.New("fail to flag reviewer conflict")
+	ErrFlagOwnConflict               = errors.New("reviewers can't flag themselves")
+	ErrConflictUserNotFound          = errors.New("user to flag does not exist")
+	ErrReviewNotFound                = errors.New("review not found")
+	ErrReviewNotAssigned             = errors.New("review is assigned to another reviewer")
+	ErrResumeHidden                  = errors.New("the resume is hidden until the application is rated")
+	ErrGetReviewSettings             = errors.New("fail to get review settings")
+	ErrUpdateReviewSettings          = errors.New("fail to update review settings")
 )
 
 type AppUser struct {
@@ -90,6 +110,13 @@ type ApplicationWithUserInfoDto struct {
 	IsEarly     bool       `json:"isEarly"`
 }
 
+// ReviewDetails is a review as its viewer may see it.
+type ReviewDetails struct {
+	Review    sqlc.GetReviewByIdRow
+	ResumeURL string
+	Blind     bool
+}
+
 type ApplicationReviewResponseDto struct {
 	ID                  uuid.UUID               `json:"id"`
 	ExperienceRating    *int32                  `json:"experienceRating"`
@@ -98,7 +125,8 @@ type ApplicationReviewResponseDto struct {
 	ReviewUpdatedAt     time.Time               `json:"reviewUpdatedAt"`
 	ReviewUpdatedBy     *uuid.UUID              `json:"reviewUpdatedBy"`
 	Application         []byte                  `json:"application"`
-	ResumeURL           string                  `json:"resumeUrl"`
+	ResumeURL           string                  `json:"resumeUrl" doc:"Empty while the resume is hidden. In blind review this is an API path relative to the API base URL"`
+	Blind               bool                    `json:"blind" doc:"Identifying answers were redacted"`
 	AutoDecisionRequest *AutoDecisionRequestDto `json:"autoDecisionRequest" required:"false"`
 }
 
@@ -124,12 +152,46 @@ type ReviewerAssignmentRequestDto struct {
 	Amount *int      `json:"amount"` // Number of applications assigned (nil if autoassign)
 }
 
+type ReassignReviewsRequestDto struct {
+	ReviewerID  uuid.UUID   `json:"reviewerId"`                                   // Reviewer who dropped out
+	ToReviewers []uuid.UUID `json:"toReviewers" required:"false" nullable:"true"` // Reviewers taking over (every other staff member and admin if empty)
+}
+
+type ReassignReviewsResponseDto struct {
+	Reassigned int `json:"reassigned"`
+}
+
+type FlagReviewerConflictRequestDto struct {
+	UserID uuid.UUID `json:"userId"`
+	Reason *string   `json:"reason" required:"false"`
+}
+
+type ReviewerConflictDto struct {
+	User      AppUser   `json:"user"`
+	Reason    *string   `json:"reason" required:"false"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
 type ReviewerProgressResponseDto struct {
 	ID             *uuid.UUID `json:"id"`
 	Name           *string    `json:"name"`
 	Image          *string    `json:"image"`
 	TotalAssigned  int64      `json:"totalAssigned"`
 	CompletedCount int64      `json:"completedCount"`
+
+	// Outlier is set when the reviewer rates noticeably harsher, more leniently or flatter
+	// than the other reviewers.
+	Outlier     bool                    `json:"outlier"`
+	Calibration *ReviewerCalibrationDto `json:"calibration" required:"false"`
+}
+
+// ReviewerCalibrationDto compares a reviewer's ratings to the ratings of all reviewers.
+// Biases are in pooled standard deviations; negative means harsher than average.
+type ReviewerCalibrationDto struct {
+	Passion        calibration.Distribution `json:"passion"`
+	Experience     calibration.Distribution `json:"experience"`
+	PassionBias    float64                  `json:"passionBias"`
+	ExperienceBias float64                  `json:"experienceBias"`
 }
 
 type SaveReviewRequestDto struct {
@@ -194,54 +256,12 @@ type SearchAutoDecisionRequestsResponseDto struct {
 	Count    int64                            `json:"count"`
 }
 
-// TODO: figure out a way to create the submission fields dynamically using the json form files with proper validation.
-// these fields are only applicable to swamphacks xi, not other events
-type ApplicationSubmissionFields struct {
-	FirstName       string `json:"firstName" validate:"required,max=50"`
-	LastName        string `json:"lastName" validate:"required,max=50"`
-	Age             string `json:"age" validate:"required"`
-	Phone           string `json:"phone" validate:"required,len=10"`
-	PreferredEmail  string `json:"preferredEmail" validate:"required,email"`
-	UniversityEmail string `json:"universityEmail" validate:"required,email"`
-	Country         string `json:"country" validate:"required"`
-	Gender          string `json:"gender"`
-	GenderOther     string `json:"gender-other"`
-	Pronouns        string `json:"pronouns"`
-	Race            string `json:"race"`
-	RaceOther       string `json:"race-other"`
-	Orientation     string `json:"orientation"`
-	Linkedin        string `json:"linkedin" validate:"required,url"`
-	// Github                  string `json:"github" validate:"required,url"`
-	AgeCertification        bool   `json:"ageCertification" validate:"required,boolean"`
-	School                  string `json:"school" validate:"required"`
-	Level                   string `json:"level" validate:"required"`
-	LevelOther              string `json:"level-other"`
-	Year                    string `json:"year" validate:"required"`
-	YearOther               string `json:"year-other"`
-	GraduationYear          string `json:"graduationYear" validate:"required"`
-	Majors                  string `json:"majors" validate:"required"`
-	Minors                  string `json:"minors"`
-	Experience              string `json:"experience" validate:"required"`
-	UfHackathonExp          string `json:"ufHackathonExp" validate:"required"`
-	ProjectExperience       string `json:"projectExperience" validate:"required"`
-	ShirtSize               string `json:"shirtSize" validate:"required"`
-	Diet                    string `json:"diet"`
-	Essay1                  string `json:"essay1" validate:"required"`
-	Essay2                  string `json:"essay2" validate:"required"`
-	Essay3                  string `json:"essay3" validate:"required"`
-	Referral                string `json:"referral" validate:"required"`
-	PictureConsent          string `json:"pictureConsent" validate:"required"`
-	InPersonAcknowledgement string `json:"inpersonAcknowledgement" validate:"required"`
-	AgreeToConduct          string `json:"agreeToConduct" validate:"required"`
-	InfoShareAuthorization  string `json:"infoShareAuthorization" validate:"required"`
-	AgreeToMLHEmails        string `json:"agreeToMLHEmails"`
+type ApplicationStatisticsDto struct {
+	DemographicStatistics []FieldStatisticsDto           `json:"demographicStats" nullable:"false"`
+	StatusStatistics      sqlc.GetApplicationStatusesRow `json:"statusStats"`
 }
 
-type ApplicationStatisticsDto struct {
-	GenderStatistics sqlc.GetSubmittedApplicationGendersRow   `json:"genderStats"`
-	AgeStatistics    sqlc.GetSubmittedApplicationAgesRow      `json:"ageStats"`
-	RaceStatistics   []sqlc.GetSubmittedApplicationRacesRow   `json:"raceStats"`
-	MajorStatistics  []sqlc.GetSubmittedApplicationMajorsRow  `json:"majorStats"`
-	SchoolStatistics []sqlc.GetSubmittedApplicationSchoolsRow `json:"schoolStats"`
-	StatusStatistics sqlc.GetApplicationStatusesRow           `json:"statusStats"`
+type FieldStatisticsDto struct {
+	Field  string                                       `json:"field"`
+	Counts []sqlc.GetSubmittedApplicationFieldCountsRow `json:"counts" nullable:"false"`
 }
@@ -0,0 +1,198 @@
+package bat
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrNoBuckets              = errors.New("At least one bucket is required")
+	ErrBucketNameRequired     = errors.New("Every bucket needs a name")
+	ErrDuplicateBucketName    = errors.New("Bucket names must be unique")
+	ErrUnknownRolloverBucket  = errors.New("Bucket rolls over into a bucket that doesn't exist")
+	ErrInvalidBucketCondition = errors.New("Bucket condition is invalid")
+)
+
+type BucketOperator string
+
+const (
+	// BucketOperatorIn matches when the field equals one of the values.
+	BucketOperatorIn BucketOperator = "in"
+
+	// BucketOperatorNotIn matches when the field is missing or equals none of the values.
+	BucketOperatorNotIn BucketOperator = "not_in"
+
+	// BucketOperatorExists matches when the field is present and not empty.
+	BucketOperatorExists BucketOperator = "exists"
+)
+
+// BucketCondition tests a single field of the application JSON. Nested fields
+// can be reached with a dotted path, e.g. "education.school". Values are compared
+// case-insensitively against the string form of the field; for list fields the
+// condition matches if any element matches.
+type BucketCondition struct {
+	Field    string         `json:"field" minLength:"1"`
+	Operator BucketOperator `json:"operator" enum:"in,not_in,exists"`
+	Values   []string       `json:"values,omitempty" required:"false"`
+}
+
+// BucketRule describes a named group of candidates with its own quota.
+// A candidate lands in the first bucket, in rule order, whose conditions all
+// match. A rule without conditions matches everyone, so it works as a catch-all
+// when placed last. Slots left unused in a bucket roll over into the bucket
+// named by Rollover, if any.
+type BucketRule struct {
+	Name       string            `json:"name" minLength:"1"`
+	Quota      int32             `json:"quota" minimum:"0"`
+	Rollover   string            `json:"rollover,omitempty" required:"false"`
+	Conditions []BucketCondition `json:"conditions" nullable:"false"`
+}
+
+// DefaultBucketRules reproduces the original UF vs other and early vs late career split.
+func DefaultBucketRules() []BucketRule {
+	isUF := BucketCondition{Field: "school", Operator: BucketOperatorIn, Values: []string{"University of Florida"}}
+	isEarlyCareer := BucketCondition{Field: "year", Operator: BucketOperatorIn, Values: []string{"first_year", "second_year"}}
+
+	return []BucketRule{
+		{Name: "uf_early", Quota: 210, Rollover: "uf_late", Conditions: []BucketCondition{isUF, isEarlyCareer}},
+		{Name: "uf_late", Quota: 140, Rollover: "uf_early", Conditions: []BucketCondition{isUF}},
+		{Name: "other_early", Quota: 90, Rollover: "other_late", Conditions: []BucketCondition{isEarlyCareer}},
+		{Name: "other_late", Quota: 60, Rollover: "other_early", Conditions: []BucketCondition{}},
+	}
+}
+
+func validateBucketRules(rules []BucketRule) error {
+	if len(rules) == 0 {
+		return ErrNoBuckets
+	}
+
+	names := make(map[string]bool, len(rules))
+	for _, rule := range rules {
+		if strings.TrimSpace(rule.Name) == "" {
+			return ErrBucketNameRequired
+		}
+		if names[rule.Name] {
+			return fmt.Errorf("%w: %s", ErrDuplicateBucketName, rule.Name)
+		}
+		if rule.Quota < 0 {
+			return fmt.Errorf("%w: %s", ErrNegativeQuota, rule.Name)
+		}
+		names[rule.Name] = true
+
+		for _, cond := range rule.Conditions {
+			if err := cond.validate(); err != nil {
+				return fmt.Errorf("%w: %s", err, rule.Name)
+			}
+		}
+	}
+
+	for _, rule := range rules {
+		if rule.Rollover != "" && (!names[rule.Rollover] || rule.Rollover == rule.Name) {
+			return fmt.Errorf("%w: %s", ErrUnknownRolloverBucket, rule.Name)
+		}
+	}
+
+	return nil
+}
+
+func (c BucketCondition) validate() error {
+	if strings.TrimSpace(c.Field) == "" {
+		return ErrInvalidBucketCondition
+	}
+
+	switch c.Operator {
+	case BucketOperatorIn, BucketOperatorNotIn:
+		if len(c.Values) == 0 {
+			return ErrInvalidBucketCondition
+		}
+	case BucketOperatorExists:
+	default:
+		return ErrInvalidBucketCondition
+	}
+
+	return nil
+}
+
+// Matches reports whether every condition of the rule holds for the application.
+func (r BucketRule) Matches(application map[string]any) bool {
+	for _, cond := range r.Conditions {
+		if !cond.Matches(application) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (c BucketCondition) Matches(application map[string]any) bool {
+	values := lookupField(application, c.Field)
+
+	switch c.Operator {
+	case BucketOperatorExists:
+		return len(values) > 0
+	case BucketOperatorIn:
+		return containsAnyFold(values, c.Values)
+	case BucketOperatorNotIn:
+		return !containsAnyFold(values, c.Values)
+	}
+
+	return false
+}
+
+// determineBucket returns the name of the first bucket that matches the application.
+func determineBucket(rules []BucketRule, application map[string]any) (string, bool) {
+	for _, rule := range rules {
+		if rule.Matches(application) {
+			return rule.Name, true
+		}
+	}
+
+	return "", false
+}
+
+// lookupField resolves a dotted path in the application JSON and returns the
+// non-empty string forms of the value found there.
+func lookupField(application map[string]any, path string) []string {
+	var current any = application
+	for part := range strings.SplitSeq(path, ".") {
+		obj, ok := current.(map[string]any)
+		if !ok {
+			return nil
+		}
+		current = obj[part]
+	}
+
+	var values []string
+	var collect func(v any)
+	collect = func(v any) {
+		switch val := v.(type) {
+		case nil:
+		case string:
+			if s := strings.TrimSpace(val); s != "" {
+				values = append(values, s)
+			}
+		case bool:
+			values = append(values, strconv.FormatBool(val))
+		case float64:
+			values = append(values, strconv.FormatFloat(val, 'f', -1, 64))
+		case []any:
+			for _, item := range val {
+				collect(item)
+			}
+		}
+	}
+	collect(current)
+
+	return values
+}
+
+func containsAnyFold(values, targets []string) bool {
+	return slices.ContainsFunc(values, func(v string) bool {
+		return slices.ContainsFunc(targets, func(t string) bool {
+			return strings.EqualFold(v, t)
+		})
+	})
+}
@@ -0,0 +1,158 @@
+package bat
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDetermineBucket(t *testing.T) {
+	rules := DefaultBucketRules()
+
+	tests := []struct {
+		name        string
+		application map[string]any
+		expected    string
+	}{
+		{
+			name:        "uf early career",
+			application: map[string]any{"school": "University of Florida", "year": "first_year"},
+			expected:    "uf_early",
+		},
+		{
+			name:        "uf late career",
+			application: map[string]any{"school": "University of Florida", "year": "fourth_year"},
+			expected:    "uf_late",
+		},
+		{
+			name:        "other early career",
+			application: map[string]any{"school": "Florida State University", "year": "second_year"},
+			expected:    "other_early",
+		},
+		{
+			name:        "other late career falls through to catch-all",
+			application: map[string]any{"school": "Florida State University"},
+			expected:    "other_late",
+		},
+		{
+			name:        "values are compared case-insensitively",
+			application: map[string]any{"school": "university of florida", "year": "FIRST_YEAR"},
+			expected:    "uf_early",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bucket, ok := determineBucket(rules, test.application)
+
+			if !ok || bucket != test.expected {
+				t.Fatalf("expected %v, got %v", test.expected, bucket)
+			}
+		})
+	}
+}
+
+func TestBucketConditionMatches(t *testing.T) {
+	application := map[string]any{
+		"country":   "United States",
+		"firstTime": true,
+		"languages": []any{"Go", "TypeScript"},
+		"education": map[string]any{"level": "undergraduate"},
+		"minors":    "",
+	}
+
+	tests := []struct {
+		name      string
+		condition BucketCondition
+		expected  bool
+	}{
+		{
+			name:      "in matches string",
+			condition: BucketCondition{Field: "country", Operator: BucketOperatorIn, Values: []string{"United States"}},
+			expected:  true,
+		},
+		{
+			name:      "in matches bool",
+			condition: BucketCondition{Field: "firstTime", Operator: BucketOperatorIn, Values: []string{"true"}},
+			expected:  true,
+		},
+		{
+			name:      "in matches any list element",
+			condition: BucketCondition{Field: "languages", Operator: BucketOperatorIn, Values: []string{"typescript"}},
+			expected:  true,
+		},
+		{
+			name:      "in matches nested field",
+			condition: BucketCondition{Field: "education.level", Operator: BucketOperatorIn, Values: []string{"undergraduate"}},
+			expected:  true,
+		},
+		{
+			name:      "not_in matches missing field",
+			condition: BucketCondition{Field: "school", Operator: BucketOperatorNotIn, Values: []string{"University of Florida"}},
+			expected:  true,
+		},
+		{
+			name:      "not_in rejects listed value",
+			condition: BucketCondition{Field: "country", Operator: BucketOperatorNotIn, Values: []string{"United States"}},
+			expected:  false,
+		},
+		{
+			name:      "exists ignores empty strings",
+			condition: BucketCondition{Field: "minors", Operator: BucketOperatorExists},
+			expected:  false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if result := test.condition.Matches(application); result != test.expected {
+				t.Fatalf("expected %v, got %v", test.expected, result)
+			}
+		})
+	}
+}
+
+func TestValidateBucketRules(t *testing.T) {
+	tests := []struct {
+		name          string
+		rules         []BucketRule
+		expectedError error
+	}{
+		{
+			name:          "default rules",
+			rules:         DefaultBucketRules(),
+			expectedError: nil,
+		},
+		{
+			name:          "no buckets",
+			rules:         []BucketRule{},
+			expectedError: ErrNoBuckets,
+		},
+		{
+			name:          "duplicate names",
+			rules:         []BucketRule{{Name: "a", Quota: 1}, {Name: "a", Quota: 1}},
+			expectedError: ErrDuplicateBucketName,
+		},
+		{
+			name:          "unknown rollover",
+			rules:         []BucketRule{{Name: "a", Quota: 1, Rollover: "b"}},
+			expectedError: ErrUnknownRolloverBucket,
+		},
+		{
+			name: "in without values",
+			rules: []BucketRule{{Name: "a", Quota: 1, Conditions: []BucketCondition{
+				{Field: "school", Operator: BucketOperatorIn},
+			}}},
+			expectedError: ErrInvalidBucketCondition,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateBucketRules(test.rules)
+
+			if !errors.Is(err, test.expectedError) {
+				t.Fatalf("expected error %v, got %v", test.expectedError, err)
+			}
+		})
+	}
+}
@@ -2,41 +2,33 @@ package bat
 
 import (
 	"errors"
+	"maps"
 	"math"
 	"math/rand"
 	"sort"
-	"time"
 
 	"github.com/google/uuid"
+	"github.com/swamphacks/core/apps/api/internal/calibration"
 )
 
 var (
 	ErrImproperWeights  = errors.New("Passion and experience weights don't add to 1.0")
 	ErrScoreOutOfBounds = errors.New("The score can only range from 1 to 5")
-)
-
-type BucketType int
 
-const (
-	BucketTypeUFEarly BucketType = iota
-	BucketTypeUFLate
-	BucketTypeOtherEarly
-	BucketTypeOtherLate
+	ErrImproperBaseConstant = errors.New("The weighted base constant must be greater than 0")
+	ErrNegativeQuota        = errors.New("Quotas can't be negative")
 )
 
-type BucketConfig struct {
-	Type        BucketType
-	QuotaPtr    *int32
-	RolloverPtr *int32
-}
-
 type AdmissionCandidate struct {
-	UserID        uuid.UUID
-	TeamID        uuid.NullUUID
-	WeightedScore float64
-	SortKey       float64
-	IsUFStudent   bool
-	IsEarlyCareer bool
+	ApplicationID uuid.UUID     `json:"applicationId"`
+	UserID        uuid.UUID     `json:"userId"`
+	TeamID        uuid.NullUUID `json:"teamId"`
+	WeightedScore float64       `json:"weightedScore"`
+	SortKey       float64       `json:"-"`
+
+	// Bucket is the name of the bucket the candidate falls into. It is empty
+	// if no bucket rule matched, in which case the candidate can't be accepted.
+	Bucket string `json:"bucket"`
 }
 
 // TeamEvaluationData holds the aggregated metrics for a group of applicants
@@ -51,21 +43,11 @@ type TeamEvaluationData struct {
 	// AverageWeightedScore is the average WeightedScore of all team members.
 	AverageWeightedScore float64
 
-	// SortKey is the non-deterministic key used for ranking teams against each other.
+	// SortKey is the randomized key used for ranking teams against each other.
 	SortKey float64
-}
 
-type AdmissionContext struct {
-	School string `json:"school"`
-	Year   string `json:"year"`
-}
-
-type CategoryQuota struct {
-	// EarlyLeft is the number of remaining slots for Early Career applicants (Freshman/Sophomore).
-	EarlyLeft int32
-
-	// LateLeft is the number of remaining slots for Upper Career applicants (Junior/Senior/Grad).
-	LateLeft int32
+	// Accepted is set once AcceptTeams has admitted the whole team.
+	Accepted bool
 }
 
 // QuotaState tracks the remaining admission slots across all categories
@@ -74,56 +56,169 @@ type CategoryQuota struct {
 type QuotaState struct {
 	// TotalAccepted is the running count of applicants already admitted.
 	// This value is primarily informational and doesn't limit acceptance.
-	TotalAccepted int32
+	TotalAccepted int32 `json:"totalAccepted"`
 
 	// TeamSlotsLeft is the maximum number of remaining *individual* applicants
 	// that can be admitted as part of a team (before solo admissions).
-	TeamSlotsLeft int32
+	TeamSlotsLeft int32 `json:"teamSlotsLeft"`
 
-	// UF holds the remaining quota slots for applicants who are University of Florida students,
-	// categorized by career stage (Early vs. Late).
-	UF CategoryQuota
+	// Buckets holds the remaining quota slots of every bucket, keyed by bucket name.
+	Buckets map[string]int32 `json:"buckets"`
+}
 
-	// Other holds the remaining quota slots for applicants who are NOT University of Florida students,
-	// categorized by career stage (Early vs. Late).
-	Other CategoryQuota
+// snapshot copies the quota state so later changes don't leak into it.
+func (q QuotaState) snapshot() QuotaState {
+	q.Buckets = maps.Clone(q.Buckets)
+	return q
+}
+
+type DecisionReason string
+
+const (
+	DecisionAcceptedTeam       DecisionReason = "accepted_team"
+	DecisionAcceptedIndividual DecisionReason = "accepted_individual"
+	DecisionBucketFull         DecisionReason = "bucket_full"
+	DecisionTeamNotFit         DecisionReason = "team_not_fit"
+	DecisionNoBucket           DecisionReason = "no_bucket"
+)
+
+// AdmissionDecision explains why a single candidate was accepted or rejected.
+type AdmissionDecision struct {
+	Candidate AdmissionCandidate
+	Reason    DecisionReason
+
+	// SortKey is the key the candidate was ranked with. For candidates accepted
+	// as part of a team this is the team's key.
+	SortKey float64
+
+	// Quota is the state of the quotas right before the decision was applied.
+	Quota QuotaState
+}
+
+// Accepted reports whether the decision admitted the candidate.
+func (d AdmissionDecision) Accepted() bool {
+	return d.Reason == DecisionAcceptedTeam || d.Reason == DecisionAcceptedIndividual
 }
 
 type BatEngine struct {
 	passionWeight        float64
 	experienceWeight     float64
 	weightedBaseConstant float64
+	buckets              []BucketRule
+	rand                 *rand.Rand
 	Quota                QuotaState
+
+	// Decisions records every accept and reject in the order they were made.
+	Decisions []AdmissionDecision
+
+	// unfitTeamMembers holds the application IDs of members whose team
+	// couldn't be admitted as a whole.
+	unfitTeamMembers map[uuid.UUID]bool
+}
+
+// BatEngineConfig holds the tunable parameters of the engine. Every hackathon
+// stores its own copy so admissions can be adjusted without a code change.
+type BatEngineConfig struct {
+	PassionWeight        float64 `json:"passionWeight"`
+	ExperienceWeight     float64 `json:"experienceWeight"`
+	WeightedBaseConstant float64 `json:"weightedBaseConstant"`
+
+	// TeamSlots is the maximum number of applicants that can be admitted as part of a team.
+	TeamSlots int32 `json:"teamSlots"`
+
+	// Buckets are the rules used to group candidates, in evaluation order.
+	Buckets []BucketRule `json:"buckets"`
+
+	// ScoreNormalization corrects the review ratings for reviewer bias before the
+	// weighted score is calculated.
+	ScoreNormalization calibration.Method `json:"scoreNormalization"`
 }
 
-func NewBatEngine(passionW, experienceW float64) (*BatEngine, error) {
-	if !equalWithinTolerance(passionW+experienceW, 1.0, 1e-9) {
-		return nil, ErrImproperWeights
+// DefaultBatEngineConfig returns the parameters used for hackathons that have not
+// configured BAT yet.
+func DefaultBatEngineConfig() BatEngineConfig {
+	return BatEngineConfig{
+		PassionWeight:        0.6,
+		ExperienceWeight:     0.4,
+		WeightedBaseConstant: 0.1,
+		TeamSlots:            50,
+		Buckets:              DefaultBucketRules(),
+		ScoreNormalization:   calibration.MethodNone,
+	}
+}
+
+// NewBatEngine builds an engine from the given config. All randomness is drawn
+// from the seed, so the same config, seed and candidates always give the same result.
+func NewBatEngine(cfg BatEngineConfig, seed int64) (*BatEngine, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
 	}
 
-	// Allow this to be set outside at some point
 	quota := QuotaState{
 		TotalAccepted: 0,
-		TeamSlotsLeft: 50,
-		UF: CategoryQuota{
-			EarlyLeft: 210,
-			LateLeft:  140,
-		},
-		Other: CategoryQuota{
-			EarlyLeft: 90,
-			LateLeft:  60,
-		},
+		TeamSlotsLeft: cfg.TeamSlots,
+		Buckets:       make(map[string]int32, len(cfg.Buckets)),
+	}
+	for _, bucket := range cfg.Buckets {
+		quota.Buckets[bucket.Name] = bucket.Quota
 	}
 
 	return &BatEngine{
-		passionWeight:        passionW,
-		experienceWeight:     experienceW,
-		weightedBaseConstant: 0.1,
+		passionWeight:        cfg.PassionWeight,
+		experienceWeight:     cfg.ExperienceWeight,
+		weightedBaseConstant: cfg.WeightedBaseConstant,
+		buckets:              cfg.Buckets,
+		rand:                 rand.New(rand.NewSource(seed)),
 		Quota:                quota,
+		unfitTeamMembers:     make(map[uuid.UUID]bool),
 	}, nil
 }
 
-func (b *BatEngine) CalculateWeightedScore(passionS, expS int32) (float64, error) {
+// Validate checks that the weights are usable and that the bucket rules are well formed.
+func (c BatEngineConfig) Validate() error {
+	if c.PassionWeight < 0 || c.ExperienceWeight < 0 {
+		return ErrImproperWeights
+	}
+
+	if !equalWithinTolerance(c.PassionWeight+c.ExperienceWeight, 1.0, 1e-9) {
+		return ErrImproperWeights
+	}
+
+	if c.WeightedBaseConstant <= 0 {
+		// The base constant keeps every weight strictly positive for generateSortKey.
+		return ErrImproperBaseConstant
+	}
+
+	if c.TeamSlots < 0 {
+		return ErrNegativeQuota
+	}
+
+	if err := c.ScoreNormalization.Validate(); err != nil {
+		return err
+	}
+
+	return validateBucketRules(c.Buckets)
+}
+
+// TotalQuota is the number of applicants the engine can accept at most.
+// Team slots are not counted since accepted teams consume the bucket quotas.
+func (c BatEngineConfig) TotalQuota() int32 {
+	var total int32
+	for _, bucket := range c.Buckets {
+		total += bucket.Quota
+	}
+
+	return total
+}
+
+// DetermineBucket returns the name of the first bucket whose rule matches the application data.
+func (b *BatEngine) DetermineBucket(application map[string]any) (string, bool) {
+	return determineBucket(b.buckets, application)
+}
+
+// CalculateWeightedScore combines the (averaged) passion and experience ratings
+// of an applicant into a single weighted score.
+func (b *BatEngine) CalculateWeightedScore(passionS, expS float64) (float64, error) {
 	if 5 < passionS || 0 > passionS {
 		return 0.0, ErrScoreOutOfBounds
 	}
@@ -132,32 +227,39 @@ func (b *BatEngine) CalculateWeightedScore(passionS, expS int32) (float64, error
 		return 0.0, ErrScoreOutOfBounds
 	}
 
-	return (float64(passionS) * b.passionWeight) + (float64(expS) * b.experienceWeight) + b.weightedBaseConstant, nil
+	return (passionS * b.passionWeight) + (expS * b.experienceWeight) + b.weightedBaseConstant, nil
 }
 
 func (b *BatEngine) GroupCandidates(admissionsData []AdmissionCandidate) ([]TeamEvaluationData, []AdmissionCandidate) {
 	teamMap := make(map[uuid.UUID][]AdmissionCandidate)
 	individualCandidates := make([]AdmissionCandidate, 0)
 
+	// Teams are kept in the order they are first seen so the result doesn't
+	// depend on map iteration, which would make runs impossible to replay.
+	teamOrder := make([]uuid.UUID, 0)
+
 	// Sort admissions candidates on a valid TeamID
 	for _, app := range admissionsData {
 		if app.TeamID.Valid {
+			if _, ok := teamMap[app.TeamID.UUID]; !ok {
+				teamOrder = append(teamOrder, app.TeamID.UUID)
+			}
 			teamMap[app.TeamID.UUID] = append(teamMap[app.TeamID.UUID], app)
 		} else {
 			individualCandidates = append(individualCandidates, app)
 		}
 	}
 
-	// Check for teams with only one member and move them to individualCandidates
-	for teamID, candidates := range teamMap {
-		if len(candidates) == 1 {
-			individualCandidates = append(individualCandidates, candidates[0])
-			delete(teamMap, teamID)
+	teamsEvalData := make([]TeamEvaluationData, 0)
+	for _, teamId := range teamOrder {
+		members := teamMap[teamId]
+
+		// Teams with only one member are treated as individual candidates
+		if len(members) == 1 {
+			individualCandidates = append(individualCandidates, members[0])
+			continue
 		}
-	}
 
-	teamsEvalData := make([]TeamEvaluationData, 0)
-	for teamId, members := range teamMap {
 		var totalScore float64
 		for _, member := range members {
 			totalScore += member.WeightedScore
@@ -176,101 +278,100 @@ func (b *BatEngine) GroupCandidates(admissionsData []AdmissionCandidate) ([]Team
 func (b *BatEngine) AcceptIndividuals(idvs []AdmissionCandidate) ([]AdmissionCandidate, []AdmissionCandidate) {
 	accepted := make([]AdmissionCandidate, 0)
 
-	pools := groupCandidateByType(idvs)
-
-	buckets := []BucketConfig{
-		{
-			Type:        BucketTypeUFEarly,
-			QuotaPtr:    &b.Quota.UF.EarlyLeft,
-			RolloverPtr: &b.Quota.UF.LateLeft,
-		},
-		{
-			Type:        BucketTypeUFLate,
-			QuotaPtr:    &b.Quota.UF.LateLeft,
-			RolloverPtr: &b.Quota.UF.EarlyLeft,
-		},
-		{
-			Type:        BucketTypeOtherEarly,
-			QuotaPtr:    &b.Quota.Other.EarlyLeft,
-			RolloverPtr: &b.Quota.Other.LateLeft,
-		},
-		{
-			Type:        BucketTypeOtherLate,
-			QuotaPtr:    &b.Quota.Other.LateLeft,
-			RolloverPtr: &b.Quota.Other.EarlyLeft,
-		},
-	}
+	pools := groupCandidatesByBucket(idvs)
 
 	// Two pass is the minimum we need to ensure convergence
 	// on the rollover quotas. As of right now we do *not* support
-	// rollover between non-leaf nodes/conditions.
+	// rollover chains longer than a single hop per pass.
 	for range 2 {
-		for _, bucket := range buckets {
-			candidates := pools[bucket.Type]
+		for _, bucket := range b.buckets {
+			candidates := pools[bucket.Name]
 			if len(candidates) == 0 {
-				if bucket.RolloverPtr != nil {
-					*bucket.RolloverPtr += *bucket.QuotaPtr
-					*bucket.QuotaPtr = 0
-				}
+				b.rollover(bucket)
 				continue
 			}
 
 			b.ApplyIndividualSortKey(candidates)
-			sort.Slice(candidates, func(i, j int) bool {
+			sort.SliceStable(candidates, func(i, j int) bool {
 				return candidates[i].SortKey > candidates[j].SortKey
 			})
 
-			remainingQuota := *bucket.QuotaPtr
-			countToAccept := min(int(remainingQuota), len(candidates))
+			remainingQuota := b.Quota.Buckets[bucket.Name]
+			countToAccept := max(min(int(remainingQuota), len(candidates)), 0)
 
 			for i := range countToAccept {
 				candidate := candidates[i]
 				accepted = append(accepted, candidate)
+				b.recordDecision(candidate, DecisionAcceptedIndividual, candidate.SortKey)
 
-				*bucket.QuotaPtr--
+				b.Quota.Buckets[bucket.Name]--
 				b.Quota.TotalAccepted++
 			}
 
 			// Rollover leftover slots to nearest neighbor condition
-			if bucket.QuotaPtr != nil && *bucket.QuotaPtr > 0 {
-				*bucket.RolloverPtr += *bucket.QuotaPtr
-				*bucket.QuotaPtr = 0
-			}
+			b.rollover(bucket)
 
 			// Removed accepted candidates (topK) from pool
-			pools[bucket.Type] = candidates[countToAccept:]
+			pools[bucket.Name] = candidates[countToAccept:]
 		}
 	}
 
+	// Walk the buckets in rule order so the result doesn't depend on map iteration.
 	rejected := make([]AdmissionCandidate, 0)
-	for _, remaining := range pools {
-		rejected = append(rejected, remaining...)
+	for _, bucket := range b.buckets {
+		rejected = append(rejected, pools[bucket.Name]...)
+	}
+	rejected = append(rejected, pools[""]...)
+
+	for _, candidate := range rejected {
+		reason := DecisionBucketFull
+		switch {
+		case candidate.Bucket == "":
+			reason = DecisionNoBucket
+		case b.unfitTeamMembers[candidate.ApplicationID]:
+			reason = DecisionTeamNotFit
+		}
+
+		b.recordDecision(candidate, reason, candidate.SortKey)
 	}
 
 	return accepted, rejected
 }
 
-func groupCandidateByType(idvs []AdmissionCandidate) map[BucketType][]AdmissionCandidate {
-	m := make(map[BucketType][]AdmissionCandidate)
-	for _, idv := range idvs {
-		t := determineCandidateBucketType(idv)
+func (b *BatEngine) recordDecision(candidate AdmissionCandidate, reason DecisionReason, sortKey float64) {
+	b.Decisions = append(b.Decisions, AdmissionDecision{
+		Candidate: candidate,
+		Reason:    reason,
+		SortKey:   sortKey,
+		Quota:     b.Quota.snapshot(),
+	})
+}
 
-		m[t] = append(m[t], idv)
+func (b *BatEngine) markTeamNotFit(team *TeamEvaluationData) {
+	for _, member := range team.Members {
+		b.unfitTeamMembers[member.ApplicationID] = true
 	}
+}
 
-	return m
+// rollover moves the unused slots of a bucket into its rollover bucket, if it has one.
+func (b *BatEngine) rollover(bucket BucketRule) {
+	if bucket.Rollover == "" {
+		return
+	}
+
+	if left := b.Quota.Buckets[bucket.Name]; left > 0 {
+		b.Quota.Buckets[bucket.Rollover] += left
+		b.Quota.Buckets[bucket.Name] = 0
+	}
 }
 
-func determineCandidateBucketType(idv AdmissionCandidate) BucketType {
-	if idv.IsEarlyCareer && idv.IsUFStudent {
-		return BucketTypeUFEarly
-	} else if !idv.IsEarlyCareer && idv.IsUFStudent {
-		return BucketTypeUFLate
-	} else if idv.IsEarlyCareer && !idv.IsUFStudent {
-		return BucketTypeOtherEarly
-	} else {
-		return BucketTypeOtherLate
+func groupCandidatesByBucket(idvs []AdmissionCandidate) map[string][]AdmissionCandidate {
+	m := make(map[string][]AdmissionCandidate)
+	for _, idv := range idvs {
+		m[idv.Bucket] = append(m[idv.Bucket], idv)
 	}
+
+	return m
 }
 
 func (b *BatEngine) ScoreTeams(teams []TeamEvaluationData) {
@@ -287,26 +388,22 @@ func (b *BatEngine) ScoreTeams(teams []TeamEvaluationData) {
 }
 
 func (b *BatEngine) ApplyIndividualSortKey(idv []AdmissionCandidate) {
-	r := rand.New(rand.NewSource(time.Now().UnixNano()))
-
 	for i := range idv {
 		indie := &idv[i]
 
-		indie.SortKey = generateSortKey(r, indie.WeightedScore)
+		indie.SortKey = generateSortKey(b.rand, indie.WeightedScore)
 	}
 }
 
 func (b *BatEngine) ApplyTeamSortKey(teams []TeamEvaluationData) {
-	r := rand.New(rand.NewSource(time.Now().UnixNano()))
-
 	for i := range teams {
 		team := &teams[i]
 
-		team.SortKey = generateSortKey(r, team.AverageWeightedScore)
+		team.SortKey = generateSortKey(b.rand, team.AverageWeightedScore)
 	}
 
 	// Sort my descending for top-k results
-	sort.Slice(teams, func(i, j int) bool {
+	sort.SliceStable(teams, func(i, j int) bool {
 		return teams[i].SortKey > teams[j].SortKey
 	})
 }
@@ -318,27 +415,35 @@ func (b *BatEngine) AcceptTeams(teams []TeamEvaluationData) ([]AdmissionCandidat
 	b.ScoreTeams(teams)
 	b.ApplyTeamSortKey(teams)
 
-	for _, team := range teams {
+	for i := range teams {
+		team := &teams[i]
+
 		if b.Quota.TeamSlotsLeft <= int32(len(team.Members)) {
+			b.markTeamNotFit(team)
 			remaining = append(remaining, team.Members...)
 			continue
 		}
 
-		requiredQuota := getTeamQuotaRequirement(&team)
+		requiredQuota := getTeamQuotaRequirement(team)
 
 		if b.canAcceptTeam(requiredQuota) {
+			team.Accepted = true
 			accepted = append(accepted, team.Members...)
 			size := int32(len(team.Members))
 
+			for _, member := range team.Members {
+				b.recordDecision(member, DecisionAcceptedTeam, team.SortKey)
+			}
+
 			b.Quota.TotalAccepted += size
 			b.Quota.TeamSlotsLeft -= size
 
 			// Adjust primary quota buckets
-			b.Quota.UF.EarlyLeft -= requiredQuota.UF.EarlyLeft
-			b.Quota.UF.LateLeft -= requiredQuota.UF.LateLeft
-			b.Quota.Other.EarlyLeft -= requiredQuota.Other.EarlyLeft
-			b.Quota.Other.LateLeft -= requiredQuota.Other.LateLeft
+			for bucket, count := range requiredQuota {
+				b.Quota.Buckets[bucket] -= count
+			}
 		} else {
+			b.markTeamNotFit(team)
 			remaining = append(remaining, team.Members...)
 		}
 
@@ -347,37 +452,47 @@ func (b *BatEngine) AcceptTeams(teams []TeamEvaluationData) ([]AdmissionCandidat
 	return accepted, remaining
 }
 
-func getTeamQuotaRequirement(team *TeamEvaluationData) QuotaState {
-	reqQuota := QuotaState{
-		TotalAccepted: 0,
-		TeamSlotsLeft: 0,
-		UF: CategoryQuota{
-			EarlyLeft: 0,
-			LateLeft:  0,
-		},
-		Other: CategoryQuota{
-			EarlyLeft: 0,
-			LateLeft:  0,
-		},
+// AdmissionResult is the outcome of a full engine run.
+type AdmissionResult struct {
+	Accepted []AdmissionCandidate
+
+	// Rejected holds every candidate that was not accepted, in bucket order.
+	Rejected []AdmissionCandidate
+
+	// Teams holds every team that was evaluated as a unit, in the order they were ranked.
+	Teams []TeamEvaluationData
+
+	// Quota is the state of the quotas once all decisions were made.
+	Quota QuotaState
+
+	// Decisions explains the outcome of every candidate.
+	Decisions []AdmissionDecision
+}
+
+// Run admits teams first and then individuals, including members of teams that
+// couldn't be admitted as a whole. An engine should only be run once.
+func (b *BatEngine) Run(candidates []AdmissionCandidate) AdmissionResult {
+	teams, idvs := b.GroupCandidates(candidates)
+	acceptedTeamMembers, remainder := b.AcceptTeams(teams)
+
+	idvs = append(idvs, remainder...)
+	acceptedIdvs, rejected := b.AcceptIndividuals(idvs)
+
+	return AdmissionResult{
+		Accepted:  append(acceptedTeamMembers, acceptedIdvs...),
+		Rejected:  rejected,
+		Teams:     teams,
+		Quota:     b.Quota,
+		Decisions: b.Decisions,
 	}
+}
 
-	for _, member := range team.Members {
-		if member.IsUFStudent {
-			if member.IsEarlyCareer {
-				reqQuota.UF.EarlyLeft += 1
-			} else {
-				reqQuota.UF.LateLeft += 1
-			}
-		} else {
-			if member.IsEarlyCareer {
-				reqQuota.Other.EarlyLeft += 1
-			} else {
-				reqQuota.Other.LateLeft += 1
-			}
-		}
+// getTeamQuotaRequirement counts how many slots of each bucket the team would take up.
+func getTeamQuotaRequirement(team *TeamEvaluationData) map[string]int32 {
+	reqQuota := make(map[string]int32)
 
-		reqQuota.TotalAccepted += 1
-		reqQuota.TeamSlotsLeft += 1
+	for _, member := range team.Members {
+		reqQuota[member.Bucket] += 1
 	}
 
 	return reqQuota
@@ -385,11 +500,15 @@ func getTeamQuotaRequirement(team *TeamEvaluationData) QuotaState {
 
 // Checks whether the current quota can fullfill the required
 // quota calculated in getTeamQuotaRequirement
-func (b BatEngine) canAcceptTeam(req QuotaState) bool {
-	return req.UF.EarlyLeft <= b.Quota.UF.EarlyLeft &&
-		req.UF.LateLeft <= b.Quota.UF.LateLeft &&
-		req.Other.EarlyLeft <= b.Quota.Other.EarlyLeft &&
-		req.Other.LateLeft <= b.Quota.Other.LateLeft
+func (b BatEngine) canAcceptTeam(req map[string]int32) bool {
+	for bucket, count := range req {
+		left, ok := b.Quota.Buckets[bucket]
+		if !ok || count > left {
+			return false
+		}
+	}
+
+	return true
 }
 
 // generateSortKey generates a priority key for weighted random sampling.
@@ -0,0 +1,202 @@
+package bat
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/swamphacks/core/apps/api/internal/calibration"
+)
+
+func TestBatEngineConfigValidate(t *testing.T) {
+	tests := []struct {
+		name          string
+		mutate        func(cfg *BatEngineConfig)
+		expectedError error
+	}{
+		{
+			name:          "default config",
+			mutate:        func(cfg *BatEngineConfig) {},
+			expectedError: nil,
+		},
+		{
+			name: "weights don't add to one",
+			mutate: func(cfg *BatEngineConfig) {
+				cfg.PassionWeight = 0.7
+			},
+			expectedError: ErrImproperWeights,
+		},
+		{
+			name: "negative weight",
+			mutate: func(cfg *BatEngineConfig) {
+				cfg.PassionWeight = -0.5
+				cfg.ExperienceWeight = 1.5
+			},
+			expectedError: ErrImproperWeights,
+		},
+		{
+			name: "zero base constant",
+			mutate: func(cfg *BatEngineConfig) {
+				cfg.WeightedBaseConstant = 0
+			},
+			expectedError: ErrImproperBaseConstant,
+		},
+		{
+			name: "negative team slots",
+			mutate: func(cfg *BatEngineConfig) {
+				cfg.TeamSlots = -1
+			},
+			expectedError: ErrNegativeQuota,
+		},
+		{
+			name: "unknown score normalization",
+			mutate: func(cfg *BatEngineConfig) {
+				cfg.ScoreNormalization = "median"
+			},
+			expectedError: calibration.ErrUnknownMethod,
+		},
+		{
+			name: "score normalization predating the option",
+			mutate: func(cfg *BatEngineConfig) {
+				cfg.ScoreNormalization = ""
+			},
+			expectedError: nil,
+		},
+		{
+			name: "negative quota",
+			mutate: func(cfg *BatEngineConfig) {
+				cfg.Buckets[3].Quota = -1
+			},
+			expectedError: ErrNegativeQuota,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := DefaultBatEngineConfig()
+			test.mutate(&cfg)
+
+			err := cfg.Validate()
+
+			if !errors.Is(err, test.expectedError) {
+				t.Fatalf("expected error %v, got %v", test.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestBatEngineConfigTotalQuota(t *testing.T) {
+	cfg := DefaultBatEngineConfig()
+
+	if total := cfg.TotalQuota(); total != 500 {
+		t.Fatalf("expected %v, got %v", 500, total)
+	}
+}
+
+func TestBatEngineRunIsDeterministic(t *testing.T) {
+	cfg := DefaultBatEngineConfig()
+	cfg.TeamSlots = 4
+	for i := range cfg.Buckets {
+		cfg.Buckets[i].Quota = 3
+	}
+
+	team := uuid.NullUUID{UUID: uuid.New(), Valid: true}
+	var candidates []AdmissionCandidate
+	for i := range 20 {
+		candidate := AdmissionCandidate{
+			ApplicationID: uuid.New(),
+			UserID:        uuid.New(),
+			WeightedScore: float64(i%5 + 1),
+			Bucket:        cfg.Buckets[i%len(cfg.Buckets)].Name,
+		}
+		if i < 3 {
+			candidate.TeamID = team
+		}
+		candidates = append(candidates, candidate)
+	}
+
+	run := func(seed int64) []uuid.UUID {
+		engine, err := NewBatEngine(cfg, seed)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var accepted []uuid.UUID
+		for _, candidate := range engine.Run(slices.Clone(candidates)).Accepted {
+			accepted = append(accepted, candidate.UserID)
+		}
+		return accepted
+	}
+
+	first, second := run(42), run(42)
+	if !slices.Equal(first, second) {
+		t.Fatalf("expected the same seed to accept %v, got %v", first, second)
+	}
+}
+
+func TestBatEngineRunDecisions(t *testing.T) {
+	tests := []struct {
+		name      string
+		quota     int32
+		teamSlots int32
+	}{
+		{name: "team fits", quota: 5, teamSlots: 10},
+		{name: "team doesn't fit the bucket", quota: 1, teamSlots: 10},
+		{name: "no team slots", quota: 5, teamSlots: 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := DefaultBatEngineConfig()
+			cfg.TeamSlots = test.teamSlots
+			cfg.Buckets = []BucketRule{{Name: "everyone", Quota: test.quota, Conditions: []BucketCondition{}}}
+
+			team := uuid.NullUUID{UUID: uuid.New(), Valid: true}
+			candidates := []AdmissionCandidate{
+				{ApplicationID: uuid.New(), UserID: uuid.New(), TeamID: team, WeightedScore: 3, Bucket: "everyone"},
+				{ApplicationID: uuid.New(), UserID: uuid.New(), TeamID: team, WeightedScore: 3, Bucket: "everyone"},
+				{ApplicationID: uuid.New(), UserID: uuid.New(), WeightedScore: 3, Bucket: "everyone"},
+				{ApplicationID: uuid.New(), UserID: uuid.New(), WeightedScore: 3},
+			}
+
+			engine, err := NewBatEngine(cfg, 7)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			result := engine.Run(candidates)
+
+			if len(result.Decisions) != len(candidates) {
+				t.Fatalf("expected %v decisions, got %v", len(candidates), len(result.Decisions))
+			}
+
+			reasons := make(map[uuid.UUID]DecisionReason)
+			accepted := 0
+			for _, decision := range result.Decisions {
+				reasons[decision.Candidate.ApplicationID] = decision.Reason
+				if decision.Accepted() {
+					accepted++
+				}
+			}
+
+			if accepted != len(result.Accepted) {
+				t.Fatalf("expected %v accepted decisions, got %v", len(result.Accepted), accepted)
+			}
+
+			if reason := reasons[candidates[3].ApplicationID]; reason != DecisionNoBucket {
+				t.Fatalf("expected unbucketed candidate to be %v, got %v", DecisionNoBucket, reason)
+			}
+
+			teamAccepted := len(result.Teams) == 1 && result.Teams[0].Accepted
+			for _, member := range candidates[:2] {
+				reason := reasons[member.ApplicationID]
+				switch {
+				case teamAccepted && reason != DecisionAcceptedTeam:
+					t.Fatalf("expected team member to be %v, got %v", DecisionAcceptedTeam, reason)
+				case !teamAccepted && reason != DecisionTeamNotFit && reason != DecisionAcceptedIndividual:
+					t.Fatalf("expected team member to be %v or %v, got %v", DecisionTeamNotFit, DecisionAcceptedIndividual, reason)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,204 @@
+package bat
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/google/uuid"
+	"github.com/swamphacks/core/apps/api/internal/calibration"
+	"github.com/swamphacks/core/apps/api/internal/database"
+)
+
+type GetRunsOutput struct {
+	Body []BatRunDto `nullable:"false"`
+}
+
+func (h *handler) handleGetRuns(ctx context.Context, input *struct{}) (*GetRunsOutput, error) {
+	runs, err := h.batService.GetRuns(ctx)
+	if err != nil {
+		return nil, huma.Error500InternalServerError("Failed to get bat runs")
+	}
+
+	runDtos := make([]BatRunDto, len(runs))
+	for i, run := range runs {
+		runDtos[i] = toBatRunDto(run)
+	}
+
+	return &GetRunsOutput{Body: runDtos}, nil
+}
+
+type GetRunOutput struct {
+	Body BatRunDto
+}
+
+func (h *handler) handleGetRun(ctx context.Context, input *struct {
+	RunID uuid.UUID `path:"runId"`
+}) (*GetRunOutput, error) {
+	run, err := h.batService.GetRunById(ctx, input.RunID)
+	if err != nil {
+		if errors.Is(err, database.ErrRunNotFound) {
+			return nil, huma.Error404NotFound("Run not found")
+		}
+		return nil, huma.Error500InternalServerError("Failed to get bat run")
+	}
+
+	return &GetRunOutput{Body: toBatRunDto(*run)}, nil
+}
+
+type GetDecisionsOutput struct {
+	Body []BatRunDecisionDto
+}
+
+func (h *handler) handleGetRunDecisions(ctx context.Context, input *struct {
+	RunID uuid.UUID `path:"runId"`
+}) (*GetDecisionsOutput, error) {
+	decisions, err := h.batService.GetRunDecisions(ctx, input.RunID)
+	if err != nil {
+		if errors.Is(err, database.ErrRunNotFound) {
+			return nil, huma.Error404NotFound("Run not found")
+		}
+		return nil, huma.Error500InternalServerError("Failed to get run decisions")
+	}
+
+	return &GetDecisionsOutput{Body: toBatRunDecisionDtos(decisions)}, nil
+}
+
+func (h *handler) handleGetApplicationDecisions(ctx context.Context, input *struct {
+	ApplicationID uuid.UUID `path:"applicationId"`
+}) (*GetDecisionsOutput, error) {
+	decisions, err := h.batService.GetApplicationDecisions(ctx, input.ApplicationID)
+	if err != nil {
+		return nil, huma.Error500InternalServerError("Failed to get application decisions")
+	}
+
+	return &GetDecisionsOutput{Body: toBatRunDecisionDtos(decisions)}, nil
+}
+
+type QueueCalculateAdmissionsOutput struct {
+	Body BatRunDto
+}
+
+func (h *handler) handleQueueCalculateAdmissions(ctx context.Context, input *struct {
+	Body *RunAdmissionsRequestDto
+}) (*QueueCalculateAdmissionsOutput, error) {
+	var seed *int64
+	if input.Body != nil {
+		seed = input.Body.Seed
+	}
+
+	run, err := h.batService.QueueCalculateAdmissionsTask(ctx, seed)
+	if err != nil {
+		if errors.Is(err, ErrRunConflict) {
+			return nil, huma.Error409Conflict(err.Error())
+		}
+		return nil, huma.Error500InternalServerError("Failed to handle admissions calculation request")
+	}
+
+	return &QueueCalculateAdmissionsOutput{Body: toBatRunDto(*run)}, nil
+}
+
+type ReplayRunOutput struct {
+	Body ReplayRunDto
+}
+
+func (h *handler) handleReplayRun(ctx context.Context, input *struct {
+	RunID uuid.UUID `path:"runId"`
+}) (*ReplayRunOutput, error) {
+	replay, err := h.batService.ReplayRun(ctx, input.RunID)
+	if err != nil {
+		switch {
+		case errors.Is(err, database.ErrRunNotFound):
+			return nil, huma.Error404NotFound("Run not found")
+		case errors.Is(err, ErrRunNotReplayable):
+			return nil, huma.Error400BadRequest(err.Error())
+		}
+		return nil, huma.Error500InternalServerError("Failed to replay run")
+	}
+
+	return &ReplayRunOutput{Body: *replay}, nil
+}
+
+type DryRunOutput struct {
+	Body AdmissionBreakdownDto
+}
+
+func (h *handler) handleDryRun(ctx context.Context, input *struct {
+	Body *RunAdmissionsRequestDto
+}) (*DryRunOutput, error) {
+	var seed *int64
+	if input.Body != nil {
+		seed = input.Body.Seed
+	}
+
+	breakdown, err := h.batService.DryRun(ctx, seed)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrNoAdmissionCandidates), errors.Is(err, ErrMissingReviewRatings):
+			return nil, huma.Error400BadRequest(err.Error())
+		}
+		return nil, huma.Error500InternalServerError("Failed to dry run admissions")
+	}
+
+	return &DryRunOutput{Body: *breakdown}, nil
+}
+
+type DeleteRunOutput struct {
+	Status int
+}
+
+func (h *handler) handleDeleteRun(ctx context.Context, input *struct {
+	RunID uuid.UUID `path:"runId"`
+}) (*DeleteRunOutput, error) {
+	err := h.batService.DeleteRunById(ctx, input.RunID)
+	if err != nil {
+		if errors.Is(err, database.ErrRunNotFound) {
+			return nil, huma.Error404NotFound("Run not found")
+		}
+		return nil, huma.Error500InternalServerError("Failed to delete run by id")
+	}
+
+	return &DeleteRunOutput{Status: http.StatusNoContent}, nil
+}
+
+type GetConfigOutput struct {
+	Body BatConfigDto
+}
+
+func (h *handler) handleGetConfig(ctx context.Context, input *struct{}) (*GetConfigOutput, error) {
+	engineConfig, err := h.batService.GetConfig(ctx)
+	if err != nil {
+		return nil, huma.Error500InternalServerError("Failed to get bat config")
+	}
+
+	return &GetConfigOutput{Body: toBatConfigDto(*engineConfig)}, nil
+}
+
+type UpdateConfigOutput struct {
+	Body BatConfigDto
+}
+
+func (h *handler) handleUpdateConfig(ctx context.Context, input *struct {
+	Body BatConfigDto
+}) (*UpdateConfigOutput, error) {
+	engineConfig, err := h.batService.UpdateConfig(ctx, input.Body.toEngineConfig())
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrImproperWeights),
+			errors.Is(err, ErrImproperBaseConstant),
+			errors.Is(err, ErrNegativeQuota),
+			errors.Is(err, ErrNoBuckets),
+			errors.Is(err, ErrBucketNameRequired),
+			errors.Is(err, ErrDuplicateBucketName),
+			errors.Is(err, ErrUnknownRolloverBucket),
+			errors.Is(err, ErrInvalidBucketCondition),
+			errors.Is(err, calibration.ErrUnknownMethod),
+			errors.Is(err, ErrQuotaExceedsMaxAttendees):
+			return nil, huma.Error400BadRequest(err.Error())
+		}
+		return nil, huma.Error500InternalServerError("Failed to update bat config")
+	}
+
+	return &UpdateConfigOutput{Body: toBatConfigDto(*engineConfig)}, nil
+}
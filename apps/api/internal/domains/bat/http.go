@@ -1,134 +1,154 @@
 package bat
 
-// import (
-// 	"context"
-// 	"net/http"
-
-// 	"github.com/danielgtaylor/huma/v2"
-// 	"github.com/google/uuid"
-// 	"github.com/rs/zerolog"
-// 	"github.com/swamphacks/core/apps/api/internal/api/middleware"
-// 	"github.com/swamphacks/core/apps/api/internal/database/sqlc"
-// )
-
-// func RegisterRoutes(batHandler *handler, group huma.API, mw *middleware.Middleware) {
-// 	huma.Register(group, huma.Operation{
-// 		OperationID: "get-bat-runs",
-// 		Method:      http.MethodGet,
-// 		Summary:     "Get Bat Runs",
-// 		Description: "Returns all bat runs",
-// 		Tags:        []string{"Bat"},
-// 		Path:        "/runs",
-// 		Middlewares: huma.Middlewares{mw.Auth.RequireAuthHuma, mw.Auth.RequireAdminHuma},
-// 		Errors:      []int{http.StatusInternalServerError, http.StatusUnauthorized},
-// 	}, batHandler.handleGetRuns)
-
-// 	huma.Register(group, huma.Operation{
-// 		OperationID: "delete-bat-run",
-// 		Method:      http.MethodDelete,
-// 		Summary:     "Delete Bat Run",
-// 		Description: "Delete a bat run by id",
-// 		Tags:        []string{"Bat"},
-// 		Path:        "/runs/{runId}",
-// 		Middlewares: huma.Middlewares{mw.Auth.RequireAuthHuma, mw.Auth.RequireAdminHuma},
-// 		Errors:      []int{http.StatusInternalServerError, http.StatusBadRequest, http.StatusUnauthorized},
-// 	}, batHandler.handleDeleteRun)
-
-// 	huma.Register(group, huma.Operation{
-// 		OperationID: "queue-schedule-waitlist-transition-task",
-// 		Method:      http.MethodPost,
-// 		Summary:     "Queue Waitlist Transition Task",
-// 		Description: "Queues an asynq task that transitions waitlisted applications, running every 3 days.",
-// 		Tags:        []string{"Bat"},
-// 		Path:        "/begin-waitlist-transition",
-// 		Middlewares: huma.Middlewares{mw.Auth.RequireAuthHuma, mw.Auth.RequireAdminHuma},
-// 		Errors:      []int{http.StatusInternalServerError, http.StatusUnauthorized},
-// 	}, batHandler.handleQueueScheduleWaitlistTransitionTask)
-
-// 	huma.Register(group, huma.Operation{
-// 		OperationID: "queue-shutdown-waitlist-scheduler-task",
-// 		Method:      http.MethodPost,
-// 		Summary:     "Queue Shutdown Waitlist Scheduler",
-// 		Description: "Shutsdown the scheduler used for the waitlist transition task. Error returned through logs if a scheduler is not active.",
-// 		Tags:        []string{"Bat"},
-// 		Path:        "/shutdown-waitlist-scheduler",
-// 		Middlewares: huma.Middlewares{mw.Auth.RequireAuthHuma, mw.Auth.RequireAdminHuma},
-// 		Errors:      []int{http.StatusInternalServerError, http.StatusUnauthorized},
-// 	}, batHandler.handleQueueShutdownWaitlistSchedulerTask)
-// }
-
-// type handler struct {
-// 	BatService *BatService
-// 	logger     zerolog.Logger
-// }
-
-// func NewHandler(BatService *BatService, logger zerolog.Logger) *handler {
-// 	return &handler{
-// 		BatService: BatService,
-// 		logger:     logger.With().Str("handler", "BatHandler").Str("domain", "bat").Logger(),
-// 	}
-// }
-
-// type GetRunsOutput struct {
-// 	Body *[]sqlc.BatRun
-// }
-
-// func (h *handler) handleGetRuns(ctx context.Context, input *struct{}) (*GetRunsOutput, error) {
-// 	runs, err := h.BatService.GetRuns(ctx)
-
-// 	if err != nil {
-// 		return nil, huma.Error500InternalServerError("Failed to get bat runs")
-// 	}
-
-// 	return &GetRunsOutput{Body: runs}, nil
-// }
-
-// type DeleteRunOutput struct {
-// 	Status int
-// }
-
-// func (h *handler) handleDeleteRun(ctx context.Context, input *struct {
-// 	RunId string `path:"runId"`
-// }) (*DeleteRunOutput, error) {
-// 	runId, err := uuid.Parse(input.RunId)
-
-// 	if err != nil {
-// 		return nil, huma.Error400BadRequest("Invalid run id")
-// 	}
-
-// 	err = h.BatService.DeleteRunById(ctx, runId)
-
-// 	if err != nil {
-// 		return nil, huma.Error500InternalServerError("Failed to delete run by id")
-// 	}
-
-// 	return &DeleteRunOutput{Status: http.StatusOK}, nil
-// }
-
-// type QueueScheduleWaitlistTransitionTaskOutput struct {
-// 	Status int
-// }
-
-// func (h *handler) handleQueueScheduleWaitlistTransitionTask(ctx context.Context, input *struct{}) (*QueueScheduleWaitlistTransitionTaskOutput, error) {
-// 	err := h.BatService.QueueScheduleWaitlistTransitionTask(ctx)
-
-// 	if err != nil {
-// 		return nil, huma.Error500InternalServerError("Failed to queue schedule waitlist transition task")
-// 	}
-
-// 	return &QueueScheduleWaitlistTransitionTaskOutput{Status: http.StatusOK}, nil
-// }
-
-// type QueueShutdownWaitlistSchedulerTaskOutput struct {
-// 	Status int
-// }
-
-// func (h *handler) handleQueueShutdownWaitlistSchedulerTask(ctx context.Context, input *struct{}) (*QueueShutdownWaitlistSchedulerTaskOutput, error) {
-// 	err := h.BatService.QueueShutdownWaitlistScheduler()
-
-// 	if err != nil {
-// 		return nil, huma.Error500InternalServerError("Failed to queue shutdown waitlist scheduler task")
-// 	}
-
-// 	return &QueueShutdownWaitlistSchedulerTaskOutput{Status: http.StatusOK}, nil
-// }
+import (
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/rs/zerolog"
+	"github.com/swamphacks/core/apps/api/internal/api/cookie"
+	"github.com/swamphacks/core/apps/api/internal/api/middleware"
+)
+
+func RegisterRoutes(batHandler *handler, group huma.API, mw *middleware.Middleware) {
+	huma.Register(group, huma.Operation{
+		OperationID:   "get-bat-runs",
+		Method:        http.MethodGet,
+		Summary:       "Get Bat Runs",
+		Description:   "Returns all bat runs, newest first",
+		Tags:          []string{"Bat"},
+		Path:          "/runs",
+		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma, mw.Auth.RequireAdminHuma},
+		Errors:        []int{http.StatusUnauthorized, http.StatusInternalServerError},
+		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
+		DefaultStatus: http.StatusOK,
+	}, batHandler.handleGetRuns)
+
+	huma.Register(group, huma.Operation{
+		OperationID:   "get-bat-run",
+		Method:        http.MethodGet,
+		Summary:       "Get Bat Run",
+		Description:   "Returns a bat run by id",
+		Tags:          []string{"Bat"},
+		Path:          "/runs/{runId}",
+		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma, mw.Auth.RequireAdminHuma},
+		Errors:        []int{http.StatusUnauthorized, http.StatusNotFound, http.StatusInternalServerError},
+		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
+		DefaultStatus: http.StatusOK,
+	}, batHandler.handleGetRun)
+
+	huma.Register(group, huma.Operation{
+		OperationID:   "get-bat-run-decisions",
+		Method:        http.MethodGet,
+		Summary:       "Get Bat Run Decisions",
+		Description:   "Returns why every candidate of a bat run was accepted or rejected",
+		Tags:          []string{"Bat"},
+		Path:          "/runs/{runId}/decisions",
+		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma, mw.Auth.RequireAdminHuma},
+		Errors:        []int{http.StatusUnauthorized, http.StatusNotFound, http.StatusInternalServerError},
+		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
+		DefaultStatus: http.StatusOK,
+	}, batHandler.handleGetRunDecisions)
+
+	huma.Register(group, huma.Operation{
+		OperationID:   "get-bat-application-decisions",
+		Method:        http.MethodGet,
+		Summary:       "Get Application Decisions",
+		Description:   "Returns why an application was accepted or rejected in every bat run, newest first",
+		Tags:          []string{"Bat"},
+		Path:          "/applications/{applicationId}/decisions",
+		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma, mw.Auth.RequireAdminHuma},
+		Errors:        []int{http.StatusUnauthorized, http.StatusInternalServerError},
+		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
+		DefaultStatus: http.StatusOK,
+	}, batHandler.handleGetApplicationDecisions)
+
+	huma.Register(group, huma.Operation{
+		OperationID:   "queue-calculate-admissions",
+		Method:        http.MethodPost,
+		Summary:       "Calculate Admissions",
+		Description:   "Creates a new bat run and queues an asynq task that calculates admissions for all applications under review. An optional seed makes the run reproduce a previous dry run.",
+		Tags:          []string{"Bat"},
+		Path:          "/runs",
+		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma, mw.Auth.RequireAdminHuma},
+		Errors:        []int{http.StatusUnauthorized, http.StatusConflict, http.StatusInternalServerError},
+		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
+		DefaultStatus: http.StatusAccepted,
+	}, batHandler.handleQueueCalculateAdmissions)
+
+	huma.Register(group, huma.Operation{
+		OperationID:   "delete-bat-run",
+		Method:        http.MethodDelete,
+		Summary:       "Delete Bat Run",
+		Description:   "Delete a bat run by id",
+		Tags:          []string{"Bat"},
+		Path:          "/runs/{runId}",
+		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma, mw.Auth.RequireAdminHuma},
+		Errors:        []int{http.StatusUnauthorized, http.StatusNotFound, http.StatusInternalServerError},
+		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
+		DefaultStatus: http.StatusNoContent,
+	}, batHandler.handleDeleteRun)
+
+	huma.Register(group, huma.Operation{
+		OperationID:   "replay-bat-run",
+		Method:        http.MethodPost,
+		Summary:       "Replay Bat Run",
+		Description:   "Recomputes a bat run from its recorded seed, config and inputs without changing anything, and reports whether the result matches the recorded decisions",
+		Tags:          []string{"Bat"},
+		Path:          "/runs/{runId}/replay",
+		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma, mw.Auth.RequireAdminHuma},
+		Errors:        []int{http.StatusUnauthorized, http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError},
+		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
+		DefaultStatus: http.StatusOK,
+	}, batHandler.handleReplayRun)
+
+	huma.Register(group, huma.Operation{
+		OperationID:   "dry-run-admissions",
+		Method:        http.MethodPost,
+		Summary:       "Dry Run Admissions",
+		Description:   "Computes a full admission result with per-bucket and per-team breakdowns for the active hackathon. No run is recorded and no application is changed.",
+		Tags:          []string{"Bat"},
+		Path:          "/dry-run",
+		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma, mw.Auth.RequireAdminHuma},
+		Errors:        []int{http.StatusUnauthorized, http.StatusBadRequest, http.StatusInternalServerError},
+		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
+		DefaultStatus: http.StatusOK,
+	}, batHandler.handleDryRun)
+
+	huma.Register(group, huma.Operation{
+		OperationID:   "get-bat-config",
+		Method:        http.MethodGet,
+		Summary:       "Get Bat Config",
+		Description:   "Returns the admission weights and quotas used by BAT for the active hackathon",
+		Tags:          []string{"Bat"},
+		Path:          "/config",
+		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma, mw.Auth.RequireAdminHuma},
+		Errors:        []int{http.StatusUnauthorized, http.StatusInternalServerError},
+		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
+		DefaultStatus: http.StatusOK,
+	}, batHandler.handleGetConfig)
+
+	huma.Register(group, huma.Operation{
+		OperationID:   "update-bat-config",
+		Method:        http.MethodPut,
+		Summary:       "Update Bat Config",
+		Description:   "Updates the admission weights and quotas used by BAT for the active hackathon. The quotas may not add up to more than the hackathon's max attendees.",
+		Tags:          []string{"Bat"},
+		Path:          "/config",
+		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma, mw.Auth.RequireAdminHuma},
+		Errors:        []int{http.StatusUnauthorized, http.StatusBadRequest, http.StatusInternalServerError},
+		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
+		DefaultStatus: http.StatusOK,
+	}, batHandler.handleUpdateConfig)
+}
+
+type handler struct {
+	batService *BatService
+	logger     zerolog.Logger
+}
+
+func NewHandler(batService *BatService, logger zerolog.Logger) *handler {
+	return &handler{
+		batService: batService,
+		logger:     logger.With().Str("handler", "BatHandler").Str("domain", "bat").Logger(),
+	}
+}
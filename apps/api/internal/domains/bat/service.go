@@ -1,266 +1,554 @@
 package bat
 
-// import (
-// 	"context"
-// 	"encoding/json"
-// 	"errors"
-
-// 	"github.com/google/uuid"
-// 	"github.com/hibiken/asynq"
-// 	"github.com/rs/zerolog"
-// 	"github.com/swamphacks/core/apps/api/internal/config"
-// 	"github.com/swamphacks/core/apps/api/internal/database"
-// 	"github.com/swamphacks/core/apps/api/internal/database/repository"
-// 	"github.com/swamphacks/core/apps/api/internal/database/sqlc"
-// 	"github.com/swamphacks/core/apps/api/internal/domains/email"
-// 	"github.com/swamphacks/core/apps/api/internal/tasks"
-// )
-
-// type BatService struct {
-// 	applicationRepo *repository.ApplicationRepository
-// 	hackathonRepo   *repository.HackathonRepository
-// 	userRepo        *repository.UserRepository
-// 	batRunsRepo     *repository.BatRunsRepository
-// 	emailService    *email.EmailService
-// 	txm             *database.TransactionManager
-// 	taskQueue       *asynq.Client
-// 	scheduler       *asynq.Scheduler
-// 	config          *config.Config
-// 	logger          zerolog.Logger
-// }
-
-// func NewBatService(
-// 	applicationRepo *repository.ApplicationRepository, hackathonRepo *repository.HackathonRepository, userRepo *repository.UserRepository,
-// 	batRunsRepo *repository.BatRunsRepository, emailService *email.EmailService, txm *database.TransactionManager,
-// 	taskQueue *asynq.Client, scheduler *asynq.Scheduler, config *config.Config, logger zerolog.Logger) *BatService {
-// 	return &BatService{
-// 		taskQueue:       taskQueue,
-// 		scheduler:       scheduler,
-// 		applicationRepo: applicationRepo,
-// 		hackathonRepo:   hackathonRepo,
-// 		userRepo:        userRepo,
-// 		batRunsRepo:     batRunsRepo,
-// 		emailService:    emailService,
-// 		txm:             txm,
-// 		config:          config,
-// 		logger:          logger.With().Str("service", "Bat Service").Logger(),
-// 	}
-// }
-
-// var (
-// 	ErrRunConflict    = errors.New("Run already exists for this event")
-// 	ErrFailedToAddRun = errors.New("Failed to add run")
-// )
-
-// func (s *BatService) AddRun(ctx context.Context) (*sqlc.BatRun, error) {
-
-// 	// TODO: don't hardcode the hackathonId
-// 	run, err := s.batRunsRepo.AddRun(ctx, "xii")
-// 	if err != nil && errors.Is(err, database.ErrDuplicateRun) {
-// 		s.logger.Err(err).Msg("Could not insert result as it already exists.")
-// 		return nil, ErrRunConflict
-// 	} else if err != nil {
-// 		s.logger.Err(err).Msg("An unknown error was caught!")
-// 		return nil, ErrFailedToAddRun
-// 	}
-
-// 	return run, nil
-// }
-
-// func (s *BatService) GetRuns(ctx context.Context) (*[]sqlc.BatRun, error) {
-// 	return s.batRunsRepo.GetRuns(ctx)
-// }
-
-// func (s *BatService) GetRunById(ctx context.Context, batId uuid.UUID) (sqlc.BatRun, error) {
-// 	return s.batRunsRepo.GetRunById(ctx, batId)
-// }
-
-// func (s *BatService) UpdateRunById(ctx context.Context, params sqlc.UpdateBatRunByIdParams) (*sqlc.BatRun, error) {
-// 	err := s.batRunsRepo.UpdateRunById(ctx, params)
-// 	if err != nil {
-// 		s.logger.Err(err).Msg("update run by id failed")
-// 		return nil, err
-// 	}
-
-// 	run, err := s.batRunsRepo.GetRunById(ctx, params.ID)
-
-// 	return &run, err
-// }
-
-// func (s *BatService) DeleteRunById(ctx context.Context, id uuid.UUID) error {
-// 	err := s.batRunsRepo.DeleteRunById(ctx, id)
-// 	if err != nil {
-// 		s.logger.Err(err).Msg("delete run by id failed")
-// 		return errors.New("Failed to delete run")
-// 	}
-
-// 	return err
-// }
-
-// func (s *BatService) QueueCalculateAdmissionsTask(ctx context.Context) (*asynq.TaskInfo, error) {
-// 	newRun, err := s.AddRun(ctx)
-// 	if err != nil {
-// 		return nil, ErrFailedToAddRun
-// 	}
-
-// 	task, err := tasks.NewTaskCalculateAdmissions(tasks.CalculateAdmissionsPayload{
-// 		BatRunID: newRun.ID,
-// 	})
-// 	if err != nil {
-// 		s.logger.Err(err).Msg("Failed to create CalculateAdmissions task")
-// 		return nil, err
-// 	}
-
-// 	info, err := s.taskQueue.Enqueue(task, asynq.Queue("bat"))
-// 	if err != nil {
-// 		s.logger.Err(err).Msg("Failed to queue CalculateAdmissions task")
-// 		return nil, err
-// 	}
-
-// 	return info, nil
-// }
-
-// func (s *BatService) CalculateAdmissions(ctx context.Context, batRunId uuid.UUID) error {
-// 	s.logger.Debug().Str("RunID", batRunId.String()).Msg("")
-
-// 	// check to make sure reviews are done, update state if true, return error if not
-// 	nonReviewedApplicantUUIDs, err := s.applicationRepo.GetNonReviewedApplications(ctx)
-// 	if err != nil {
-// 		return errors.New("Could not get determine if application reviews have finished.")
-// 	}
-
-// 	areReviewsComplete := len(nonReviewedApplicantUUIDs) == 0
-
-// 	if !areReviewsComplete {
-// 		return errors.New("Please make sure reviews are finished before calculating application decisions.")
-// 	}
-
-// 	engine, err := NewBatEngine(0.6, 0.4)
-// 	if err != nil {
-// 		return err
-// 	}
-
-// 	// Aggregate data necessary
-// 	applications, err := s.applicationRepo.ListAdmissionCandidates(ctx)
-// 	if err != nil || len(applications) == 0 {
-// 		return errors.New("Failed to retrieve applications")
-// 	}
-
-// 	admissionCandidates, err := s.mapToCandidates(engine, applications)
-// 	if err != nil {
-// 		return err
-// 	}
-
-// 	teams, idvs := engine.GroupCandidates(admissionCandidates)
-// 	acceptedTeamMembers, remainder := engine.AcceptTeams(teams)
-
-// 	idvs = append(idvs, remainder...)
-// 	acceptedIdvs, rejected := engine.AcceptIndividuals(idvs)
-
-// 	accepted := append(acceptedTeamMembers, acceptedIdvs...)
-
-// 	acceptedIDs := make([]uuid.UUID, 0, len(accepted))
-// 	rejectedIDs := make([]uuid.UUID, 0, len(rejected))
-
-// 	for _, applicant := range accepted {
-// 		acceptedIDs = append(acceptedIDs, applicant.UserID)
-// 	}
-// 	for _, applicant := range rejected {
-// 		rejectedIDs = append(rejectedIDs, applicant.UserID)
-// 	}
-
-// 	params := sqlc.UpdateBatRunByIdParams{
-// 		// TODO: add UF/other/early/late info?
-// 		AcceptedApplicantsDoUpdate: true,
-// 		RejectedApplicantsDoUpdate: true,
-// 		StatusDoUpdate:             true,
-// 		AcceptedApplicants:         acceptedIDs,
-// 		RejectedApplicants:         rejectedIDs,
-// 		Status:                     sqlc.BatRunStatusCompleted,
-// 		ID:                         batRunId,
-// 	}
-
-// 	err = s.batRunsRepo.UpdateRunById(ctx, params)
-// 	if err != nil {
-// 		return errors.New("Failed to update run")
-// 	}
-
-// 	s.logger.Info().Int("Teams Members Accepted", int(len(acceptedTeamMembers))).Int("Accepted", int(engine.Quota.TotalAccepted)).Int("Rejected", len(rejected)).Msg("Finished Algo")
-
-// 	return nil
-// }
-
-// // This could be moved into the engine instead, or some mapping function within the bat package.
-// func (s *BatService) mapToCandidates(engine *BatEngine, applications []sqlc.ListAdmissionCandidatesRow) ([]AdmissionCandidate, error) {
-// 	var appAdmissionsData []AdmissionCandidate
-// 	for _, app := range applications {
-// 		if app.ExperienceRating == nil || app.PassionRating == nil {
-// 			return []AdmissionCandidate{}, errors.New("Some applications are missing their review ratings")
-// 		}
-
-// 		var admissionContext AdmissionContext
-// 		if err := json.Unmarshal(app.Application, &admissionContext); err != nil {
-// 			s.logger.Debug().Bytes("App", app.Application).Msg("Application data")
-// 			return []AdmissionCandidate{}, err
-// 		}
-
-// 		var teamId uuid.UUID
-// 		if app.TeamID != nil {
-// 			teamId = *app.TeamID
-// 		}
-
-// 		wScore, err := engine.CalculateWeightedScore(*app.PassionRating, *app.ExperienceRating)
-// 		if err != nil {
-// 			return []AdmissionCandidate{}, err
-// 		}
-// 		appAdmissionsData = append(appAdmissionsData, AdmissionCandidate{
-// 			UserID: app.UserID,
-// 			TeamID: uuid.NullUUID{
-// 				UUID:  teamId,
-// 				Valid: app.TeamID != nil,
-// 			},
-// 			WeightedScore: wScore,
-// 			SortKey:       0.0,
-// 			IsUFStudent:   admissionContext.School == "University of Florida",
-// 			IsEarlyCareer: admissionContext.Year == "first_year" || admissionContext.Year == "second_year",
-// 		})
-// 	}
-
-// 	return appAdmissionsData, nil
-// }
-
-// func (s *BatService) QueueScheduleWaitlistTransitionTask(ctx context.Context) error {
-// 	task, err := tasks.NewTaskScheduleTransitionWaitlist(tasks.ScheduleTransitionWaitlistPayload{
-// 		Period: s.config.AcceptFromWaitlistPeriod,
-// 	})
-// 	if err != nil {
-// 		s.logger.Err(err).Msg("Failed to create ScheduleTransitionWaitlist task")
-// 		return err
-// 	}
-
-// 	_, err = s.taskQueue.Enqueue(task, asynq.Queue("bat"))
-// 	if err != nil {
-// 		s.logger.Err(err).Msg("Failed to queue ScheduleTransitionWaitlist task")
-// 		return err
-// 	}
-// 	s.logger.Info().Msg("Queued TransitionWaitlist task")
-
-// 	return nil
-// }
-
-// func (s *BatService) QueueShutdownWaitlistScheduler() error {
-// 	task, err := tasks.NewTaskShutdownScheduler()
-// 	if err != nil {
-// 		s.logger.Err(err).Msg("Failed to create ShutdownWaitlistScheduler task")
-// 		return err
-// 	}
-
-// 	_, err = s.taskQueue.Enqueue(task, asynq.Queue("bat"))
-// 	if err != nil {
-// 		s.logger.Err(err).Msg("Failed to queue ShutdownWaitlistScheduler task")
-// 		return err
-// 	}
-// 	s.logger.Info().Msg("Queued ShutdownWaitlistScheduler task")
-
-// 	return nil
-// }
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"math/rand"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5"
+	"github.com/rs/zerolog"
+	"github.com/swamphacks/core/apps/api/internal/calibration"
+	"github.com/swamphacks/core/apps/api/internal/config"
+	"github.com/swamphacks/core/apps/api/internal/database"
+	"github.com/swamphacks/core/apps/api/internal/database/sqlc"
+	"github.com/swamphacks/core/apps/api/internal/tasks"
+)
+
+type BatService struct {
+	db        *database.DB
+	txm       *database.TransactionManager
+	taskQueue *asynq.Client
+	config    *config.Config
+	logger    zerolog.Logger
+}
+
+func NewService(
+	db *database.DB, txm *database.TransactionManager, taskQueue *asynq.Client,
+	config *config.Config, logger zerolog.Logger,
+) *BatService {
+	return &BatService{
+		db:        db,
+		txm:       txm,
+		taskQueue: taskQueue,
+		config:    config,
+		logger:    logger.With().Str("service", "BatService").Str("domain", "bat").Logger(),
+	}
+}
+
+// AddRun creates a new run for the active hackathon. A random seed is picked
+// when none is given so that every run can be replayed later on.
+func (s *BatService) AddRun(ctx context.Context, seed *int64) (*sqlc.BatRun, error) {
+	hackathon, err := s.db.Query.GetHackathon(ctx)
+	if err != nil {
+		s.logger.Err(err).Msg("AddRun fail because can't retrieve hackathon")
+		return nil, ErrFailedToAddRun
+	}
+
+	if seed == nil {
+		generated := rand.Int63()
+		seed = &generated
+	}
+
+	run, err := s.db.Query.AddBatRun(ctx, sqlc.AddBatRunParams{
+		HackathonID: hackathon.ID,
+		Seed:        seed,
+	})
+	if err != nil {
+		if database.IsUniqueViolation(err) {
+			return nil, ErrRunConflict
+		}
+		s.logger.Err(err).Msg("AddRun fail")
+		return nil, ErrFailedToAddRun
+	}
+
+	return &run, nil
+}
+
+func (s *BatService) GetRuns(ctx context.Context) ([]sqlc.BatRun, error) {
+	runs, err := s.db.Query.GetBatRuns(ctx)
+	if err != nil {
+		s.logger.Err(err).Msg("GetRuns fail")
+		return nil, ErrGetRuns
+	}
+
+	return runs, nil
+}
+
+func (s *BatService) GetRunById(ctx context.Context, runID uuid.UUID) (*sqlc.BatRun, error) {
+	run, err := s.db.Query.GetBatRunById(ctx, runID)
+	if err != nil {
+		if database.IsNotFound(err) {
+			return nil, database.ErrRunNotFound
+		}
+		s.logger.Err(err).Msg("GetRunById fail")
+		return nil, ErrGetRuns
+	}
+
+	return &run, nil
+}
+
+func (s *BatService) UpdateRunById(ctx context.Context, params sqlc.UpdateBatRunByIdParams) (*sqlc.BatRun, error) {
+	if err := s.db.Query.UpdateBatRunById(ctx, params); err != nil {
+		s.logger.Err(err).Msg("UpdateRunById fail")
+		return nil, err
+	}
+
+	return s.GetRunById(ctx, params.ID)
+}
+
+func (s *BatService) DeleteRunById(ctx context.Context, runID uuid.UUID) error {
+	affected, err := s.db.Query.DeleteBatRunById(ctx, runID)
+	if err != nil {
+		s.logger.Err(err).Msg("DeleteRunById fail")
+		return ErrDeleteRun
+	}
+
+	switch {
+	case affected == 0:
+		return database.ErrRunNotFound
+	case affected > 1:
+		// Should never happen since id is the primary key.
+		return database.ErrMultipleRunsDeleted
+	}
+
+	return nil
+}
+
+// MarkRunFailed flags a run as failed so that it is not mistaken for one that is still running.
+func (s *BatService) MarkRunFailed(ctx context.Context, runID uuid.UUID) error {
+	now := time.Now()
+
+	return s.db.Query.UpdateBatRunById(ctx, sqlc.UpdateBatRunByIdParams{
+		ID:                  runID,
+		StatusDoUpdate:      true,
+		Status:              sqlc.BatRunStatusFailed,
+		CompletedAtDoUpdate: true,
+		CompletedAt:         &now,
+	})
+}
+
+// QueueCalculateAdmissionsTask creates a new run and hands it off to the BAT worker.
+func (s *BatService) QueueCalculateAdmissionsTask(ctx context.Context, seed *int64) (*sqlc.BatRun, error) {
+	run, err := s.AddRun(ctx, seed)
+	if err != nil {
+		return nil, err
+	}
+
+	task, err := tasks.NewTaskCalculateAdmissions(tasks.CalculateAdmissionsPayload{
+		BatRunID: run.ID,
+	})
+	if err != nil {
+		s.logger.Err(err).Msg("Failed to create CalculateAdmissions task")
+		_ = s.MarkRunFailed(ctx, run.ID)
+		return nil, ErrQueueCalculateAdmissions
+	}
+
+	info, err := s.taskQueue.Enqueue(task, asynq.Queue("bat"))
+	if err != nil {
+		s.logger.Err(err).Msg("Failed to queue CalculateAdmissions task")
+		_ = s.MarkRunFailed(ctx, run.ID)
+		return nil, ErrQueueCalculateAdmissions
+	}
+
+	s.logger.Info().Str("RunID", run.ID.String()).Str("TaskID", info.ID).Msg("Queued CalculateAdmissions task")
+
+	return run, nil
+}
+
+// CalculateAdmissions runs the BAT engine over every application that is under review
+// for the run's hackathon and records the accepted and rejected applicants on the run.
+func (s *BatService) CalculateAdmissions(ctx context.Context, runID uuid.UUID) error {
+	run, err := s.GetRunById(ctx, runID)
+	if err != nil {
+		return err
+	}
+
+	if run.Status != sqlc.BatRunStatusRunning {
+		return ErrRunNotRunning
+	}
+
+	if run.Seed == nil {
+		return ErrRunMissingSeed
+	}
+
+	engineConfig, err := s.getEngineConfig(ctx, run.HackathonID)
+	if err != nil {
+		return err
+	}
+
+	engine, candidates, err := s.prepareEngine(ctx, run.HackathonID, *engineConfig, *run.Seed)
+	if err != nil {
+		return err
+	}
+
+	// Record the inputs before running so that even a failed run can be inspected.
+	configSnapshot, err := json.Marshal(engineConfig)
+	if err != nil {
+		return err
+	}
+
+	inputSnapshot, err := json.Marshal(candidates)
+	if err != nil {
+		return err
+	}
+
+	if err := s.db.Query.UpdateBatRunById(ctx, sqlc.UpdateBatRunByIdParams{
+		ID:                    runID,
+		ConfigDoUpdate:        true,
+		Config:                configSnapshot,
+		InputSnapshotDoUpdate: true,
+		InputSnapshot:         inputSnapshot,
+	}); err != nil {
+		s.logger.Err(err).Msg("Failed to record run inputs")
+		return ErrUpdateRun
+	}
+
+	result := engine.Run(candidates)
+	accepted, rejected := result.Accepted, result.Rejected
+
+	acceptedIDs := make([]uuid.UUID, 0, len(accepted))
+	rejectedIDs := make([]uuid.UUID, 0, len(rejected))
+
+	for _, applicant := range accepted {
+		acceptedIDs = append(acceptedIDs, applicant.UserID)
+	}
+	for _, applicant := range rejected {
+		rejectedIDs = append(rejectedIDs, applicant.UserID)
+	}
+
+	now := time.Now()
+
+	// Decisions and results are stored together so a completed run always has
+	// an explanation for every candidate.
+	err = s.txm.WithTx(ctx, func(tx pgx.Tx) error {
+		txDB := s.db.NewTX(tx)
+
+		for _, decision := range result.Decisions {
+			params, err := toCreateBatRunDecisionParams(runID, decision)
+			if err != nil {
+				return err
+			}
+
+			if err := txDB.Query.CreateBatRunDecision(ctx, params); err != nil {
+				s.logger.Err(err).Str("ApplicationID", decision.Candidate.ApplicationID.String()).Msg("Failed to record admission decision")
+				return ErrRecordDecisions
+			}
+		}
+
+		if err := txDB.Query.UpdateBatRunById(ctx, sqlc.UpdateBatRunByIdParams{
+			ID:                         runID,
+			AcceptedApplicantsDoUpdate: true,
+			AcceptedApplicants:         acceptedIDs,
+			RejectedApplicantsDoUpdate: true,
+			RejectedApplicants:         rejectedIDs,
+			StatusDoUpdate:             true,
+			Status:                     sqlc.BatRunStatusCompleted,
+			CompletedAtDoUpdate:        true,
+			CompletedAt:                &now,
+		}); err != nil {
+			s.logger.Err(err).Msg("Failed to record run results")
+			return ErrUpdateRun
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	s.logger.Info().
+		Str("RunID", runID.String()).
+		Int("Accepted", len(accepted)).
+		Int("Rejected", len(rejected)).
+		Msg("Finished calculating admissions")
+
+	return nil
+}
+
+// DryRun computes a full admission result for the active hackathon with its current
+// config, without recording a run or touching any application.
+func (s *BatService) DryRun(ctx context.Context, seed *int64) (*AdmissionBreakdownDto, error) {
+	hackathon, err := s.db.Query.GetHackathon(ctx)
+	if err != nil {
+		s.logger.Err(err).Msg("DryRun fail because can't retrieve hackathon")
+		return nil, ErrDryRun
+	}
+
+	if seed == nil {
+		generated := rand.Int63()
+		seed = &generated
+	}
+
+	engineConfig, err := s.getEngineConfig(ctx, hackathon.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	engine, candidates, err := s.prepareEngine(ctx, hackathon.ID, *engineConfig, *seed)
+	if err != nil {
+		return nil, err
+	}
+
+	result := engine.Run(candidates)
+	breakdown := buildAdmissionBreakdown(*engineConfig, *seed, candidates, result)
+
+	return &breakdown, nil
+}
+
+// ReplayRun recomputes a completed run from its stored seed, config and input snapshot.
+// Nothing is written; the result tells whether the replay matches the recorded decisions.
+func (s *BatService) ReplayRun(ctx context.Context, runID uuid.UUID) (*ReplayRunDto, error) {
+	run, err := s.GetRunById(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+
+	if run.Seed == nil || run.Config == nil || run.InputSnapshot == nil {
+		return nil, ErrRunNotReplayable
+	}
+
+	var engineConfig BatEngineConfig
+	if err := json.Unmarshal(run.Config, &engineConfig); err != nil {
+		s.logger.Err(err).Str("RunID", runID.String()).Msg("Failed to parse run config")
+		return nil, ErrRunNotReplayable
+	}
+
+	var candidates []AdmissionCandidate
+	if err := json.Unmarshal(run.InputSnapshot, &candidates); err != nil {
+		s.logger.Err(err).Str("RunID", runID.String()).Msg("Failed to parse run input snapshot")
+		return nil, ErrRunNotReplayable
+	}
+
+	engine, err := NewBatEngine(engineConfig, *run.Seed)
+	if err != nil {
+		return nil, err
+	}
+
+	result := engine.Run(candidates)
+	breakdown := buildAdmissionBreakdown(engineConfig, *run.Seed, candidates, result)
+
+	return &ReplayRunDto{
+		AdmissionBreakdownDto: breakdown,
+		MatchesRun: run.Status == sqlc.BatRunStatusCompleted &&
+			sameApplicants(breakdown.AcceptedApplicants, run.AcceptedApplicants) &&
+			sameApplicants(breakdown.RejectedApplicants, run.RejectedApplicants),
+	}, nil
+}
+
+// GetRunDecisions returns the explanation of every candidate considered in a run.
+func (s *BatService) GetRunDecisions(ctx context.Context, runID uuid.UUID) ([]sqlc.BatRunDecision, error) {
+	if _, err := s.GetRunById(ctx, runID); err != nil {
+		return nil, err
+	}
+
+	decisions, err := s.db.Query.ListBatRunDecisionsByRunId(ctx, runID)
+	if err != nil {
+		s.logger.Err(err).Str("RunID", runID.String()).Msg("Failed to list run decisions")
+		return nil, ErrGetDecisions
+	}
+
+	return decisions, nil
+}
+
+// GetApplicationDecisions returns how an application was decided in every run, newest first.
+func (s *BatService) GetApplicationDecisions(ctx context.Context, applicationID uuid.UUID) ([]sqlc.BatRunDecision, error) {
+	decisions, err := s.db.Query.ListBatRunDecisionsByApplicationId(ctx, applicationID)
+	if err != nil {
+		s.logger.Err(err).Str("ApplicationID", applicationID.String()).Msg("Failed to list application decisions")
+		return nil, ErrGetDecisions
+	}
+
+	return decisions, nil
+}
+
+// prepareEngine builds a seeded engine and the candidates it should decide on.
+func (s *BatService) prepareEngine(ctx context.Context, hackathonID string, engineConfig BatEngineConfig, seed int64) (*BatEngine, []AdmissionCandidate, error) {
+	applications, err := s.db.Query.ListAdmissionCandidates(ctx, hackathonID)
+	if err != nil {
+		s.logger.Err(err).Msg("Failed to list admission candidates")
+		return nil, nil, ErrGetAdmissionCandidates
+	}
+
+	if len(applications) == 0 {
+		return nil, nil, ErrNoAdmissionCandidates
+	}
+
+	engine, err := NewBatEngine(engineConfig, seed)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ratings, err := s.normalizedRatings(ctx, hackathonID, engineConfig.ScoreNormalization)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	candidates, err := s.mapToCandidates(engine, applications, ratings)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return engine, candidates, nil
+}
+
+// normalizedRatings returns the average calibrated ratings of every reviewed application
+// of the hackathon, or nil when the raw ratings should be used.
+func (s *BatService) normalizedRatings(ctx context.Context, hackathonID string, method calibration.Method) (map[uuid.UUID]calibration.Averages, error) {
+	if method == "" || method == calibration.MethodNone {
+		return nil, nil
+	}
+
+	reviews, err := s.db.Query.ListCompletedReviews(ctx, &hackathonID)
+	if err != nil {
+		s.logger.Err(err).Msg("Failed to list completed reviews")
+		return nil, ErrGetAdmissionCandidates
+	}
+
+	ratings := make([]calibration.Rating, len(reviews))
+	for i, review := range reviews {
+		ratings[i] = calibration.Rating{
+			ApplicationID: review.ApplicationID,
+			ReviewerID:    review.ReviewerID,
+			Passion:       float64(review.PassionRating),
+			Experience:    float64(review.ExperienceRating),
+		}
+	}
+
+	return calibration.AverageByApplication(calibration.Normalize(ratings, method)), nil
+}
+
+// GetConfig returns the engine configuration of the active hackathon.
+func (s *BatService) GetConfig(ctx context.Context) (*BatEngineConfig, error) {
+	hackathon, err := s.db.Query.GetHackathon(ctx)
+	if err != nil {
+		s.logger.Err(err).Msg("GetConfig fail because can't retrieve hackathon")
+		return nil, ErrGetConfig
+	}
+
+	return s.getEngineConfig(ctx, hackathon.ID)
+}
+
+// UpdateConfig validates and stores the engine configuration of the active hackathon.
+// The bucket quotas may not add up to more than the hackathon's max attendees.
+func (s *BatService) UpdateConfig(ctx context.Context, engineConfig BatEngineConfig) (*BatEngineConfig, error) {
+	if err := engineConfig.Validate(); err != nil {
+		return nil, err
+	}
+
+	hackathon, err := s.db.Query.GetHackathon(ctx)
+	if err != nil {
+		s.logger.Err(err).Msg("UpdateConfig fail because can't retrieve hackathon")
+		return nil, ErrUpdateConfig
+	}
+
+	if hackathon.MaxAttendees != nil && engineConfig.TotalQuota() > *hackathon.MaxAttendees {
+		return nil, ErrQuotaExceedsMaxAttendees
+	}
+
+	buckets, err := json.Marshal(engineConfig.Buckets)
+	if err != nil {
+		s.logger.Err(err).Msg("UpdateConfig fail because buckets could not be marshalled")
+		return nil, ErrUpdateConfig
+	}
+
+	row, err := s.db.Query.UpsertBatConfig(ctx, sqlc.UpsertBatConfigParams{
+		HackathonID:          hackathon.ID,
+		PassionWeight:        engineConfig.PassionWeight,
+		ExperienceWeight:     engineConfig.ExperienceWeight,
+		WeightedBaseConstant: engineConfig.WeightedBaseConstant,
+		TeamSlots:            engineConfig.TeamSlots,
+		Buckets:              buckets,
+		ScoreNormalization:   string(cmp.Or(engineConfig.ScoreNormalization, calibration.MethodNone)),
+	})
+	if err != nil {
+		s.logger.Err(err).Msg("UpdateConfig fail")
+		return nil, ErrUpdateConfig
+	}
+
+	return s.toEngineConfig(row)
+}
+
+// getEngineConfig loads the stored engine configuration of a hackathon, falling back
+// to the defaults when none has been saved yet.
+func (s *BatService) getEngineConfig(ctx context.Context, hackathonID string) (*BatEngineConfig, error) {
+	row, err := s.db.Query.GetBatConfigByHackathonId(ctx, hackathonID)
+	if err != nil {
+		if database.IsNotFound(err) {
+			engineConfig := DefaultBatEngineConfig()
+			return &engineConfig, nil
+		}
+		s.logger.Err(err).Msg("Failed to get bat config")
+		return nil, ErrGetConfig
+	}
+
+	return s.toEngineConfig(row)
+}
+
+func (s *BatService) toEngineConfig(row sqlc.BatConfig) (*BatEngineConfig, error) {
+	var buckets []BucketRule
+	if err := json.Unmarshal(row.Buckets, &buckets); err != nil {
+		s.logger.Err(err).Str("HackathonID", row.HackathonID).Msg("Failed to parse stored bucket rules")
+		return nil, ErrGetConfig
+	}
+
+	return &BatEngineConfig{
+		PassionWeight:        row.PassionWeight,
+		ExperienceWeight:     row.ExperienceWeight,
+		WeightedBaseConstant: row.WeightedBaseConstant,
+		TeamSlots:            row.TeamSlots,
+		Buckets:              buckets,
+		ScoreNormalization:   calibration.Method(row.ScoreNormalization),
+	}, nil
+}
+
+// mapToCandidates turns the aggregated review data of each application into an AdmissionCandidate.
+// Every application must have at least one completed review. When normalized ratings are given
+// they replace the raw averages.
+func (s *BatService) mapToCandidates(engine *BatEngine, applications []sqlc.ListAdmissionCandidatesRow, normalized map[uuid.UUID]calibration.Averages) ([]AdmissionCandidate, error) {
+	candidates := make([]AdmissionCandidate, 0, len(applications))
+
+	for _, app := range applications {
+		if app.RatedReviewCount == 0 {
+			s.logger.Warn().Str("ApplicationID", app.ID.String()).Msg("Application is missing review ratings")
+			return nil, ErrMissingReviewRatings
+		}
+
+		var applicationData map[string]any
+		if err := json.Unmarshal(app.Application, &applicationData); err != nil {
+			s.logger.Err(err).Str("ApplicationID", app.ID.String()).Msg("Failed to parse application data")
+			return nil, err
+		}
+
+		passion, experience := app.PassionRating, app.ExperienceRating
+		if averages, ok := normalized[app.ID]; ok {
+			passion, experience = averages.Passion, averages.Experience
+		}
+
+		wScore, err := engine.CalculateWeightedScore(passion, experience)
+		if err != nil {
+			return nil, err
+		}
+
+		// Candidates that match no bucket are kept so they show up as rejected.
+		bucket, _ := engine.DetermineBucket(applicationData)
+
+		var teamID uuid.NullUUID
+		if app.TeamID != nil {
+			teamID = uuid.NullUUID{UUID: *app.TeamID, Valid: true}
+		}
+
+		candidates = append(candidates, AdmissionCandidate{
+			ApplicationID: app.ID,
+			UserID:        app.UserID,
+			TeamID:        teamID,
+			WeightedScore: wScore,
+			Bucket:        bucket,
+		})
+	}
+
+	return candidates, nil
+}
@@ -0,0 +1,313 @@
+package bat
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/swamphacks/core/apps/api/internal/calibration"
+	"github.com/swamphacks/core/apps/api/internal/database/sqlc"
+)
+
+var (
+	ErrRunConflict              = errors.New("run already exists for this event")
+	ErrFailedToAddRun           = errors.New("failed to add run")
+	ErrGetRuns                  = errors.New("unable to get runs")
+	ErrDeleteRun                = errors.New("unable to delete run")
+	ErrUpdateRun                = errors.New("unable to update run")
+	ErrRunNotRunning            = errors.New("run is no longer running")
+	ErrQueueCalculateAdmissions = errors.New("unable to queue admissions calculation")
+	ErrGetAdmissionCandidates   = errors.New("unable to get admission candidates")
+	ErrNoAdmissionCandidates    = errors.New("there are no applications under review")
+	ErrMissingReviewRatings     = errors.New("some applications are missing their review ratings")
+	ErrGetConfig                = errors.New("unable to get bat config")
+	ErrUpdateConfig             = errors.New("unable to update bat config")
+	ErrQuotaExceedsMaxAttendees = errors.New("quotas add up to more than the hackathon's max attendees")
+	ErrRunMissingSeed           = errors.New("run has no seed")
+	ErrRunNotReplayable         = errors.New("run has no recorded inputs to replay")
+	ErrDryRun                   = errors.New("unable to dry run admissions")
+	ErrRecordDecisions          = errors.New("unable to record admission decisions")
+	ErrGetDecisions             = errors.New("unable to get admission decisions")
+)
+
+type BatRunDto struct {
+	ID                 uuid.UUID         `json:"id"`
+	HackathonID        string            `json:"hackathonId"`
+	Status             sqlc.BatRunStatus `json:"status"`
+	AcceptedApplicants []uuid.UUID       `json:"acceptedApplicants" nullable:"false"`
+	RejectedApplicants []uuid.UUID       `json:"rejectedApplicants" nullable:"false"`
+	Seed               *int64            `json:"seed"`
+	Config             *BatConfigDto     `json:"config"`
+	CreatedAt          time.Time         `json:"createdAt"`
+	CompletedAt        *time.Time        `json:"completedAt"`
+}
+
+func toBatRunDto(run sqlc.BatRun) BatRunDto {
+	accepted := run.AcceptedApplicants
+	if accepted == nil {
+		accepted = []uuid.UUID{}
+	}
+
+	rejected := run.RejectedApplicants
+	if rejected == nil {
+		rejected = []uuid.UUID{}
+	}
+
+	// Runs created before configs were recorded have no config to show.
+	var runConfig *BatConfigDto
+	var engineConfig BatEngineConfig
+	if run.Config != nil && json.Unmarshal(run.Config, &engineConfig) == nil {
+		dto := toBatConfigDto(engineConfig)
+		runConfig = &dto
+	}
+
+	return BatRunDto{
+		ID:                 run.ID,
+		HackathonID:        run.HackathonID,
+		Status:             run.Status,
+		AcceptedApplicants: accepted,
+		RejectedApplicants: rejected,
+		Seed:               run.Seed,
+		Config:             runConfig,
+		CreatedAt:          run.CreatedAt,
+		CompletedAt:        run.CompletedAt,
+	}
+}
+
+type BatConfigDto struct {
+	PassionWeight        float64      `json:"passionWeight" minimum:"0" maximum:"1"`
+	ExperienceWeight     float64      `json:"experienceWeight" minimum:"0" maximum:"1"`
+	WeightedBaseConstant float64      `json:"weightedBaseConstant" exclusiveMinimum:"0"`
+	TeamSlots            int32        `json:"teamSlots" minimum:"0"`
+	Buckets              []BucketRule `json:"buckets" minItems:"1" nullable:"false"`
+	ScoreNormalization   string       `json:"scoreNormalization" enum:"none,zscore,percentile" required:"false"`
+}
+
+func toBatConfigDto(cfg BatEngineConfig) BatConfigDto {
+	return BatConfigDto{
+		PassionWeight:        cfg.PassionWeight,
+		ExperienceWeight:     cfg.ExperienceWeight,
+		WeightedBaseConstant: cfg.WeightedBaseConstant,
+		TeamSlots:            cfg.TeamSlots,
+		Buckets:              cfg.Buckets,
+		ScoreNormalization:   string(cmp.Or(cfg.ScoreNormalization, calibration.MethodNone)),
+	}
+}
+
+func (d BatConfigDto) toEngineConfig() BatEngineConfig {
+	return BatEngineConfig{
+		PassionWeight:        d.PassionWeight,
+		ExperienceWeight:     d.ExperienceWeight,
+		WeightedBaseConstant: d.WeightedBaseConstant,
+		TeamSlots:            d.TeamSlots,
+		Buckets:              d.Buckets,
+		ScoreNormalization:   cmp.Or(calibration.Method(d.ScoreNormalization), calibration.MethodNone),
+	}
+}
+
+type BucketBreakdownDto struct {
+	Name       string `json:"name"`
+	Quota      int32  `json:"quota"`
+	Candidates int    `json:"candidates"`
+	Accepted   int    `json:"accepted"`
+	Rejected   int    `json:"rejected"`
+	SlotsLeft  int32  `json:"slotsLeft"`
+}
+
+type TeamBreakdownDto struct {
+	TeamID               uuid.UUID `json:"teamId"`
+	Members              int       `json:"members"`
+	AverageWeightedScore float64   `json:"averageWeightedScore"`
+	SortKey              float64   `json:"sortKey"`
+	AcceptedAsTeam       bool      `json:"acceptedAsTeam"`
+	AcceptedMembers      int       `json:"acceptedMembers"`
+}
+
+type AdmissionBreakdownDto struct {
+	Seed               int64                `json:"seed"`
+	Config             BatConfigDto         `json:"config"`
+	TotalCandidates    int                  `json:"totalCandidates"`
+	AcceptedCount      int                  `json:"acceptedCount"`
+	RejectedCount      int                  `json:"rejectedCount"`
+	UnbucketedCount    int                  `json:"unbucketedCount"`
+	AcceptedApplicants []uuid.UUID          `json:"acceptedApplicants" nullable:"false"`
+	RejectedApplicants []uuid.UUID          `json:"rejectedApplicants" nullable:"false"`
+	Buckets            []BucketBreakdownDto `json:"buckets" nullable:"false"`
+	Teams              []TeamBreakdownDto   `json:"teams" nullable:"false"`
+}
+
+type ReplayRunDto struct {
+	AdmissionBreakdownDto
+	MatchesRun bool `json:"matchesRun"`
+}
+
+type RunAdmissionsRequestDto struct {
+	Seed *int64 `json:"seed,omitempty" required:"false" doc:"Seed for the random draw. A random one is picked when omitted."`
+}
+
+func buildAdmissionBreakdown(cfg BatEngineConfig, seed int64, candidates []AdmissionCandidate, result AdmissionResult) AdmissionBreakdownDto {
+	breakdown := AdmissionBreakdownDto{
+		Seed:               seed,
+		Config:             toBatConfigDto(cfg),
+		TotalCandidates:    len(candidates),
+		AcceptedCount:      len(result.Accepted),
+		RejectedCount:      len(result.Rejected),
+		AcceptedApplicants: make([]uuid.UUID, 0, len(result.Accepted)),
+		RejectedApplicants: make([]uuid.UUID, 0, len(result.Rejected)),
+		Buckets:            make([]BucketBreakdownDto, 0, len(cfg.Buckets)),
+		Teams:              make([]TeamBreakdownDto, 0, len(result.Teams)),
+	}
+
+	acceptedByBucket := make(map[string]int)
+	acceptedUsers := make(map[uuid.UUID]bool, len(result.Accepted))
+	for _, candidate := range result.Accepted {
+		breakdown.AcceptedApplicants = append(breakdown.AcceptedApplicants, candidate.UserID)
+		acceptedByBucket[candidate.Bucket]++
+		acceptedUsers[candidate.UserID] = true
+	}
+
+	rejectedByBucket := make(map[string]int)
+	for _, candidate := range result.Rejected {
+		breakdown.RejectedApplicants = append(breakdown.RejectedApplicants, candidate.UserID)
+		rejectedByBucket[candidate.Bucket]++
+	}
+
+	candidatesByBucket := make(map[string]int)
+	for _, candidate := range candidates {
+		candidatesByBucket[candidate.Bucket]++
+	}
+	breakdown.UnbucketedCount = candidatesByBucket[""]
+
+	for _, bucket := range cfg.Buckets {
+		breakdown.Buckets = append(breakdown.Buckets, BucketBreakdownDto{
+			Name:       bucket.Name,
+			Quota:      bucket.Quota,
+			Candidates: candidatesByBucket[bucket.Name],
+			Accepted:   acceptedByBucket[bucket.Name],
+			Rejected:   rejectedByBucket[bucket.Name],
+			SlotsLeft:  result.Quota.Buckets[bucket.Name],
+		})
+	}
+
+	for _, team := range result.Teams {
+		acceptedMembers := 0
+		for _, member := range team.Members {
+			if acceptedUsers[member.UserID] {
+				acceptedMembers++
+			}
+		}
+
+		breakdown.Teams = append(breakdown.Teams, TeamBreakdownDto{
+			TeamID:               team.TeamID,
+			Members:              len(team.Members),
+			AverageWeightedScore: team.AverageWeightedScore,
+			SortKey:              team.SortKey,
+			AcceptedAsTeam:       team.Accepted,
+			AcceptedMembers:      acceptedMembers,
+		})
+	}
+
+	return breakdown
+}
+
+// sameApplicants reports whether both lists hold the same applicants, regardless of order.
+func sameApplicants(a, b []uuid.UUID) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	sortedA := slices.Clone(a)
+	sortedB := slices.Clone(b)
+	slices.SortFunc(sortedA, compareUUIDs)
+	slices.SortFunc(sortedB, compareUUIDs)
+
+	return slices.Equal(sortedA, sortedB)
+}
+
+func compareUUIDs(a, b uuid.UUID) int {
+	return strings.Compare(a.String(), b.String())
+}
+
+type BatRunDecisionDto struct {
+	ID            uuid.UUID      `json:"id"`
+	RunID         uuid.UUID      `json:"runId"`
+	ApplicationID uuid.UUID      `json:"applicationId"`
+	UserID        uuid.UUID      `json:"userId"`
+	TeamID        *uuid.UUID     `json:"teamId"`
+	Bucket        *string        `json:"bucket"`
+	WeightedScore float64        `json:"weightedScore"`
+	SortKey       float64        `json:"sortKey"`
+	Reason        DecisionReason `json:"reason" enum:"accepted_team,accepted_individual,bucket_full,team_not_fit,no_bucket"`
+	Accepted      bool           `json:"accepted"`
+	Quota         QuotaState     `json:"quota" doc:"Remaining quotas right before the decision was made"`
+	CreatedAt     time.Time      `json:"createdAt"`
+}
+
+func toBatRunDecisionDto(decision sqlc.BatRunDecision) BatRunDecisionDto {
+	var quota QuotaState
+	if err := json.Unmarshal(decision.QuotaState, &quota); err != nil {
+		// The snapshot is written by us, so this only happens if the row was edited by hand.
+		quota = QuotaState{}
+	}
+
+	reason := DecisionReason(decision.Reason)
+
+	return BatRunDecisionDto{
+		ID:            decision.ID,
+		RunID:         decision.RunID,
+		ApplicationID: decision.ApplicationID,
+		UserID:        decision.UserID,
+		TeamID:        decision.TeamID,
+		Bucket:        decision.Bucket,
+		WeightedScore: decision.WeightedScore,
+		SortKey:       decision.SortKey,
+		Reason:        reason,
+		Accepted:      AdmissionDecision{Reason: reason}.Accepted(),
+		Quota:         quota,
+		CreatedAt:     decision.CreatedAt,
+	}
+}
+
+func toBatRunDecisionDtos(decisions []sqlc.BatRunDecision) []BatRunDecisionDto {
+	dtos := make([]BatRunDecisionDto, 0, len(decisions))
+	for _, decision := range decisions {
+		dtos = append(dtos, toBatRunDecisionDto(decision))
+	}
+
+	return dtos
+}
+
+func toCreateBatRunDecisionParams(runID uuid.UUID, decision AdmissionDecision) (sqlc.CreateBatRunDecisionParams, error) {
+	quota, err := json.Marshal(decision.Quota)
+	if err != nil {
+		return sqlc.CreateBatRunDecisionParams{}, err
+	}
+
+	candidate := decision.Candidate
+
+	var teamID *uuid.UUID
+	if candidate.TeamID.Valid {
+		teamID = &candidate.TeamID.UUID
+	}
+
+	var bucket *string
+	if candidate.Bucket != "" {
+		bucket = &candidate.Bucket
+	}
+
+	return sqlc.CreateBatRunDecisionParams{
+		RunID:         runID,
+		ApplicationID: candidate.ApplicationID,
+		UserID:        candidate.UserID,
+		TeamID:        teamID,
+		Bucket:        bucket,
+		WeightedScore: candidate.WeightedScore,
+		SortKey:       decision.SortKey,
+		Reason:        sqlc.BatDecisionReason(decision.Reason),
+		QuotaState:    quota,
+	}, nil
+}
@@ -0,0 +1,94 @@
+package decisions
+
+import (
+	"context"
+	"errors"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/google/uuid"
+	"github.com/swamphacks/core/apps/api/internal/ctxutils"
+	"github.com/swamphacks/core/apps/api/internal/database"
+)
+
+type GetReleasesOutput struct {
+	Body []DecisionReleaseDto `nullable:"false"`
+}
+
+func (h *handler) handleGetReleases(ctx context.Context, input *struct{}) (*GetReleasesOutput, error) {
+	releases, err := h.decisionService.GetReleases(ctx)
+	if err != nil {
+		return nil, huma.Error500InternalServerError("Failed to get decision releases")
+	}
+
+	releaseDtos := make([]DecisionReleaseDto, len(releases))
+	for i, release := range releases {
+		releaseDtos[i] = toDecisionReleaseDto(release)
+	}
+
+	return &GetReleasesOutput{Body: releaseDtos}, nil
+}
+
+type ReleaseOutput struct {
+	Body DecisionReleaseDto
+}
+
+func (h *handler) handleGetRelease(ctx context.Context, input *struct {
+	ReleaseID uuid.UUID `path:"releaseId"`
+}) (*ReleaseOutput, error) {
+	release, err := h.decisionService.GetReleaseById(ctx, input.ReleaseID)
+	if err != nil {
+		if errors.Is(err, ErrReleaseNotFound) {
+			return nil, huma.Error404NotFound("Release not found")
+		}
+		return nil, huma.Error500InternalServerError("Failed to get decision release")
+	}
+
+	return &ReleaseOutput{Body: toDecisionReleaseDto(*release)}, nil
+}
+
+func (h *handler) handleCreateRelease(ctx context.Context, input *struct {
+	Body CreateReleaseRequestDto
+}) (*ReleaseOutput, error) {
+	userCtx := ctxutils.GetUserFromCtx(ctx)
+
+	if userCtx == nil {
+		return nil, huma.Error400BadRequest("Failed to get current user info")
+	}
+
+	release, err := h.decisionService.CreateRelease(ctx, input.Body, userCtx.UserID)
+	if err != nil {
+		switch {
+		case errors.Is(err, database.ErrRunNotFound):
+			return nil, huma.Error404NotFound("Run not found")
+		case errors.Is(err, ErrReleaseConflict):
+			return nil, huma.Error409Conflict(err.Error())
+		case errors.Is(err, ErrRunMismatch),
+			errors.Is(err, ErrRunNotCompleted),
+			errors.Is(err, ErrNoDecisionReleaseTime),
+			errors.Is(err, ErrDecisionReleasePassed),
+			errors.Is(err, ErrNegativeWaitlistCount),
+			errors.Is(err, ErrWaitlistCountTooLarge):
+			return nil, huma.Error400BadRequest(err.Error())
+		}
+		return nil, huma.Error500InternalServerError(err.Error())
+	}
+
+	return &ReleaseOutput{Body: toDecisionReleaseDto(*release)}, nil
+}
+
+func (h *handler) handleRollbackRelease(ctx context.Context, input *struct {
+	ReleaseID uuid.UUID `path:"releaseId"`
+}) (*ReleaseOutput, error) {
+	release, err := h.decisionService.RollbackRelease(ctx, input.ReleaseID)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrReleaseNotFound):
+			return nil, huma.Error404NotFound("Release not found")
+		case errors.Is(err, ErrReleaseNotRollbackable):
+			return nil, huma.Error409Conflict(err.Error())
+		}
+		return nil, huma.Error500InternalServerError("Failed to roll back decision release")
+	}
+
+	return &ReleaseOutput{Body: toDecisionReleaseDto(*release)}, nil
+}
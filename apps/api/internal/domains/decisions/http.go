@@ -0,0 +1,76 @@
+package decisions
+
+import (
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/rs/zerolog"
+	"github.com/swamphacks/core/apps/api/internal/api/cookie"
+	"github.com/swamphacks/core/apps/api/internal/api/middleware"
+)
+
+func RegisterRoutes(decisionHandler *handler, group huma.API, mw *middleware.Middleware) {
+	huma.Register(group, huma.Operation{
+		OperationID:   "get-decision-releases",
+		Method:        http.MethodGet,
+		Summary:       "Get Decision Releases",
+		Description:   "Returns all decision releases of the active hackathon, newest first",
+		Tags:          []string{"Decisions"},
+		Path:          "/releases",
+		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma, mw.Auth.RequireAdminHuma},
+		Errors:        []int{http.StatusUnauthorized, http.StatusInternalServerError},
+		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
+		DefaultStatus: http.StatusOK,
+	}, decisionHandler.handleGetReleases)
+
+	huma.Register(group, huma.Operation{
+		OperationID:   "get-decision-release",
+		Method:        http.MethodGet,
+		Summary:       "Get Decision Release",
+		Description:   "Returns a decision release by id, including how many decision emails have been queued",
+		Tags:          []string{"Decisions"},
+		Path:          "/releases/{releaseId}",
+		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma, mw.Auth.RequireAdminHuma},
+		Errors:        []int{http.StatusUnauthorized, http.StatusNotFound, http.StatusInternalServerError},
+		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
+		DefaultStatus: http.StatusOK,
+	}, decisionHandler.handleGetRelease)
+
+	huma.Register(group, huma.Operation{
+		OperationID:   "create-decision-release",
+		Method:        http.MethodPost,
+		Summary:       "Release Decisions",
+		Description:   "Releases the decisions of a completed bat run, either now or at the hackathon's decision release time. Decision emails go out once the configured delay has passed, until then the release can be rolled back. If the emails couldn't be queued the release is applied anyway and its lastError says so; roll it back and release again to queue them.",
+		Tags:          []string{"Decisions"},
+		Path:          "/releases",
+		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma, mw.Auth.RequireAdminHuma},
+		Errors:        []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusNotFound, http.StatusConflict, http.StatusInternalServerError},
+		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
+		DefaultStatus: http.StatusCreated,
+	}, decisionHandler.handleCreateRelease)
+
+	huma.Register(group, huma.Operation{
+		OperationID:   "rollback-decision-release",
+		Method:        http.MethodPost,
+		Summary:       "Roll Back Decision Release",
+		Description:   "Cancels a scheduled release, or restores the previous application statuses of an applied release whose emails haven't gone out yet",
+		Tags:          []string{"Decisions"},
+		Path:          "/releases/{releaseId}/rollback",
+		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma, mw.Auth.RequireAdminHuma},
+		Errors:        []int{http.StatusUnauthorized, http.StatusNotFound, http.StatusConflict, http.StatusInternalServerError},
+		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
+		DefaultStatus: http.StatusOK,
+	}, decisionHandler.handleRollbackRelease)
+}
+
+type handler struct {
+	decisionService *DecisionService
+	logger          zerolog.Logger
+}
+
+func NewHandler(decisionService *DecisionService, logger zerolog.Logger) *handler {
+	return &handler{
+		decisionService: decisionService,
+		logger:          logger.With().Str("handler", "DecisionHandler").Str("domain", "decisions").Logger(),
+	}
+}
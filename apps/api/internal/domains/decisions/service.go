@@ -0,0 +1,593 @@
+package decisions
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5"
+	"github.com/rs/zerolog"
+	"github.com/swamphacks/core/apps/api/internal/config"
+	"github.com/swamphacks/core/apps/api/internal/database"
+	"github.com/swamphacks/core/apps/api/internal/database/sqlc"
+	"github.com/swamphacks/core/apps/api/internal/domains/email"
+	"github.com/swamphacks/core/apps/api/internal/tasks"
+)
+
+// decisionEmailBatchSize is how many decision emails are queued before progress is saved.
+const decisionEmailBatchSize = 100
+
+type DecisionService struct {
+	db           *database.DB
+	txm          *database.TransactionManager
+	taskQueue    *asynq.Client
+	emailService *email.EmailService
+	config       *config.Config
+	logger       zerolog.Logger
+}
+
+func NewService(
+	db *database.DB, txm *database.TransactionManager, taskQueue *asynq.Client,
+	emailService *email.EmailService, config *config.Config, logger zerolog.Logger,
+) *DecisionService {
+	return &DecisionService{
+		db:           db,
+		txm:          txm,
+		taskQueue:    taskQueue,
+		emailService: emailService,
+		config:       config,
+		logger:       logger.With().Str("service", "DecisionService").Str("domain", "decisions").Logger(),
+	}
+}
+
+func (s *DecisionService) GetReleases(ctx context.Context) ([]sqlc.DecisionRelease, error) {
+	hackathon, err := s.db.Query.GetHackathon(ctx)
+	if err != nil {
+		s.logger.Err(err).Msg("GetReleases fail, unable to get hackathon")
+		return nil, ErrGetHackathon
+	}
+
+	releases, err := s.db.Query.ListDecisionReleases(ctx, hackathon.ID)
+	if err != nil {
+		s.logger.Err(err).Msg("GetReleases fail")
+		return nil, ErrGetReleases
+	}
+
+	return releases, nil
+}
+
+func (s *DecisionService) GetReleaseById(ctx context.Context, releaseID uuid.UUID) (*sqlc.DecisionRelease, error) {
+	release, err := s.db.Query.GetDecisionReleaseById(ctx, releaseID)
+	if err != nil {
+		if database.IsNotFound(err) {
+			return nil, ErrReleaseNotFound
+		}
+		s.logger.Err(err).Msg("GetReleaseById fail")
+		return nil, ErrGetReleases
+	}
+
+	return &release, nil
+}
+
+// CreateRelease releases the decisions of a completed bat run. Unless it is scheduled
+// for the hackathon's decision release time, the decisions are applied right away.
+// Either way the decision emails only go out once DecisionEmailDelay has passed
+// after applying, which leaves time to roll back a bad release. If the emails can't
+// be queued the applied release is still returned, with its last error set. Rolling
+// it back and releasing again queues them anew.
+func (s *DecisionService) CreateRelease(ctx context.Context, req CreateReleaseRequestDto, createdBy uuid.UUID) (*sqlc.DecisionRelease, error) {
+	if req.WaitlistCount < 0 {
+		return nil, ErrNegativeWaitlistCount
+	}
+
+	hackathon, err := s.db.Query.GetHackathon(ctx)
+	if err != nil {
+		s.logger.Err(err).Msg("CreateRelease fail, unable to get hackathon")
+		return nil, ErrGetHackathon
+	}
+
+	run, err := s.db.Query.GetBatRunById(ctx, req.RunID)
+	if err != nil {
+		if database.IsNotFound(err) {
+			return nil, database.ErrRunNotFound
+		}
+		s.logger.Err(err).Msg("CreateRelease fail, unable to get run")
+		return nil, ErrCreateRelease
+	}
+
+	if run.HackathonID != hackathon.ID {
+		return nil, ErrRunMismatch
+	}
+
+	if run.Status != sqlc.BatRunStatusCompleted {
+		return nil, ErrRunNotCompleted
+	}
+
+	if int(req.WaitlistCount) > len(run.RejectedApplicants) {
+		return nil, ErrWaitlistCountTooLarge
+	}
+
+	releaseAt := time.Now()
+	if req.Scheduled {
+		if hackathon.DecisionRelease == nil {
+			return nil, ErrNoDecisionReleaseTime
+		}
+		if !hackathon.DecisionRelease.After(releaseAt) {
+			return nil, ErrDecisionReleasePassed
+		}
+		releaseAt = *hackathon.DecisionRelease
+	}
+
+	release, err := s.db.Query.CreateDecisionRelease(ctx, sqlc.CreateDecisionReleaseParams{
+		HackathonID:   hackathon.ID,
+		RunID:         run.ID,
+		WaitlistCount: req.WaitlistCount,
+		ReleaseAt:     releaseAt,
+		CreatedBy:     &createdBy,
+	})
+	if err != nil {
+		if database.IsUniqueViolation(err) {
+			return nil, ErrReleaseConflict
+		}
+		s.logger.Err(err).Msg("CreateRelease fail")
+		return nil, ErrCreateRelease
+	}
+
+	if req.Scheduled {
+		if err := s.queueApplyRelease(release); err != nil {
+			s.markReleaseFailed(ctx, release.ID, err)
+			return nil, ErrScheduleRelease
+		}
+	} else if err := s.ApplyRelease(ctx, release.ID); err != nil {
+		// The decisions are applied even if their emails couldn't be queued. The release
+		// is returned with its last error, so it can be rolled back and released again.
+		if !errors.Is(err, ErrQueueReleaseEmails) {
+			s.markReleaseFailed(ctx, release.ID, err)
+			return nil, err
+		}
+	}
+
+	return s.GetReleaseById(ctx, release.ID)
+}
+
+func (s *DecisionService) queueApplyRelease(release sqlc.DecisionRelease) error {
+	task, err := tasks.NewTaskApplyDecisionRelease(tasks.DecisionReleasePayload{ReleaseID: release.ID})
+	if err != nil {
+		return err
+	}
+
+	info, err := s.taskQueue.Enqueue(task,
+		asynq.Queue("bat"),
+		asynq.ProcessAt(release.ReleaseAt),
+		asynq.TaskID("decision-release:apply:"+release.ID.String()),
+	)
+	if err != nil {
+		s.logger.Err(err).Str("ReleaseID", release.ID.String()).Msg("Failed to schedule decision release")
+		return err
+	}
+
+	s.logger.Info().Str("TaskID", info.ID).Str("ReleaseID", release.ID.String()).Time("ReleaseAt", release.ReleaseAt).Msg("Scheduled decision release")
+
+	return nil
+}
+
+// ApplyRelease sets the statuses of every application in the release's run in one
+// transaction and then queues the decision emails. Only applications still under
+// review are touched, so withdrawn applicants are left alone.
+func (s *DecisionService) ApplyRelease(ctx context.Context, releaseID uuid.UUID) error {
+	var applied sqlc.DecisionRelease
+
+	err := s.txm.WithTx(ctx, func(tx pgx.Tx) error {
+		txDB := s.db.NewTX(tx)
+
+		release, err := txDB.Query.GetDecisionReleaseByIdForUpdate(ctx, releaseID)
+		if err != nil {
+			if database.IsNotFound(err) {
+				return ErrReleaseNotFound
+			}
+			return err
+		}
+
+		if release.Status != sqlc.DecisionReleaseStatusScheduled {
+			return ErrReleaseNotScheduled
+		}
+
+		run, err := txDB.Query.GetBatRunById(ctx, release.RunID)
+		if err != nil {
+			return err
+		}
+
+		decisions, err := txDB.Query.ListBatRunDecisionsByRunId(ctx, run.ID)
+		if err != nil {
+			return err
+		}
+
+		waitlisted, rejected := splitWaitlist(run.RejectedApplicants, decisions, int(release.WaitlistCount))
+
+		applications, err := txDB.Query.ListApplicationsByUserIds(ctx, sqlc.ListApplicationsByUserIdsParams{
+			HackathonID: release.HackathonID,
+			UserIds:     slices.Concat(run.AcceptedApplicants, run.RejectedApplicants),
+		})
+		if err != nil {
+			return err
+		}
+
+		underReview := make(map[uuid.UUID]sqlc.ListApplicationsByUserIdsRow, len(applications))
+		for _, application := range applications {
+			if application.Status == sqlc.ApplicationStatusUnderReview {
+				underReview[application.UserID] = application
+			}
+		}
+
+		var previous []previousStatus
+		decide := func(userIDs []uuid.UUID, status sqlc.ApplicationStatus) (decidedUsers, applicationIDs []uuid.UUID) {
+			for _, userID := range userIDs {
+				application, ok := underReview[userID]
+				if !ok {
+					continue
+				}
+
+				decidedUsers = append(decidedUsers, userID)
+				applicationIDs = append(applicationIDs, application.ID)
+				previous = append(previous, previousStatus{
+					ApplicationID:    application.ID,
+					Status:           application.Status,
+					ReleasedStatus:   status,
+					WaitlistJoinTime: application.WaitlistJoinTime,
+					RsvpDueAt:        application.RsvpDueAt,
+				})
+			}
+			return decidedUsers, applicationIDs
+		}
+
+		acceptedUsers, acceptedIDs := decide(run.AcceptedApplicants, sqlc.ApplicationStatusAccepted)
+		waitlistedUsers, waitlistedIDs := decide(waitlisted, sqlc.ApplicationStatusWaitlisted)
+		rejectedUsers, rejectedIDs := decide(rejected, sqlc.ApplicationStatusRejected)
+
+		if err := txDB.Query.UpdateApplicationsStatusByIds(ctx, sqlc.UpdateApplicationsStatusByIdsParams{
+			Status: sqlc.ApplicationStatusAccepted,
+			Ids:    acceptedIDs,
+		}); err != nil {
+			return err
+		}
+
+		now := time.Now()
+
+		// Waitlisted applicants keep their rank as their place on the waitlist.
+		if err := txDB.Query.WaitlistApplicationsInOrder(ctx, sqlc.WaitlistApplicationsInOrderParams{
+			JoinedAt: now,
+			Ids:      waitlistedIDs,
+		}); err != nil {
+			return err
+		}
+
+		if err := txDB.Query.UpdateApplicationsStatusByIds(ctx, sqlc.UpdateApplicationsStatusByIdsParams{
+			Status: sqlc.ApplicationStatusRejected,
+			Ids:    rejectedIDs,
+		}); err != nil {
+			return err
+		}
+
+		previousStatuses, err := json.Marshal(previous)
+		if err != nil {
+			return err
+		}
+
+		if err := txDB.Query.UpdateDecisionReleaseById(ctx, sqlc.UpdateDecisionReleaseByIdParams{
+			ID:                       release.ID,
+			StatusDoUpdate:           true,
+			Status:                   sqlc.DecisionReleaseStatusApplied,
+			ApplicantsDoUpdate:       true,
+			AcceptedApplicants:       nonNil(acceptedUsers),
+			WaitlistedApplicants:     nonNil(waitlistedUsers),
+			RejectedApplicants:       nonNil(rejectedUsers),
+			PreviousStatusesDoUpdate: true,
+			PreviousStatuses:         previousStatuses,
+			AppliedAtDoUpdate:        true,
+			AppliedAt:                &now,
+		}); err != nil {
+			return err
+		}
+
+		applied = release
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, ErrReleaseNotFound) || errors.Is(err, ErrReleaseNotScheduled) {
+			return err
+		}
+		s.logger.Err(err).Str("ReleaseID", releaseID.String()).Msg("ApplyRelease fail")
+		return ErrApplyRelease
+	}
+
+	s.logger.Info().Str("ReleaseID", releaseID.String()).Msg("Applied decision release")
+
+	task, err := tasks.NewTaskSendDecisionEmails(tasks.DecisionReleasePayload{ReleaseID: applied.ID})
+	if err == nil {
+		_, err = s.taskQueue.Enqueue(task,
+			asynq.Queue("bat"),
+			asynq.ProcessIn(s.config.DecisionEmailDelay),
+			asynq.TaskID("decision-release:emails:"+applied.ID.String()),
+		)
+	}
+	if err != nil {
+		// The decisions are applied, so only record the error. Rolling back and
+		// releasing again queues the emails anew.
+		s.logger.Err(err).Str("ReleaseID", releaseID.String()).Msg("Failed to queue decision emails")
+		s.setLastError(ctx, releaseID, err)
+		return ErrQueueReleaseEmails
+	}
+
+	return nil
+}
+
+// SendReleaseEmails queues the decision emails of an applied release in batches.
+// Progress is saved after every batch, so a retried task picks up where the
+// failed one stopped. Once this starts the release can no longer be rolled back.
+func (s *DecisionService) SendReleaseEmails(ctx context.Context, releaseID uuid.UUID) error {
+	var release sqlc.DecisionRelease
+
+	err := s.txm.WithTx(ctx, func(tx pgx.Tx) error {
+		txDB := s.db.NewTX(tx)
+
+		var err error
+		release, err = txDB.Query.GetDecisionReleaseByIdForUpdate(ctx, releaseID)
+		if err != nil {
+			if database.IsNotFound(err) {
+				return ErrReleaseNotFound
+			}
+			return err
+		}
+
+		switch release.Status {
+		case sqlc.DecisionReleaseStatusApplied:
+			release.Status = sqlc.DecisionReleaseStatusSending
+			return txDB.Query.UpdateDecisionReleaseById(ctx, sqlc.UpdateDecisionReleaseByIdParams{
+				ID:             release.ID,
+				StatusDoUpdate: true,
+				Status:         sqlc.DecisionReleaseStatusSending,
+			})
+		case sqlc.DecisionReleaseStatusSending:
+			// A retry of an interrupted send.
+			return nil
+		default:
+			return ErrReleaseNotApplied
+		}
+	})
+	if err != nil {
+		if errors.Is(err, ErrReleaseNotFound) || errors.Is(err, ErrReleaseNotApplied) {
+			return err
+		}
+		s.logger.Err(err).Str("ReleaseID", releaseID.String()).Msg("SendReleaseEmails fail")
+		return ErrQueueReleaseEmails
+	}
+
+	recipients := releaseRecipients(release)
+
+	for start := int(release.EmailsQueued); start < len(recipients); start += decisionEmailBatchSize {
+		end := min(start+decisionEmailBatchSize, len(recipients))
+
+		for _, group := range groupRecipientsByStatus(recipients[start:end]) {
+			if err := s.emailService.QueueDecisionEmails(ctx, group.status, group.userIDs); err != nil {
+				s.logger.Err(err).Str("ReleaseID", releaseID.String()).Int("BatchStart", start).Msg("Failed to queue decision email batch")
+				s.setLastError(ctx, releaseID, err)
+				return fmt.Errorf("%w: %w", ErrReleaseEmailsIncomplete, err)
+			}
+		}
+
+		if err := s.db.Query.UpdateDecisionReleaseById(ctx, sqlc.UpdateDecisionReleaseByIdParams{
+			ID:                   releaseID,
+			EmailsQueuedDoUpdate: true,
+			EmailsQueued:         int32(end),
+		}); err != nil {
+			s.logger.Err(err).Str("ReleaseID", releaseID.String()).Msg("Failed to save decision email progress")
+			return ErrUpdateRelease
+		}
+	}
+
+	now := time.Now()
+	if err := s.db.Query.UpdateDecisionReleaseById(ctx, sqlc.UpdateDecisionReleaseByIdParams{
+		ID:                releaseID,
+		StatusDoUpdate:    true,
+		Status:            sqlc.DecisionReleaseStatusSent,
+		SentAtDoUpdate:    true,
+		SentAt:            &now,
+		LastErrorDoUpdate: true,
+		LastError:         nil,
+	}); err != nil {
+		s.logger.Err(err).Str("ReleaseID", releaseID.String()).Msg("Failed to mark decision release as sent")
+		return ErrUpdateRelease
+	}
+
+	s.logger.Info().Str("ReleaseID", releaseID.String()).Int("Emails", len(recipients)).Msg("Queued all decision emails")
+
+	return nil
+}
+
+// RollbackRelease undoes a release whose emails haven't started going out. A scheduled
+// release is simply cancelled. For an applied release every application goes back to
+// the status it had before, unless it has changed since, e.g. because the applicant
+// already confirmed their attendance.
+func (s *DecisionService) RollbackRelease(ctx context.Context, releaseID uuid.UUID) (*sqlc.DecisionRelease, error) {
+	skipped := 0
+
+	err := s.txm.WithTx(ctx, func(tx pgx.Tx) error {
+		txDB := s.db.NewTX(tx)
+
+		release, err := txDB.Query.GetDecisionReleaseByIdForUpdate(ctx, releaseID)
+		if err != nil {
+			if database.IsNotFound(err) {
+				return ErrReleaseNotFound
+			}
+			return err
+		}
+
+		switch release.Status {
+		case sqlc.DecisionReleaseStatusScheduled:
+		case sqlc.DecisionReleaseStatusApplied:
+			var previous []previousStatus
+			if err := json.Unmarshal(release.PreviousStatuses, &previous); err != nil {
+				return err
+			}
+
+			for _, application := range previous {
+				restored, err := txDB.Query.RestoreApplicationStatus(ctx, sqlc.RestoreApplicationStatusParams{
+					ID:               application.ApplicationID,
+					Status:           application.Status,
+					WaitlistJoinTime: application.WaitlistJoinTime,
+					RsvpDueAt:        application.RsvpDueAt,
+					CurrentStatus:    application.ReleasedStatus,
+				})
+				if err != nil {
+					return err
+				}
+				if restored == 0 {
+					skipped++
+				}
+			}
+		default:
+			return ErrReleaseNotRollbackable
+		}
+
+		now := time.Now()
+		return txDB.Query.UpdateDecisionReleaseById(ctx, sqlc.UpdateDecisionReleaseByIdParams{
+			ID:                   release.ID,
+			StatusDoUpdate:       true,
+			Status:               sqlc.DecisionReleaseStatusRolledBack,
+			RolledBackAtDoUpdate: true,
+			RolledBackAt:         &now,
+		})
+	})
+	if err != nil {
+		if errors.Is(err, ErrReleaseNotFound) || errors.Is(err, ErrReleaseNotRollbackable) {
+			return nil, err
+		}
+		s.logger.Err(err).Str("ReleaseID", releaseID.String()).Msg("RollbackRelease fail")
+		return nil, ErrRollbackRelease
+	}
+
+	if skipped > 0 {
+		s.logger.Warn().Str("ReleaseID", releaseID.String()).Int("Skipped", skipped).Msg("Some applications changed after the release and were not rolled back")
+	}
+
+	return s.GetReleaseById(ctx, releaseID)
+}
+
+// MarkReleaseFailed records that a release could not be applied.
+func (s *DecisionService) MarkReleaseFailed(ctx context.Context, releaseID uuid.UUID, cause error) error {
+	message := cause.Error()
+
+	return s.db.Query.UpdateDecisionReleaseById(ctx, sqlc.UpdateDecisionReleaseByIdParams{
+		ID:                releaseID,
+		StatusDoUpdate:    true,
+		Status:            sqlc.DecisionReleaseStatusFailed,
+		LastErrorDoUpdate: true,
+		LastError:         &message,
+	})
+}
+
+func (s *DecisionService) markReleaseFailed(ctx context.Context, releaseID uuid.UUID, cause error) {
+	if err := s.MarkReleaseFailed(ctx, releaseID, cause); err != nil {
+		s.logger.Err(err).Str("ReleaseID", releaseID.String()).Msg("Failed to mark decision release as failed")
+	}
+}
+
+func (s *DecisionService) setLastError(ctx context.Context, releaseID uuid.UUID, cause error) {
+	message := cause.Error()
+
+	if err := s.db.Query.UpdateDecisionReleaseById(ctx, sqlc.UpdateDecisionReleaseByIdParams{
+		ID:                releaseID,
+		LastErrorDoUpdate: true,
+		LastError:         &message,
+	}); err != nil {
+		s.logger.Err(err).Str("ReleaseID", releaseID.String()).Msg("Failed to record decision release error")
+	}
+}
+
+// splitWaitlist picks the count best ranked rejected applicants for the waitlist, best
+// first. Applicants are ranked by the weighted score the run gave them. Applicants
+// without a recorded decision, e.g. from runs made before decisions were recorded,
+// rank last in their original order, and applicants that matched no bucket are never
+// waitlisted.
+func splitWaitlist(rejected []uuid.UUID, decisions []sqlc.BatRunDecision, count int) (waitlisted, stillRejected []uuid.UUID) {
+	byUser := make(map[uuid.UUID]sqlc.BatRunDecision, len(decisions))
+	for _, decision := range decisions {
+		byUser[decision.UserID] = decision
+	}
+
+	ranked := make([]uuid.UUID, 0, len(rejected))
+	for _, userID := range rejected {
+		if decision, ok := byUser[userID]; ok && decision.Reason == sqlc.BatDecisionReasonNoBucket {
+			continue
+		}
+		ranked = append(ranked, userID)
+	}
+
+	slices.SortStableFunc(ranked, func(a, b uuid.UUID) int {
+		decisionA, okA := byUser[a]
+		decisionB, okB := byUser[b]
+
+		switch {
+		case okA && !okB:
+			return -1
+		case !okA && okB:
+			return 1
+		case !okA && !okB:
+			return 0
+		}
+
+		if c := cmp.Compare(decisionB.WeightedScore, decisionA.WeightedScore); c != 0 {
+			return c
+		}
+		return cmp.Compare(decisionB.SortKey, decisionA.SortKey)
+	})
+
+	waitlisted = ranked[:min(max(count, 0), len(ranked))]
+
+	onWaitlist := make(map[uuid.UUID]bool, len(waitlisted))
+	for _, userID := range waitlisted {
+		onWaitlist[userID] = true
+	}
+
+	stillRejected = make([]uuid.UUID, 0, len(rejected)-len(waitlisted))
+	for _, userID := range rejected {
+		if !onWaitlist[userID] {
+			stillRejected = append(stillRejected, userID)
+		}
+	}
+
+	return waitlisted, stillRejected
+}
+
+type recipientGroup struct {
+	status  sqlc.ApplicationStatus
+	userIDs []uuid.UUID
+}
+
+// groupRecipientsByStatus splits recipients into runs of the same decision, keeping their order.
+func groupRecipientsByStatus(recipients []decisionRecipient) []recipientGroup {
+	var groups []recipientGroup
+
+	for _, recipient := range recipients {
+		if len(groups) == 0 || groups[len(groups)-1].status != recipient.Status {
+			groups = append(groups, recipientGroup{status: recipient.Status})
+		}
+		last := &groups[len(groups)-1]
+		last.userIDs = append(last.userIDs, recipient.UserID)
+	}
+
+	return groups
+}
+
+func nonNil(ids []uuid.UUID) []uuid.UUID {
+	if ids == nil {
+		return []uuid.UUID{}
+	}
+	return ids
+}
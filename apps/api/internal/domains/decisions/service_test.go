@@ -0,0 +1,96 @@
+package decisions
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/swamphacks/core/apps/api/internal/database/sqlc"
+)
+
+func TestSplitWaitlist(t *testing.T) {
+	a, b, c, d := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+	decisions := []sqlc.BatRunDecision{
+		{UserID: a, WeightedScore: 3.5, SortKey: 0.2, Reason: sqlc.BatDecisionReasonBucketFull},
+		{UserID: b, WeightedScore: 4.5, SortKey: 0.1, Reason: sqlc.BatDecisionReasonBucketFull},
+		{UserID: c, WeightedScore: 3.5, SortKey: 0.9, Reason: sqlc.BatDecisionReasonTeamNotFit},
+		{UserID: d, WeightedScore: 5, SortKey: 0.9, Reason: sqlc.BatDecisionReasonNoBucket},
+	}
+
+	tests := []struct {
+		name               string
+		rejected           []uuid.UUID
+		decisions          []sqlc.BatRunDecision
+		count              int
+		expectedWaitlisted []uuid.UUID
+		expectedRejected   []uuid.UUID
+	}{
+		{
+			name:               "best weighted score first, sort key breaks ties",
+			rejected:           []uuid.UUID{a, b, c},
+			decisions:          decisions,
+			count:              2,
+			expectedWaitlisted: []uuid.UUID{b, c},
+			expectedRejected:   []uuid.UUID{a},
+		},
+		{
+			name:               "applicants without a bucket are never waitlisted",
+			rejected:           []uuid.UUID{a, b, c, d},
+			decisions:          decisions,
+			count:              4,
+			expectedWaitlisted: []uuid.UUID{b, c, a},
+			expectedRejected:   []uuid.UUID{d},
+		},
+		{
+			name:               "applicants without a decision rank last in their original order",
+			rejected:           []uuid.UUID{d, c, a},
+			decisions:          decisions[:1],
+			count:              3,
+			expectedWaitlisted: []uuid.UUID{a, d, c},
+			expectedRejected:   []uuid.UUID{},
+		},
+		{
+			name:               "no waitlist",
+			rejected:           []uuid.UUID{a, b},
+			decisions:          decisions,
+			count:              0,
+			expectedWaitlisted: []uuid.UUID{},
+			expectedRejected:   []uuid.UUID{a, b},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			waitlisted, rejected := splitWaitlist(test.rejected, test.decisions, test.count)
+
+			if !slices.Equal(waitlisted, test.expectedWaitlisted) {
+				t.Fatalf("expected waitlist %v, got %v", test.expectedWaitlisted, waitlisted)
+			}
+			if !slices.Equal(rejected, test.expectedRejected) {
+				t.Fatalf("expected rejected %v, got %v", test.expectedRejected, rejected)
+			}
+		})
+	}
+}
+
+func TestGroupRecipientsByStatus(t *testing.T) {
+	a, b, c := uuid.New(), uuid.New(), uuid.New()
+
+	release := sqlc.DecisionRelease{
+		AcceptedApplicants:   []uuid.UUID{a},
+		WaitlistedApplicants: []uuid.UUID{b, c},
+	}
+
+	recipients := releaseRecipients(release)
+
+	groups := groupRecipientsByStatus(recipients[1:])
+	if len(groups) != 1 || groups[0].status != sqlc.ApplicationStatusWaitlisted || !slices.Equal(groups[0].userIDs, []uuid.UUID{b, c}) {
+		t.Fatalf("expected a single waitlisted group, got %+v", groups)
+	}
+
+	groups = groupRecipientsByStatus(recipients)
+	if len(groups) != 2 || groups[0].status != sqlc.ApplicationStatusAccepted || groups[1].status != sqlc.ApplicationStatusWaitlisted {
+		t.Fatalf("expected accepted then waitlisted groups, got %+v", groups)
+	}
+}
@@ -0,0 +1,112 @@
+package decisions
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/swamphacks/core/apps/api/internal/database/sqlc"
+)
+
+var (
+	ErrGetHackathon            = errors.New("failed to get hackathon information")
+	ErrReleaseNotFound         = errors.New("decision release not found")
+	ErrGetReleases             = errors.New("unable to get decision releases")
+	ErrCreateRelease           = errors.New("unable to create decision release")
+	ErrReleaseConflict         = errors.New("decisions for this hackathon have already been released")
+	ErrRunMismatch             = errors.New("that bat run does not belong to this hackathon")
+	ErrRunNotCompleted         = errors.New("only completed bat runs can be released")
+	ErrNoDecisionReleaseTime   = errors.New("the hackathon has no decision release time")
+	ErrDecisionReleasePassed   = errors.New("the hackathon's decision release time has already passed")
+	ErrScheduleRelease         = errors.New("unable to schedule decision release")
+	ErrApplyRelease            = errors.New("unable to apply decision release")
+	ErrReleaseNotScheduled     = errors.New("decision release is no longer scheduled")
+	ErrReleaseNotApplied       = errors.New("decision release has not been applied")
+	ErrQueueReleaseEmails      = errors.New("unable to queue decision emails")
+	ErrReleaseNotRollbackable  = errors.New("decision emails have already gone out, the release can't be rolled back")
+	ErrRollbackRelease         = errors.New("unable to roll back decision release")
+	ErrUpdateRelease           = errors.New("unable to update decision release")
+	ErrNegativeWaitlistCount   = errors.New("waitlist count can't be negative")
+	ErrWaitlistCountTooLarge   = errors.New("waitlist count is larger than the number of rejected applicants")
+	ErrReleaseEmailsIncomplete = errors.New("not every decision email could be queued")
+)
+
+type CreateReleaseRequestDto struct {
+	RunID         uuid.UUID `json:"runId"`
+	WaitlistCount int32     `json:"waitlistCount" minimum:"0" required:"false" doc:"How many of the best ranked rejected applicants are waitlisted instead"`
+	Scheduled     bool      `json:"scheduled" required:"false" doc:"Release at the hackathon's decision release time instead of right away"`
+}
+
+type DecisionReleaseDto struct {
+	ID              uuid.UUID                  `json:"id"`
+	HackathonID     string                     `json:"hackathonId"`
+	RunID           uuid.UUID                  `json:"runId"`
+	Status          sqlc.DecisionReleaseStatus `json:"status"`
+	WaitlistCount   int32                      `json:"waitlistCount"`
+	ReleaseAt       time.Time                  `json:"releaseAt"`
+	AcceptedCount   int                        `json:"acceptedCount"`
+	WaitlistedCount int                        `json:"waitlistedCount"`
+	RejectedCount   int                        `json:"rejectedCount"`
+	EmailsQueued    int32                      `json:"emailsQueued"`
+	LastError       *string                    `json:"lastError"`
+	CreatedBy       *uuid.UUID                 `json:"createdBy"`
+	AppliedAt       *time.Time                 `json:"appliedAt"`
+	SentAt          *time.Time                 `json:"sentAt"`
+	RolledBackAt    *time.Time                 `json:"rolledBackAt"`
+	CreatedAt       time.Time                  `json:"createdAt"`
+}
+
+func toDecisionReleaseDto(release sqlc.DecisionRelease) DecisionReleaseDto {
+	return DecisionReleaseDto{
+		ID:              release.ID,
+		HackathonID:     release.HackathonID,
+		RunID:           release.RunID,
+		Status:          release.Status,
+		WaitlistCount:   release.WaitlistCount,
+		ReleaseAt:       release.ReleaseAt,
+		AcceptedCount:   len(release.AcceptedApplicants),
+		WaitlistedCount: len(release.WaitlistedApplicants),
+		RejectedCount:   len(release.RejectedApplicants),
+		EmailsQueued:    release.EmailsQueued,
+		LastError:       release.LastError,
+		CreatedBy:       release.CreatedBy,
+		AppliedAt:       release.AppliedAt,
+		SentAt:          release.SentAt,
+		RolledBackAt:    release.RolledBackAt,
+		CreatedAt:       release.CreatedAt,
+	}
+}
+
+// previousStatus is what an application looked like before a release touched it.
+// Releases keep a list of them so they can be rolled back.
+type previousStatus struct {
+	ApplicationID    uuid.UUID              `json:"applicationId"`
+	Status           sqlc.ApplicationStatus `json:"status"`
+	ReleasedStatus   sqlc.ApplicationStatus `json:"releasedStatus"`
+	WaitlistJoinTime *time.Time             `json:"waitlistJoinTime"`
+	RsvpDueAt        *time.Time             `json:"rsvpDueAt"`
+}
+
+// decisionRecipient is a single decision email of a release.
+type decisionRecipient struct {
+	UserID uuid.UUID
+	Status sqlc.ApplicationStatus
+}
+
+// releaseRecipients lists the decision emails of a release in the order they are sent.
+// The order must stay stable, since interrupted sends resume from EmailsQueued.
+func releaseRecipients(release sqlc.DecisionRelease) []decisionRecipient {
+	recipients := make([]decisionRecipient, 0, len(release.AcceptedApplicants)+len(release.WaitlistedApplicants)+len(release.RejectedApplicants))
+
+	for _, userID := range release.AcceptedApplicants {
+		recipients = append(recipients, decisionRecipient{UserID: userID, Status: sqlc.ApplicationStatusAccepted})
+	}
+	for _, userID := range release.WaitlistedApplicants {
+		recipients = append(recipients, decisionRecipient{UserID: userID, Status: sqlc.ApplicationStatusWaitlisted})
+	}
+	for _, userID := range release.RejectedApplicants {
+		recipients = append(recipients, decisionRecipient{UserID: userID, Status: sqlc.ApplicationStatusRejected})
+	}
+
+	return recipients
+}
@@ -76,10 +76,36 @@ func RegisterCampaignRoutes(emailCampaignHandler *emailCampaignHandler, group hu
 		Tags:          []string{"Email Campaigns"},
 		Path:          "/campaigns/{campaignId}/status",
 		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma, mw.Auth.RequireAdminHuma},
-		Errors:        []int{http.StatusUnauthorized, http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError},
+		Errors:        []int{http.StatusUnauthorized, http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusInternalServerError},
 		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
 		DefaultStatus: http.StatusOK,
 	}, emailCampaignHandler.handleUpdateCampaignStatus)
+
+	huma.Register(group, huma.Operation{
+		OperationID:   "send-email-campaign",
+		Method:        http.MethodPost,
+		Summary:       "Send Email Campaign",
+		Description:   "Moves a draft, scheduled, or failed email campaign to sending and queues it. Recipients are resolved when the campaign goes out.",
+		Tags:          []string{"Email Campaigns"},
+		Path:          "/campaigns/{campaignId}/send",
+		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma, mw.Auth.RequireAdminHuma},
+		Errors:        []int{http.StatusUnauthorized, http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError},
+		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
+		DefaultStatus: http.StatusAccepted,
+	}, emailCampaignHandler.handleSendCampaign)
+
+	huma.Register(group, huma.Operation{
+		OperationID:   "resend-failed-email-campaign",
+		Method:        http.MethodPost,
+		Summary:       "Resend Failed Email Campaign Deliveries",
+		Description:   "Sends a sent or failed email campaign again, only to the recipients whose delivery failed.",
+		Tags:          []string{"Email Campaigns"},
+		Path:          "/campaigns/{campaignId}/resend-failed",
+		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma, mw.Auth.RequireAdminHuma},
+		Errors:        []int{http.StatusUnauthorized, http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError},
+		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
+		DefaultStatus: http.StatusAccepted,
+	}, emailCampaignHandler.handleResendFailedCampaign)
 }
 
 type emailCampaignHandler struct {
@@ -102,6 +128,7 @@ type CreateEmailCampaignRequest struct {
 	Body           string                   `json:"body" minLength:"1"`
 	Format         sqlc.EmailCampaignFormat `json:"format" required:"true"`
 	RecipientTypes []string                 `json:"recipientTypes" minItems:"1"`
+	Category       sqlc.EmailCategory       `json:"category,omitempty" enum:"marketing,event_updates,team_notifications,workshop_reminders" default:"marketing"`
 	ScheduledAt    *time.Time               `json:"scheduledAt,omitempty"`
 }
 
@@ -112,6 +139,7 @@ type UpdateEmailCampaignRequest struct {
 	Body           *string                   `json:"body,omitempty"`
 	Format         *sqlc.EmailCampaignFormat `json:"format,omitempty"`
 	RecipientTypes *[]string                 `json:"recipientTypes,omitempty"`
+	Category       *sqlc.EmailCategory       `json:"category,omitempty" enum:"marketing,event_updates,team_notifications,workshop_reminders"`
 	ScheduledAt    *time.Time                `json:"scheduledAt,omitempty"`
 }
 
@@ -122,12 +150,18 @@ type UpdateEmailCampaignStatusRequest struct {
 	LastError   *string                  `json:"lastError,omitempty"`
 }
 
+// EmailCampaignResponse is a campaign with how its deliveries went.
+type EmailCampaignResponse struct {
+	sqlc.EmailCampaign
+	Deliveries EmailDeliveryCounts `json:"deliveries"`
+}
+
 type EmailCampaignOutput struct {
-	Body *sqlc.EmailCampaign
+	Body *EmailCampaignResponse
 }
 
 type ListEmailCampaignsOutput struct {
-	Body []sqlc.EmailCampaign
+	Body []EmailCampaignResponse
 }
 
 func (h *emailCampaignHandler) handleCreateCampaign(ctx context.Context, input *struct {
@@ -146,6 +180,7 @@ func (h *emailCampaignHandler) handleCreateCampaign(ctx context.Context, input *
 		Body:            input.Body.Body,
 		Format:          input.Body.Format,
 		RecipientTypes:  input.Body.RecipientTypes,
+		Category:        input.Body.Category,
 		ScheduledAt:     input.Body.ScheduledAt,
 		CreatedByUserID: &userCtx.UserID,
 		UpdatedByUserID: &userCtx.UserID,
@@ -155,7 +190,7 @@ func (h *emailCampaignHandler) handleCreateCampaign(ctx context.Context, input *
 		return nil, campaignHTTPError(err, "Failed to create email campaign")
 	}
 
-	return &EmailCampaignOutput{Body: campaign}, nil
+	return h.campaignOutput(ctx, campaign)
 }
 
 func (h *emailCampaignHandler) handleListCampaigns(ctx context.Context, input *struct {
@@ -166,7 +201,12 @@ func (h *emailCampaignHandler) handleListCampaigns(ctx context.Context, input *s
 		return nil, campaignHTTPError(err, "Failed to list email campaigns")
 	}
 
-	return &ListEmailCampaignsOutput{Body: campaigns}, nil
+	responses, err := h.campaignResponses(ctx, campaigns)
+	if err != nil {
+		return nil, campaignHTTPError(err, "Failed to list email campaigns")
+	}
+
+	return &ListEmailCampaignsOutput{Body: responses}, nil
 }
 
 func (h *emailCampaignHandler) handleGetCampaign(ctx context.Context, input *struct {
@@ -186,7 +226,7 @@ func (h *emailCampaignHandler) handleGetCampaign(ctx context.Context, input *str
 		return nil, campaignHTTPError(err, "Failed to get email campaign")
 	}
 
-	return &EmailCampaignOutput{Body: campaign}, nil
+	return h.campaignOutput(ctx, campaign)
 }
 
 func (h *emailCampaignHandler) handleUpdateCampaign(ctx context.Context, input *struct {
@@ -223,6 +263,7 @@ func (h *emailCampaignHandler) handleUpdateCampaign(ctx context.Context, input *
 		BodyDoUpdate:            input.Body.Body != nil,
 		FormatDoUpdate:          input.Body.Format != nil,
 		RecipientTypesDoUpdate:  input.Body.RecipientTypes != nil,
+		CategoryDoUpdate:        input.Body.Category != nil,
 		ScheduledAtDoUpdate:     input.Body.ScheduledAt != nil,
 		UpdatedByUserIDDoUpdate: true,
 		UpdatedByUserID:         userCtx.UserID,
@@ -251,6 +292,12 @@ func (h *emailCampaignHandler) handleUpdateCampaign(ctx context.Context, input *
 	if input.Body.RecipientTypes != nil {
 		params.RecipientTypes = *input.Body.RecipientTypes
 	}
+	if input.Body.Category != nil {
+		params.Category = sqlc.NullEmailCategory{
+			EmailCategory: *input.Body.Category,
+			Valid:         true,
+		}
+	}
 	if input.Body.ScheduledAt != nil {
 		params.ScheduledAt = input.Body.ScheduledAt
 	}
@@ -261,7 +308,7 @@ func (h *emailCampaignHandler) handleUpdateCampaign(ctx context.Context, input *
 		return nil, campaignHTTPError(err, "Failed to update email campaign")
 	}
 
-	return &EmailCampaignOutput{Body: campaign}, nil
+	return h.campaignOutput(ctx, campaign)
 }
 
 func (h *emailCampaignHandler) handleUpdateCampaignStatus(ctx context.Context, input *struct {
@@ -296,7 +343,82 @@ func (h *emailCampaignHandler) handleUpdateCampaignStatus(ctx context.Context, i
 		return nil, campaignHTTPError(err, "Failed to update email campaign status")
 	}
 
-	return &EmailCampaignOutput{Body: campaign}, nil
+	return h.campaignOutput(ctx, campaign)
+}
+
+func (h *emailCampaignHandler) handleSendCampaign(ctx context.Context, input *struct {
+	CampaignID  string `path:"campaignId"`
+	HackathonID string `query:"hackathonId" required:"true"`
+}) (*EmailCampaignOutput, error) {
+	userCtx := ctxutils.GetUserFromCtx(ctx)
+	if userCtx == nil {
+		return nil, huma.Error400BadRequest("Failed to get current user info")
+	}
+
+	campaignID, err := uuid.Parse(input.CampaignID)
+	if err != nil {
+		return nil, huma.Error400BadRequest("Invalid campaign id")
+	}
+
+	campaign, err := h.emailCampaignService.SendCampaign(ctx, campaignID, input.HackathonID, &userCtx.UserID)
+	if err != nil {
+		h.logger.Err(err).Msg("Failed to send email campaign")
+		return nil, campaignHTTPError(err, "Failed to send email campaign")
+	}
+
+	return h.campaignOutput(ctx, campaign)
+}
+
+func (h *emailCampaignHandler) handleResendFailedCampaign(ctx context.Context, input *struct {
+	CampaignID  string `path:"campaignId"`
+	HackathonID string `query:"hackathonId" required:"true"`
+}) (*EmailCampaignOutput, error) {
+	userCtx := ctxutils.GetUserFromCtx(ctx)
+	if userCtx == nil {
+		return nil, huma.Error400BadRequest("Failed to get current user info")
+	}
+
+	campaignID, err := uuid.Parse(input.CampaignID)
+	if err != nil {
+		return nil, huma.Error400BadRequest("Invalid campaign id")
+	}
+
+	campaign, err := h.emailCampaignService.ResendFailedDeliveries(ctx, campaignID, input.HackathonID, &userCtx.UserID)
+	if err != nil {
+		h.logger.Err(err).Msg("Failed to resend email campaign")
+		return nil, campaignHTTPError(err, "Failed to resend email campaign")
+	}
+
+	return h.campaignOutput(ctx, campaign)
+}
+
+func (h *emailCampaignHandler) campaignOutput(ctx context.Context, campaign *sqlc.EmailCampaign) (*EmailCampaignOutput, error) {
+	responses, err := h.campaignResponses(ctx, []sqlc.EmailCampaign{*campaign})
+	if err != nil {
+		return nil, campaignHTTPError(err, "Failed to get email campaign deliveries")
+	}
+
+	return &EmailCampaignOutput{Body: &responses[0]}, nil
+}
+
+// campaignResponses adds the delivery counts to the campaigns.
+func (h *emailCampaignHandler) campaignResponses(ctx context.Context, campaigns []sqlc.EmailCampaign) ([]EmailCampaignResponse, error) {
+	campaignIDs := make([]uuid.UUID, len(campaigns))
+	for i, campaign := range campaigns {
+		campaignIDs[i] = campaign.ID
+	}
+
+	counts, err := h.emailCampaignService.GetDeliveryCounts(ctx, campaignIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]EmailCampaignResponse, len(campaigns))
+	for i, campaign := range campaigns {
+		responses[i] = EmailCampaignResponse{EmailCampaign: campaign, Deliveries: counts[campaign.ID]}
+	}
+
+	return responses, nil
 }
 
 func campaignHTTPError(err error, fallback string) error {
@@ -309,10 +431,19 @@ func campaignHTTPError(err error, fallback string) error {
 		errors.Is(err, ErrEmailCampaignSubjectRequired) ||
 		errors.Is(err, ErrEmailCampaignBodyRequired) ||
 		errors.Is(err, ErrEmailCampaignRecipientsRequired) ||
+		errors.Is(err, ErrUnknownEmailCategory) ||
 		errors.Is(err, ErrEmailCampaignScheduledAtRequired) ||
-		errors.Is(err, ErrEmailCampaignSentAtRequired) {
+		errors.Is(err, ErrEmailCampaignSentAtRequired) ||
+		errors.Is(err, ErrEmailCampaignCannotSend) ||
+		errors.Is(err, ErrEmailCampaignManualSending) ||
+		errors.Is(err, ErrEmailCampaignCannotResend) ||
+		errors.Is(err, ErrEmailCampaignNoFailedDeliveries) {
 		return huma.Error400BadRequest(err.Error())
 	}
 
+	if errors.Is(err, ErrEmailCampaignStatusChanged) {
+		return huma.Error409Conflict(err.Error())
+	}
+
 	return huma.Error500InternalServerError(fallback)
 }
@@ -0,0 +1,586 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"slices"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	"github.com/swamphacks/core/apps/api/internal/database/sqlc"
+	"github.com/swamphacks/core/apps/api/internal/emailutils"
+	"github.com/swamphacks/core/apps/api/internal/tasks"
+)
+
+var (
+	ErrEmailCampaignCannotSend   = errors.New("email campaign is already sending or sent")
+	ErrEmailCampaignNotSending   = errors.New("email campaign is not sending")
+	ErrEmailCampaignNoRecipients = errors.New("email campaign has no recipients")
+	ErrEmailCampaignRender       = errors.New("email campaign body could not be rendered")
+	ErrQueueEmailCampaign        = errors.New("failed to queue email campaign")
+	ErrDispatchEmailCampaigns    = errors.New("failed to dispatch scheduled email campaigns")
+)
+
+const (
+	// campaignBatchSize is how many recipients a single batch task sends to.
+	campaignBatchSize = 50
+
+	// campaignSendRate is how many campaign emails a batch sends per second. The email
+	// worker runs one task at a time, so this is also the overall rate, kept below the
+	// SES sending limit so transactional emails still get through.
+	campaignSendRate = 10
+
+	// campaignDispatchLimit is how many due campaigns are claimed at once.
+	campaignDispatchLimit = 20
+)
+
+// campaignTemplateData is what campaign bodies can use, e.g. {{ .Name }}. Interest
+// subscribers have no name. UnsubscribeLink opens the unsubscribe page of the web app,
+// it's empty when unsubscribe links aren't configured.
+type campaignTemplateData struct {
+	Name            string
+	UnsubscribeLink string
+}
+
+// SendCampaign moves a campaign to sending and queues it on the email queue. Draft and
+// scheduled campaigns can be sent, and failed ones sent again.
+func (s *EmailCampaignService) SendCampaign(
+	ctx context.Context,
+	campaignID uuid.UUID,
+	hackathonID string,
+	userID *uuid.UUID,
+) (*sqlc.EmailCampaign, error) {
+	existingCampaign, err := s.emailCampaignRepo.GetEmailCampaignByID(ctx, sqlc.GetEmailCampaignByIDParams{
+		ID:          campaignID,
+		HackathonID: hackathonID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !canSendCampaign(existingCampaign.Status) {
+		return nil, ErrEmailCampaignCannotSend
+	}
+
+	campaign, err := s.emailCampaignRepo.StartEmailCampaignSending(ctx, sqlc.StartEmailCampaignSendingParams{
+		UpdatedByUserID: userID,
+		ID:              campaignID,
+		HackathonID:     hackathonID,
+	})
+	if err != nil {
+		// Someone else started sending it in the meantime.
+		if errors.Is(err, ErrEmailCampaignNotFound) {
+			return nil, ErrEmailCampaignCannotSend
+		}
+		return nil, err
+	}
+
+	if err := s.queueCampaign(ctx, campaign); err != nil {
+		return nil, err
+	}
+
+	return campaign, nil
+}
+
+// DispatchDueCampaigns starts sending every scheduled campaign whose scheduled_at has
+// passed and returns how many it started. Claiming a campaign moves it to sending in
+// the same statement that finds it, so a campaign is only sent once even if several
+// workers dispatch at the same time, and cancelling or rescheduling it works until then.
+func (s *EmailCampaignService) DispatchDueCampaigns(ctx context.Context) (int, error) {
+	dispatched := 0
+
+	for {
+		campaigns, err := s.emailCampaignRepo.ClaimDueEmailCampaigns(ctx, campaignDispatchLimit)
+		if err != nil {
+			s.logger.Err(err).Msg("Failed to claim due email campaigns")
+			return dispatched, ErrDispatchEmailCampaigns
+		}
+
+		for i := range campaigns {
+			// A campaign that fails to queue is marked as failed, the others still go out.
+			if err := s.queueCampaign(ctx, &campaigns[i]); err == nil {
+				dispatched++
+			}
+		}
+
+		if len(campaigns) < campaignDispatchLimit {
+			return dispatched, nil
+		}
+	}
+}
+
+// queueCampaign queues a campaign that was moved to sending. If that fails the campaign
+// is marked as failed.
+func (s *EmailCampaignService) queueCampaign(ctx context.Context, campaign *sqlc.EmailCampaign) error {
+	task, err := tasks.NewTaskSendCampaign(tasks.SendCampaignPayload{
+		CampaignID:  campaign.ID,
+		HackathonID: campaign.HackathonID,
+	})
+	if err == nil {
+		_, err = s.taskQueue.Enqueue(task, asynq.Queue("email"))
+	}
+	if err != nil {
+		s.logger.Err(err).Str("CampaignID", campaign.ID.String()).Msg("Failed to queue email campaign")
+		s.failCampaign(ctx, campaign, err)
+		return ErrQueueEmailCampaign
+	}
+
+	return nil
+}
+
+// PrepareCampaign resolves the recipients of a sending campaign, records a delivery for
+// each and queues a batch task for every campaignBatchSize of them. The last batch to
+// finish marks the campaign as sent, or it's marked as sent right away if an earlier
+// attempt already reached every recipient. If the campaign can't go out it is marked
+// as failed.
+func (s *EmailCampaignService) PrepareCampaign(ctx context.Context, campaignID uuid.UUID, hackathonID string) error {
+	campaign, err := s.getSendingCampaign(ctx, campaignID, hackathonID)
+	if err != nil {
+		return err
+	}
+
+	recipientTypes := make([]string, len(campaign.RecipientTypes))
+	for i, recipientType := range campaign.RecipientTypes {
+		recipientTypes[i] = string(recipientType)
+	}
+
+	rows, err := s.emailCampaignRepo.ListEmailCampaignRecipients(ctx, sqlc.ListEmailCampaignRecipientsParams{
+		RecipientTypes: recipientTypes,
+		HackathonID:    campaign.HackathonID,
+	})
+	if err != nil {
+		s.failCampaign(ctx, campaign, err)
+		return err
+	}
+
+	recipients := dedupeRecipients(rows)
+
+	suppressed, err := s.suppressedRecipients(ctx, recipients)
+	if err != nil {
+		s.failCampaign(ctx, campaign, err)
+		return err
+	}
+	if len(suppressed) > 0 {
+		recipients = slices.DeleteFunc(recipients, func(recipient tasks.CampaignRecipient) bool {
+			return suppressed[recipient.Email]
+		})
+		s.logger.Info().Str("CampaignID", campaign.ID.String()).Int("Suppressed", len(suppressed)).Msg("Left suppressed recipients out of email campaign")
+	}
+
+	unsubscribed, err := s.unsubscribedRecipients(ctx, campaign.Category, recipients)
+	if err != nil {
+		s.failCampaign(ctx, campaign, err)
+		return err
+	}
+	if len(unsubscribed) > 0 {
+		recipients = slices.DeleteFunc(recipients, func(recipient tasks.CampaignRecipient) bool {
+			return unsubscribed[recipient.Email]
+		})
+		s.logger.Info().Str("CampaignID", campaign.ID.String()).Int("Unsubscribed", len(unsubscribed)).Msg("Left unsubscribed recipients out of email campaign")
+	}
+
+	if len(recipients) == 0 {
+		s.failCampaign(ctx, campaign, ErrEmailCampaignNoRecipients)
+		return ErrEmailCampaignNoRecipients
+	}
+
+	// Catch template errors once instead of in every email.
+	if _, err := renderCampaignBody(campaign.Format, campaign.Body, campaignTemplateData{Name: recipients[0].Name}); err != nil {
+		s.failCampaign(ctx, campaign, err)
+		return err
+	}
+
+	created, err := s.createCampaignDeliveries(ctx, campaign, recipients)
+	if err != nil {
+		s.failCampaign(ctx, campaign, err)
+		return err
+	}
+
+	// A failed campaign was sent again after an earlier attempt reached everyone.
+	if len(created) == 0 {
+		return s.completeDeliveredCampaign(ctx, campaign, len(recipients))
+	}
+	recipients = created
+
+	if err := s.emailCampaignRepo.UpdateEmailCampaignRecipientCount(ctx, sqlc.UpdateEmailCampaignRecipientCountParams{
+		RecipientCount: int32(len(recipients)),
+		ID:             campaign.ID,
+	}); err != nil {
+		s.failCampaign(ctx, campaign, err)
+		return err
+	}
+
+	return s.queueCampaignBatches(ctx, campaign, recipients)
+}
+
+// completeDeliveredCampaign marks a campaign whose recipients were all reached by an
+// earlier attempt as sent to them.
+func (s *EmailCampaignService) completeDeliveredCampaign(ctx context.Context, campaign *sqlc.EmailCampaign, delivered int) error {
+	if err := s.emailCampaignRepo.UpdateEmailCampaignRecipientCount(ctx, sqlc.UpdateEmailCampaignRecipientCountParams{
+		RecipientCount: int32(delivered),
+		ID:             campaign.ID,
+	}); err != nil {
+		s.failCampaign(ctx, campaign, err)
+		return err
+	}
+
+	if _, err := s.emailCampaignRepo.RecordEmailCampaignBatch(ctx, sqlc.RecordEmailCampaignBatchParams{
+		Sent: int32(delivered),
+		ID:   campaign.ID,
+	}); err != nil {
+		s.failCampaign(ctx, campaign, err)
+		return err
+	}
+
+	s.logger.Info().Str("CampaignID", campaign.ID.String()).Int("Recipients", delivered).Msg("Every recipient of the email campaign was already sent to")
+
+	return nil
+}
+
+// createCampaignDeliveries records a delivery for every recipient and returns the
+// recipients with their delivery. When a failed campaign is sent again, recipients an
+// earlier attempt reached are left out.
+func (s *EmailCampaignService) createCampaignDeliveries(ctx context.Context, campaign *sqlc.EmailCampaign, recipients []tasks.CampaignRecipient) ([]tasks.CampaignRecipient, error) {
+	if err := s.emailDeliveryRepo.DeleteUnsentCampaignDeliveries(ctx, campaign.ID); err != nil {
+		return nil, err
+	}
+
+	addresses := make([]string, len(recipients))
+	names := make([]string, len(recipients))
+	for i, recipient := range recipients {
+		addresses[i] = recipient.Email
+		names[i] = recipient.Name
+	}
+
+	rows, err := s.emailDeliveryRepo.CreateCampaignDeliveries(ctx, sqlc.CreateCampaignDeliveriesParams{
+		CampaignID:     campaign.ID,
+		Subject:        campaign.Subject,
+		Recipients:     addresses,
+		RecipientNames: names,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	created := make([]tasks.CampaignRecipient, len(rows))
+	for i, row := range rows {
+		created[i] = campaignRecipient(row.ID, row.Recipient, row.RecipientName)
+	}
+
+	return created, nil
+}
+
+// queueCampaignBatches queues a batch task for every campaignBatchSize recipients of a
+// sending campaign. If that fails the campaign is marked as failed.
+func (s *EmailCampaignService) queueCampaignBatches(ctx context.Context, campaign *sqlc.EmailCampaign, recipients []tasks.CampaignRecipient) error {
+	for start := 0; start < len(recipients); start += campaignBatchSize {
+		end := min(start+campaignBatchSize, len(recipients))
+
+		task, err := tasks.NewTaskSendCampaignBatch(tasks.SendCampaignBatchPayload{
+			CampaignID:  campaign.ID,
+			HackathonID: campaign.HackathonID,
+			Recipients:  recipients[start:end],
+		})
+		if err == nil {
+			_, err = s.taskQueue.Enqueue(task, asynq.Queue("email"))
+		}
+		if err != nil {
+			// Batches that were already queued skip themselves once the campaign failed.
+			s.logger.Err(err).Str("CampaignID", campaign.ID.String()).Int("BatchStart", start).Msg("Failed to queue email campaign batch")
+			s.failCampaign(ctx, campaign, err)
+			return ErrQueueEmailCampaign
+		}
+	}
+
+	s.logger.Info().Str("CampaignID", campaign.ID.String()).Int("Recipients", len(recipients)).Msg("Queued email campaign batches")
+
+	return nil
+}
+
+// SendCampaignBatch sends a campaign to a batch of recipients at campaignSendRate and
+// records how many emails went out. Emails that fail are counted, not retried. If the
+// batch can't be sent the campaign is marked as failed, unless the worker is shutting
+// down: then the context error is returned and the batch can run again, skipping the
+// recipients that were already sent to.
+func (s *EmailCampaignService) SendCampaignBatch(ctx context.Context, payload tasks.SendCampaignBatchPayload) error {
+	campaign, err := s.getSendingCampaign(ctx, payload.CampaignID, payload.HackathonID)
+	if err != nil {
+		return err
+	}
+
+	// An earlier run of the batch may have been interrupted after sending some emails.
+	alreadySent, err := s.sentDeliveries(ctx, payload.Recipients)
+	if err != nil {
+		s.failCampaign(ctx, campaign, err)
+		return err
+	}
+
+	// Recipients can bounce, complain about an earlier email or unsubscribe while the
+	// campaign is sending.
+	suppressed, err := s.suppressedRecipients(ctx, payload.Recipients)
+	if err != nil {
+		s.failCampaign(ctx, campaign, err)
+		return err
+	}
+	unsubscribed, err := s.unsubscribedRecipients(ctx, campaign.Category, payload.Recipients)
+	if err != nil {
+		s.failCampaign(ctx, campaign, err)
+		return err
+	}
+
+	ticker := time.NewTicker(time.Second / campaignSendRate)
+	defer ticker.Stop()
+
+	var sent, failed int32
+	var lastError *string
+
+	for i, recipient := range payload.Recipients {
+		// The interrupted run never recorded its counts, so they're counted here.
+		if alreadySent[recipient.DeliveryID] {
+			sent++
+			continue
+		}
+		if suppressed[recipient.Email] {
+			recordDelivery(ctx, s.emailDeliveryRepo, s.logger, &recipient.DeliveryID, "", ErrRecipientSuppressed)
+			failed++
+			continue
+		}
+		if unsubscribed[recipient.Email] {
+			recordDelivery(ctx, s.emailDeliveryRepo, s.logger, &recipient.DeliveryID, "", ErrRecipientUnsubscribed)
+			failed++
+			continue
+		}
+
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-ticker.C:
+			}
+		}
+
+		messageID, err := s.sendCampaignEmail(ctx, campaign, recipient)
+		recordDelivery(ctx, s.emailDeliveryRepo, s.logger, &recipient.DeliveryID, messageID, err)
+		if err != nil {
+			s.logger.Err(err).Str("CampaignID", campaign.ID.String()).Msg("Failed to send campaign email")
+			failed++
+			message := fmt.Sprintf("failed to send to %s: %v", recipient.Email, err)
+			lastError = &message
+			continue
+		}
+		sent++
+	}
+
+	updated, err := s.emailCampaignRepo.RecordEmailCampaignBatch(ctx, sqlc.RecordEmailCampaignBatchParams{
+		Sent:      sent,
+		Failed:    failed,
+		LastError: lastError,
+		ID:        campaign.ID,
+	})
+	if err != nil {
+		s.logger.Err(err).Str("CampaignID", campaign.ID.String()).Msg("Failed to record email campaign batch")
+		s.failCampaign(ctx, campaign, err)
+		return err
+	}
+
+	if updated.Status != sqlc.EmailCampaignStatusSending {
+		s.logger.Info().
+			Str("CampaignID", updated.ID.String()).
+			Str("Status", string(updated.Status)).
+			Int32("Sent", updated.EmailsSent).
+			Int32("Failed", updated.EmailsFailed).
+			Msg("Finished sending email campaign")
+	}
+
+	return nil
+}
+
+// sentDeliveries returns the deliveries of the recipients that were already sent.
+func (s *EmailCampaignService) sentDeliveries(ctx context.Context, recipients []tasks.CampaignRecipient) (map[uuid.UUID]bool, error) {
+	ids := make([]uuid.UUID, len(recipients))
+	for i, recipient := range recipients {
+		ids[i] = recipient.DeliveryID
+	}
+
+	sentIDs, err := s.emailDeliveryRepo.ListSentEmailDeliveryIds(ctx, ids)
+	if err != nil {
+		s.logger.Err(err).Msg("Failed to check sent email deliveries")
+		return nil, err
+	}
+
+	sent := make(map[uuid.UUID]bool, len(sentIDs))
+	for _, id := range sentIDs {
+		sent[id] = true
+	}
+
+	return sent, nil
+}
+
+// suppressedRecipients returns the addresses of the recipients that are on the
+// suppression list.
+func (s *EmailCampaignService) suppressedRecipients(ctx context.Context, recipients []tasks.CampaignRecipient) (map[string]bool, error) {
+	addresses := make([]string, len(recipients))
+	for i, recipient := range recipients {
+		addresses[i] = recipient.Email
+	}
+
+	_, suppressedAddresses, err := filterSuppressed(ctx, s.emailSuppressionRepo, addresses)
+	if err != nil {
+		s.logger.Err(err).Msg("Failed to check email suppressions")
+		return nil, err
+	}
+
+	suppressed := make(map[string]bool, len(suppressedAddresses))
+	for _, address := range suppressedAddresses {
+		suppressed[address] = true
+	}
+
+	return suppressed, nil
+}
+
+// unsubscribedRecipients returns the addresses of the recipients that unsubscribed from
+// the campaign's category.
+func (s *EmailCampaignService) unsubscribedRecipients(ctx context.Context, category sqlc.EmailCategory, recipients []tasks.CampaignRecipient) (map[string]bool, error) {
+	addresses := make([]string, len(recipients))
+	for i, recipient := range recipients {
+		addresses[i] = recipient.Email
+	}
+
+	_, unsubscribedAddresses, err := filterUnsubscribed(ctx, s.emailPreferenceRepo, category, addresses)
+	if err != nil {
+		s.logger.Err(err).Msg("Failed to check email preferences")
+		return nil, err
+	}
+
+	unsubscribed := make(map[string]bool, len(unsubscribedAddresses))
+	for _, address := range unsubscribedAddresses {
+		unsubscribed[address] = true
+	}
+
+	return unsubscribed, nil
+}
+
+func (s *EmailCampaignService) sendCampaignEmail(ctx context.Context, campaign *sqlc.EmailCampaign, recipient tasks.CampaignRecipient) (string, error) {
+	body, err := renderCampaignBody(campaign.Format, campaign.Body, campaignTemplateData{
+		Name:            recipient.Name,
+		UnsubscribeLink: unsubscribePageURL(s.config, recipient.Email, campaign.Category),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	email := emailutils.Email{
+		To:      []string{recipient.Email},
+		From:    defaultSender,
+		Subject: campaign.Subject,
+	}
+	if campaign.Format == sqlc.EmailCampaignFormatHtml {
+		email.Html = body
+	} else {
+		email.Text = body
+	}
+
+	return s.sender.Send(ctx, withOptions(email, withUnsubscribe(s.config, tasks.EmailOptions{}, recipient.Email, campaign.Category)))
+}
+
+func (s *EmailCampaignService) getSendingCampaign(ctx context.Context, campaignID uuid.UUID, hackathonID string) (*sqlc.EmailCampaign, error) {
+	campaign, err := s.emailCampaignRepo.GetEmailCampaignByID(ctx, sqlc.GetEmailCampaignByIDParams{
+		ID:          campaignID,
+		HackathonID: hackathonID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if campaign.Status != sqlc.EmailCampaignStatusSending {
+		return nil, ErrEmailCampaignNotSending
+	}
+
+	return campaign, nil
+}
+
+// failCampaign marks a campaign as failed with the cause as its last error.
+func (s *EmailCampaignService) failCampaign(ctx context.Context, campaign *sqlc.EmailCampaign, cause error) {
+	lastError := cause.Error()
+
+	if _, err := s.emailCampaignRepo.UpdateEmailCampaignStatus(ctx, sqlc.UpdateEmailCampaignStatusParams{
+		Status:            sqlc.EmailCampaignStatusFailed,
+		LastErrorDoUpdate: true,
+		LastError:         &lastError,
+		ID:                campaign.ID,
+		HackathonID:       campaign.HackathonID,
+		ExpectedStatus: sqlc.NullEmailCampaignStatus{
+			EmailCampaignStatus: sqlc.EmailCampaignStatusSending,
+			Valid:               true,
+		},
+	}); err != nil {
+		s.logger.Err(err).Str("CampaignID", campaign.ID.String()).Msg("Failed to mark email campaign as failed")
+	}
+}
+
+// canSendCampaign reports whether a campaign in the given status can start sending.
+func canSendCampaign(status sqlc.EmailCampaignStatus) bool {
+	return status == sqlc.EmailCampaignStatusDraft ||
+		status == sqlc.EmailCampaignStatusScheduled ||
+		status == sqlc.EmailCampaignStatusFailed
+}
+
+// dedupeRecipients drops invalid addresses and keeps one recipient per address, compared
+// case-insensitively. A recipient with a name wins over one without, so users who also
+// subscribed to interest updates are greeted by name.
+func dedupeRecipients(rows []sqlc.ListEmailCampaignRecipientsRow) []tasks.CampaignRecipient {
+	var recipients []tasks.CampaignRecipient
+	indexes := make(map[string]int, len(rows))
+
+	for _, row := range rows {
+		address := strings.TrimSpace(row.Email)
+		if address == "" || !emailutils.IsValidEmail(address) {
+			continue
+		}
+
+		key := strings.ToLower(address)
+		if i, ok := indexes[key]; ok {
+			if recipients[i].Name == "" {
+				recipients[i].Name = row.Name
+			}
+			continue
+		}
+
+		indexes[key] = len(recipients)
+		recipients = append(recipients, tasks.CampaignRecipient{Name: row.Name, Email: address})
+	}
+
+	return recipients
+}
+
+// renderCampaignBody fills in the campaign body template. HTML bodies escape the data.
+func renderCampaignBody(format sqlc.EmailCampaignFormat, body string, data campaignTemplateData) (string, error) {
+	var rendered bytes.Buffer
+	var err error
+
+	if format == sqlc.EmailCampaignFormatHtml {
+		var tmpl *htmltemplate.Template
+		tmpl, err = htmltemplate.New("campaign").Parse(body)
+		if err == nil {
+			err = tmpl.Execute(&rendered, data)
+		}
+	} else {
+		var tmpl *texttemplate.Template
+		tmpl, err = texttemplate.New("campaign").Parse(body)
+		if err == nil {
+			err = tmpl.Execute(&rendered, data)
+		}
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrEmailCampaignRender, err)
+	}
+
+	return rendered.String(), nil
+}
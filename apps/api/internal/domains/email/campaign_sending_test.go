@@ -0,0 +1,147 @@
+package email
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/swamphacks/core/apps/api/internal/database/sqlc"
+	"github.com/swamphacks/core/apps/api/internal/tasks"
+)
+
+func TestCanSendCampaign(t *testing.T) {
+	tests := []struct {
+		status   sqlc.EmailCampaignStatus
+		expected bool
+	}{
+		{status: sqlc.EmailCampaignStatusDraft, expected: true},
+		{status: sqlc.EmailCampaignStatusScheduled, expected: true},
+		{status: sqlc.EmailCampaignStatusSending, expected: false},
+		{status: sqlc.EmailCampaignStatusSent, expected: false},
+		{status: sqlc.EmailCampaignStatusFailed, expected: true},
+	}
+
+	for _, test := range tests {
+		t.Run(string(test.status), func(t *testing.T) {
+			result := canSendCampaign(test.status)
+
+			if result != test.expected {
+				t.Fatalf("expected %v, got %v", test.expected, result)
+			}
+		})
+	}
+}
+
+func TestDedupeRecipients(t *testing.T) {
+	tests := []struct {
+		name     string
+		rows     []sqlc.ListEmailCampaignRecipientsRow
+		expected []tasks.CampaignRecipient
+	}{
+		{
+			name:     "no rows",
+			rows:     nil,
+			expected: nil,
+		},
+		{
+			name: "same address in different case",
+			rows: []sqlc.ListEmailCampaignRecipientsRow{
+				{Name: "Albert", Email: "albert@ufl.edu"},
+				{Name: "Albert Gator", Email: " Albert@UFL.edu "},
+			},
+			expected: []tasks.CampaignRecipient{
+				{Name: "Albert", Email: "albert@ufl.edu"},
+			},
+		},
+		{
+			name: "subscriber who is also a user keeps the name",
+			rows: []sqlc.ListEmailCampaignRecipientsRow{
+				{Name: "", Email: "alberta@ufl.edu"},
+				{Name: "Alberta", Email: "alberta@ufl.edu"},
+			},
+			expected: []tasks.CampaignRecipient{
+				{Name: "Alberta", Email: "alberta@ufl.edu"},
+			},
+		},
+		{
+			name: "invalid and empty addresses are dropped",
+			rows: []sqlc.ListEmailCampaignRecipientsRow{
+				{Name: "No Email", Email: ""},
+				{Name: "Typo", Email: "not-an-address"},
+				{Name: "Valid", Email: "valid@swamphacks.com"},
+			},
+			expected: []tasks.CampaignRecipient{
+				{Name: "Valid", Email: "valid@swamphacks.com"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result := dedupeRecipients(test.rows)
+
+			if !slices.Equal(result, test.expected) {
+				t.Fatalf("expected %v, got %v", test.expected, result)
+			}
+		})
+	}
+}
+
+func TestRenderCampaignBody(t *testing.T) {
+	tests := []struct {
+		name          string
+		format        sqlc.EmailCampaignFormat
+		body          string
+		data          campaignTemplateData
+		expected      string
+		expectedError error
+	}{
+		{
+			name:     "text with name",
+			format:   sqlc.EmailCampaignFormatText,
+			body:     "Hi {{ .Name }}, see you soon!",
+			data:     campaignTemplateData{Name: "Albert"},
+			expected: "Hi Albert, see you soon!",
+		},
+		{
+			name:     "text is not escaped",
+			format:   sqlc.EmailCampaignFormatText,
+			body:     "Hi {{ .Name }}",
+			data:     campaignTemplateData{Name: "<Albert>"},
+			expected: "Hi <Albert>",
+		},
+		{
+			name:     "html is escaped",
+			format:   sqlc.EmailCampaignFormatHtml,
+			body:     "<p>Hi {{ .Name }}</p>",
+			data:     campaignTemplateData{Name: "<Albert>"},
+			expected: "<p>Hi &lt;Albert&gt;</p>",
+		},
+		{
+			name:          "unknown field",
+			format:        sqlc.EmailCampaignFormatText,
+			body:          "Hi {{ .FirstName }}",
+			expectedError: ErrEmailCampaignRender,
+		},
+		{
+			name:          "invalid template",
+			format:        sqlc.EmailCampaignFormatHtml,
+			body:          "<p>Hi {{ .Name </p>",
+			expectedError: ErrEmailCampaignRender,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := renderCampaignBody(test.format, test.body, test.data)
+
+			if !errors.Is(err, test.expectedError) {
+				t.Fatalf("expected error %v, got %v", test.expectedError, err)
+			}
+
+			if result != test.expected {
+				t.Fatalf("expected %q, got %q", test.expected, result)
+			}
+		})
+	}
+}
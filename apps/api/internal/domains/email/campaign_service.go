@@ -5,9 +5,12 @@ import (
 	"errors"
 	"strings"
 
+	"github.com/hibiken/asynq"
 	"github.com/rs/zerolog"
+	"github.com/swamphacks/core/apps/api/internal/config"
 	"github.com/swamphacks/core/apps/api/internal/database/repository"
 	"github.com/swamphacks/core/apps/api/internal/database/sqlc"
+	"github.com/swamphacks/core/apps/api/internal/emailutils"
 )
 
 var (
@@ -20,7 +23,11 @@ var (
 	ErrEmailCampaignBodyRequired       = errors.New("email campaign body is required")
 	ErrEmailCampaignRecipientsRequired = errors.New("email campaign recipients are required")
 
-	ErrEmailCampaignCannotEdit = errors.New("email campaign cannot be edited")
+	ErrEmailCampaignCannotEdit    = errors.New("email campaign cannot be edited")
+	ErrEmailCampaignStatusChanged = errors.New("email campaign status changed in the meantime")
+
+	// Campaigns only start sending through SendCampaign or the scheduled dispatcher.
+	ErrEmailCampaignManualSending = errors.New("email campaigns cannot be moved to sending manually")
 
 	//status-specific validation errors
 	ErrEmailCampaignScheduledAtRequired = errors.New("scheduled_at is required for scheduled campaigns")
@@ -29,19 +36,37 @@ var (
 
 // EmailCampaignService owns business rules for saved email campaigns.
 type EmailCampaignService struct {
-	emailCampaignRepo *repository.EmailCampaignRepository
-	logger            zerolog.Logger
+	emailCampaignRepo    *repository.EmailCampaignRepository
+	emailDeliveryRepo    *repository.EmailDeliveryRepository
+	emailSuppressionRepo *repository.EmailSuppressionRepository
+	emailPreferenceRepo  *repository.EmailPreferenceRepository
+	taskQueue            *asynq.Client
+	sender               emailutils.EmailSender
+	config               *config.Config
+	logger               zerolog.Logger
 }
 
 // NewEmailCampaignService creates the service and stores its dependencies.
-// This will eventually be called from api.go when wiring the app together.
+// The task queue and email sender are used to send campaigns.
 func NewEmailCampaignService(
 	emailCampaignRepo *repository.EmailCampaignRepository,
+	emailDeliveryRepo *repository.EmailDeliveryRepository,
+	emailSuppressionRepo *repository.EmailSuppressionRepository,
+	emailPreferenceRepo *repository.EmailPreferenceRepository,
+	taskQueue *asynq.Client,
+	sender emailutils.EmailSender,
+	config *config.Config,
 	logger zerolog.Logger,
 ) *EmailCampaignService {
 	return &EmailCampaignService{
-		emailCampaignRepo: emailCampaignRepo,
-		logger:            logger.With().Str("service", "EmailCampaignService").Str("domain", "email").Logger(),
+		emailCampaignRepo:    emailCampaignRepo,
+		emailDeliveryRepo:    emailDeliveryRepo,
+		emailSuppressionRepo: emailSuppressionRepo,
+		emailPreferenceRepo:  emailPreferenceRepo,
+		taskQueue:            taskQueue,
+		sender:               sender,
+		config:               config,
+		logger:               logger.With().Str("service", "EmailCampaignService").Str("domain", "email").Logger(),
 	}
 }
 
@@ -55,6 +80,13 @@ func (s *EmailCampaignService) CreateCampaign(
 		return nil, err
 	}
 
+	if params.Category == "" {
+		params.Category = sqlc.EmailCategoryMarketing
+	}
+	if !isEmailCategory(params.Category) {
+		return nil, ErrUnknownEmailCategory
+	}
+
 	return s.emailCampaignRepo.CreateEmailCampaign(ctx, params)
 }
 
@@ -94,11 +126,18 @@ func (s *EmailCampaignService) UpdateCampaign(
 		return nil, ErrEmailCampaignCannotEdit
 	}
 
-	return s.emailCampaignRepo.UpdateEmailCampaign(ctx, params)
+	campaign, err := s.emailCampaignRepo.UpdateEmailCampaign(ctx, params)
+	if errors.Is(err, ErrEmailCampaignNotFound) {
+		// The campaign started sending in the meantime.
+		return nil, ErrEmailCampaignCannotEdit
+	}
+
+	return campaign, err
 }
 
-// UpdateCampaignStatus changes lifecycle fields such as draft -> scheduled or sending -> sent.
+// UpdateCampaignStatus changes lifecycle fields such as draft -> scheduled or scheduled -> draft.
 // The database also has constraints, but checking here gives cleaner service-level errors.
+// A sending campaign can only be marked as failed, which stops its remaining batches.
 func (s *EmailCampaignService) UpdateCampaignStatus(
 	ctx context.Context,
 	params sqlc.UpdateEmailCampaignStatusParams,
@@ -111,7 +150,34 @@ func (s *EmailCampaignService) UpdateCampaignStatus(
 		return nil, ErrEmailCampaignSentAtRequired
 	}
 
-	return s.emailCampaignRepo.UpdateEmailCampaignStatus(ctx, params)
+	if params.Status == sqlc.EmailCampaignStatusSending {
+		return nil, ErrEmailCampaignManualSending
+	}
+
+	existingCampaign, err := s.emailCampaignRepo.GetEmailCampaignByID(ctx, sqlc.GetEmailCampaignByIDParams{
+		ID:          params.ID,
+		HackathonID: params.HackathonID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if existingCampaign.Status == sqlc.EmailCampaignStatusSending && params.Status != sqlc.EmailCampaignStatusFailed {
+		return nil, ErrEmailCampaignCannotEdit
+	}
+
+	// Only apply the change if the dispatcher didn't claim the campaign since it was read.
+	params.ExpectedStatus = sqlc.NullEmailCampaignStatus{
+		EmailCampaignStatus: existingCampaign.Status,
+		Valid:               true,
+	}
+
+	campaign, err := s.emailCampaignRepo.UpdateEmailCampaignStatus(ctx, params)
+	if errors.Is(err, ErrEmailCampaignNotFound) {
+		return nil, ErrEmailCampaignStatusChanged
+	}
+
+	return campaign, err
 }
 
 // validateCampaignContent checks fields that every campaign needs before it is saved.
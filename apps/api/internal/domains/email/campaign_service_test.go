@@ -136,4 +136,23 @@ func TestUpdateCampaignStatusRequiresSentAt(t *testing.T) {
 			err,
 		)
 	}
-}
\ No newline at end of file
+}
+
+func TestUpdateCampaignStatusRejectsManualSending(t *testing.T) {
+	service := &EmailCampaignService{}
+
+	_, err := service.UpdateCampaignStatus(
+		context.Background(),
+		sqlc.UpdateEmailCampaignStatusParams{
+			Status: sqlc.EmailCampaignStatusSending,
+		},
+	)
+
+	if !errors.Is(err, ErrEmailCampaignManualSending) {
+		t.Fatalf(
+			"expected %v, got %v",
+			ErrEmailCampaignManualSending,
+			err,
+		)
+	}
+}
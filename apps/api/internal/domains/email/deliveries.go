@@ -0,0 +1,171 @@
+package email
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/swamphacks/core/apps/api/internal/database/repository"
+	"github.com/swamphacks/core/apps/api/internal/database/sqlc"
+	"github.com/swamphacks/core/apps/api/internal/tasks"
+)
+
+var (
+	ErrEmailCampaignCannotResend       = errors.New("only sent or failed email campaigns can be resent")
+	ErrEmailCampaignNoFailedDeliveries = errors.New("email campaign has no failed deliveries")
+	ErrGetEmailCampaignDeliveryCounts  = errors.New("failed to get email campaign delivery counts")
+	ErrResendEmailCampaign             = errors.New("failed to resend email campaign")
+)
+
+// EmailDeliveryCounts are how many deliveries of a campaign are in every status.
+type EmailDeliveryCounts struct {
+	Queued     int64 `json:"queued"`
+	Sent       int64 `json:"sent"`
+	Failed     int64 `json:"failed"`
+	Bounced    int64 `json:"bounced"`
+	Complained int64 `json:"complained"`
+}
+
+// GetDeliveryCounts returns the delivery counts of the given campaigns, by campaign.
+// Campaigns without deliveries are left out.
+func (s *EmailCampaignService) GetDeliveryCounts(ctx context.Context, campaignIDs []uuid.UUID) (map[uuid.UUID]EmailDeliveryCounts, error) {
+	rows, err := s.emailDeliveryRepo.ListEmailDeliveryCountsByCampaignIds(ctx, campaignIDs)
+	if err != nil {
+		s.logger.Err(err).Msg("Failed to get email campaign delivery counts")
+		return nil, ErrGetEmailCampaignDeliveryCounts
+	}
+
+	counts := make(map[uuid.UUID]EmailDeliveryCounts, len(rows))
+	for _, row := range rows {
+		counts[row.CampaignID] = EmailDeliveryCounts{
+			Queued:     row.Queued,
+			Sent:       row.Sent,
+			Failed:     row.Failed,
+			Bounced:    row.Bounced,
+			Complained: row.Complained,
+		}
+	}
+
+	return counts, nil
+}
+
+// ResendFailedDeliveries sends a sent or failed campaign again, only to the recipients
+// whose delivery failed.
+func (s *EmailCampaignService) ResendFailedDeliveries(
+	ctx context.Context,
+	campaignID uuid.UUID,
+	hackathonID string,
+	userID *uuid.UUID,
+) (*sqlc.EmailCampaign, error) {
+	existingCampaign, err := s.emailCampaignRepo.GetEmailCampaignByID(ctx, sqlc.GetEmailCampaignByIDParams{
+		ID:          campaignID,
+		HackathonID: hackathonID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if existingCampaign.Status != sqlc.EmailCampaignStatusSent && existingCampaign.Status != sqlc.EmailCampaignStatusFailed {
+		return nil, ErrEmailCampaignCannotResend
+	}
+
+	counts, err := s.GetDeliveryCounts(ctx, []uuid.UUID{campaignID})
+	if err != nil {
+		return nil, err
+	}
+
+	failed := counts[campaignID].Failed
+	if failed == 0 {
+		return nil, ErrEmailCampaignNoFailedDeliveries
+	}
+
+	campaign, err := s.emailCampaignRepo.StartEmailCampaignResend(ctx, sqlc.StartEmailCampaignResendParams{
+		RecipientCount:  int32(failed),
+		UpdatedByUserID: userID,
+		ID:              campaignID,
+		HackathonID:     hackathonID,
+	})
+	if err != nil {
+		// Someone else started sending it in the meantime.
+		if errors.Is(err, ErrEmailCampaignNotFound) {
+			return nil, ErrEmailCampaignCannotResend
+		}
+		return nil, err
+	}
+
+	rows, err := s.emailDeliveryRepo.RequeueFailedCampaignDeliveries(ctx, campaignID)
+	if err != nil {
+		s.logger.Err(err).Str("CampaignID", campaignID.String()).Msg("Failed to requeue failed campaign deliveries")
+		s.failCampaign(ctx, campaign, err)
+		return nil, ErrResendEmailCampaign
+	}
+
+	if len(rows) == 0 {
+		s.failCampaign(ctx, campaign, ErrEmailCampaignNoFailedDeliveries)
+		return nil, ErrEmailCampaignNoFailedDeliveries
+	}
+
+	if int64(len(rows)) != failed {
+		campaign.RecipientCount = int32(len(rows))
+		if err := s.emailCampaignRepo.UpdateEmailCampaignRecipientCount(ctx, sqlc.UpdateEmailCampaignRecipientCountParams{
+			RecipientCount: campaign.RecipientCount,
+			ID:             campaign.ID,
+		}); err != nil {
+			s.failCampaign(ctx, campaign, err)
+			return nil, ErrResendEmailCampaign
+		}
+	}
+
+	recipients := make([]tasks.CampaignRecipient, len(rows))
+	for i, row := range rows {
+		recipients[i] = campaignRecipient(row.ID, row.Recipient, row.RecipientName)
+	}
+
+	if err := s.queueCampaignBatches(ctx, campaign, recipients); err != nil {
+		return nil, err
+	}
+
+	return campaign, nil
+}
+
+func campaignRecipient(deliveryID uuid.UUID, address string, name *string) tasks.CampaignRecipient {
+	recipient := tasks.CampaignRecipient{DeliveryID: deliveryID, Email: address}
+	if name != nil {
+		recipient.Name = *name
+	}
+	return recipient
+}
+
+// recordDelivery stores the outcome of sending a tracked email. Failing to record it is
+// only logged, since the email itself was already sent or not.
+func recordDelivery(
+	ctx context.Context,
+	emailDeliveryRepo *repository.EmailDeliveryRepository,
+	logger zerolog.Logger,
+	deliveryID *uuid.UUID,
+	messageID string,
+	sendErr error,
+) {
+	if deliveryID == nil {
+		return
+	}
+
+	var err error
+	if sendErr != nil {
+		message := sendErr.Error()
+		err = emailDeliveryRepo.MarkEmailDeliveryFailed(ctx, sqlc.MarkEmailDeliveryFailedParams{
+			Error: &message,
+			ID:    *deliveryID,
+		})
+	} else {
+		err = emailDeliveryRepo.MarkEmailDeliverySent(ctx, sqlc.MarkEmailDeliverySentParams{
+			SesMessageID: &messageID,
+			ID:           *deliveryID,
+		})
+	}
+
+	if err != nil {
+		logger.Err(err).Str("DeliveryID", deliveryID.String()).Msg("Failed to record email delivery")
+	}
+}
@@ -2,6 +2,7 @@ package email
 
 import (
 	"context"
+	"errors"
 	"net/http"
 
 	"github.com/danielgtaylor/huma/v2"
@@ -9,6 +10,7 @@ import (
 	"github.com/rs/zerolog"
 	"github.com/swamphacks/core/apps/api/internal/api/middleware"
 	"github.com/swamphacks/core/apps/api/internal/emailutils"
+	"github.com/swamphacks/core/apps/api/internal/tasks"
 )
 
 func RegisterRoutes(emailHandler *handler, group huma.API, mw *middleware.Middleware) {
@@ -70,9 +72,17 @@ func NewHandler(emailService *EmailService, logger zerolog.Logger) *handler {
 }
 
 type QueueTextEmailRequest struct {
-	To      []string `json:"to"`
-	Subject string   `json:"subject" minLength:"1"`
-	Body    string   `json:"body" minLength:"1"`
+	To          []string              `json:"to"`
+	Subject     string                `json:"subject" minLength:"1"`
+	Body        string                `json:"body" minLength:"1"`
+	ReplyTo     []string              `json:"replyTo,omitempty"`
+	Attachments []EmailAttachmentBody `json:"attachments,omitempty"`
+}
+
+type EmailAttachmentBody struct {
+	Filename    string `json:"filename" minLength:"1"`
+	ContentType string `json:"contentType,omitempty" doc:"Guessed from the filename when empty"`
+	Data        []byte `json:"data" doc:"Base64 encoded file"`
 }
 
 type QueueTextEmailOutput struct {
@@ -80,7 +90,8 @@ type QueueTextEmailOutput struct {
 }
 
 func (h *handler) handleQueueTextEmail(ctx context.Context, input *struct {
-	Body QueueTextEmailRequest
+	IdempotencyKey string `header:"Idempotency-Key" doc:"Requests with the same key, recipients and subject only queue the email once"`
+	Body           QueueTextEmailRequest
 }) (*QueueTextEmailOutput, error) {
 	for _, to := range input.Body.To {
 		if !emailutils.IsValidEmail(to) {
@@ -88,8 +99,28 @@ func (h *handler) handleQueueTextEmail(ctx context.Context, input *struct {
 		}
 	}
 
-	taskInfo, err := h.emailService.QueueSendTextEmail(input.Body.To, input.Body.Subject, input.Body.Body)
+	options := tasks.EmailOptions{ReplyTo: input.Body.ReplyTo}
+	for _, attachment := range input.Body.Attachments {
+		options.Attachments = append(options.Attachments, tasks.EmailAttachment{
+			Filename:    attachment.Filename,
+			ContentType: attachment.ContentType,
+			Data:        attachment.Data,
+		})
+	}
+
+	taskInfo, err := h.emailService.QueueSendTextEmail(ctx, input.Body.To, input.Body.Subject, input.Body.Body, input.IdempotencyKey, options)
 
+	if errors.Is(err, ErrDuplicateEmailTask) {
+		// A retried request, the email is already on its way.
+		return &QueueTextEmailOutput{Status: http.StatusOK}, nil
+	}
+
+	if errors.Is(err, ErrRecipientSuppressed) {
+		return nil, huma.Error400BadRequest("All recipients are on the suppression list")
+	}
+	if errors.Is(err, ErrInvalidReplyTo) || errors.Is(err, ErrInvalidAttachment) || errors.Is(err, ErrAttachmentsTooLarge) {
+		return nil, huma.Error400BadRequest(err.Error())
+	}
 	if err != nil {
 		h.logger.Err(err).Msg("Failed to queue SendTextEmail from EmailHandler")
 		return nil, huma.Error500InternalServerError("Failed to queue text email")
@@ -112,7 +143,7 @@ type QueueConfirmationEmailOutput struct {
 func (h *handler) handleQueueConfirmationEmail(ctx context.Context, input *struct {
 	Body QueueConfirmationEmailRequest
 }) (*QueueConfirmationEmailOutput, error) {
-	err := h.emailService.QueueApplicationConfirmationEmail(input.Body.Email, input.Body.FirstName)
+	err := h.emailService.QueueApplicationConfirmationEmail(ctx, input.Body.Email, input.Body.FirstName)
 
 	if err != nil {
 		return nil, huma.Error500InternalServerError("Failed to queue confirmation email")
@@ -0,0 +1,249 @@
+package email
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/rs/zerolog"
+	"github.com/swamphacks/core/apps/api/internal/config"
+	"github.com/swamphacks/core/apps/api/internal/database/repository"
+	"github.com/swamphacks/core/apps/api/internal/database/sqlc"
+	"github.com/swamphacks/core/apps/api/internal/emailutils"
+)
+
+var (
+	ErrInvalidSESNotification = errors.New("invalid SES notification")
+	ErrUnverifiedNotification = errors.New("notification could not be verified")
+	ErrProcessSESNotification = errors.New("failed to process SES notification")
+	ErrRecipientSuppressed    = errors.New("recipient is on the suppression list")
+)
+
+// sesNotification is the part of an SES bounce or complaint notification we use. SES
+// sets notificationType for identity notifications and eventType for event publishing.
+type sesNotification struct {
+	NotificationType string `json:"notificationType"`
+	EventType        string `json:"eventType"`
+	Bounce           *struct {
+		BounceType        string `json:"bounceType"`
+		BounceSubType     string `json:"bounceSubType"`
+		BouncedRecipients []struct {
+			EmailAddress   string `json:"emailAddress"`
+			DiagnosticCode string `json:"diagnosticCode"`
+		} `json:"bouncedRecipients"`
+	} `json:"bounce"`
+	Complaint *struct {
+		ComplaintFeedbackType string `json:"complaintFeedbackType"`
+		ComplainedRecipients  []struct {
+			EmailAddress string `json:"emailAddress"`
+		} `json:"complainedRecipients"`
+	} `json:"complaint"`
+	Mail struct {
+		MessageID string `json:"messageId"`
+	} `json:"mail"`
+}
+
+// sesFeedback is what a notification means for us: the status of the delivery it is
+// about and the addresses we should stop sending to.
+type sesFeedback struct {
+	MessageID    string
+	Status       sqlc.EmailDeliveryStatus
+	Reason       sqlc.EmailSuppressionReason
+	Detail       string
+	Suppressions []string
+}
+
+// parseSESFeedback turns an SES notification into feedback. It returns nil for
+// notifications that don't need anything done, like transient bounces or deliveries.
+func parseSESFeedback(message []byte) (*sesFeedback, error) {
+	var notification sesNotification
+	if err := json.Unmarshal(message, &notification); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSESNotification, err)
+	}
+
+	notificationType := notification.NotificationType
+	if notificationType == "" {
+		notificationType = notification.EventType
+	}
+
+	feedback := &sesFeedback{MessageID: notification.Mail.MessageID}
+
+	switch notificationType {
+	case "Bounce":
+		if notification.Bounce == nil {
+			return nil, ErrInvalidSESNotification
+		}
+		// Transient bounces, like a full mailbox, may go through next time.
+		if notification.Bounce.BounceType != "Permanent" {
+			return nil, nil
+		}
+
+		feedback.Status = sqlc.EmailDeliveryStatusBounced
+		feedback.Reason = sqlc.EmailSuppressionReasonBounce
+		feedback.Detail = notification.Bounce.BounceSubType
+		for _, recipient := range notification.Bounce.BouncedRecipients {
+			feedback.Suppressions = append(feedback.Suppressions, recipient.EmailAddress)
+			if recipient.DiagnosticCode != "" {
+				feedback.Detail = recipient.DiagnosticCode
+			}
+		}
+	case "Complaint":
+		if notification.Complaint == nil {
+			return nil, ErrInvalidSESNotification
+		}
+
+		feedback.Status = sqlc.EmailDeliveryStatusComplained
+		feedback.Reason = sqlc.EmailSuppressionReasonComplaint
+		feedback.Detail = notification.Complaint.ComplaintFeedbackType
+		for _, recipient := range notification.Complaint.ComplainedRecipients {
+			feedback.Suppressions = append(feedback.Suppressions, recipient.EmailAddress)
+		}
+	default:
+		return nil, nil
+	}
+
+	if feedback.Detail == "" {
+		feedback.Detail = strings.ToLower(notificationType)
+	}
+
+	return feedback, nil
+}
+
+// NotificationService handles the bounce and complaint notifications SES publishes
+// through SNS.
+type NotificationService struct {
+	emailDeliveryRepo    *repository.EmailDeliveryRepository
+	emailSuppressionRepo *repository.EmailSuppressionRepository
+	verifier             *emailutils.SNSVerifier
+	config               *config.Config
+	logger               zerolog.Logger
+}
+
+func NewNotificationService(
+	emailDeliveryRepo *repository.EmailDeliveryRepository,
+	emailSuppressionRepo *repository.EmailSuppressionRepository,
+	verifier *emailutils.SNSVerifier,
+	config *config.Config,
+	logger zerolog.Logger,
+) *NotificationService {
+	return &NotificationService{
+		emailDeliveryRepo:    emailDeliveryRepo,
+		emailSuppressionRepo: emailSuppressionRepo,
+		verifier:             verifier,
+		config:               config,
+		logger:               logger.With().Str("service", "NotificationService").Str("component", "email").Logger(),
+	}
+}
+
+// HandleSNSMessage verifies a message SNS posted to us, confirms new subscriptions and
+// suppresses the addresses of permanent bounces and complaints.
+func (s *NotificationService) HandleSNSMessage(ctx context.Context, body []byte) error {
+	message, err := emailutils.ParseSNSMessage(body)
+	if err != nil {
+		return ErrInvalidSESNotification
+	}
+
+	// Anyone can subscribe us to a topic of their own and sign messages for it, so only
+	// the configured topic is trusted. Without one, nothing is.
+	if s.config.AWS.SNSTopicArn == "" {
+		s.logger.Warn().Str("TopicArn", message.TopicArn).Msg("Rejected SNS message, SNS_TOPIC_ARN is not set")
+		return ErrUnverifiedNotification
+	}
+
+	if message.TopicArn != s.config.AWS.SNSTopicArn {
+		s.logger.Warn().Str("TopicArn", message.TopicArn).Msg("Rejected SNS message from an unknown topic")
+		return ErrUnverifiedNotification
+	}
+
+	if err := s.verifier.Verify(ctx, message); err != nil {
+		s.logger.Warn().Err(err).Str("MessageID", message.MessageId).Msg("Rejected SNS message with an invalid signature")
+		return ErrUnverifiedNotification
+	}
+
+	switch message.Type {
+	case emailutils.SNSTypeSubscriptionConfirmation:
+		if err := s.verifier.ConfirmSubscription(ctx, message); err != nil {
+			s.logger.Err(err).Str("TopicArn", message.TopicArn).Msg("Failed to confirm SNS subscription")
+			return ErrProcessSESNotification
+		}
+		s.logger.Info().Str("TopicArn", message.TopicArn).Msg("Confirmed SNS subscription")
+		return nil
+	case emailutils.SNSTypeNotification:
+	default:
+		return nil
+	}
+
+	feedback, err := parseSESFeedback([]byte(message.Message))
+	if err != nil {
+		return err
+	}
+	if feedback == nil {
+		return nil
+	}
+
+	for _, address := range feedback.Suppressions {
+		if err := s.emailSuppressionRepo.UpsertEmailSuppression(ctx, sqlc.UpsertEmailSuppressionParams{
+			Email:        address,
+			Reason:       feedback.Reason,
+			Detail:       &feedback.Detail,
+			SesMessageID: &feedback.MessageID,
+		}); err != nil {
+			s.logger.Err(err).Msg("Failed to suppress email address")
+			return ErrProcessSESNotification
+		}
+	}
+
+	if feedback.MessageID != "" {
+		if err := s.emailDeliveryRepo.UpdateEmailDeliveryStatusBySesMessageId(ctx, sqlc.UpdateEmailDeliveryStatusBySesMessageIdParams{
+			Status:       feedback.Status,
+			Error:        &feedback.Detail,
+			SesMessageID: &feedback.MessageID,
+		}); err != nil {
+			s.logger.Err(err).Str("SesMessageID", feedback.MessageID).Msg("Failed to update email delivery status")
+			return ErrProcessSESNotification
+		}
+	}
+
+	s.logger.Info().Str("Status", string(feedback.Status)).Int("Suppressed", len(feedback.Suppressions)).Msg("Processed SES notification")
+
+	return nil
+}
+
+// filterSuppressed drops the suppressed addresses from recipients. Addresses are
+// compared case-insensitively.
+func filterSuppressed(
+	ctx context.Context,
+	emailSuppressionRepo *repository.EmailSuppressionRepository,
+	recipients []string,
+) (allowed []string, suppressed []string, err error) {
+	if len(recipients) == 0 {
+		return nil, nil, nil
+	}
+
+	lowered := make([]string, len(recipients))
+	for i, recipient := range recipients {
+		lowered[i] = strings.ToLower(strings.TrimSpace(recipient))
+	}
+
+	suppressedEmails, err := emailSuppressionRepo.ListSuppressedEmails(ctx, lowered)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	isSuppressed := make(map[string]bool, len(suppressedEmails))
+	for _, email := range suppressedEmails {
+		isSuppressed[email] = true
+	}
+
+	for i, recipient := range recipients {
+		if isSuppressed[lowered[i]] {
+			suppressed = append(suppressed, recipient)
+		} else {
+			allowed = append(allowed, recipient)
+		}
+	}
+
+	return allowed, suppressed, nil
+}
@@ -0,0 +1,54 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/rs/zerolog"
+)
+
+func RegisterNotificationRoutes(notificationHandler *notificationHandler, group huma.API) {
+	huma.Register(group, huma.Operation{
+		OperationID:   "receive-ses-notification",
+		Method:        http.MethodPost,
+		Summary:       "Receive SES Notification",
+		Description:   "Receives SES bounce and complaint notifications from SNS and adds the addresses to the suppression list. Messages must be signed by SNS.",
+		Tags:          []string{"Email"},
+		Path:          "/ses/notifications",
+		Errors:        []int{http.StatusBadRequest, http.StatusForbidden, http.StatusInternalServerError},
+		DefaultStatus: http.StatusNoContent,
+	}, notificationHandler.handleSESNotification)
+}
+
+type notificationHandler struct {
+	notificationService *NotificationService
+	logger              zerolog.Logger
+}
+
+func NewNotificationHandler(notificationService *NotificationService, logger zerolog.Logger) *notificationHandler {
+	return &notificationHandler{
+		notificationService: notificationService,
+		logger:              logger.With().Str("handler", "NotificationHandler").Str("domain", "email").Logger(),
+	}
+}
+
+// SNS posts its messages as text/plain, so the body is read as is.
+func (h *notificationHandler) handleSESNotification(ctx context.Context, input *struct {
+	RawBody []byte
+}) (*struct{}, error) {
+	err := h.notificationService.HandleSNSMessage(ctx, input.RawBody)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrInvalidSESNotification):
+			return nil, huma.Error400BadRequest(err.Error())
+		case errors.Is(err, ErrUnverifiedNotification):
+			return nil, huma.Error403Forbidden(err.Error())
+		default:
+			return nil, huma.Error500InternalServerError("Failed to process SES notification")
+		}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,113 @@
+package email
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/swamphacks/core/apps/api/internal/database/sqlc"
+)
+
+func TestParseSESFeedback(t *testing.T) {
+	tests := []struct {
+		name          string
+		message       string
+		expected      *sesFeedback
+		expectedError error
+	}{
+		{
+			name: "permanent bounce",
+			message: `{
+				"notificationType": "Bounce",
+				"bounce": {
+					"bounceType": "Permanent",
+					"bounceSubType": "General",
+					"bouncedRecipients": [{"emailAddress": "Bounced@Example.com", "diagnosticCode": "smtp; 550 user unknown"}]
+				},
+				"mail": {"messageId": "bounced-message"}
+			}`,
+			expected: &sesFeedback{
+				MessageID:    "bounced-message",
+				Status:       sqlc.EmailDeliveryStatusBounced,
+				Reason:       sqlc.EmailSuppressionReasonBounce,
+				Detail:       "smtp; 550 user unknown",
+				Suppressions: []string{"Bounced@Example.com"},
+			},
+		},
+		{
+			name: "transient bounce is ignored",
+			message: `{
+				"notificationType": "Bounce",
+				"bounce": {
+					"bounceType": "Transient",
+					"bounceSubType": "MailboxFull",
+					"bouncedRecipients": [{"emailAddress": "full@example.com"}]
+				},
+				"mail": {"messageId": "full-message"}
+			}`,
+			expected: nil,
+		},
+		{
+			name: "complaint from event publishing",
+			message: `{
+				"eventType": "Complaint",
+				"complaint": {
+					"complaintFeedbackType": "abuse",
+					"complainedRecipients": [{"emailAddress": "complained@example.com"}]
+				},
+				"mail": {"messageId": "complained-message"}
+			}`,
+			expected: &sesFeedback{
+				MessageID:    "complained-message",
+				Status:       sqlc.EmailDeliveryStatusComplained,
+				Reason:       sqlc.EmailSuppressionReasonComplaint,
+				Detail:       "abuse",
+				Suppressions: []string{"complained@example.com"},
+			},
+		},
+		{
+			name: "complaint without feedback type",
+			message: `{
+				"notificationType": "Complaint",
+				"complaint": {"complainedRecipients": [{"emailAddress": "complained@example.com"}]},
+				"mail": {"messageId": "complained-message"}
+			}`,
+			expected: &sesFeedback{
+				MessageID:    "complained-message",
+				Status:       sqlc.EmailDeliveryStatusComplained,
+				Reason:       sqlc.EmailSuppressionReasonComplaint,
+				Detail:       "complaint",
+				Suppressions: []string{"complained@example.com"},
+			},
+		},
+		{
+			name:     "delivery is ignored",
+			message:  `{"notificationType": "Delivery", "mail": {"messageId": "delivered-message"}}`,
+			expected: nil,
+		},
+		{
+			name:          "bounce without details",
+			message:       `{"notificationType": "Bounce", "mail": {"messageId": "bounced-message"}}`,
+			expectedError: ErrInvalidSESNotification,
+		},
+		{
+			name:          "not json",
+			message:       "Successfully validated SNS topic for Amazon SES event publishing.",
+			expectedError: ErrInvalidSESNotification,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := parseSESFeedback([]byte(test.message))
+
+			if !errors.Is(err, test.expectedError) {
+				t.Fatalf("expected error %v, got %v", test.expectedError, err)
+			}
+
+			if !reflect.DeepEqual(result, test.expected) {
+				t.Fatalf("expected %+v, got %+v", test.expected, result)
+			}
+		})
+	}
+}
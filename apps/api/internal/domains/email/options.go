@@ -0,0 +1,64 @@
+package email
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/swamphacks/core/apps/api/internal/database/sqlc"
+	"github.com/swamphacks/core/apps/api/internal/emailutils"
+	"github.com/swamphacks/core/apps/api/internal/tasks"
+)
+
+// maxAttachmentsSize keeps emails under the 10 MB SES limit once attachments are base64
+// encoded.
+const maxAttachmentsSize = 7 << 20
+
+var (
+	ErrInvalidReplyTo      = errors.New("invalid reply-to address")
+	ErrInvalidAttachment   = errors.New("attachment needs a filename and data")
+	ErrAttachmentsTooLarge = errors.New("attachments are too large")
+)
+
+// validateEmailOptions checks the options an email is queued with, so a bad email fails
+// when it is queued instead of in the worker.
+func validateEmailOptions(options tasks.EmailOptions) error {
+	if options.Category != "" && !isEmailCategory(sqlc.EmailCategory(options.Category)) {
+		return fmt.Errorf("%w: %s", ErrUnknownEmailCategory, options.Category)
+	}
+
+	for _, address := range options.ReplyTo {
+		if !emailutils.IsValidEmail(address) {
+			return fmt.Errorf("%w: %q", ErrInvalidReplyTo, address)
+		}
+	}
+
+	size := 0
+	for _, attachment := range options.Attachments {
+		if attachment.Filename == "" || len(attachment.Data) == 0 {
+			return ErrInvalidAttachment
+		}
+		size += len(attachment.Data)
+	}
+	if size > maxAttachmentsSize {
+		return fmt.Errorf("%w: %d bytes, the limit is %d", ErrAttachmentsTooLarge, size, maxAttachmentsSize)
+	}
+
+	return nil
+}
+
+// withOptions adds the options an email was queued with to the email.
+func withOptions(email emailutils.Email, options tasks.EmailOptions) emailutils.Email {
+	email.ReplyTo = options.ReplyTo
+	email.ListUnsubscribe = options.ListUnsubscribe
+	email.ListUnsubscribePost = options.ListUnsubscribePost
+
+	for _, attachment := range options.Attachments {
+		email.Attachments = append(email.Attachments, emailutils.Attachment{
+			Filename:    attachment.Filename,
+			ContentType: attachment.ContentType,
+			Data:        attachment.Data,
+		})
+	}
+
+	return email
+}
@@ -0,0 +1,67 @@
+package email
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/swamphacks/core/apps/api/internal/tasks"
+)
+
+func TestValidateEmailOptions(t *testing.T) {
+	tests := []struct {
+		name          string
+		options       tasks.EmailOptions
+		expectedError error
+	}{
+		{
+			name: "no options",
+		},
+		{
+			name: "reply-to and attachment",
+			options: tasks.EmailOptions{
+				ReplyTo: []string{"Sponsorships <sponsors@swamphacks.com>"},
+				Attachments: []tasks.EmailAttachment{
+					{Filename: "invite.ics", ContentType: "text/calendar", Data: []byte("BEGIN:VCALENDAR")},
+				},
+			},
+		},
+		{
+			name:          "unknown category",
+			options:       tasks.EmailOptions{Category: "newsletter"},
+			expectedError: ErrUnknownEmailCategory,
+		},
+		{
+			name:          "invalid reply-to",
+			options:       tasks.EmailOptions{ReplyTo: []string{"sponsors"}},
+			expectedError: ErrInvalidReplyTo,
+		},
+		{
+			name: "attachment without filename",
+			options: tasks.EmailOptions{
+				Attachments: []tasks.EmailAttachment{{Data: []byte("BEGIN:VCALENDAR")}},
+			},
+			expectedError: ErrInvalidAttachment,
+		},
+		{
+			name: "attachments over the limit",
+			options: tasks.EmailOptions{
+				Attachments: []tasks.EmailAttachment{
+					{Filename: "a.pdf", Data: bytes.Repeat([]byte{0}, maxAttachmentsSize/2)},
+					{Filename: "b.pdf", Data: bytes.Repeat([]byte{0}, maxAttachmentsSize/2+1)},
+				},
+			},
+			expectedError: ErrAttachmentsTooLarge,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateEmailOptions(test.options)
+
+			if !errors.Is(err, test.expectedError) {
+				t.Fatalf("expected error %v, got %v", test.expectedError, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,271 @@
+package email
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/rs/zerolog"
+	"github.com/swamphacks/core/apps/api/internal/config"
+	"github.com/swamphacks/core/apps/api/internal/database"
+	"github.com/swamphacks/core/apps/api/internal/database/repository"
+	"github.com/swamphacks/core/apps/api/internal/database/sqlc"
+)
+
+// EmailCategoryInfo describes a category of email people can unsubscribe from. Emails
+// about someone's own application, like decisions, have no category and always go out.
+type EmailCategoryInfo struct {
+	Category    sqlc.EmailCategory `json:"category"`
+	Name        string             `json:"name"`
+	Description string             `json:"description"`
+}
+
+var emailCategories = []EmailCategoryInfo{
+	{
+		Category:    sqlc.EmailCategoryMarketing,
+		Name:        "News and announcements",
+		Description: "Announcements about SwampHacks and future events",
+	},
+	{
+		Category:    sqlc.EmailCategoryEventUpdates,
+		Name:        "Event updates",
+		Description: "Schedules, logistics and updates about events you're attending",
+	},
+	{
+		Category:    sqlc.EmailCategoryTeamNotifications,
+		Name:        "Team notifications",
+		Description: "Invitations and join requests for your team",
+	},
+	{
+		Category:    sqlc.EmailCategoryWorkshopReminders,
+		Name:        "Workshop reminders",
+		Description: "Reminders about workshops you registered for",
+	},
+}
+
+func isEmailCategory(category sqlc.EmailCategory) bool {
+	for _, info := range emailCategories {
+		if info.Category == category {
+			return true
+		}
+	}
+	return false
+}
+
+// EmailCategoryPreference is whether an address gets a category of email.
+type EmailCategoryPreference struct {
+	EmailCategoryInfo
+	Subscribed bool `json:"subscribed"`
+}
+
+type EmailPreferences struct {
+	Email      string                    `json:"email"`
+	Categories []EmailCategoryPreference `json:"categories"`
+}
+
+type EmailPreferenceChange struct {
+	Category   sqlc.EmailCategory `json:"category" enum:"marketing,event_updates,team_notifications,workshop_reminders"`
+	Subscribed bool               `json:"subscribed"`
+}
+
+type EmailPreferenceService struct {
+	emailPreferenceRepo *repository.EmailPreferenceRepository
+	userRepo            *repository.UserRepository
+	txm                 *database.TransactionManager
+	config              *config.Config
+	logger              zerolog.Logger
+}
+
+func NewEmailPreferenceService(
+	emailPreferenceRepo *repository.EmailPreferenceRepository,
+	userRepo *repository.UserRepository,
+	txm *database.TransactionManager,
+	config *config.Config,
+	logger zerolog.Logger,
+) *EmailPreferenceService {
+	return &EmailPreferenceService{
+		emailPreferenceRepo: emailPreferenceRepo,
+		userRepo:            userRepo,
+		txm:                 txm,
+		config:              config,
+		logger:              logger.With().Str("service", "EmailPreferenceService").Str("domain", "email").Logger(),
+	}
+}
+
+// GetUserPreferences returns the preferences of a user's contact address.
+func (s *EmailPreferenceService) GetUserPreferences(ctx context.Context, userID uuid.UUID) (*EmailPreferences, error) {
+	email, err := s.contactEmail(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.getPreferences(ctx, email)
+}
+
+// UpdateUserPreferences changes the preferences of a user's contact address.
+func (s *EmailPreferenceService) UpdateUserPreferences(ctx context.Context, userID uuid.UUID, changes []EmailPreferenceChange) (*EmailPreferences, error) {
+	email, err := s.contactEmail(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.updatePreferences(ctx, email, changes)
+}
+
+// GetTokenPreferences returns the preferences of the address an unsubscribe link was
+// sent to, so they can be managed without logging in.
+func (s *EmailPreferenceService) GetTokenPreferences(ctx context.Context, token string) (*EmailPreferences, error) {
+	email, _, err := parseUnsubscribeToken(s.config.EmailUnsubscribeSecret, token)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.getPreferences(ctx, email)
+}
+
+// UpdateTokenPreferences changes the preferences of the address an unsubscribe link was
+// sent to.
+func (s *EmailPreferenceService) UpdateTokenPreferences(ctx context.Context, token string, changes []EmailPreferenceChange) (*EmailPreferences, error) {
+	email, _, err := parseUnsubscribeToken(s.config.EmailUnsubscribeSecret, token)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.updatePreferences(ctx, email, changes)
+}
+
+// Unsubscribe unsubscribes the address of an unsubscribe link from its category, or
+// from every category if the link has none.
+func (s *EmailPreferenceService) Unsubscribe(ctx context.Context, token string) (*EmailPreferences, error) {
+	email, category, err := parseUnsubscribeToken(s.config.EmailUnsubscribeSecret, token)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []EmailPreferenceChange
+	for _, info := range emailCategories {
+		if category == "" || info.Category == category {
+			changes = append(changes, EmailPreferenceChange{Category: info.Category, Subscribed: false})
+		}
+	}
+
+	preferences, err := s.updatePreferences(ctx, email, changes)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info().Str("Category", string(category)).Msg("Unsubscribed address from emails")
+
+	return preferences, nil
+}
+
+func (s *EmailPreferenceService) contactEmail(ctx context.Context, userID uuid.UUID) (string, error) {
+	info, err := s.userRepo.GetUserEmailInfoById(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+
+	email, ok := info.ContactEmail.(string)
+	if !ok || email == "" {
+		return "", ErrEmailPreferencesNotFound
+	}
+
+	return email, nil
+}
+
+func (s *EmailPreferenceService) getPreferences(ctx context.Context, email string) (*EmailPreferences, error) {
+	rows, err := s.emailPreferenceRepo.ListEmailPreferences(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+
+	subscribed := make(map[sqlc.EmailCategory]bool, len(rows))
+	for _, row := range rows {
+		subscribed[row.Category] = row.Subscribed
+	}
+
+	preferences := &EmailPreferences{
+		Email:      strings.ToLower(email),
+		Categories: make([]EmailCategoryPreference, len(emailCategories)),
+	}
+	for i, info := range emailCategories {
+		value, ok := subscribed[info.Category]
+		preferences.Categories[i] = EmailCategoryPreference{
+			EmailCategoryInfo: info,
+			Subscribed:        !ok || value,
+		}
+	}
+
+	return preferences, nil
+}
+
+func (s *EmailPreferenceService) updatePreferences(ctx context.Context, email string, changes []EmailPreferenceChange) (*EmailPreferences, error) {
+	for _, change := range changes {
+		if !isEmailCategory(change.Category) {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownEmailCategory, change.Category)
+		}
+	}
+
+	err := s.txm.WithTx(ctx, func(tx pgx.Tx) error {
+		txRepo := s.emailPreferenceRepo.NewTx(tx)
+		for _, change := range changes {
+			if err := txRepo.UpsertEmailPreference(ctx, sqlc.UpsertEmailPreferenceParams{
+				Email:      email,
+				Category:   change.Category,
+				Subscribed: change.Subscribed,
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		s.logger.Err(err).Msg("Failed to update email preferences")
+		return nil, err
+	}
+
+	return s.getPreferences(ctx, email)
+}
+
+// filterUnsubscribed drops the recipients that unsubscribed from a category. Addresses
+// are compared case-insensitively.
+func filterUnsubscribed(
+	ctx context.Context,
+	emailPreferenceRepo *repository.EmailPreferenceRepository,
+	category sqlc.EmailCategory,
+	recipients []string,
+) (allowed []string, unsubscribed []string, err error) {
+	if len(recipients) == 0 || category == "" {
+		return recipients, nil, nil
+	}
+
+	lowered := make([]string, len(recipients))
+	for i, recipient := range recipients {
+		lowered[i] = strings.ToLower(strings.TrimSpace(recipient))
+	}
+
+	unsubscribedEmails, err := emailPreferenceRepo.ListUnsubscribedEmails(ctx, sqlc.ListUnsubscribedEmailsParams{
+		Category: category,
+		Emails:   lowered,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	isUnsubscribed := make(map[string]bool, len(unsubscribedEmails))
+	for _, email := range unsubscribedEmails {
+		isUnsubscribed[email] = true
+	}
+
+	for i, recipient := range recipients {
+		if isUnsubscribed[lowered[i]] {
+			unsubscribed = append(unsubscribed, recipient)
+		} else {
+			allowed = append(allowed, recipient)
+		}
+	}
+
+	return allowed, unsubscribed, nil
+}
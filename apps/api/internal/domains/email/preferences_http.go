@@ -0,0 +1,177 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/rs/zerolog"
+	"github.com/swamphacks/core/apps/api/internal/api/cookie"
+	"github.com/swamphacks/core/apps/api/internal/api/middleware"
+	"github.com/swamphacks/core/apps/api/internal/ctxutils"
+	"github.com/swamphacks/core/apps/api/internal/database/repository"
+)
+
+func RegisterPreferenceRoutes(emailPreferenceHandler *emailPreferenceHandler, group huma.API, mw *middleware.Middleware) {
+	huma.Register(group, huma.Operation{
+		OperationID:   "get-my-email-preferences",
+		Method:        http.MethodGet,
+		Summary:       "Get My Email Preferences",
+		Description:   "Returns which categories of email the current user's contact address gets.",
+		Tags:          []string{"Email Preferences"},
+		Path:          "/preferences",
+		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma},
+		Errors:        []int{http.StatusUnauthorized, http.StatusNotFound, http.StatusInternalServerError},
+		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
+		DefaultStatus: http.StatusOK,
+	}, emailPreferenceHandler.handleGetMyPreferences)
+
+	huma.Register(group, huma.Operation{
+		OperationID:   "update-my-email-preferences",
+		Method:        http.MethodPut,
+		Summary:       "Update My Email Preferences",
+		Description:   "Subscribes or unsubscribes the current user's contact address from categories of email.",
+		Tags:          []string{"Email Preferences"},
+		Path:          "/preferences",
+		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma},
+		Errors:        []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusNotFound, http.StatusInternalServerError},
+		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
+		DefaultStatus: http.StatusOK,
+	}, emailPreferenceHandler.handleUpdateMyPreferences)
+
+	huma.Register(group, huma.Operation{
+		OperationID:   "get-email-preferences-by-token",
+		Method:        http.MethodGet,
+		Summary:       "Get Email Preferences By Token",
+		Description:   "Returns the preferences of the address an unsubscribe link was sent to. No login is needed.",
+		Tags:          []string{"Email Preferences"},
+		Path:          "/unsubscribe",
+		Errors:        []int{http.StatusBadRequest, http.StatusServiceUnavailable, http.StatusInternalServerError},
+		DefaultStatus: http.StatusOK,
+	}, emailPreferenceHandler.handleGetTokenPreferences)
+
+	huma.Register(group, huma.Operation{
+		OperationID:   "update-email-preferences-by-token",
+		Method:        http.MethodPut,
+		Summary:       "Update Email Preferences By Token",
+		Description:   "Subscribes or unsubscribes the address an unsubscribe link was sent to from categories of email. No login is needed.",
+		Tags:          []string{"Email Preferences"},
+		Path:          "/unsubscribe",
+		Errors:        []int{http.StatusBadRequest, http.StatusServiceUnavailable, http.StatusInternalServerError},
+		DefaultStatus: http.StatusOK,
+	}, emailPreferenceHandler.handleUpdateTokenPreferences)
+
+	huma.Register(group, huma.Operation{
+		OperationID:   "unsubscribe-email",
+		Method:        http.MethodPost,
+		Summary:       "One-Click Unsubscribe",
+		Description:   "Unsubscribes the address of an unsubscribe link from the link's category. Mail clients post here for List-Unsubscribe-Post (RFC 8058), so the body is ignored.",
+		Tags:          []string{"Email Preferences"},
+		Path:          "/unsubscribe",
+		Errors:        []int{http.StatusBadRequest, http.StatusServiceUnavailable, http.StatusInternalServerError},
+		DefaultStatus: http.StatusNoContent,
+	}, emailPreferenceHandler.handleUnsubscribe)
+}
+
+type emailPreferenceHandler struct {
+	emailPreferenceService *EmailPreferenceService
+	logger                 zerolog.Logger
+}
+
+func NewPreferenceHandler(emailPreferenceService *EmailPreferenceService, logger zerolog.Logger) *emailPreferenceHandler {
+	return &emailPreferenceHandler{
+		emailPreferenceService: emailPreferenceService,
+		logger:                 logger.With().Str("handler", "EmailPreferenceHandler").Str("domain", "email").Logger(),
+	}
+}
+
+type UpdateEmailPreferencesRequest struct {
+	Categories []EmailPreferenceChange `json:"categories" minItems:"1"`
+}
+
+type EmailPreferencesOutput struct {
+	Body *EmailPreferences
+}
+
+func (h *emailPreferenceHandler) handleGetMyPreferences(ctx context.Context, input *struct{}) (*EmailPreferencesOutput, error) {
+	userCtx := ctxutils.GetUserFromCtx(ctx)
+	if userCtx == nil {
+		return nil, huma.Error400BadRequest("Failed to get current user info")
+	}
+
+	preferences, err := h.emailPreferenceService.GetUserPreferences(ctx, userCtx.UserID)
+	if err != nil {
+		return nil, preferenceHTTPError(err, "Failed to get email preferences")
+	}
+
+	return &EmailPreferencesOutput{Body: preferences}, nil
+}
+
+func (h *emailPreferenceHandler) handleUpdateMyPreferences(ctx context.Context, input *struct {
+	Body UpdateEmailPreferencesRequest
+}) (*EmailPreferencesOutput, error) {
+	userCtx := ctxutils.GetUserFromCtx(ctx)
+	if userCtx == nil {
+		return nil, huma.Error400BadRequest("Failed to get current user info")
+	}
+
+	preferences, err := h.emailPreferenceService.UpdateUserPreferences(ctx, userCtx.UserID, input.Body.Categories)
+	if err != nil {
+		return nil, preferenceHTTPError(err, "Failed to update email preferences")
+	}
+
+	return &EmailPreferencesOutput{Body: preferences}, nil
+}
+
+func (h *emailPreferenceHandler) handleGetTokenPreferences(ctx context.Context, input *struct {
+	Token string `query:"token" required:"true"`
+}) (*EmailPreferencesOutput, error) {
+	preferences, err := h.emailPreferenceService.GetTokenPreferences(ctx, input.Token)
+	if err != nil {
+		return nil, preferenceHTTPError(err, "Failed to get email preferences")
+	}
+
+	return &EmailPreferencesOutput{Body: preferences}, nil
+}
+
+func (h *emailPreferenceHandler) handleUpdateTokenPreferences(ctx context.Context, input *struct {
+	Token string `query:"token" required:"true"`
+	Body  UpdateEmailPreferencesRequest
+}) (*EmailPreferencesOutput, error) {
+	preferences, err := h.emailPreferenceService.UpdateTokenPreferences(ctx, input.Token, input.Body.Categories)
+	if err != nil {
+		return nil, preferenceHTTPError(err, "Failed to update email preferences")
+	}
+
+	return &EmailPreferencesOutput{Body: preferences}, nil
+}
+
+// Mail clients post List-Unsubscribe=One-Click as a form, so the body is read as is.
+func (h *emailPreferenceHandler) handleUnsubscribe(ctx context.Context, input *struct {
+	Token   string `query:"token" required:"true"`
+	RawBody []byte
+}) (*struct{}, error) {
+	if _, err := h.emailPreferenceService.Unsubscribe(ctx, input.Token); err != nil {
+		return nil, preferenceHTTPError(err, "Failed to unsubscribe")
+	}
+
+	return nil, nil
+}
+
+func preferenceHTTPError(err error, fallback string) error {
+	if errors.Is(err, repository.ErrUserNotFound) || errors.Is(err, ErrEmailPreferencesNotFound) {
+		return huma.Error404NotFound(ErrEmailPreferencesNotFound.Error())
+	}
+
+	if errors.Is(err, ErrInvalidUnsubscribeToken) ||
+		errors.Is(err, ErrUnknownEmailCategory) {
+		return huma.Error400BadRequest(err.Error())
+	}
+
+	if errors.Is(err, ErrUnsubscribeDisabled) {
+		return huma.Error503ServiceUnavailable(err.Error())
+	}
+
+	return huma.Error500InternalServerError(fallback)
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"text/template"
 
 	"github.com/google/uuid"
@@ -12,6 +13,7 @@ import (
 	"github.com/rs/zerolog"
 	"github.com/skip2/go-qrcode"
 	"github.com/swamphacks/core/apps/api/internal/config"
+	"github.com/swamphacks/core/apps/api/internal/database"
 	"github.com/swamphacks/core/apps/api/internal/database/repository"
 	"github.com/swamphacks/core/apps/api/internal/database/sqlc"
 	"github.com/swamphacks/core/apps/api/internal/emailutils"
@@ -19,40 +21,50 @@ import (
 	"github.com/swamphacks/core/apps/api/internal/tasks"
 )
 
+// defaultSender is the address emails are sent from.
+const defaultSender = "SwampHacks <contact@swamphacks.com>"
+
 type EmailService struct {
-	hackathonRepo *repository.HackathonRepository
-	userRepo      *repository.UserRepository
-	logger        zerolog.Logger
-	taskQueue     *asynq.Client
-	SESClient     *emailutils.SESClient
-	storage       storage.Storage
-	config        *config.Config
+	hackathonRepo        *repository.HackathonRepository
+	userRepo             *repository.UserRepository
+	emailDeliveryRepo    *repository.EmailDeliveryRepository
+	emailSuppressionRepo *repository.EmailSuppressionRepository
+	emailPreferenceRepo  *repository.EmailPreferenceRepository
+	emailTemplateRepo    *repository.EmailTemplateRepository
+	logger               zerolog.Logger
+	taskQueue            *asynq.Client
+	sender               emailutils.EmailSender
+	storage              storage.Storage
+	config               *config.Config
 }
 
 func NewEmailService(
 	hackathonRepo *repository.HackathonRepository, userRepo *repository.UserRepository,
-	taskQueue *asynq.Client, SESClient *emailutils.SESClient, storage storage.Storage,
+	emailDeliveryRepo *repository.EmailDeliveryRepository, emailSuppressionRepo *repository.EmailSuppressionRepository,
+	emailPreferenceRepo *repository.EmailPreferenceRepository, emailTemplateRepo *repository.EmailTemplateRepository,
+	taskQueue *asynq.Client, sender emailutils.EmailSender, storage storage.Storage,
 	logger zerolog.Logger, config *config.Config,
 ) *EmailService {
 	return &EmailService{
-		hackathonRepo: hackathonRepo,
-		userRepo:      userRepo,
-		logger:        logger.With().Str("service", "EmailService").Str("component", "email").Logger(),
-		taskQueue:     taskQueue,
-		SESClient:     SESClient,
-		storage:       storage,
-		config:        config,
+		hackathonRepo:        hackathonRepo,
+		userRepo:             userRepo,
+		emailDeliveryRepo:    emailDeliveryRepo,
+		emailSuppressionRepo: emailSuppressionRepo,
+		emailPreferenceRepo:  emailPreferenceRepo,
+		emailTemplateRepo:    emailTemplateRepo,
+		logger:               logger.With().Str("service", "EmailService").Str("component", "email").Logger(),
+		taskQueue:            taskQueue,
+		sender:               sender,
+		storage:              storage,
+		config:               config,
 	}
 }
 
-func (s *EmailService) QueueApplicationConfirmationEmail(recipient string, name string) error {
-	subject := "SwampHacks XII: We received your application!"
-	templateEmailFilepath := s.config.EmailTemplateDirectory + "ConfirmationEmail.html"
-
+func (s *EmailService) QueueApplicationConfirmationEmail(ctx context.Context, recipient string, name string) error {
 	type emailTemplateData struct {
 		Name string
 	}
-	_, err := s.QueueSendHtmlEmailTask(recipient, subject, emailTemplateData{Name: name}, templateEmailFilepath)
+	_, err := s.QueueTemplateEmail(ctx, recipient, TemplateApplicationConfirmation, emailTemplateData{Name: name}, "")
 
 	if err != nil {
 		s.logger.Err(err).Msg("Failed to send confirmation email to recipient")
@@ -83,14 +95,11 @@ func (s *EmailService) QueueWelcomeEmail(ctx context.Context, recipient string,
 
 	qrPngLink := fmt.Sprintf("%s/%s", s.config.CoreBuckets.QRCodesBaseUrl, userID.String())
 
-	subject := "SwampHacks XII – A welcome from our Organizers!"
-	templateEmailFilepath := s.config.EmailTemplateDirectory + "WelcomeEmail.html"
-
 	type emailTemplateData struct {
 		Name      string
 		QRPngLink string
 	}
-	_, err = s.QueueSendHtmlEmailTask(recipient, subject, emailTemplateData{Name: name, QRPngLink: qrPngLink}, templateEmailFilepath)
+	_, err = s.QueueTemplateEmail(ctx, recipient, TemplateWelcome, emailTemplateData{Name: name, QRPngLink: qrPngLink}, "")
 
 	if err != nil {
 		s.logger.Err(err).Msgf("Failed to send welcome email to recipient with userID %s", userID.String())
@@ -100,14 +109,11 @@ func (s *EmailService) QueueWelcomeEmail(ctx context.Context, recipient string,
 	return nil
 }
 
-func (s *EmailService) QueueWaitlistAcceptanceEmail(recipient string, name string) error {
-	subject := "Congratulations! You're in – confirm in 72 hours to keep your spot in SwampHacks XII"
-	templateEmailFilepath := s.config.EmailTemplateDirectory + "WaitlistAcceptanceEmail.html"
-
+func (s *EmailService) QueueWaitlistAcceptanceEmail(ctx context.Context, recipient string, name string) error {
 	type emailTemplateData struct {
 		Name string
 	}
-	_, err := s.QueueSendHtmlEmailTask(recipient, subject, emailTemplateData{Name: name}, templateEmailFilepath)
+	_, err := s.QueueTemplateEmail(ctx, recipient, TemplateWaitlistAcceptance, emailTemplateData{Name: name}, "")
 
 	if err != nil {
 		s.logger.Err(err).Msg("Failed to send waitlist acceptance email to recipient")
@@ -117,38 +123,274 @@ func (s *EmailService) QueueWaitlistAcceptanceEmail(recipient string, name strin
 	return nil
 }
 
-func (s *EmailService) QueueSendHtmlEmailTask(to string, subject string, templateData interface{}, templateFilePath string) (*asynq.TaskInfo, error) {
-	if len(to) == 0 {
-		s.logger.Warn().Msgf("No recipient email found for email being sent from template '%s'", templateFilePath)
+// QueueTeamJoinRequestEmail tells a team owner someone asked to join their team. Each
+// request is emailed once, and again if it expired and was renewed.
+func (s *EmailService) QueueTeamJoinRequestEmail(ctx context.Context, ownerID uuid.UUID, request sqlc.TeamJoinRequest, requesterName string, teamName string) error {
+	emailInfo, err := s.userRepo.GetUserEmailInfoById(ctx, ownerID)
+	if err != nil {
+		return ErrCouldNotGetEmailInfo
+	}
+
+	contactEmail, ok := emailInfo.ContactEmail.(string)
+	if !ok {
+		return ErrFailedToGetContactEmail
+	}
+
+	type emailTemplateData struct {
+		Name          string
+		RequesterName string
+		TeamName      string
+		Message       string
+	}
+	data := emailTemplateData{Name: emailInfo.Name, RequesterName: requesterName, TeamName: teamName}
+	if request.RequestMessage != nil {
+		data.Message = *request.RequestMessage
+	}
+
+	contextKey := fmt.Sprintf("%s:%d", request.ID, request.CreatedAt.Unix())
+	if _, err := s.QueueTemplateEmail(ctx, contactEmail, TemplateTeamJoinRequest, data, contextKey); err != nil {
+		s.logger.Err(err).Str("RequestID", request.ID.String()).Msg("Failed to send team join request email to owner")
+		return err
+	}
+
+	return nil
+}
+
+// QueueTeamInvitationEmail emails an invitation to join a team. The recipient may not
+// have an account yet, in which case they are greeted without a name.
+func (s *EmailService) QueueTeamInvitationEmail(ctx context.Context, recipient string, name string, invitationID uuid.UUID, inviterName string, teamName string, inviteLink string) error {
+	if name == "" {
+		name = "there"
+	}
+
+	type emailTemplateData struct {
+		Name        string
+		InviterName string
+		TeamName    string
+		InviteLink  string
+	}
+	data := emailTemplateData{Name: name, InviterName: inviterName, TeamName: teamName, InviteLink: inviteLink}
+
+	if _, err := s.QueueTemplateEmail(ctx, recipient, TemplateTeamInvitation, data, invitationID.String()); err != nil {
+		s.logger.Err(err).Str("InvitationID", invitationID.String()).Msg("Failed to send team invitation email")
+		return err
+	}
+
+	return nil
+}
+
+// QueueTemplateEmail queues a built-in email. If the current hackathon overrides its
+// template, the override's current version is sent instead of the file on disk. Emails
+// the recipient unsubscribed from, or that were already queued for the recipient in
+// the current hackathon with the same context key, aren't queued and return a nil
+// TaskInfo. Emails sent once per hackathon pass an empty context key.
+func (s *EmailService) QueueTemplateEmail(ctx context.Context, to string, key TemplateKey, templateData interface{}, contextKey string) (*asynq.TaskInfo, error) {
+	builtin, ok := getBuiltinTemplate(key)
+	if !ok {
+		return nil, ErrUnknownEmailTemplate
+	}
+
+	_, unsubscribed, err := filterUnsubscribed(ctx, s.emailPreferenceRepo, builtin.Category, []string{to})
+	if err != nil {
+		s.logger.Err(err).Msg("Failed to check email preferences")
+		return nil, err
+	}
+	if len(unsubscribed) > 0 {
+		s.logger.Info().Str("Template", string(key)).Msg("Recipient unsubscribed, not queueing email")
+		return nil, nil
+	}
+
+	hackathonID, err := s.getHackathonID(ctx)
+	if err != nil {
+		s.logger.Err(err).Msg("Failed to get current hackathon")
+		return nil, err
+	}
+
+	payload := tasks.SendHtmlEmailPayload{
+		To:               to,
+		Subject:          builtin.Subject,
+		TemplateData:     templateData,
+		TemplateFilePath: s.config.EmailTemplateDirectory + builtin.file,
+	}
+	if builtin.Category != "" {
+		payload.Options = withUnsubscribe(s.config, payload.Options, to, builtin.Category)
+	}
+	templateName := payload.TemplateFilePath
+
+	version, err := s.getTemplateOverride(ctx, hackathonID, key)
+	if err != nil {
+		s.logger.Err(err).Str("Template", string(key)).Msg("Failed to get email template override")
+		return nil, err
+	}
+
+	if version != nil {
+		subject, err := renderTextTemplate("subject", version.Subject, templateData)
+		if err != nil {
+			s.logger.Err(err).Str("Template", string(key)).Msg("Failed to render email template subject")
+			return nil, err
+		}
+
+		payload.Subject = strings.TrimSpace(subject)
+		payload.TemplateVersionID = &version.ID
+		if version.ReplyTo != nil {
+			payload.Options.ReplyTo = []string{*version.ReplyTo}
+		}
+		templateName = fmt.Sprintf("%s v%d", key, version.Version)
+	}
+
+	// Every built-in email goes to a recipient once per hackathon and context key.
+	if contextKey != "" {
+		contextKey = hackathonID + ":" + contextKey
+	} else {
+		contextKey = hackathonID
+	}
+	taskID := emailTaskID(string(key), []string{to}, contextKey)
+
+	info, err := s.queueHtmlEmail(ctx, payload, templateName, taskID)
+	if errors.Is(err, ErrDuplicateEmailTask) {
+		return nil, nil
+	}
+
+	return info, err
+}
+
+// getHackathonID returns the ID of the current hackathon, or empty if there is none.
+func (s *EmailService) getHackathonID(ctx context.Context) (string, error) {
+	hackathon, err := s.hackathonRepo.GetHackathon(ctx)
+	if errors.Is(err, database.ErrEntityNotFound) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+
+	return hackathon.ID, nil
+}
+
+// getTemplateOverride returns the version of a template a hackathon sends, or nil if it
+// sends the built-in one.
+func (s *EmailService) getTemplateOverride(ctx context.Context, hackathonID string, key TemplateKey) (*sqlc.EmailTemplateVersion, error) {
+	if hackathonID == "" {
+		return nil, nil
+	}
+
+	version, err := s.emailTemplateRepo.GetCurrentEmailTemplateVersionByKey(ctx, sqlc.GetCurrentEmailTemplateVersionByKeyParams{
+		HackathonID: hackathonID,
+		Key:         string(key),
+	})
+	if errors.Is(err, ErrEmailTemplateNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
 	}
 
-	task, err := tasks.NewTaskSendHtmlEmail(tasks.SendHtmlEmailPayload{
+	return version, nil
+}
+
+// QueueSendHtmlEmailTask records a delivery for the email and queues it on the email queue.
+// contextKey is what the email is about, like a hackathon ID. The same template can only
+// be queued once for a recipient and context key, so a retried request doesn't send it
+// twice. It returns ErrDuplicateEmailTask for duplicates. An empty contextKey turns the
+// check off.
+func (s *EmailService) QueueSendHtmlEmailTask(ctx context.Context, to string, subject string, templateData interface{}, templateFilePath string, contextKey string, options tasks.EmailOptions) (*asynq.TaskInfo, error) {
+	var taskID string
+	if contextKey != "" {
+		taskID = emailTaskID(templateFilePath, []string{to}, contextKey)
+	}
+
+	return s.queueHtmlEmail(ctx, tasks.SendHtmlEmailPayload{
 		To:               to,
 		Subject:          subject,
 		TemplateData:     templateData,
 		TemplateFilePath: templateFilePath,
+		Options:          options,
+	}, templateFilePath, taskID)
+}
+
+// queueHtmlEmail records a delivery and queues the email. With a taskID, an email that is
+// already queued or was sent within the retention is rejected with ErrDuplicateEmailTask.
+func (s *EmailService) queueHtmlEmail(ctx context.Context, payload tasks.SendHtmlEmailPayload, templateName string, taskID string) (*asynq.TaskInfo, error) {
+	if len(payload.To) == 0 {
+		s.logger.Warn().Msgf("No recipient email found for email being sent from template '%s'", templateName)
+	}
+
+	if err := validateEmailOptions(payload.Options); err != nil {
+		return nil, err
+	}
+
+	delivery, err := s.emailDeliveryRepo.CreateEmailDelivery(ctx, sqlc.CreateEmailDeliveryParams{
+		Recipient: payload.To,
+		Subject:   payload.Subject,
+		Template:  &templateName,
 	})
+	if err != nil {
+		s.logger.Err(err).Msg("Failed to record email delivery")
+		return nil, err
+	}
+
+	payload.DeliveryID = &delivery.ID
+	task, err := tasks.NewTaskSendHtmlEmail(payload)
 
 	if err != nil {
 		s.logger.Err(err).Msg("Failed to create SendHtmlEmail task")
 		return nil, err
 	}
 
-	taskInfo, err := s.taskQueue.Enqueue(task, asynq.Queue("email"))
-	s.logger.Info().Str("TaskID", taskInfo.ID).Str("Task Queue", taskInfo.Queue).Str("Task Type", taskInfo.Type).Msg("Queued SendHtmlEmail task!")
+	taskInfo, err := s.taskQueue.Enqueue(task, emailTaskOptions(s.config, taskID)...)
+	if errors.Is(err, asynq.ErrTaskIDConflict) {
+		s.logger.Info().Str("TaskID", taskID).Msgf("Email from template '%s' was already queued", templateName)
+		if err := s.emailDeliveryRepo.DeleteQueuedEmailDelivery(ctx, delivery.ID); err != nil {
+			s.logger.Err(err).Msg("Failed to delete duplicate email delivery")
+		}
+		return nil, ErrDuplicateEmailTask
+	}
 	if err != nil {
 		s.logger.Err(err).Msg("Failed to queue SendHtmlEmail task")
 		return nil, err
 	}
+	s.logger.Info().Str("TaskID", taskInfo.ID).Str("Task Queue", taskInfo.Queue).Str("Task Type", taskInfo.Type).Msg("Queued SendHtmlEmail task!")
 
 	return taskInfo, nil
 }
 
-func (s *EmailService) QueueSendTextEmail(to []string, subject string, body string) (*asynq.TaskInfo, error) {
+// QueueSendTextEmail queues a text email to every recipient that isn't on the
+// suppression list or unsubscribed from the options' category. It returns
+// ErrRecipientSuppressed or ErrRecipientUnsubscribed if none are left. With an
+// idempotencyKey, queueing the same subject to the same recipients again returns
+// ErrDuplicateEmailTask.
+func (s *EmailService) QueueSendTextEmail(ctx context.Context, to []string, subject string, body string, idempotencyKey string, options tasks.EmailOptions) (*asynq.TaskInfo, error) {
+	if err := validateEmailOptions(options); err != nil {
+		return nil, err
+	}
+
+	to, suppressed, err := filterSuppressed(ctx, s.emailSuppressionRepo, to)
+	if err != nil {
+		s.logger.Err(err).Msg("Failed to check email suppressions")
+		return nil, err
+	}
+	if len(suppressed) > 0 {
+		s.logger.Info().Int("Suppressed", len(suppressed)).Msg("Left suppressed recipients out of text email")
+	}
+	if len(to) == 0 && len(suppressed) > 0 {
+		return nil, ErrRecipientSuppressed
+	}
+
+	to, unsubscribed, err := filterUnsubscribed(ctx, s.emailPreferenceRepo, sqlc.EmailCategory(options.Category), to)
+	if err != nil {
+		s.logger.Err(err).Msg("Failed to check email preferences")
+		return nil, err
+	}
+	if len(unsubscribed) > 0 {
+		s.logger.Info().Int("Unsubscribed", len(unsubscribed)).Msg("Left unsubscribed recipients out of text email")
+	}
+	if len(to) == 0 && len(unsubscribed) > 0 {
+		return nil, ErrRecipientUnsubscribed
+	}
+
 	task, err := tasks.NewTaskSendTextEmail(tasks.SendTextEmailPayload{
 		To:      to,
 		Subject: subject,
 		Body:    body,
+		Options: options,
 	})
 
 	if err != nil {
@@ -156,7 +398,16 @@ func (s *EmailService) QueueSendTextEmail(to []string, subject string, body stri
 		return nil, err
 	}
 
-	info, err := s.taskQueue.Enqueue(task, asynq.Queue("email"))
+	var taskID string
+	if idempotencyKey != "" {
+		taskID = emailTaskID("text:"+subject, to, idempotencyKey)
+	}
+
+	info, err := s.taskQueue.Enqueue(task, emailTaskOptions(s.config, taskID)...)
+	if errors.Is(err, asynq.ErrTaskIDConflict) {
+		s.logger.Info().Str("TaskID", taskID).Msg("Text email was already queued")
+		return nil, ErrDuplicateEmailTask
+	}
 	if err != nil {
 		s.logger.Err(err).Msg("Failed to queue SendTextEmail task")
 		return nil, err
@@ -165,6 +416,44 @@ func (s *EmailService) QueueSendTextEmail(to []string, subject string, body stri
 	return info, nil
 }
 
+// SendTextEmail sends a queued text email to the recipients that haven't bounced,
+// complained or unsubscribed since it was queued.
+func (s *EmailService) SendTextEmail(ctx context.Context, to []string, subject string, body string, options tasks.EmailOptions) error {
+	to, suppressed, err := filterSuppressed(ctx, s.emailSuppressionRepo, to)
+	if err != nil {
+		s.logger.Err(err).Msg("Failed to check email suppressions")
+		return err
+	}
+	if len(suppressed) > 0 {
+		s.logger.Info().Int("Suppressed", len(suppressed)).Msg("Skipped text email to suppressed recipients")
+	}
+
+	to, unsubscribed, err := filterUnsubscribed(ctx, s.emailPreferenceRepo, sqlc.EmailCategory(options.Category), to)
+	if err != nil {
+		s.logger.Err(err).Msg("Failed to check email preferences")
+		return err
+	}
+	if len(unsubscribed) > 0 {
+		s.logger.Info().Int("Unsubscribed", len(unsubscribed)).Msg("Skipped text email to unsubscribed recipients")
+	}
+	if len(to) == 0 {
+		return nil
+	}
+
+	if _, err := s.sender.Send(ctx, withOptions(emailutils.Email{
+		To:      to,
+		From:    defaultSender,
+		Subject: subject,
+		Text:    body,
+	}, options)); err != nil {
+		s.logger.Err(err).Msg("Failed to send text email")
+		return err
+	}
+	s.logger.Info().Int("Recipients", len(to)).Msg("Sent text email")
+
+	return nil
+}
+
 // SendHtmlEmail
 //
 //	  templateData: a struct holding the data which should replace {{}} tags inside of an html template.
@@ -172,20 +461,73 @@ func (s *EmailService) QueueSendTextEmail(to []string, subject string, body stri
 //	     type templateData struct {
 //	         Name string
 //	     }
-func (s *EmailService) SendHtmlEmail(recipient string, subject string, templateData interface{}, templateFilePath string) error {
+//
+//	deliveryID: the delivery recorded when the email was queued, which gets the outcome. Nil for
+//	  emails queued before deliveries were tracked.
+//
+//	templateVersionID: the hackathon's version of the template to render instead of the file. If
+//	  the version was deleted since, the file is used.
+//
+//	options: the category, reply-to, List-Unsubscribe URLs and attachments the email was queued with.
+func (s *EmailService) SendHtmlEmail(ctx context.Context, deliveryID *uuid.UUID, templateVersionID *uuid.UUID, recipient string, subject string, templateData interface{}, templateFilePath string, options tasks.EmailOptions) error {
+	// The recipient may have bounced or complained since the email was queued.
+	_, suppressed, err := filterSuppressed(ctx, s.emailSuppressionRepo, []string{recipient})
+	if err != nil {
+		s.logger.Err(err).Msg("Failed to check email suppressions")
+		return err
+	}
+	if len(suppressed) > 0 {
+		s.logger.Info().Str("Template", templateFilePath).Msg("Skipped email to suppressed recipient")
+		recordDelivery(ctx, s.emailDeliveryRepo, s.logger, deliveryID, "", ErrRecipientSuppressed)
+		return nil
+	}
+
+	_, unsubscribed, err := filterUnsubscribed(ctx, s.emailPreferenceRepo, sqlc.EmailCategory(options.Category), []string{recipient})
+	if err != nil {
+		s.logger.Err(err).Msg("Failed to check email preferences")
+		return err
+	}
+	if len(unsubscribed) > 0 {
+		s.logger.Info().Str("Template", templateFilePath).Msg("Skipped email to unsubscribed recipient")
+		recordDelivery(ctx, s.emailDeliveryRepo, s.logger, deliveryID, "", ErrRecipientUnsubscribed)
+		return nil
+	}
+
+	if templateVersionID != nil {
+		version, err := s.emailTemplateRepo.GetEmailTemplateVersionByID(ctx, *templateVersionID)
+		if err == nil {
+			return s.sendTemplateVersion(ctx, deliveryID, version, recipient, subject, templateData, options)
+		}
+		if !errors.Is(err, ErrEmailTemplateNotFound) {
+			s.logger.Err(err).Msg("Failed to get email template version")
+			return err
+		}
+		s.logger.Warn().Str("TemplateVersionID", templateVersionID.String()).Msg("Email template version was deleted, sending the built-in template")
+	}
+
 	var body bytes.Buffer
 
 	template, err := template.ParseFiles(templateFilePath)
 	if err != nil {
 		s.logger.Err(err).Msg("Failed to parse email template for recipient")
+		recordDelivery(ctx, s.emailDeliveryRepo, s.logger, deliveryID, "", err)
+		return err
 	}
 
 	err = template.Execute(&body, templateData)
 	if err != nil {
-		s.logger.Err(err).Msg("Failed to inject template variables for recipient '%s'.")
+		s.logger.Err(err).Msg("Failed to inject template variables for recipient")
+		recordDelivery(ctx, s.emailDeliveryRepo, s.logger, deliveryID, "", err)
+		return err
 	}
 
-	err = s.SESClient.SendHTMLEmail([]string{recipient}, "SwampHacks <contact@swamphacks.com>", subject, body.String())
+	messageID, err := s.sender.Send(ctx, withOptions(emailutils.Email{
+		To:      []string{recipient},
+		From:    defaultSender,
+		Subject: subject,
+		Html:    body.String(),
+	}, options))
+	recordDelivery(ctx, s.emailDeliveryRepo, s.logger, deliveryID, messageID, err)
 	if err != nil {
 		s.logger.Err(err).Msg("Failed to send html email to recipient")
 		return err
@@ -195,6 +537,39 @@ func (s *EmailService) SendHtmlEmail(recipient string, subject string, templateD
 	return nil
 }
 
+func (s *EmailService) sendTemplateVersion(
+	ctx context.Context,
+	deliveryID *uuid.UUID,
+	version *sqlc.EmailTemplateVersion,
+	recipient string,
+	subject string,
+	templateData interface{},
+	options tasks.EmailOptions,
+) error {
+	rendered, err := renderTemplateContent(versionContent(version), templateData)
+	if err != nil {
+		s.logger.Err(err).Msg("Failed to render email template version for recipient")
+		recordDelivery(ctx, s.emailDeliveryRepo, s.logger, deliveryID, "", err)
+		return err
+	}
+
+	messageID, err := s.sender.Send(ctx, withOptions(emailutils.Email{
+		To:      []string{recipient},
+		From:    defaultSender,
+		Subject: subject,
+		Html:    rendered.Html,
+		Text:    rendered.Text,
+	}, options))
+	recordDelivery(ctx, s.emailDeliveryRepo, s.logger, deliveryID, messageID, err)
+	if err != nil {
+		s.logger.Err(err).Msg("Failed to send html email to recipient")
+		return err
+	}
+	s.logger.Info().Str("TemplateVersionID", version.ID.String()).Int32("Version", version.Version).Msg("Sent email")
+
+	return nil
+}
+
 func (s *EmailService) SendWelcomeEmailToAttendees(ctx context.Context) error {
 	attendees, err := s.hackathonRepo.GetAttendeeUserIds(ctx)
 	if err != nil {
@@ -246,37 +621,34 @@ var (
 	ErrCouldNotGetEmailInfo            = errors.New("Could not get email info for applicant.")
 	ErrParseTemplateFilepathFailed     = errors.New("Could not parse filepath for template.")
 	ErrFailedToSendDecisionEmails      = errors.New("Failed to send decision emails")
+	ErrNoDecisionEmail                 = errors.New("There is no decision email for this status")
 	ErrTestErr                         = errors.New("Err while testing")
 	ErrFailedToGetContactEmail         = errors.New("Failed to get contact email")
 	ErrUserNotAttendee                 = errors.New("user is not an attendee")
 	ErrUserCheckedIn                   = errors.New("user already checked in")
 )
 
-func (s *EmailService) SendDecisionEmails(ctx context.Context, batRun sqlc.BatRun) error {
-	accepetedEmailTemplatePath := s.config.EmailTemplateDirectory + "ApplicationAcceptedEmail.html"
-	rejectedEmailTemplatePath := s.config.EmailTemplateDirectory + "ApplicationRejectedEmail.html"
-	acceptedEmailSubject := "Congratulations on being accepted to hack in SwampHacks XII!"
-	rejectedEmailSubject := "Update on Your SwampHacks XII Application"
-
-	for _, uuid := range batRun.AcceptedApplicants {
-		emailInfo, err := s.userRepo.GetUserEmailInfoById(ctx, uuid)
-		if err != nil {
-			return ErrCouldNotGetEmailInfo
-		}
+// QueueDecisionEmails queues the decision email matching status for every given user.
+func (s *EmailService) QueueDecisionEmails(ctx context.Context, status sqlc.ApplicationStatus, userIDs []uuid.UUID) error {
+	var key TemplateKey
+
+	switch status {
+	case sqlc.ApplicationStatusAccepted:
+		key = TemplateApplicationAccepted
+	case sqlc.ApplicationStatusWaitlisted:
+		key = TemplateApplicationWaitlisted
+	case sqlc.ApplicationStatusRejected:
+		key = TemplateApplicationRejected
+	default:
+		return ErrNoDecisionEmail
+	}
 
-		contactEmail, ok := emailInfo.ContactEmail.(string)
-		if !ok {
-			return ErrFailedToGetContactEmail
-		}
-		type emailTemplateData struct {
-			Name string
-		}
-		taskInfo, err := s.QueueSendHtmlEmailTask(contactEmail, acceptedEmailSubject, emailTemplateData{Name: emailInfo.Name}, accepetedEmailTemplatePath)
-		s.logger.Info().Str("TaskID", taskInfo.ID).Str("Task Queue", taskInfo.Queue).Str("Task Type", taskInfo.Type).Msg("Queued acceptance email")
+	type emailTemplateData struct {
+		Name string
 	}
 
-	for _, uuid := range batRun.RejectedApplicants {
-		emailInfo, err := s.userRepo.GetUserEmailInfoById(ctx, uuid)
+	for _, userID := range userIDs {
+		emailInfo, err := s.userRepo.GetUserEmailInfoById(ctx, userID)
 		if err != nil {
 			return ErrCouldNotGetEmailInfo
 		}
@@ -285,11 +657,10 @@ func (s *EmailService) SendDecisionEmails(ctx context.Context, batRun sqlc.BatRu
 		if !ok {
 			return ErrFailedToGetContactEmail
 		}
-		type emailTemplateData struct {
-			Name string
+
+		if _, err := s.QueueTemplateEmail(ctx, contactEmail, key, emailTemplateData{Name: emailInfo.Name}, ""); err != nil {
+			return ErrFailedToSendDecisionEmails
 		}
-		taskInfo, err := s.QueueSendHtmlEmailTask(contactEmail, rejectedEmailSubject, emailTemplateData{emailInfo.Name}, rejectedEmailTemplatePath)
-		s.logger.Info().Str("TaskID", taskInfo.ID).Str("Task Queue", taskInfo.Queue).Str("Task Type", taskInfo.Type).Msg("Queued rejection email")
 	}
 
 	return nil
@@ -0,0 +1,189 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/rs/zerolog"
+	"github.com/swamphacks/core/apps/api/internal/api/cookie"
+	"github.com/swamphacks/core/apps/api/internal/api/middleware"
+)
+
+func RegisterTaskRoutes(emailTaskHandler *emailTaskHandler, group huma.API, mw *middleware.Middleware) {
+	huma.Register(group, huma.Operation{
+		OperationID:   "list-archived-email-tasks",
+		Method:        http.MethodGet,
+		Summary:       "List Archived Email Tasks",
+		Description:   "Returns the emails and campaign tasks that failed every retry.",
+		Tags:          []string{"Email Tasks"},
+		Path:          "/tasks/archived",
+		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma, mw.Auth.RequireAdminHuma},
+		Errors:        []int{http.StatusUnauthorized, http.StatusInternalServerError},
+		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
+		DefaultStatus: http.StatusOK,
+	}, emailTaskHandler.handleListArchivedTasks)
+
+	huma.Register(group, huma.Operation{
+		OperationID:   "get-archived-email-task",
+		Method:        http.MethodGet,
+		Summary:       "Get Archived Email Task",
+		Description:   "Returns an archived email task with the error of its last attempt.",
+		Tags:          []string{"Email Tasks"},
+		Path:          "/tasks/archived/{taskId}",
+		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma, mw.Auth.RequireAdminHuma},
+		Errors:        []int{http.StatusUnauthorized, http.StatusNotFound, http.StatusInternalServerError},
+		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
+		DefaultStatus: http.StatusOK,
+	}, emailTaskHandler.handleGetArchivedTask)
+
+	huma.Register(group, huma.Operation{
+		OperationID:   "retry-archived-email-task",
+		Method:        http.MethodPost,
+		Summary:       "Retry Archived Email Task",
+		Description:   "Queues an archived email task to run again.",
+		Tags:          []string{"Email Tasks"},
+		Path:          "/tasks/archived/{taskId}/retry",
+		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma, mw.Auth.RequireAdminHuma},
+		Errors:        []int{http.StatusUnauthorized, http.StatusNotFound, http.StatusInternalServerError},
+		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
+		DefaultStatus: http.StatusNoContent,
+	}, emailTaskHandler.handleRetryArchivedTask)
+
+	huma.Register(group, huma.Operation{
+		OperationID:   "discard-archived-email-task",
+		Method:        http.MethodDelete,
+		Summary:       "Discard Archived Email Task",
+		Description:   "Deletes an archived email task without sending it.",
+		Tags:          []string{"Email Tasks"},
+		Path:          "/tasks/archived/{taskId}",
+		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma, mw.Auth.RequireAdminHuma},
+		Errors:        []int{http.StatusUnauthorized, http.StatusNotFound, http.StatusInternalServerError},
+		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
+		DefaultStatus: http.StatusNoContent,
+	}, emailTaskHandler.handleDiscardArchivedTask)
+
+	huma.Register(group, huma.Operation{
+		OperationID:   "retry-all-archived-email-tasks",
+		Method:        http.MethodPost,
+		Summary:       "Retry All Archived Email Tasks",
+		Description:   "Queues every archived email task to run again.",
+		Tags:          []string{"Email Tasks"},
+		Path:          "/tasks/archived/retry",
+		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma, mw.Auth.RequireAdminHuma},
+		Errors:        []int{http.StatusUnauthorized, http.StatusInternalServerError},
+		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
+		DefaultStatus: http.StatusOK,
+	}, emailTaskHandler.handleRetryAllArchivedTasks)
+
+	huma.Register(group, huma.Operation{
+		OperationID:   "discard-all-archived-email-tasks",
+		Method:        http.MethodDelete,
+		Summary:       "Discard All Archived Email Tasks",
+		Description:   "Deletes every archived email task.",
+		Tags:          []string{"Email Tasks"},
+		Path:          "/tasks/archived",
+		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma, mw.Auth.RequireAdminHuma},
+		Errors:        []int{http.StatusUnauthorized, http.StatusInternalServerError},
+		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
+		DefaultStatus: http.StatusOK,
+	}, emailTaskHandler.handleDiscardAllArchivedTasks)
+}
+
+type emailTaskHandler struct {
+	emailTaskService *EmailTaskService
+	logger           zerolog.Logger
+}
+
+func NewTaskHandler(emailTaskService *EmailTaskService, logger zerolog.Logger) *emailTaskHandler {
+	return &emailTaskHandler{
+		emailTaskService: emailTaskService,
+		logger:           logger.With().Str("handler", "EmailTaskHandler").Str("domain", "email").Logger(),
+	}
+}
+
+type ArchivedEmailTaskOutput struct {
+	Body *ArchivedEmailTask
+}
+
+type ListArchivedEmailTasksOutput struct {
+	Body []ArchivedEmailTask
+}
+
+type ArchivedEmailTaskCount struct {
+	Count int `json:"count"`
+}
+
+type ArchivedEmailTaskCountOutput struct {
+	Body ArchivedEmailTaskCount
+}
+
+func (h *emailTaskHandler) handleListArchivedTasks(ctx context.Context, input *struct {
+	Page     int `query:"page" minimum:"1" default:"1"`
+	PageSize int `query:"pageSize" minimum:"1" maximum:"100" default:"20"`
+}) (*ListArchivedEmailTasksOutput, error) {
+	archived, err := h.emailTaskService.ListArchivedTasks(input.Page, input.PageSize)
+	if err != nil {
+		return nil, taskHTTPError(err, "Failed to list archived email tasks")
+	}
+
+	return &ListArchivedEmailTasksOutput{Body: archived}, nil
+}
+
+func (h *emailTaskHandler) handleGetArchivedTask(ctx context.Context, input *struct {
+	TaskID string `path:"taskId"`
+}) (*ArchivedEmailTaskOutput, error) {
+	archived, err := h.emailTaskService.GetArchivedTask(input.TaskID)
+	if err != nil {
+		return nil, taskHTTPError(err, "Failed to get archived email task")
+	}
+
+	return &ArchivedEmailTaskOutput{Body: archived}, nil
+}
+
+func (h *emailTaskHandler) handleRetryArchivedTask(ctx context.Context, input *struct {
+	TaskID string `path:"taskId"`
+}) (*struct{}, error) {
+	if err := h.emailTaskService.RetryArchivedTask(input.TaskID); err != nil {
+		return nil, taskHTTPError(err, "Failed to retry archived email task")
+	}
+
+	return nil, nil
+}
+
+func (h *emailTaskHandler) handleDiscardArchivedTask(ctx context.Context, input *struct {
+	TaskID string `path:"taskId"`
+}) (*struct{}, error) {
+	if err := h.emailTaskService.DiscardArchivedTask(input.TaskID); err != nil {
+		return nil, taskHTTPError(err, "Failed to discard archived email task")
+	}
+
+	return nil, nil
+}
+
+func (h *emailTaskHandler) handleRetryAllArchivedTasks(ctx context.Context, input *struct{}) (*ArchivedEmailTaskCountOutput, error) {
+	count, err := h.emailTaskService.RetryAllArchivedTasks()
+	if err != nil {
+		return nil, taskHTTPError(err, "Failed to retry archived email tasks")
+	}
+
+	return &ArchivedEmailTaskCountOutput{Body: ArchivedEmailTaskCount{Count: count}}, nil
+}
+
+func (h *emailTaskHandler) handleDiscardAllArchivedTasks(ctx context.Context, input *struct{}) (*ArchivedEmailTaskCountOutput, error) {
+	count, err := h.emailTaskService.DiscardAllArchivedTasks()
+	if err != nil {
+		return nil, taskHTTPError(err, "Failed to discard archived email tasks")
+	}
+
+	return &ArchivedEmailTaskCountOutput{Body: ArchivedEmailTaskCount{Count: count}}, nil
+}
+
+func taskHTTPError(err error, fallback string) error {
+	if errors.Is(err, ErrEmailTaskNotFound) {
+		return huma.Error404NotFound(err.Error())
+	}
+
+	return huma.Error500InternalServerError(fallback)
+}
@@ -0,0 +1,234 @@
+package email
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog"
+	"github.com/swamphacks/core/apps/api/internal/config"
+	"github.com/swamphacks/core/apps/api/internal/tasks"
+)
+
+// emailQueue is the asynq queue emails and campaigns are sent from.
+const emailQueue = "email"
+
+var (
+	ErrDuplicateEmailTask = errors.New("email was already queued")
+	ErrEmailTaskNotFound  = errors.New("archived email task not found")
+)
+
+// emailTaskID is the task ID of an email, made from its template, recipients and the
+// context it is sent in, so queueing the same email twice is rejected by the queue.
+// Addresses are hashed to keep them out of task IDs. Without a context key the email
+// gets a random ID and isn't checked for duplicates.
+func emailTaskID(template string, recipients []string, contextKey string) string {
+	if contextKey == "" {
+		return ""
+	}
+
+	addresses := make([]string, len(recipients))
+	for i, recipient := range recipients {
+		addresses[i] = strings.ToLower(strings.TrimSpace(recipient))
+	}
+	slices.Sort(addresses)
+
+	sum := sha256.Sum256([]byte(template + "\n" + strings.Join(addresses, ",") + "\n" + contextKey))
+
+	return "email:" + hex.EncodeToString(sum[:16])
+}
+
+// emailTaskOptions are the options emails are queued with. Emails with a task ID are kept
+// for EmailTaskRetention after they are sent, so duplicates are rejected until then.
+func emailTaskOptions(cfg *config.Config, taskID string) []asynq.Option {
+	options := []asynq.Option{asynq.Queue(emailQueue)}
+	if taskID != "" {
+		options = append(options, asynq.TaskID(taskID), asynq.Retention(cfg.EmailTaskRetention))
+	}
+	return options
+}
+
+// ArchivedEmailTask is a task on the email queue that ran out of retries. Attachments
+// and template data are left out.
+type ArchivedEmailTask struct {
+	ID           string     `json:"id"`
+	Type         string     `json:"type"`
+	To           []string   `json:"to,omitempty"`
+	Subject      string     `json:"subject,omitempty"`
+	Template     string     `json:"template,omitempty"`
+	DeliveryID   *uuid.UUID `json:"deliveryId,omitempty"`
+	CampaignID   *uuid.UUID `json:"campaignId,omitempty"`
+	Retried      int        `json:"retried"`
+	MaxRetry     int        `json:"maxRetry"`
+	LastError    string     `json:"lastError"`
+	LastFailedAt *time.Time `json:"lastFailedAt,omitempty"`
+}
+
+// EmailTaskService lets admins look at the email tasks asynq archived after their last
+// retry failed, and retry or discard them.
+type EmailTaskService struct {
+	inspector *asynq.Inspector
+	logger    zerolog.Logger
+}
+
+func NewEmailTaskService(inspector *asynq.Inspector, logger zerolog.Logger) *EmailTaskService {
+	return &EmailTaskService{
+		inspector: inspector,
+		logger:    logger.With().Str("service", "EmailTaskService").Str("domain", "email").Logger(),
+	}
+}
+
+// ListArchivedTasks returns a page of archived email tasks, starting at page 1.
+func (s *EmailTaskService) ListArchivedTasks(page int, pageSize int) ([]ArchivedEmailTask, error) {
+	infos, err := s.inspector.ListArchivedTasks(emailQueue, asynq.Page(page), asynq.PageSize(pageSize))
+	if errors.Is(err, asynq.ErrQueueNotFound) {
+		// Nothing was ever queued.
+		return []ArchivedEmailTask{}, nil
+	} else if err != nil {
+		s.logger.Err(err).Msg("Failed to list archived email tasks")
+		return nil, err
+	}
+
+	archived := make([]ArchivedEmailTask, len(infos))
+	for i, info := range infos {
+		archived[i] = archivedEmailTask(info)
+	}
+
+	return archived, nil
+}
+
+func (s *EmailTaskService) GetArchivedTask(taskID string) (*ArchivedEmailTask, error) {
+	info, err := s.getArchivedTask(taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	archived := archivedEmailTask(info)
+	return &archived, nil
+}
+
+// RetryArchivedTask queues an archived task to run again right away.
+func (s *EmailTaskService) RetryArchivedTask(taskID string) error {
+	if _, err := s.getArchivedTask(taskID); err != nil {
+		return err
+	}
+
+	if err := s.inspector.RunTask(emailQueue, taskID); err != nil {
+		s.logger.Err(err).Str("TaskID", taskID).Msg("Failed to retry archived email task")
+		return err
+	}
+	s.logger.Info().Str("TaskID", taskID).Msg("Retrying archived email task")
+
+	return nil
+}
+
+// DiscardArchivedTask deletes an archived task. Its delivery stays failed.
+func (s *EmailTaskService) DiscardArchivedTask(taskID string) error {
+	if _, err := s.getArchivedTask(taskID); err != nil {
+		return err
+	}
+
+	if err := s.inspector.DeleteTask(emailQueue, taskID); err != nil {
+		s.logger.Err(err).Str("TaskID", taskID).Msg("Failed to discard archived email task")
+		return err
+	}
+	s.logger.Info().Str("TaskID", taskID).Msg("Discarded archived email task")
+
+	return nil
+}
+
+// RetryAllArchivedTasks queues every archived task to run again and returns how many.
+func (s *EmailTaskService) RetryAllArchivedTasks() (int, error) {
+	count, err := s.inspector.RunAllArchivedTasks(emailQueue)
+	if errors.Is(err, asynq.ErrQueueNotFound) {
+		return 0, nil
+	} else if err != nil {
+		s.logger.Err(err).Msg("Failed to retry archived email tasks")
+		return 0, err
+	}
+	s.logger.Info().Int("Count", count).Msg("Retrying archived email tasks")
+
+	return count, nil
+}
+
+// DiscardAllArchivedTasks deletes every archived task and returns how many.
+func (s *EmailTaskService) DiscardAllArchivedTasks() (int, error) {
+	count, err := s.inspector.DeleteAllArchivedTasks(emailQueue)
+	if errors.Is(err, asynq.ErrQueueNotFound) {
+		return 0, nil
+	} else if err != nil {
+		s.logger.Err(err).Msg("Failed to discard archived email tasks")
+		return 0, err
+	}
+	s.logger.Info().Int("Count", count).Msg("Discarded archived email tasks")
+
+	return count, nil
+}
+
+func (s *EmailTaskService) getArchivedTask(taskID string) (*asynq.TaskInfo, error) {
+	info, err := s.inspector.GetTaskInfo(emailQueue, taskID)
+	if errors.Is(err, asynq.ErrTaskNotFound) || errors.Is(err, asynq.ErrQueueNotFound) {
+		return nil, ErrEmailTaskNotFound
+	} else if err != nil {
+		s.logger.Err(err).Str("TaskID", taskID).Msg("Failed to get email task")
+		return nil, err
+	}
+
+	if info.State != asynq.TaskStateArchived {
+		return nil, ErrEmailTaskNotFound
+	}
+
+	return info, nil
+}
+
+// archivedEmailTask reads what an admin needs to recognize a task from its payload.
+func archivedEmailTask(info *asynq.TaskInfo) ArchivedEmailTask {
+	archived := ArchivedEmailTask{
+		ID:        info.ID,
+		Type:      info.Type,
+		Retried:   info.Retried,
+		MaxRetry:  info.MaxRetry,
+		LastError: info.LastErr,
+	}
+	if !info.LastFailedAt.IsZero() {
+		archived.LastFailedAt = &info.LastFailedAt
+	}
+
+	switch info.Type {
+	case tasks.TypeSendHtmlEmail:
+		var payload tasks.SendHtmlEmailPayload
+		if json.Unmarshal(info.Payload, &payload) == nil {
+			archived.To = []string{payload.To}
+			archived.Subject = payload.Subject
+			archived.Template = payload.TemplateFilePath
+			archived.DeliveryID = payload.DeliveryID
+		}
+	case tasks.TypeSendTextEmail:
+		var payload tasks.SendTextEmailPayload
+		if json.Unmarshal(info.Payload, &payload) == nil {
+			archived.To = payload.To
+			archived.Subject = payload.Subject
+		}
+	case tasks.TypeSendCampaign:
+		var payload tasks.SendCampaignPayload
+		if json.Unmarshal(info.Payload, &payload) == nil {
+			archived.CampaignID = &payload.CampaignID
+		}
+	case tasks.TypeSendCampaignBatch:
+		var payload tasks.SendCampaignBatchPayload
+		if json.Unmarshal(info.Payload, &payload) == nil {
+			archived.CampaignID = &payload.CampaignID
+			for _, recipient := range payload.Recipients {
+				archived.To = append(archived.To, recipient.Email)
+			}
+		}
+	}
+
+	return archived
+}
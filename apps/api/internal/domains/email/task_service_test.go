@@ -0,0 +1,72 @@
+package email
+
+import "testing"
+
+func TestEmailTaskID(t *testing.T) {
+	base := emailTaskID("welcome", []string{"albert@ufl.edu"}, "swamphacks-xii")
+
+	tests := []struct {
+		name       string
+		template   string
+		recipients []string
+		contextKey string
+		expectSame bool
+	}{
+		{
+			name:       "same email",
+			template:   "welcome",
+			recipients: []string{"albert@ufl.edu"},
+			contextKey: "swamphacks-xii",
+			expectSame: true,
+		},
+		{
+			name:       "address differs in case",
+			template:   "welcome",
+			recipients: []string{" Albert@UFL.edu"},
+			contextKey: "swamphacks-xii",
+			expectSame: true,
+		},
+		{
+			name:       "other template",
+			template:   "application_confirmation",
+			recipients: []string{"albert@ufl.edu"},
+			contextKey: "swamphacks-xii",
+		},
+		{
+			name:       "other recipient",
+			template:   "welcome",
+			recipients: []string{"alberta@ufl.edu"},
+			contextKey: "swamphacks-xii",
+		},
+		{
+			name:       "other context",
+			template:   "welcome",
+			recipients: []string{"albert@ufl.edu"},
+			contextKey: "swamphacks-xiii",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			id := emailTaskID(test.template, test.recipients, test.contextKey)
+
+			if (id == base) != test.expectSame {
+				t.Fatalf("expected same ID %v, got %q and %q", test.expectSame, id, base)
+			}
+		})
+	}
+
+	t.Run("recipient order", func(t *testing.T) {
+		a := emailTaskID("text:Hi", []string{"a@ufl.edu", "b@ufl.edu"}, "key")
+		b := emailTaskID("text:Hi", []string{"b@ufl.edu", "a@ufl.edu"}, "key")
+		if a != b {
+			t.Fatalf("expected the same ID for reordered recipients, got %q and %q", a, b)
+		}
+	})
+
+	t.Run("no context key", func(t *testing.T) {
+		if id := emailTaskID("welcome", []string{"albert@ufl.edu"}, ""); id != "" {
+			t.Fatalf("expected no ID without a context key, got %q", id)
+		}
+	})
+}
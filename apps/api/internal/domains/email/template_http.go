@@ -0,0 +1,386 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/swamphacks/core/apps/api/internal/api/cookie"
+	"github.com/swamphacks/core/apps/api/internal/api/middleware"
+	"github.com/swamphacks/core/apps/api/internal/ctxutils"
+	"github.com/swamphacks/core/apps/api/internal/database/sqlc"
+)
+
+func RegisterTemplateRoutes(emailTemplateHandler *emailTemplateHandler, group huma.API, mw *middleware.Middleware) {
+	huma.Register(group, huma.Operation{
+		OperationID:   "list-builtin-email-templates",
+		Method:        http.MethodGet,
+		Summary:       "List Built-in Email Templates",
+		Description:   "Returns the emails the API sends, with their default subject and the variables they can use.",
+		Tags:          []string{"Email Templates"},
+		Path:          "/templates/builtin",
+		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma, mw.Auth.RequireAdminHuma},
+		Errors:        []int{http.StatusUnauthorized},
+		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
+		DefaultStatus: http.StatusOK,
+	}, emailTemplateHandler.handleListBuiltinTemplates)
+
+	huma.Register(group, huma.Operation{
+		OperationID:   "list-email-templates",
+		Method:        http.MethodGet,
+		Summary:       "List Email Templates",
+		Description:   "Returns the email templates a hackathon overrides.",
+		Tags:          []string{"Email Templates"},
+		Path:          "/templates",
+		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma, mw.Auth.RequireAdminHuma},
+		Errors:        []int{http.StatusUnauthorized, http.StatusInternalServerError},
+		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
+		DefaultStatus: http.StatusOK,
+	}, emailTemplateHandler.handleListTemplates)
+
+	huma.Register(group, huma.Operation{
+		OperationID:   "create-email-template",
+		Method:        http.MethodPost,
+		Summary:       "Create Email Template",
+		Description:   "Overrides a built-in email for a hackathon. The content becomes version 1.",
+		Tags:          []string{"Email Templates"},
+		Path:          "/templates",
+		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma, mw.Auth.RequireAdminHuma},
+		Errors:        []int{http.StatusUnauthorized, http.StatusBadRequest, http.StatusConflict, http.StatusInternalServerError},
+		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
+		DefaultStatus: http.StatusCreated,
+	}, emailTemplateHandler.handleCreateTemplate)
+
+	huma.Register(group, huma.Operation{
+		OperationID:   "preview-email-template-content",
+		Method:        http.MethodPost,
+		Summary:       "Preview Email Template Content",
+		Description:   "Renders unsaved template content for a user, or for a sample user.",
+		Tags:          []string{"Email Templates"},
+		Path:          "/templates/preview",
+		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma, mw.Auth.RequireAdminHuma},
+		Errors:        []int{http.StatusUnauthorized, http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError},
+		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
+		DefaultStatus: http.StatusOK,
+	}, emailTemplateHandler.handlePreviewContent)
+
+	huma.Register(group, huma.Operation{
+		OperationID:   "get-email-template",
+		Method:        http.MethodGet,
+		Summary:       "Get Email Template",
+		Description:   "Returns an email template with its current version.",
+		Tags:          []string{"Email Templates"},
+		Path:          "/templates/{templateId}",
+		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma, mw.Auth.RequireAdminHuma},
+		Errors:        []int{http.StatusUnauthorized, http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError},
+		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
+		DefaultStatus: http.StatusOK,
+	}, emailTemplateHandler.handleGetTemplate)
+
+	huma.Register(group, huma.Operation{
+		OperationID:   "delete-email-template",
+		Method:        http.MethodDelete,
+		Summary:       "Delete Email Template",
+		Description:   "Deletes a hackathon's template with all its versions, so the built-in email is sent again.",
+		Tags:          []string{"Email Templates"},
+		Path:          "/templates/{templateId}",
+		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma, mw.Auth.RequireAdminHuma},
+		Errors:        []int{http.StatusUnauthorized, http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError},
+		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
+		DefaultStatus: http.StatusNoContent,
+	}, emailTemplateHandler.handleDeleteTemplate)
+
+	huma.Register(group, huma.Operation{
+		OperationID:   "list-email-template-versions",
+		Method:        http.MethodGet,
+		Summary:       "List Email Template Versions",
+		Description:   "Returns every version of an email template, newest first.",
+		Tags:          []string{"Email Templates"},
+		Path:          "/templates/{templateId}/versions",
+		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma, mw.Auth.RequireAdminHuma},
+		Errors:        []int{http.StatusUnauthorized, http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError},
+		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
+		DefaultStatus: http.StatusOK,
+	}, emailTemplateHandler.handleListVersions)
+
+	huma.Register(group, huma.Operation{
+		OperationID:   "create-email-template-version",
+		Method:        http.MethodPost,
+		Summary:       "Create Email Template Version",
+		Description:   "Saves new content for an email template. It is sent from now on.",
+		Tags:          []string{"Email Templates"},
+		Path:          "/templates/{templateId}/versions",
+		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma, mw.Auth.RequireAdminHuma},
+		Errors:        []int{http.StatusUnauthorized, http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError},
+		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
+		DefaultStatus: http.StatusCreated,
+	}, emailTemplateHandler.handleCreateVersion)
+
+	huma.Register(group, huma.Operation{
+		OperationID:   "restore-email-template-version",
+		Method:        http.MethodPost,
+		Summary:       "Restore Email Template Version",
+		Description:   "Saves the content of an earlier version as a new version.",
+		Tags:          []string{"Email Templates"},
+		Path:          "/templates/{templateId}/versions/{version}/restore",
+		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma, mw.Auth.RequireAdminHuma},
+		Errors:        []int{http.StatusUnauthorized, http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError},
+		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
+		DefaultStatus: http.StatusCreated,
+	}, emailTemplateHandler.handleRestoreVersion)
+
+	huma.Register(group, huma.Operation{
+		OperationID:   "preview-email-template",
+		Method:        http.MethodPost,
+		Summary:       "Preview Email Template",
+		Description:   "Renders a version of an email template, the current one by default, for a user or for a sample user.",
+		Tags:          []string{"Email Templates"},
+		Path:          "/templates/{templateId}/preview",
+		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma, mw.Auth.RequireAdminHuma},
+		Errors:        []int{http.StatusUnauthorized, http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError},
+		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
+		DefaultStatus: http.StatusOK,
+	}, emailTemplateHandler.handlePreviewTemplate)
+}
+
+type emailTemplateHandler struct {
+	emailTemplateService *EmailTemplateService
+	logger               zerolog.Logger
+}
+
+func NewTemplateHandler(emailTemplateService *EmailTemplateService, logger zerolog.Logger) *emailTemplateHandler {
+	return &emailTemplateHandler{
+		emailTemplateService: emailTemplateService,
+		logger:               logger.With().Str("handler", "EmailTemplateHandler").Str("domain", "email").Logger(),
+	}
+}
+
+type CreateEmailTemplateRequest struct {
+	HackathonID string      `json:"hackathonId" required:"true"`
+	Key         TemplateKey `json:"key" required:"true" enum:"application_confirmation,waitlist_acceptance,welcome,application_accepted,application_waitlisted,application_rejected,team_join_request,team_invitation"`
+	Description *string     `json:"description,omitempty"`
+	EmailTemplateContent
+}
+
+type PreviewEmailTemplateContentRequest struct {
+	Key    TemplateKey `json:"key" required:"true" enum:"application_confirmation,waitlist_acceptance,welcome,application_accepted,application_waitlisted,application_rejected,team_join_request,team_invitation"`
+	UserID *uuid.UUID  `json:"userId,omitempty"`
+	EmailTemplateContent
+}
+
+type PreviewEmailTemplateRequest struct {
+	Version *int32     `json:"version,omitempty"`
+	UserID  *uuid.UUID `json:"userId,omitempty"`
+}
+
+type ListBuiltinEmailTemplatesOutput struct {
+	Body []BuiltinEmailTemplate
+}
+
+type ListEmailTemplatesOutput struct {
+	Body []sqlc.EmailTemplate
+}
+
+type EmailTemplateOutput struct {
+	Body *EmailTemplateDetails
+}
+
+type ListEmailTemplateVersionsOutput struct {
+	Body []sqlc.EmailTemplateVersion
+}
+
+type RenderedEmailOutput struct {
+	Body *RenderedEmail
+}
+
+func (h *emailTemplateHandler) handleListBuiltinTemplates(ctx context.Context, input *struct{}) (*ListBuiltinEmailTemplatesOutput, error) {
+	return &ListBuiltinEmailTemplatesOutput{Body: h.emailTemplateService.ListBuiltinTemplates()}, nil
+}
+
+func (h *emailTemplateHandler) handleListTemplates(ctx context.Context, input *struct {
+	HackathonID string `query:"hackathonId" required:"true"`
+}) (*ListEmailTemplatesOutput, error) {
+	templates, err := h.emailTemplateService.ListTemplates(ctx, input.HackathonID)
+	if err != nil {
+		h.logger.Err(err).Msg("Failed to list email templates")
+		return nil, templateHTTPError(err, "Failed to list email templates")
+	}
+
+	return &ListEmailTemplatesOutput{Body: templates}, nil
+}
+
+func (h *emailTemplateHandler) handleCreateTemplate(ctx context.Context, input *struct {
+	Body CreateEmailTemplateRequest
+}) (*EmailTemplateOutput, error) {
+	userCtx := ctxutils.GetUserFromCtx(ctx)
+	if userCtx == nil {
+		return nil, huma.Error400BadRequest("Failed to get current user info")
+	}
+
+	template, err := h.emailTemplateService.CreateTemplate(
+		ctx,
+		input.Body.HackathonID,
+		input.Body.Key,
+		input.Body.Description,
+		input.Body.EmailTemplateContent,
+		&userCtx.UserID,
+	)
+	if err != nil {
+		return nil, templateHTTPError(err, "Failed to create email template")
+	}
+
+	return &EmailTemplateOutput{Body: template}, nil
+}
+
+func (h *emailTemplateHandler) handlePreviewContent(ctx context.Context, input *struct {
+	Body PreviewEmailTemplateContentRequest
+}) (*RenderedEmailOutput, error) {
+	rendered, err := h.emailTemplateService.PreviewContent(ctx, input.Body.Key, input.Body.EmailTemplateContent, input.Body.UserID)
+	if err != nil {
+		return nil, templateHTTPError(err, "Failed to preview email template")
+	}
+
+	return &RenderedEmailOutput{Body: rendered}, nil
+}
+
+func (h *emailTemplateHandler) handleGetTemplate(ctx context.Context, input *struct {
+	TemplateID  string `path:"templateId"`
+	HackathonID string `query:"hackathonId" required:"true"`
+}) (*EmailTemplateOutput, error) {
+	templateID, err := uuid.Parse(input.TemplateID)
+	if err != nil {
+		return nil, huma.Error400BadRequest("Invalid template id")
+	}
+
+	template, err := h.emailTemplateService.GetTemplate(ctx, templateID, input.HackathonID)
+	if err != nil {
+		return nil, templateHTTPError(err, "Failed to get email template")
+	}
+
+	return &EmailTemplateOutput{Body: template}, nil
+}
+
+func (h *emailTemplateHandler) handleDeleteTemplate(ctx context.Context, input *struct {
+	TemplateID  string `path:"templateId"`
+	HackathonID string `query:"hackathonId" required:"true"`
+}) (*struct{}, error) {
+	templateID, err := uuid.Parse(input.TemplateID)
+	if err != nil {
+		return nil, huma.Error400BadRequest("Invalid template id")
+	}
+
+	if err := h.emailTemplateService.DeleteTemplate(ctx, templateID, input.HackathonID); err != nil {
+		return nil, templateHTTPError(err, "Failed to delete email template")
+	}
+
+	return nil, nil
+}
+
+func (h *emailTemplateHandler) handleListVersions(ctx context.Context, input *struct {
+	TemplateID  string `path:"templateId"`
+	HackathonID string `query:"hackathonId" required:"true"`
+}) (*ListEmailTemplateVersionsOutput, error) {
+	templateID, err := uuid.Parse(input.TemplateID)
+	if err != nil {
+		return nil, huma.Error400BadRequest("Invalid template id")
+	}
+
+	versions, err := h.emailTemplateService.ListVersions(ctx, templateID, input.HackathonID)
+	if err != nil {
+		return nil, templateHTTPError(err, "Failed to list email template versions")
+	}
+
+	return &ListEmailTemplateVersionsOutput{Body: versions}, nil
+}
+
+func (h *emailTemplateHandler) handleCreateVersion(ctx context.Context, input *struct {
+	TemplateID  string `path:"templateId"`
+	HackathonID string `query:"hackathonId" required:"true"`
+	Body        EmailTemplateContent
+}) (*EmailTemplateOutput, error) {
+	userCtx := ctxutils.GetUserFromCtx(ctx)
+	if userCtx == nil {
+		return nil, huma.Error400BadRequest("Failed to get current user info")
+	}
+
+	templateID, err := uuid.Parse(input.TemplateID)
+	if err != nil {
+		return nil, huma.Error400BadRequest("Invalid template id")
+	}
+
+	template, err := h.emailTemplateService.CreateVersion(ctx, templateID, input.HackathonID, input.Body, &userCtx.UserID)
+	if err != nil {
+		return nil, templateHTTPError(err, "Failed to save email template")
+	}
+
+	return &EmailTemplateOutput{Body: template}, nil
+}
+
+func (h *emailTemplateHandler) handleRestoreVersion(ctx context.Context, input *struct {
+	TemplateID  string `path:"templateId"`
+	Version     int32  `path:"version" minimum:"1"`
+	HackathonID string `query:"hackathonId" required:"true"`
+}) (*EmailTemplateOutput, error) {
+	userCtx := ctxutils.GetUserFromCtx(ctx)
+	if userCtx == nil {
+		return nil, huma.Error400BadRequest("Failed to get current user info")
+	}
+
+	templateID, err := uuid.Parse(input.TemplateID)
+	if err != nil {
+		return nil, huma.Error400BadRequest("Invalid template id")
+	}
+
+	template, err := h.emailTemplateService.RestoreVersion(ctx, templateID, input.HackathonID, input.Version, &userCtx.UserID)
+	if err != nil {
+		return nil, templateHTTPError(err, "Failed to restore email template version")
+	}
+
+	return &EmailTemplateOutput{Body: template}, nil
+}
+
+func (h *emailTemplateHandler) handlePreviewTemplate(ctx context.Context, input *struct {
+	TemplateID  string `path:"templateId"`
+	HackathonID string `query:"hackathonId" required:"true"`
+	Body        PreviewEmailTemplateRequest
+}) (*RenderedEmailOutput, error) {
+	templateID, err := uuid.Parse(input.TemplateID)
+	if err != nil {
+		return nil, huma.Error400BadRequest("Invalid template id")
+	}
+
+	rendered, err := h.emailTemplateService.PreviewTemplate(ctx, templateID, input.HackathonID, input.Body.Version, input.Body.UserID)
+	if err != nil {
+		return nil, templateHTTPError(err, "Failed to preview email template")
+	}
+
+	return &RenderedEmailOutput{Body: rendered}, nil
+}
+
+func templateHTTPError(err error, fallback string) error {
+	if errors.Is(err, ErrEmailTemplateNotFound) {
+		return huma.Error404NotFound("Email template not found")
+	}
+
+	if errors.Is(err, ErrPreviewUserNotFound) {
+		return huma.Error404NotFound(err.Error())
+	}
+
+	if errors.Is(err, ErrUnknownEmailTemplate) ||
+		errors.Is(err, ErrEmailTemplateSubjectRequired) ||
+		errors.Is(err, ErrEmailTemplateHtmlRequired) ||
+		errors.Is(err, ErrEmailTemplateUnknownVariable) ||
+		errors.Is(err, ErrEmailTemplateInvalidReplyTo) ||
+		errors.Is(err, ErrEmailTemplateRender) {
+		return huma.Error400BadRequest(err.Error())
+	}
+
+	if errors.Is(err, ErrEmailTemplateExists) {
+		return huma.Error409Conflict(err.Error())
+	}
+
+	return huma.Error500InternalServerError(fallback)
+}
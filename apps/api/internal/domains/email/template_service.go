@@ -0,0 +1,391 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/rs/zerolog"
+	"github.com/swamphacks/core/apps/api/internal/config"
+	"github.com/swamphacks/core/apps/api/internal/database"
+	"github.com/swamphacks/core/apps/api/internal/database/repository"
+	"github.com/swamphacks/core/apps/api/internal/database/sqlc"
+	"github.com/swamphacks/core/apps/api/internal/emailutils"
+)
+
+var (
+	ErrEmailTemplateNotFound = repository.ErrEmailTemplateNotFound
+	ErrEmailTemplateExists   = repository.ErrEmailTemplateExists
+	ErrPreviewUserNotFound   = errors.New("preview user not found")
+	ErrSaveEmailTemplate     = errors.New("failed to save email template")
+)
+
+// sampleName is used in previews that aren't rendered for a real user.
+const sampleName = "Albert Gator"
+
+// sampleValues fill in the variables that don't come from the previewed user, like the
+// team of a team email. Variables without one are previewed as "sample".
+var sampleValues = map[string]string{
+	"RequesterName": "Alberta Gator",
+	"TeamName":      "Swamp Squad",
+	"Message":       "Hi! I'd love to build something with your team.",
+	"InviterName":   "Alberta Gator",
+}
+
+// sampleData returns preview values for every variable of a template.
+func sampleData(variables []string) map[string]any {
+	data := make(map[string]any, len(variables))
+	for _, variable := range variables {
+		value, ok := sampleValues[variable]
+		if !ok {
+			value = "sample"
+		}
+		data[variable] = value
+	}
+	return data
+}
+
+// EmailTemplateService manages the email templates hackathons override the built-in
+// emails with. Every edit is kept as a version.
+type EmailTemplateService struct {
+	emailTemplateRepo *repository.EmailTemplateRepository
+	userRepo          *repository.UserRepository
+	txm               *database.TransactionManager
+	config            *config.Config
+	logger            zerolog.Logger
+}
+
+func NewEmailTemplateService(
+	emailTemplateRepo *repository.EmailTemplateRepository,
+	userRepo *repository.UserRepository,
+	txm *database.TransactionManager,
+	config *config.Config,
+	logger zerolog.Logger,
+) *EmailTemplateService {
+	return &EmailTemplateService{
+		emailTemplateRepo: emailTemplateRepo,
+		userRepo:          userRepo,
+		txm:               txm,
+		config:            config,
+		logger:            logger.With().Str("service", "EmailTemplateService").Str("domain", "email").Logger(),
+	}
+}
+
+// EmailTemplateDetails is a template with the version used to send it.
+type EmailTemplateDetails struct {
+	sqlc.EmailTemplate
+	Current *sqlc.EmailTemplateVersion `json:"current"`
+}
+
+func (s *EmailTemplateService) ListBuiltinTemplates() []BuiltinEmailTemplate {
+	return builtinTemplates
+}
+
+func (s *EmailTemplateService) ListTemplates(ctx context.Context, hackathonID string) ([]sqlc.EmailTemplate, error) {
+	return s.emailTemplateRepo.ListEmailTemplates(ctx, hackathonID)
+}
+
+func (s *EmailTemplateService) GetTemplate(ctx context.Context, id uuid.UUID, hackathonID string) (*EmailTemplateDetails, error) {
+	template, err := s.emailTemplateRepo.GetEmailTemplateByID(ctx, sqlc.GetEmailTemplateByIDParams{
+		ID:          id,
+		HackathonID: hackathonID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := s.emailTemplateRepo.GetEmailTemplateVersion(ctx, sqlc.GetEmailTemplateVersionParams{
+		TemplateID: template.ID,
+		Version:    template.CurrentVersion,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &EmailTemplateDetails{EmailTemplate: *template, Current: current}, nil
+}
+
+// CreateTemplate overrides a built-in email for a hackathon, starting at version 1.
+func (s *EmailTemplateService) CreateTemplate(
+	ctx context.Context,
+	hackathonID string,
+	key TemplateKey,
+	description *string,
+	content EmailTemplateContent,
+	userID *uuid.UUID,
+) (*EmailTemplateDetails, error) {
+	builtin, ok := getBuiltinTemplate(key)
+	if !ok {
+		return nil, ErrUnknownEmailTemplate
+	}
+
+	content = normalizeContent(content)
+	if err := validateTemplateContent(builtin, content); err != nil {
+		return nil, err
+	}
+
+	var details *EmailTemplateDetails
+	err := s.txm.WithTx(ctx, func(tx pgx.Tx) error {
+		txRepo := s.emailTemplateRepo.NewTx(tx)
+
+		template, err := txRepo.CreateEmailTemplate(ctx, sqlc.CreateEmailTemplateParams{
+			HackathonID:     hackathonID,
+			Key:             string(key),
+			Name:            builtin.Name,
+			Description:     description,
+			CreatedByUserID: userID,
+		})
+		if err != nil {
+			return err
+		}
+
+		version, err := txRepo.CreateEmailTemplateVersion(ctx, versionParams(template, content, userID))
+		if err != nil {
+			return err
+		}
+
+		details = &EmailTemplateDetails{EmailTemplate: *template, Current: version}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, ErrEmailTemplateExists) {
+			return nil, ErrEmailTemplateExists
+		}
+		s.logger.Err(err).Str("Key", string(key)).Msg("Failed to create email template")
+		return nil, ErrSaveEmailTemplate
+	}
+
+	return details, nil
+}
+
+// CreateVersion saves new content for a template. The new version is used from now on.
+func (s *EmailTemplateService) CreateVersion(
+	ctx context.Context,
+	id uuid.UUID,
+	hackathonID string,
+	content EmailTemplateContent,
+	userID *uuid.UUID,
+) (*EmailTemplateDetails, error) {
+	template, err := s.emailTemplateRepo.GetEmailTemplateByID(ctx, sqlc.GetEmailTemplateByIDParams{
+		ID:          id,
+		HackathonID: hackathonID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	builtin, ok := getBuiltinTemplate(TemplateKey(template.Key))
+	if !ok {
+		return nil, ErrUnknownEmailTemplate
+	}
+
+	content = normalizeContent(content)
+	if err := validateTemplateContent(builtin, content); err != nil {
+		return nil, err
+	}
+
+	var details *EmailTemplateDetails
+	err = s.txm.WithTx(ctx, func(tx pgx.Tx) error {
+		txRepo := s.emailTemplateRepo.NewTx(tx)
+
+		template, err := txRepo.IncrementEmailTemplateVersion(ctx, sqlc.IncrementEmailTemplateVersionParams{
+			UpdatedByUserID: userID,
+			ID:              id,
+			HackathonID:     hackathonID,
+		})
+		if err != nil {
+			return err
+		}
+
+		version, err := txRepo.CreateEmailTemplateVersion(ctx, versionParams(template, content, userID))
+		if err != nil {
+			return err
+		}
+
+		details = &EmailTemplateDetails{EmailTemplate: *template, Current: version}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, ErrEmailTemplateNotFound) {
+			return nil, ErrEmailTemplateNotFound
+		}
+		s.logger.Err(err).Str("TemplateID", id.String()).Msg("Failed to create email template version")
+		return nil, ErrSaveEmailTemplate
+	}
+
+	return details, nil
+}
+
+// RestoreVersion saves the content of an earlier version as a new version.
+func (s *EmailTemplateService) RestoreVersion(
+	ctx context.Context,
+	id uuid.UUID,
+	hackathonID string,
+	version int32,
+	userID *uuid.UUID,
+) (*EmailTemplateDetails, error) {
+	_, previous, err := s.getVersion(ctx, id, hackathonID, &version)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.CreateVersion(ctx, id, hackathonID, versionContent(previous), userID)
+}
+
+func (s *EmailTemplateService) ListVersions(ctx context.Context, id uuid.UUID, hackathonID string) ([]sqlc.EmailTemplateVersion, error) {
+	template, err := s.emailTemplateRepo.GetEmailTemplateByID(ctx, sqlc.GetEmailTemplateByIDParams{
+		ID:          id,
+		HackathonID: hackathonID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.emailTemplateRepo.ListEmailTemplateVersions(ctx, template.ID)
+}
+
+// DeleteTemplate removes a hackathon's override with all its versions, so the built-in
+// email is sent again.
+func (s *EmailTemplateService) DeleteTemplate(ctx context.Context, id uuid.UUID, hackathonID string) error {
+	return s.emailTemplateRepo.DeleteEmailTemplate(ctx, sqlc.DeleteEmailTemplateParams{
+		ID:          id,
+		HackathonID: hackathonID,
+	})
+}
+
+// PreviewTemplate renders a version of a template, the current one if version is nil,
+// for a user or for a sample user if userID is nil.
+func (s *EmailTemplateService) PreviewTemplate(
+	ctx context.Context,
+	id uuid.UUID,
+	hackathonID string,
+	version *int32,
+	userID *uuid.UUID,
+) (*RenderedEmail, error) {
+	template, templateVersion, err := s.getVersion(ctx, id, hackathonID, version)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.PreviewContent(ctx, TemplateKey(template.Key), versionContent(templateVersion), userID)
+}
+
+// PreviewContent renders unsaved content for a template, so it can be checked before
+// it becomes a version.
+func (s *EmailTemplateService) PreviewContent(
+	ctx context.Context,
+	key TemplateKey,
+	content EmailTemplateContent,
+	userID *uuid.UUID,
+) (*RenderedEmail, error) {
+	builtin, ok := getBuiltinTemplate(key)
+	if !ok {
+		return nil, ErrUnknownEmailTemplate
+	}
+
+	content = normalizeContent(content)
+	if err := validateTemplateContent(builtin, content); err != nil {
+		return nil, err
+	}
+
+	data, err := s.previewData(ctx, builtin, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	rendered, err := renderTemplateContent(content, data)
+	if err != nil {
+		return nil, err
+	}
+
+	// Show the text alternative recipients get when there's no text body.
+	if rendered.Text == "" {
+		rendered.Text = emailutils.HTMLToText(rendered.Html)
+	}
+
+	return rendered, nil
+}
+
+func (s *EmailTemplateService) getVersion(
+	ctx context.Context,
+	id uuid.UUID,
+	hackathonID string,
+	version *int32,
+) (*sqlc.EmailTemplate, *sqlc.EmailTemplateVersion, error) {
+	template, err := s.emailTemplateRepo.GetEmailTemplateByID(ctx, sqlc.GetEmailTemplateByIDParams{
+		ID:          id,
+		HackathonID: hackathonID,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	number := template.CurrentVersion
+	if version != nil {
+		number = *version
+	}
+
+	templateVersion, err := s.emailTemplateRepo.GetEmailTemplateVersion(ctx, sqlc.GetEmailTemplateVersionParams{
+		TemplateID: template.ID,
+		Version:    number,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return template, templateVersion, nil
+}
+
+// previewData is what the API would pass to a template for the user. Variables that
+// don't come from the user get sample values.
+func (s *EmailTemplateService) previewData(ctx context.Context, builtin BuiltinEmailTemplate, userID *uuid.UUID) (map[string]any, error) {
+	data := sampleData(builtin.Variables)
+	data["InviteLink"] = strings.TrimRight(s.config.ClientUrl, "/") + "/team/join/" + uuid.Nil.String()
+
+	if userID == nil {
+		data["Name"] = sampleName
+		data["QRPngLink"] = fmt.Sprintf("%s/%s", s.config.CoreBuckets.QRCodesBaseUrl, uuid.Nil)
+		return data, nil
+	}
+
+	user, err := s.userRepo.GetUserByID(ctx, *userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil, ErrPreviewUserNotFound
+		}
+		return nil, err
+	}
+
+	data["Name"] = user.Name
+	data["QRPngLink"] = fmt.Sprintf("%s/%s", s.config.CoreBuckets.QRCodesBaseUrl, user.ID)
+	return data, nil
+}
+
+func normalizeContent(content EmailTemplateContent) EmailTemplateContent {
+	if content.Variables == nil {
+		content.Variables = []string{}
+	}
+	if content.TextBody != nil && *content.TextBody == "" {
+		content.TextBody = nil
+	}
+	if content.ReplyTo != nil && *content.ReplyTo == "" {
+		content.ReplyTo = nil
+	}
+	return content
+}
+
+func versionParams(template *sqlc.EmailTemplate, content EmailTemplateContent, userID *uuid.UUID) sqlc.CreateEmailTemplateVersionParams {
+	return sqlc.CreateEmailTemplateVersionParams{
+		TemplateID:      template.ID,
+		Version:         template.CurrentVersion,
+		Subject:         content.Subject,
+		HtmlBody:        content.HtmlBody,
+		TextBody:        content.TextBody,
+		Variables:       content.Variables,
+		ReplyTo:         content.ReplyTo,
+		CreatedByUserID: userID,
+	}
+}
@@ -0,0 +1,220 @@
+package email
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"slices"
+	"strings"
+	texttemplate "text/template"
+
+	"github.com/swamphacks/core/apps/api/internal/database/sqlc"
+	"github.com/swamphacks/core/apps/api/internal/emailutils"
+)
+
+var (
+	ErrUnknownEmailTemplate         = errors.New("unknown email template")
+	ErrEmailTemplateSubjectRequired = errors.New("email template subject is required")
+	ErrEmailTemplateHtmlRequired    = errors.New("email template html body is required")
+	ErrEmailTemplateUnknownVariable = errors.New("email template declares a variable that isn't available")
+	ErrEmailTemplateInvalidReplyTo  = errors.New("email template reply-to is not a valid email address")
+	ErrEmailTemplateRender          = errors.New("failed to render email template")
+)
+
+// TemplateKey names an email the API sends. Every hackathon can override each of them.
+type TemplateKey string
+
+const (
+	TemplateApplicationConfirmation TemplateKey = "application_confirmation"
+	TemplateWaitlistAcceptance      TemplateKey = "waitlist_acceptance"
+	TemplateWelcome                 TemplateKey = "welcome"
+	TemplateApplicationAccepted     TemplateKey = "application_accepted"
+	TemplateApplicationWaitlisted   TemplateKey = "application_waitlisted"
+	TemplateApplicationRejected     TemplateKey = "application_rejected"
+	TemplateTeamJoinRequest         TemplateKey = "team_join_request"
+	TemplateTeamInvitation          TemplateKey = "team_invitation"
+)
+
+// BuiltinEmailTemplate is an email the API sends and what it looks like when a
+// hackathon doesn't override it. Variables are what the API passes to the template.
+// Category is the preference category recipients can unsubscribe from, empty for emails
+// about their own application.
+type BuiltinEmailTemplate struct {
+	Key       TemplateKey        `json:"key"`
+	Name      string             `json:"name"`
+	Subject   string             `json:"subject"`
+	Variables []string           `json:"variables"`
+	Category  sqlc.EmailCategory `json:"category,omitempty"`
+	file      string
+}
+
+var builtinTemplates = []BuiltinEmailTemplate{
+	{
+		Key:       TemplateApplicationConfirmation,
+		Name:      "Application confirmation",
+		Subject:   "SwampHacks XII: We received your application!",
+		Variables: []string{"Name"},
+		file:      "ConfirmationEmail.html",
+	},
+	{
+		Key:       TemplateWaitlistAcceptance,
+		Name:      "Accepted off the waitlist",
+		Subject:   "Congratulations! You're in – confirm in 72 hours to keep your spot in SwampHacks XII",
+		Variables: []string{"Name"},
+		file:      "WaitlistAcceptanceEmail.html",
+	},
+	{
+		Key:       TemplateWelcome,
+		Name:      "Welcome",
+		Subject:   "SwampHacks XII – A welcome from our Organizers!",
+		Variables: []string{"Name", "QRPngLink"},
+		Category:  sqlc.EmailCategoryEventUpdates,
+		file:      "WelcomeEmail.html",
+	},
+	{
+		Key:       TemplateApplicationAccepted,
+		Name:      "Application accepted",
+		Subject:   "Congratulations on being accepted to hack in SwampHacks XII!",
+		Variables: []string{"Name"},
+		file:      "ApplicationAcceptedEmail.html",
+	},
+	{
+		Key:       TemplateApplicationWaitlisted,
+		Name:      "Application waitlisted",
+		Subject:   "You're on the SwampHacks XII waitlist",
+		Variables: []string{"Name"},
+		file:      "ApplicationWaitlistedEmail.html",
+	},
+	{
+		Key:       TemplateApplicationRejected,
+		Name:      "Application rejected",
+		Subject:   "Update on Your SwampHacks XII Application",
+		Variables: []string{"Name"},
+		file:      "ApplicationRejectedEmail.html",
+	},
+	{
+		Key:       TemplateTeamJoinRequest,
+		Name:      "Team join request",
+		Subject:   "Someone wants to join your SwampHacks team",
+		Variables: []string{"Name", "RequesterName", "TeamName", "Message"},
+		Category:  sqlc.EmailCategoryTeamNotifications,
+		file:      "TeamJoinRequestEmail.html",
+	},
+	{
+		Key:       TemplateTeamInvitation,
+		Name:      "Team invitation",
+		Subject:   "You're invited to join a SwampHacks team",
+		Variables: []string{"Name", "InviterName", "TeamName", "InviteLink"},
+		Category:  sqlc.EmailCategoryTeamNotifications,
+		file:      "TeamInvitationEmail.html",
+	},
+}
+
+func getBuiltinTemplate(key TemplateKey) (BuiltinEmailTemplate, bool) {
+	for _, template := range builtinTemplates {
+		if template.Key == key {
+			return template, true
+		}
+	}
+	return BuiltinEmailTemplate{}, false
+}
+
+// EmailTemplateContent is the editable part of a template version. Without a text body,
+// the plain text alternative is generated from the HTML when the email is sent.
+type EmailTemplateContent struct {
+	Subject   string   `json:"subject" minLength:"1"`
+	HtmlBody  string   `json:"htmlBody" minLength:"1"`
+	TextBody  *string  `json:"textBody,omitempty"`
+	Variables []string `json:"variables,omitempty"`
+	ReplyTo   *string  `json:"replyTo,omitempty" doc:"Where replies go instead of the sender"`
+}
+
+func versionContent(version *sqlc.EmailTemplateVersion) EmailTemplateContent {
+	return EmailTemplateContent{
+		Subject:   version.Subject,
+		HtmlBody:  version.HtmlBody,
+		TextBody:  version.TextBody,
+		Variables: version.Variables,
+		ReplyTo:   version.ReplyTo,
+	}
+}
+
+// RenderedEmail is a template rendered for one recipient.
+type RenderedEmail struct {
+	Subject string `json:"subject"`
+	Html    string `json:"html"`
+	Text    string `json:"text,omitempty"`
+}
+
+// renderTemplateContent renders every part of a template. Variables that aren't in
+// data fail the render instead of coming out empty.
+func renderTemplateContent(content EmailTemplateContent, data any) (*RenderedEmail, error) {
+	subject, err := renderTextTemplate("subject", content.Subject, data)
+	if err != nil {
+		return nil, err
+	}
+
+	htmlTmpl, err := htmltemplate.New("html").Option("missingkey=error").Parse(content.HtmlBody)
+	if err != nil {
+		return nil, fmt.Errorf("%w: html: %v", ErrEmailTemplateRender, err)
+	}
+
+	var html bytes.Buffer
+	if err := htmlTmpl.Execute(&html, data); err != nil {
+		return nil, fmt.Errorf("%w: html: %v", ErrEmailTemplateRender, err)
+	}
+
+	rendered := &RenderedEmail{
+		Subject: strings.TrimSpace(subject),
+		Html:    html.String(),
+	}
+
+	if content.TextBody != nil && *content.TextBody != "" {
+		rendered.Text, err = renderTextTemplate("text", *content.TextBody, data)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return rendered, nil
+}
+
+func renderTextTemplate(name string, text string, data any) (string, error) {
+	tmpl, err := texttemplate.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s: %v", ErrEmailTemplateRender, name, err)
+	}
+
+	var out bytes.Buffer
+	if err := tmpl.Execute(&out, data); err != nil {
+		return "", fmt.Errorf("%w: %s: %v", ErrEmailTemplateRender, name, err)
+	}
+
+	return out.String(), nil
+}
+
+// validateTemplateContent checks that a template only declares variables the API passes
+// to it and renders with nothing but its declared variables.
+func validateTemplateContent(builtin BuiltinEmailTemplate, content EmailTemplateContent) error {
+	if strings.TrimSpace(content.Subject) == "" {
+		return ErrEmailTemplateSubjectRequired
+	}
+	if strings.TrimSpace(content.HtmlBody) == "" {
+		return ErrEmailTemplateHtmlRequired
+	}
+	if content.ReplyTo != nil && !emailutils.IsValidEmail(*content.ReplyTo) {
+		return ErrEmailTemplateInvalidReplyTo
+	}
+
+	data := make(map[string]any, len(content.Variables))
+	for _, variable := range content.Variables {
+		if !slices.Contains(builtin.Variables, variable) {
+			return fmt.Errorf("%w: %s", ErrEmailTemplateUnknownVariable, variable)
+		}
+		data[variable] = "sample"
+	}
+
+	_, err := renderTemplateContent(content, data)
+	return err
+}
@@ -0,0 +1,192 @@
+package email
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateTemplateContent(t *testing.T) {
+	welcome, _ := getBuiltinTemplate(TemplateWelcome)
+	text := "Hi {{ .Name }}, your QR code: {{ .QRPngLink }}"
+
+	tests := []struct {
+		name          string
+		content       EmailTemplateContent
+		expectedError error
+	}{
+		{
+			name: "declared variables",
+			content: EmailTemplateContent{
+				Subject:   "Welcome, {{ .Name }}!",
+				HtmlBody:  `<p>Hi {{ .Name }}</p><img src="{{ .QRPngLink }}">`,
+				TextBody:  &text,
+				Variables: []string{"Name", "QRPngLink"},
+			},
+		},
+		{
+			name: "no variables",
+			content: EmailTemplateContent{
+				Subject:   "Welcome!",
+				HtmlBody:  "<p>See you soon</p>",
+				Variables: []string{},
+			},
+		},
+		{
+			name: "missing subject",
+			content: EmailTemplateContent{
+				Subject:  " ",
+				HtmlBody: "<p>See you soon</p>",
+			},
+			expectedError: ErrEmailTemplateSubjectRequired,
+		},
+		{
+			name: "missing html",
+			content: EmailTemplateContent{
+				Subject: "Welcome!",
+			},
+			expectedError: ErrEmailTemplateHtmlRequired,
+		},
+		{
+			name: "variable the api doesn't pass",
+			content: EmailTemplateContent{
+				Subject:   "Welcome!",
+				HtmlBody:  "<p>Your team is {{ .TeamName }}</p>",
+				Variables: []string{"TeamName"},
+			},
+			expectedError: ErrEmailTemplateUnknownVariable,
+		},
+		{
+			name: "invalid reply-to",
+			content: EmailTemplateContent{
+				Subject:  "Welcome!",
+				HtmlBody: "<p>See you soon</p>",
+				ReplyTo:  &text,
+			},
+			expectedError: ErrEmailTemplateInvalidReplyTo,
+		},
+		{
+			name: "undeclared variable in text",
+			content: EmailTemplateContent{
+				Subject:   "Welcome!",
+				HtmlBody:  "<p>Hi {{ .Name }}</p>",
+				TextBody:  &text,
+				Variables: []string{"Name"},
+			},
+			expectedError: ErrEmailTemplateRender,
+		},
+		{
+			name: "invalid template",
+			content: EmailTemplateContent{
+				Subject:   "Welcome, {{ .Name }",
+				HtmlBody:  "<p>Hi</p>",
+				Variables: []string{"Name"},
+			},
+			expectedError: ErrEmailTemplateRender,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateTemplateContent(welcome, test.content)
+
+			if !errors.Is(err, test.expectedError) {
+				t.Fatalf("expected error %v, got %v", test.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestRenderTemplateContent(t *testing.T) {
+	text := "Hi {{ .Name }}"
+
+	tests := []struct {
+		name     string
+		content  EmailTemplateContent
+		data     any
+		expected RenderedEmail
+	}{
+		{
+			name: "html is escaped, subject and text are not",
+			content: EmailTemplateContent{
+				Subject:  " Welcome, {{ .Name }}! ",
+				HtmlBody: "<p>Hi {{ .Name }}</p>",
+				TextBody: &text,
+			},
+			data: map[string]any{"Name": "<Albert>"},
+			expected: RenderedEmail{
+				Subject: "Welcome, <Albert>!",
+				Html:    "<p>Hi &lt;Albert&gt;</p>",
+				Text:    "Hi <Albert>",
+			},
+		},
+		{
+			name: "without text",
+			content: EmailTemplateContent{
+				Subject:  "Welcome",
+				HtmlBody: "<p>Hi {{ .Name }}</p>",
+			},
+			data: struct{ Name string }{Name: "Albert"},
+			expected: RenderedEmail{
+				Subject: "Welcome",
+				Html:    "<p>Hi Albert</p>",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := renderTemplateContent(test.content, test.data)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			if *result != test.expected {
+				t.Fatalf("expected %+v, got %+v", test.expected, *result)
+			}
+		})
+	}
+}
+
+func TestSampleData(t *testing.T) {
+	joinRequest, _ := getBuiltinTemplate(TemplateTeamJoinRequest)
+	invitation, _ := getBuiltinTemplate(TemplateTeamInvitation)
+
+	tests := []struct {
+		name     string
+		builtin  BuiltinEmailTemplate
+		content  EmailTemplateContent
+		expected string
+	}{
+		{
+			name:    "team join request",
+			builtin: joinRequest,
+			content: EmailTemplateContent{
+				Subject:  "{{ .RequesterName }} wants to join {{ .TeamName }}",
+				HtmlBody: "<p>{{ .Message }}</p>",
+			},
+			expected: "Alberta Gator wants to join Swamp Squad",
+		},
+		{
+			name:    "team invitation",
+			builtin: invitation,
+			content: EmailTemplateContent{
+				Subject:  "{{ .InviterName }} invited you to {{ .TeamName }}",
+				HtmlBody: `<a href="{{ .InviteLink }}">Join</a>`,
+			},
+			expected: "Alberta Gator invited you to Swamp Squad",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := renderTemplateContent(test.content, sampleData(test.builtin.Variables))
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			if result.Subject != test.expected {
+				t.Fatalf("expected %q, got %q", test.expected, result.Subject)
+			}
+		})
+	}
+}
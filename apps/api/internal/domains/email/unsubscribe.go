@@ -0,0 +1,121 @@
+package email
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/url"
+	"strings"
+
+	"github.com/swamphacks/core/apps/api/internal/config"
+	"github.com/swamphacks/core/apps/api/internal/database/sqlc"
+	"github.com/swamphacks/core/apps/api/internal/tasks"
+)
+
+var (
+	ErrUnsubscribeDisabled      = errors.New("unsubscribe links are not configured")
+	ErrInvalidUnsubscribeToken  = errors.New("invalid unsubscribe token")
+	ErrUnknownEmailCategory     = errors.New("unknown email category")
+	ErrRecipientUnsubscribed    = errors.New("recipient unsubscribed from this category of email")
+	ErrEmailPreferencesNotFound = errors.New("no email address to manage preferences for")
+)
+
+// signUnsubscribeToken signs an address and category into the token of an unsubscribe
+// link. An empty category unsubscribes from every category. Tokens don't expire, so old
+// emails can still be unsubscribed from.
+func signUnsubscribeToken(secret string, email string, category sqlc.EmailCategory) (string, error) {
+	if secret == "" {
+		return "", ErrUnsubscribeDisabled
+	}
+
+	payload := []byte(strings.ToLower(strings.TrimSpace(email)) + "\n" + string(category))
+
+	return base64.RawURLEncoding.EncodeToString(payload) + "." +
+		base64.RawURLEncoding.EncodeToString(unsubscribeMAC(secret, payload)), nil
+}
+
+// parseUnsubscribeToken checks the signature of a token and returns the address and
+// category it was signed for.
+func parseUnsubscribeToken(secret string, token string) (string, sqlc.EmailCategory, error) {
+	if secret == "" {
+		return "", "", ErrUnsubscribeDisabled
+	}
+
+	encodedPayload, encodedMAC, ok := strings.Cut(token, ".")
+	if !ok {
+		return "", "", ErrInvalidUnsubscribeToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return "", "", ErrInvalidUnsubscribeToken
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(encodedMAC)
+	if err != nil {
+		return "", "", ErrInvalidUnsubscribeToken
+	}
+
+	if !hmac.Equal(mac, unsubscribeMAC(secret, payload)) {
+		return "", "", ErrInvalidUnsubscribeToken
+	}
+
+	email, category, ok := strings.Cut(string(payload), "\n")
+	if !ok || email == "" || (category != "" && !isEmailCategory(sqlc.EmailCategory(category))) {
+		return "", "", ErrInvalidUnsubscribeToken
+	}
+
+	return email, sqlc.EmailCategory(category), nil
+}
+
+func unsubscribeMAC(secret string, payload []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+// unsubscribeURL is the one-click unsubscribe link for an address and category, or empty
+// when the API URL or the signing secret isn't configured. Mail clients post to it, so
+// it only belongs in the List-Unsubscribe header.
+func unsubscribeURL(cfg *config.Config, email string, category sqlc.EmailCategory) string {
+	return signedUnsubscribeURL(cfg, cfg.ApiUrl, "/email/unsubscribe", email, category)
+}
+
+// unsubscribePageURL links an address to the web page where it can unsubscribe from a
+// category or change its other preferences, or is empty when the client URL or the
+// signing secret isn't configured. Links in email bodies go here, since opening the API
+// link doesn't unsubscribe anyone.
+func unsubscribePageURL(cfg *config.Config, email string, category sqlc.EmailCategory) string {
+	return signedUnsubscribeURL(cfg, cfg.ClientUrl, "/unsubscribe", email, category)
+}
+
+func signedUnsubscribeURL(cfg *config.Config, baseURL, path, email string, category sqlc.EmailCategory) string {
+	if baseURL == "" {
+		return ""
+	}
+
+	token, err := signUnsubscribeToken(cfg.EmailUnsubscribeSecret, email, category)
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimSuffix(baseURL, "/") + path + "?token=" + url.QueryEscape(token)
+}
+
+// withUnsubscribe marks an email to an address as part of a category, so the worker
+// checks the recipient's preferences, and adds its List-Unsubscribe links.
+func withUnsubscribe(cfg *config.Config, options tasks.EmailOptions, email string, category sqlc.EmailCategory) tasks.EmailOptions {
+	options.Category = string(category)
+	options.ListUnsubscribe = nil
+	options.ListUnsubscribePost = false
+
+	if link := unsubscribeURL(cfg, email, category); link != "" {
+		options.ListUnsubscribe = append(options.ListUnsubscribe, link)
+		options.ListUnsubscribePost = true
+	}
+	if address := cfg.EmailUnsubscribeAddress; address != "" {
+		options.ListUnsubscribe = append(options.ListUnsubscribe, "mailto:"+address+"?subject=unsubscribe")
+	}
+
+	return options
+}
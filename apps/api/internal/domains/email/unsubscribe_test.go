@@ -0,0 +1,187 @@
+package email
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/swamphacks/core/apps/api/internal/config"
+	"github.com/swamphacks/core/apps/api/internal/database/sqlc"
+	"github.com/swamphacks/core/apps/api/internal/tasks"
+)
+
+func TestParseUnsubscribeToken(t *testing.T) {
+	const secret = "test-secret"
+
+	sign := func(email string, category sqlc.EmailCategory) string {
+		token, err := signUnsubscribeToken(secret, email, category)
+		if err != nil {
+			t.Fatalf("failed to sign token: %v", err)
+		}
+		return token
+	}
+
+	tests := []struct {
+		name             string
+		secret           string
+		token            string
+		expectedEmail    string
+		expectedCategory sqlc.EmailCategory
+		expectedError    error
+	}{
+		{
+			name:             "category",
+			secret:           secret,
+			token:            sign("Albert@UFL.edu ", sqlc.EmailCategoryMarketing),
+			expectedEmail:    "albert@ufl.edu",
+			expectedCategory: sqlc.EmailCategoryMarketing,
+		},
+		{
+			name:          "every category",
+			secret:        secret,
+			token:         sign("albert@ufl.edu", ""),
+			expectedEmail: "albert@ufl.edu",
+		},
+		{
+			name:          "signed with another secret",
+			secret:        "other-secret",
+			token:         sign("albert@ufl.edu", sqlc.EmailCategoryMarketing),
+			expectedError: ErrInvalidUnsubscribeToken,
+		},
+		{
+			name:          "changed address",
+			secret:        secret,
+			token:         "YWxiZXJ0QGdtYWlsLmNvbQptYXJrZXRpbmc." + strings.SplitN(sign("albert@ufl.edu", sqlc.EmailCategoryMarketing), ".", 2)[1],
+			expectedError: ErrInvalidUnsubscribeToken,
+		},
+		{
+			name:          "malformed",
+			secret:        secret,
+			token:         "not-a-token",
+			expectedError: ErrInvalidUnsubscribeToken,
+		},
+		{
+			name:          "no secret",
+			token:         sign("albert@ufl.edu", sqlc.EmailCategoryMarketing),
+			expectedError: ErrUnsubscribeDisabled,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			email, category, err := parseUnsubscribeToken(test.secret, test.token)
+
+			if !errors.Is(err, test.expectedError) {
+				t.Fatalf("expected error %v, got %v", test.expectedError, err)
+			}
+			if email != test.expectedEmail {
+				t.Errorf("expected email %q, got %q", test.expectedEmail, email)
+			}
+			if category != test.expectedCategory {
+				t.Errorf("expected category %q, got %q", test.expectedCategory, category)
+			}
+		})
+	}
+}
+
+func TestWithUnsubscribe(t *testing.T) {
+	tests := []struct {
+		name         string
+		config       config.Config
+		expectedURLs int
+		expectedPost bool
+	}{
+		{
+			name: "not configured",
+		},
+		{
+			name:         "mailto only",
+			config:       config.Config{EmailUnsubscribeAddress: "unsubscribe@swamphacks.com"},
+			expectedURLs: 1,
+		},
+		{
+			name: "one-click and mailto",
+			config: config.Config{
+				ApiUrl:                  "https://api.swamphacks.com/",
+				EmailUnsubscribeSecret:  "test-secret",
+				EmailUnsubscribeAddress: "unsubscribe@swamphacks.com",
+			},
+			expectedURLs: 2,
+			expectedPost: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			options := withUnsubscribe(&test.config, tasks.EmailOptions{}, "albert@ufl.edu", sqlc.EmailCategoryEventUpdates)
+
+			if options.Category != string(sqlc.EmailCategoryEventUpdates) {
+				t.Errorf("expected category %q, got %q", sqlc.EmailCategoryEventUpdates, options.Category)
+			}
+			if len(options.ListUnsubscribe) != test.expectedURLs {
+				t.Fatalf("expected %d List-Unsubscribe URLs, got %v", test.expectedURLs, options.ListUnsubscribe)
+			}
+			if options.ListUnsubscribePost != test.expectedPost {
+				t.Errorf("expected List-Unsubscribe-Post %v, got %v", test.expectedPost, options.ListUnsubscribePost)
+			}
+			if test.expectedPost && !strings.HasPrefix(options.ListUnsubscribe[0], "https://api.swamphacks.com/email/unsubscribe?token=") {
+				t.Errorf("unexpected one-click URL %q", options.ListUnsubscribe[0])
+			}
+		})
+	}
+}
+
+func TestUnsubscribePageURL(t *testing.T) {
+	tests := []struct {
+		name           string
+		config         config.Config
+		expectedPrefix string
+	}{
+		{
+			name:   "not configured",
+			config: config.Config{ApiUrl: "https://api.swamphacks.com", EmailUnsubscribeSecret: "test-secret"},
+		},
+		{
+			name:   "no secret",
+			config: config.Config{ClientUrl: "https://app.swamphacks.com"},
+		},
+		{
+			name: "web page, not the API",
+			config: config.Config{
+				ApiUrl:                 "https://api.swamphacks.com",
+				ClientUrl:              "https://app.swamphacks.com/",
+				EmailUnsubscribeSecret: "test-secret",
+			},
+			expectedPrefix: "https://app.swamphacks.com/unsubscribe?token=",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			link := unsubscribePageURL(&test.config, "albert@ufl.edu", sqlc.EmailCategoryMarketing)
+
+			if test.expectedPrefix == "" {
+				if link != "" {
+					t.Errorf("expected no link, got %q", link)
+				}
+				return
+			}
+			if !strings.HasPrefix(link, test.expectedPrefix) {
+				t.Fatalf("expected link starting with %q, got %q", test.expectedPrefix, link)
+			}
+
+			token, err := url.QueryUnescape(strings.TrimPrefix(link, test.expectedPrefix))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			email, category, err := parseUnsubscribeToken(test.config.EmailUnsubscribeSecret, token)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if email != "albert@ufl.edu" || category != sqlc.EmailCategoryMarketing {
+				t.Errorf("unexpected token for %q and %q", email, category)
+			}
+		})
+	}
+}
@@ -0,0 +1,470 @@
+package teams
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/swamphacks/core/apps/api/internal/database"
+	"github.com/swamphacks/core/apps/api/internal/database/sqlc"
+)
+
+// Staff can see and change every team. Each change is written to the team audit log in
+// the same transaction, so the log never has changes that didn't happen or misses ones
+// that did.
+
+// AdminTeamFilter narrows down the staff team list. Search matches the team name or the
+// name or email of a member.
+type AdminTeamFilter struct {
+	Search string
+	Limit  int32
+	Offset int32
+}
+
+func (f AdminTeamFilter) search() *string {
+	if f.Search == "" {
+		return nil
+	}
+	return &f.Search
+}
+
+// teamLockOrder sorts two team ids so transactions locking both of them always lock
+// them in the same order and can't deadlock each other.
+func teamLockOrder(a, b uuid.UUID) (uuid.UUID, uuid.UUID) {
+	if bytes.Compare(a[:], b[:]) > 0 {
+		return b, a
+	}
+	return a, b
+}
+
+// lockTeams locks two teams and returns them in the order they were given.
+func lockTeams(ctx context.Context, txDB *database.DB, aID, bID uuid.UUID) (sqlc.Team, sqlc.Team, error) {
+	firstID, secondID := teamLockOrder(aID, bID)
+
+	first, err := txDB.Query.GetTeamByIdForUpdate(ctx, firstID)
+	if err != nil {
+		return sqlc.Team{}, sqlc.Team{}, err
+	}
+
+	second, err := txDB.Query.GetTeamByIdForUpdate(ctx, secondID)
+	if err != nil {
+		return sqlc.Team{}, sqlc.Team{}, err
+	}
+
+	if first.ID == aID {
+		return first, second, nil
+	}
+	return second, first, nil
+}
+
+func writeAuditLog(ctx context.Context, txDB *database.DB, teamID, actorID uuid.UUID, action sqlc.TeamAuditAction, details map[string]any) error {
+	encoded, err := json.Marshal(details)
+	if err != nil {
+		return err
+	}
+
+	return txDB.Query.CreateTeamAuditLog(ctx, sqlc.CreateTeamAuditLogParams{
+		TeamID:  teamID,
+		ActorID: &actorID,
+		Action:  action,
+		Details: encoded,
+	})
+}
+
+// adminHackathonID returns the active hackathon the application statuses are shown for.
+// Staff can still see teams when no hackathon is active, just without the statuses.
+func (s *TeamService) adminHackathonID(ctx context.Context) (string, error) {
+	hackathonID, err := s.currentHackathonID(ctx)
+
+	if errors.Is(err, ErrNoActiveHackathon) {
+		return "", nil
+	}
+
+	return hackathonID, err
+}
+
+func (s *TeamService) AdminListTeams(ctx context.Context, filter AdminTeamFilter) ([]sqlc.ListTeamsWithMembersRow, error) {
+	hackathonID, err := s.adminHackathonID(ctx)
+
+	if err != nil {
+		s.logger.Err(err).Msg("AdminListTeams fail, unable to get hackathon")
+		return nil, ErrGetTeams
+	}
+
+	teams, err := s.db.Query.ListTeamsWithMembers(ctx, sqlc.ListTeamsWithMembersParams{
+		HackathonID: hackathonID,
+		Search:      filter.search(),
+		Limit:       filter.Limit,
+		Offset:      filter.Offset,
+	})
+
+	if err != nil {
+		s.logger.Err(err).Msg("AdminListTeams fail")
+		return nil, ErrGetTeams
+	}
+
+	return teams, nil
+}
+
+func (s *TeamService) AdminGetTeam(ctx context.Context, teamID uuid.UUID) (*sqlc.GetTeamWithMembersRow, error) {
+	hackathonID, err := s.adminHackathonID(ctx)
+
+	if err != nil {
+		s.logger.Err(err).Msg("AdminGetTeam fail, unable to get hackathon")
+		return nil, ErrGetTeam
+	}
+
+	team, err := s.db.Query.GetTeamWithMembers(ctx, sqlc.GetTeamWithMembersParams{
+		HackathonID: hackathonID,
+		ID:          teamID,
+	})
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNoTeamFound
+		}
+		s.logger.Err(err).Msg("AdminGetTeam fail")
+		return nil, ErrGetTeam
+	}
+
+	return &team, nil
+}
+
+func (s *TeamService) RenameTeam(ctx context.Context, actorID, teamID uuid.UUID, name string) (*sqlc.Team, error) {
+	var updated sqlc.Team
+
+	err := s.txm.WithTx(ctx, func(tx pgx.Tx) error {
+		txDB := s.db.NewTX(tx)
+
+		team, err := txDB.Query.GetTeamByIdForUpdate(ctx, teamID)
+
+		if err != nil {
+			return err
+		}
+
+		updated, err = txDB.Query.UpdateTeamById(ctx, sqlc.UpdateTeamByIdParams{
+			NameDoUpdate: true,
+			Name:         name,
+			ID:           teamID,
+		})
+
+		if err != nil {
+			return err
+		}
+
+		return writeAuditLog(ctx, txDB, teamID, actorID, sqlc.TeamAuditActionRename, map[string]any{
+			"oldName": team.Name,
+			"newName": name,
+		})
+	})
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNoTeamFound
+		}
+		s.logger.Err(err).Msg("RenameTeam fail")
+		return nil, ErrUpdateTeam
+	}
+
+	return &updated, nil
+}
+
+// DisbandTeam deletes a team. Its members are left without a team.
+func (s *TeamService) DisbandTeam(ctx context.Context, actorID, teamID uuid.UUID) error {
+	err := s.txm.WithTx(ctx, func(tx pgx.Tx) error {
+		txDB := s.db.NewTX(tx)
+
+		team, err := txDB.Query.GetTeamByIdForUpdate(ctx, teamID)
+
+		if err != nil {
+			return err
+		}
+
+		members, err := txDB.Query.GetTeamMembers(ctx, teamID)
+
+		if err != nil {
+			return err
+		}
+
+		memberIDs := make([]uuid.UUID, len(members))
+		for i, member := range members {
+			memberIDs[i] = member.UserID
+		}
+
+		if err := txDB.Query.DeleteTeamById(ctx, teamID); err != nil {
+			return err
+		}
+
+		return writeAuditLog(ctx, txDB, teamID, actorID, sqlc.TeamAuditActionDisband, map[string]any{
+			"name":      team.Name,
+			"ownerId":   team.OwnerID,
+			"memberIds": memberIDs,
+		})
+	})
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrNoTeamFound
+		}
+		s.logger.Err(err).Msg("DisbandTeam fail")
+		return ErrDeleteTeam
+	}
+
+	return nil
+}
+
+// MergeTeams moves every member of the source team to the target team and deletes the
+// source team. The target team keeps its owner, and must have room for everyone.
+func (s *TeamService) MergeTeams(ctx context.Context, actorID, sourceID, targetID uuid.UUID) (*sqlc.Team, error) {
+	if sourceID == targetID {
+		return nil, ErrMergeSameTeam
+	}
+
+	var merged sqlc.Team
+
+	err := s.txm.WithTx(ctx, func(tx pgx.Tx) error {
+		txDB := s.db.NewTX(tx)
+
+		source, target, err := lockTeams(ctx, txDB, sourceID, targetID)
+
+		if err != nil {
+			return err
+		}
+
+		sourceCount, err := txDB.Query.CountTeamMembers(ctx, sourceID)
+
+		if err != nil {
+			return err
+		}
+
+		targetCount, err := txDB.Query.CountTeamMembers(ctx, targetID)
+
+		if err != nil {
+			return err
+		}
+
+		if sourceCount+targetCount > memberLimit(target.MaxMembers) {
+			return ErrMembersLimitReached
+		}
+
+		moved, err := txDB.Query.MoveAllTeamMembers(ctx, sqlc.MoveAllTeamMembersParams{
+			ToTeamID:   targetID,
+			FromTeamID: sourceID,
+		})
+
+		if err != nil {
+			return err
+		}
+
+		if err := txDB.Query.DeleteTeamById(ctx, sourceID); err != nil {
+			return err
+		}
+
+		if err := writeAuditLog(ctx, txDB, sourceID, actorID, sqlc.TeamAuditActionMerge, map[string]any{
+			"name":         source.Name,
+			"intoTeamId":   targetID,
+			"movedMembers": moved,
+		}); err != nil {
+			return err
+		}
+
+		if err := writeAuditLog(ctx, txDB, targetID, actorID, sqlc.TeamAuditActionMerge, map[string]any{
+			"fromTeamId":   sourceID,
+			"fromTeamName": source.Name,
+			"movedMembers": moved,
+		}); err != nil {
+			return err
+		}
+
+		merged = target
+		return nil
+	})
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNoTeamFound
+		}
+		if errors.Is(err, ErrMembersLimitReached) {
+			return nil, err
+		}
+		s.logger.Err(err).Msg("MergeTeams fail")
+		return nil, ErrMergeTeams
+	}
+
+	return &merged, nil
+}
+
+// MoveMember moves a user from their team to another one with room for them. If they
+// owned their old team, the member who has been on it the longest becomes the owner, and
+// the old team is deleted if they were its last member.
+func (s *TeamService) MoveMember(ctx context.Context, actorID, userID, teamID uuid.UUID) (*sqlc.Team, error) {
+	var target sqlc.Team
+
+	err := s.txm.WithTx(ctx, func(tx pgx.Tx) error {
+		txDB := s.db.NewTX(tx)
+
+		currentTeam, err := txDB.Query.GetTeamByUserId(ctx, userID)
+
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrNotInTeam
+			}
+			return err
+		}
+
+		if currentTeam.ID == teamID {
+			return ErrJoinSameTeam
+		}
+
+		var source sqlc.Team
+
+		source, target, err = lockTeams(ctx, txDB, currentTeam.ID, teamID)
+
+		if err != nil {
+			return err
+		}
+
+		count, err := txDB.Query.CountTeamMembers(ctx, teamID)
+
+		if err != nil {
+			return err
+		}
+
+		if count >= memberLimit(target.MaxMembers) {
+			return ErrMembersLimitReached
+		}
+
+		// The user may have left their team before it was locked.
+		moved, err := txDB.Query.MoveTeamMember(ctx, sqlc.MoveTeamMemberParams{
+			ToTeamID:   teamID,
+			UserID:     userID,
+			FromTeamID: source.ID,
+		})
+
+		if err != nil {
+			return err
+		}
+
+		if moved == 0 {
+			return ErrNotInTeam
+		}
+
+		sourceDisbanded := false
+
+		if source.OwnerID == userID {
+			nextOwnerID, err := txDB.Query.GetLongestTenuredTeamMember(ctx, sqlc.GetLongestTenuredTeamMemberParams{
+				TeamID: source.ID,
+				UserID: userID,
+			})
+
+			switch {
+			case errors.Is(err, pgx.ErrNoRows):
+				if err := txDB.Query.DeleteTeamById(ctx, source.ID); err != nil {
+					return err
+				}
+				sourceDisbanded = true
+
+			case err != nil:
+				return err
+
+			default:
+				if _, err := txDB.Query.UpdateTeamById(ctx, sqlc.UpdateTeamByIdParams{
+					OwnerIDDoUpdate: true,
+					OwnerID:         nextOwnerID,
+					ID:              source.ID,
+				}); err != nil {
+					return err
+				}
+			}
+		}
+
+		if err := writeAuditLog(ctx, txDB, source.ID, actorID, sqlc.TeamAuditActionMoveMember, map[string]any{
+			"userId":    userID,
+			"toTeamId":  teamID,
+			"disbanded": sourceDisbanded,
+		}); err != nil {
+			return err
+		}
+
+		return writeAuditLog(ctx, txDB, teamID, actorID, sqlc.TeamAuditActionMoveMember, map[string]any{
+			"userId":       userID,
+			"fromTeamId":   source.ID,
+			"fromTeamName": source.Name,
+		})
+	})
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNoTeamFound
+		}
+		if errors.Is(err, ErrNotInTeam) ||
+			errors.Is(err, ErrJoinSameTeam) ||
+			errors.Is(err, ErrMembersLimitReached) {
+			return nil, err
+		}
+		s.logger.Err(err).Msg("MoveMember fail")
+		return nil, ErrMoveMember
+	}
+
+	return &target, nil
+}
+
+// SetMemberLimit overrides how many members a team can have, or goes back to the default
+// when maxMembers is nil. Members over a lowered limit stay, the team just can't take
+// new ones.
+func (s *TeamService) SetMemberLimit(ctx context.Context, actorID, teamID uuid.UUID, maxMembers *int32) (*sqlc.Team, error) {
+	var updated sqlc.Team
+
+	err := s.txm.WithTx(ctx, func(tx pgx.Tx) error {
+		txDB := s.db.NewTX(tx)
+
+		team, err := txDB.Query.GetTeamByIdForUpdate(ctx, teamID)
+
+		if err != nil {
+			return err
+		}
+
+		updated, err = txDB.Query.SetTeamMaxMembers(ctx, sqlc.SetTeamMaxMembersParams{
+			MaxMembers: maxMembers,
+			ID:         teamID,
+		})
+
+		if err != nil {
+			return err
+		}
+
+		return writeAuditLog(ctx, txDB, teamID, actorID, sqlc.TeamAuditActionSetMemberLimit, map[string]any{
+			"oldMaxMembers": team.MaxMembers,
+			"newMaxMembers": maxMembers,
+		})
+	})
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNoTeamFound
+		}
+		s.logger.Err(err).Msg("SetMemberLimit fail")
+		return nil, ErrUpdateTeam
+	}
+
+	return &updated, nil
+}
+
+// ListAuditLogs returns the audit log of every team, or only of teamID when it's set.
+func (s *TeamService) ListAuditLogs(ctx context.Context, teamID *uuid.UUID, limit, offset int32) ([]sqlc.ListTeamAuditLogsRow, error) {
+	logs, err := s.db.Query.ListTeamAuditLogs(ctx, sqlc.ListTeamAuditLogsParams{
+		TeamID: teamID,
+		Limit:  limit,
+		Offset: offset,
+	})
+
+	if err != nil {
+		s.logger.Err(err).Msg("ListAuditLogs fail")
+		return nil, ErrGetAuditLogs
+	}
+
+	return logs, nil
+}
@@ -0,0 +1,211 @@
+package teams
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/google/uuid"
+	"github.com/swamphacks/core/apps/api/internal/ctxutils"
+	"github.com/swamphacks/core/apps/api/internal/database/sqlc"
+)
+
+type AdminListTeamsOutput struct {
+	Body []AdminTeamDto
+}
+
+func (h *handler) handleAdminListTeams(ctx context.Context, input *AdminTeamsDto) (*AdminListTeamsOutput, error) {
+	teams, err := h.teamService.AdminListTeams(ctx, AdminTeamFilter{
+		Search: input.Search,
+		Limit:  input.Limit,
+		Offset: input.Offset,
+	})
+
+	if err != nil {
+		return nil, teamHTTPError(err, "Failed to get teams")
+	}
+
+	teamsDto := make([]AdminTeamDto, len(teams))
+	for i, team := range teams {
+		teamDto, err := toAdminTeamDto(team)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to parse team members")
+		}
+		teamsDto[i] = teamDto
+	}
+
+	return &AdminListTeamsOutput{Body: teamsDto}, nil
+}
+
+type AdminTeamOutput struct {
+	Body AdminTeamDto
+}
+
+func (h *handler) handleAdminGetTeam(ctx context.Context, input *struct {
+	TeamId uuid.UUID `path:"teamId"`
+}) (*AdminTeamOutput, error) {
+	return h.adminTeamOutput(ctx, input.TeamId)
+}
+
+func (h *handler) handleAdminRenameTeam(ctx context.Context, input *struct {
+	Body   RenameTeamRequestDto
+	TeamId uuid.UUID `path:"teamId"`
+}) (*AdminTeamOutput, error) {
+	userCtx := ctxutils.GetUserFromCtx(ctx)
+
+	if userCtx == nil {
+		return nil, huma.Error400BadRequest("Failed to get current user info")
+	}
+
+	team, err := h.teamService.RenameTeam(ctx, userCtx.UserID, input.TeamId, input.Body.Name)
+
+	if err != nil {
+		return nil, teamHTTPError(err, "Failed to rename team")
+	}
+
+	return h.adminTeamOutput(ctx, team.ID)
+}
+
+func (h *handler) handleAdminDisbandTeam(ctx context.Context, input *struct {
+	TeamId uuid.UUID `path:"teamId"`
+}) (*struct{}, error) {
+	userCtx := ctxutils.GetUserFromCtx(ctx)
+
+	if userCtx == nil {
+		return nil, huma.Error400BadRequest("Failed to get current user info")
+	}
+
+	if err := h.teamService.DisbandTeam(ctx, userCtx.UserID, input.TeamId); err != nil {
+		return nil, teamHTTPError(err, "Failed to disband team")
+	}
+
+	return nil, nil
+}
+
+func (h *handler) handleAdminMergeTeams(ctx context.Context, input *struct {
+	Body   MergeTeamsRequestDto
+	TeamId uuid.UUID `path:"teamId"`
+}) (*AdminTeamOutput, error) {
+	userCtx := ctxutils.GetUserFromCtx(ctx)
+
+	if userCtx == nil {
+		return nil, huma.Error400BadRequest("Failed to get current user info")
+	}
+
+	team, err := h.teamService.MergeTeams(ctx, userCtx.UserID, input.Body.SourceTeamID, input.TeamId)
+
+	if err != nil {
+		return nil, teamHTTPError(err, "Failed to merge teams")
+	}
+
+	return h.adminTeamOutput(ctx, team.ID)
+}
+
+func (h *handler) handleAdminMoveMember(ctx context.Context, input *struct {
+	Body   MoveMemberRequestDto
+	UserId uuid.UUID `path:"userId"`
+}) (*AdminTeamOutput, error) {
+	userCtx := ctxutils.GetUserFromCtx(ctx)
+
+	if userCtx == nil {
+		return nil, huma.Error400BadRequest("Failed to get current user info")
+	}
+
+	team, err := h.teamService.MoveMember(ctx, userCtx.UserID, input.UserId, input.Body.TeamID)
+
+	if err != nil {
+		return nil, teamHTTPError(err, "Failed to move member")
+	}
+
+	return h.adminTeamOutput(ctx, team.ID)
+}
+
+func (h *handler) handleAdminSetMemberLimit(ctx context.Context, input *struct {
+	Body   SetMemberLimitRequestDto
+	TeamId uuid.UUID `path:"teamId"`
+}) (*AdminTeamOutput, error) {
+	userCtx := ctxutils.GetUserFromCtx(ctx)
+
+	if userCtx == nil {
+		return nil, huma.Error400BadRequest("Failed to get current user info")
+	}
+
+	team, err := h.teamService.SetMemberLimit(ctx, userCtx.UserID, input.TeamId, input.Body.MaxMembers)
+
+	if err != nil {
+		return nil, teamHTTPError(err, "Failed to set team member limit")
+	}
+
+	return h.adminTeamOutput(ctx, team.ID)
+}
+
+type AdminListAuditLogsOutput struct {
+	Body []TeamAuditLogDto
+}
+
+func (h *handler) handleAdminListAuditLogs(ctx context.Context, input *TeamAuditLogsDto) (*AdminListAuditLogsOutput, error) {
+	var teamID *uuid.UUID
+	if input.TeamID != uuid.Nil {
+		teamID = &input.TeamID
+	}
+
+	logs, err := h.teamService.ListAuditLogs(ctx, teamID, input.Limit, input.Offset)
+
+	if err != nil {
+		return nil, teamHTTPError(err, "Failed to get team audit logs")
+	}
+
+	logsDto := make([]TeamAuditLogDto, len(logs))
+	for i, log := range logs {
+		var details map[string]any
+		if err := json.Unmarshal(log.Details, &details); err != nil {
+			return nil, huma.Error500InternalServerError("Failed to parse audit log details")
+		}
+
+		logsDto[i] = TeamAuditLogDto{
+			ID:        log.ID,
+			TeamID:    log.TeamID,
+			ActorID:   log.ActorID,
+			ActorName: log.ActorName,
+			Action:    log.Action,
+			Details:   details,
+			CreatedAt: log.CreatedAt,
+		}
+	}
+
+	return &AdminListAuditLogsOutput{Body: logsDto}, nil
+}
+
+// adminTeamOutput responds with a team as it is after a change, with its members.
+func (h *handler) adminTeamOutput(ctx context.Context, teamID uuid.UUID) (*AdminTeamOutput, error) {
+	team, err := h.teamService.AdminGetTeam(ctx, teamID)
+
+	if err != nil {
+		return nil, teamHTTPError(err, "Failed to get team")
+	}
+
+	teamDto, err := toAdminTeamDto(sqlc.ListTeamsWithMembersRow(*team))
+	if err != nil {
+		return nil, huma.Error500InternalServerError("Failed to parse team members")
+	}
+
+	return &AdminTeamOutput{Body: teamDto}, nil
+}
+
+func toAdminTeamDto(team sqlc.ListTeamsWithMembersRow) (AdminTeamDto, error) {
+	var members []AdminTeamMemberDto
+	if err := json.Unmarshal(team.Members, &members); err != nil {
+		return AdminTeamDto{}, err
+	}
+
+	return AdminTeamDto{
+		ID:                 team.ID,
+		Name:               team.Name,
+		OwnerID:            team.OwnerID,
+		MaxMembers:         memberLimit(team.MaxMembers),
+		MaxMembersOverride: team.MaxMembers,
+		MemberCount:        team.MemberCount,
+		CreatedAt:          team.CreatedAt,
+		Members:            members,
+	}, nil
+}
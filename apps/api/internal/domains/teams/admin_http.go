@@ -0,0 +1,115 @@
+package teams
+
+import (
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/swamphacks/core/apps/api/internal/api/cookie"
+	"github.com/swamphacks/core/apps/api/internal/api/middleware"
+)
+
+func RegisterAdminRoutes(teamHandler *handler, group huma.API, mw *middleware.Middleware) {
+	huma.Register(group, huma.Operation{
+		OperationID:   "admin-list-teams",
+		Method:        http.MethodGet,
+		Summary:       "List Teams",
+		Description:   "Returns every team with its members and their application status for the active hackathon. Staff only.",
+		Tags:          []string{"Team Admin"},
+		Path:          "/admin/teams",
+		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma, mw.Auth.RequireStaffHuma},
+		Errors:        []int{http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError},
+		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
+		DefaultStatus: http.StatusOK,
+	}, teamHandler.handleAdminListTeams)
+
+	huma.Register(group, huma.Operation{
+		OperationID:   "admin-get-team",
+		Method:        http.MethodGet,
+		Summary:       "Get Team",
+		Description:   "Returns a team with its members and their application status for the active hackathon. Staff only.",
+		Tags:          []string{"Team Admin"},
+		Path:          "/admin/teams/{teamId}",
+		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma, mw.Auth.RequireStaffHuma},
+		Errors:        []int{http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound, http.StatusInternalServerError},
+		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
+		DefaultStatus: http.StatusOK,
+	}, teamHandler.handleAdminGetTeam)
+
+	huma.Register(group, huma.Operation{
+		OperationID:   "admin-rename-team",
+		Method:        http.MethodPatch,
+		Summary:       "Rename Team",
+		Description:   "Renames a team. Staff only.",
+		Tags:          []string{"Team Admin"},
+		Path:          "/admin/teams/{teamId}",
+		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma, mw.Auth.RequireStaffHuma},
+		Errors:        []int{http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound, http.StatusBadRequest, http.StatusInternalServerError},
+		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
+		DefaultStatus: http.StatusOK,
+	}, teamHandler.handleAdminRenameTeam)
+
+	huma.Register(group, huma.Operation{
+		OperationID:   "admin-disband-team",
+		Method:        http.MethodDelete,
+		Summary:       "Disband Team",
+		Description:   "Deletes a team, its members are left without one. Staff only.",
+		Tags:          []string{"Team Admin"},
+		Path:          "/admin/teams/{teamId}",
+		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma, mw.Auth.RequireStaffHuma},
+		Errors:        []int{http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound, http.StatusInternalServerError},
+		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
+		DefaultStatus: http.StatusNoContent,
+	}, teamHandler.handleAdminDisbandTeam)
+
+	huma.Register(group, huma.Operation{
+		OperationID:   "admin-merge-teams",
+		Method:        http.MethodPost,
+		Summary:       "Merge Teams",
+		Description:   "Moves every member of the source team to this team and deletes the source team. This team keeps its owner and must have room for everyone. Staff only.",
+		Tags:          []string{"Team Admin"},
+		Path:          "/admin/teams/{teamId}/merge",
+		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma, mw.Auth.RequireStaffHuma},
+		Errors:        []int{http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound, http.StatusBadRequest, http.StatusConflict, http.StatusInternalServerError},
+		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
+		DefaultStatus: http.StatusOK,
+	}, teamHandler.handleAdminMergeTeams)
+
+	huma.Register(group, huma.Operation{
+		OperationID:   "admin-set-team-member-limit",
+		Method:        http.MethodPut,
+		Summary:       "Set Team Member Limit",
+		Description:   "Overrides how many members a team can have, or goes back to the default. Staff only.",
+		Tags:          []string{"Team Admin"},
+		Path:          "/admin/teams/{teamId}/member-limit",
+		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma, mw.Auth.RequireStaffHuma},
+		Errors:        []int{http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound, http.StatusBadRequest, http.StatusInternalServerError},
+		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
+		DefaultStatus: http.StatusOK,
+	}, teamHandler.handleAdminSetMemberLimit)
+
+	huma.Register(group, huma.Operation{
+		OperationID:   "admin-move-team-member",
+		Method:        http.MethodPost,
+		Summary:       "Move Team Member",
+		Description:   "Moves a user from their team to another one with room for them. If they owned their old team, its longest-tenured member becomes the owner. Staff only.",
+		Tags:          []string{"Team Admin"},
+		Path:          "/admin/members/{userId}/move",
+		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma, mw.Auth.RequireStaffHuma},
+		Errors:        []int{http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound, http.StatusBadRequest, http.StatusConflict, http.StatusInternalServerError},
+		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
+		DefaultStatus: http.StatusOK,
+	}, teamHandler.handleAdminMoveMember)
+
+	huma.Register(group, huma.Operation{
+		OperationID:   "admin-list-team-audit-logs",
+		Method:        http.MethodGet,
+		Summary:       "List Team Audit Logs",
+		Description:   "Returns the changes staff made to teams, newest first. Staff only.",
+		Tags:          []string{"Team Admin"},
+		Path:          "/admin/audit-logs",
+		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma, mw.Auth.RequireStaffHuma},
+		Errors:        []int{http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError},
+		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
+		DefaultStatus: http.StatusOK,
+	}, teamHandler.handleAdminListAuditLogs)
+}
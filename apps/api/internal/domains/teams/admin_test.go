@@ -0,0 +1,77 @@
+package teams
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestMemberLimit(t *testing.T) {
+	two := int32(2)
+	six := int32(6)
+
+	tests := []struct {
+		name       string
+		maxMembers *int32
+		expected   int64
+	}{
+		{
+			name:       "default limit",
+			maxMembers: nil,
+			expected:   maxTeamMembers,
+		},
+		{
+			name:       "raised limit",
+			maxMembers: &six,
+			expected:   6,
+		},
+		{
+			name:       "lowered limit",
+			maxMembers: &two,
+			expected:   2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if limit := memberLimit(test.maxMembers); limit != test.expected {
+				t.Fatalf("expected %v, got %v", test.expected, limit)
+			}
+		})
+	}
+}
+
+func TestTeamLockOrder(t *testing.T) {
+	low := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	high := uuid.MustParse("ffffffff-0000-0000-0000-000000000000")
+
+	tests := []struct {
+		name          string
+		a, b          uuid.UUID
+		first, second uuid.UUID
+	}{
+		{
+			name:   "already sorted",
+			a:      low,
+			b:      high,
+			first:  low,
+			second: high,
+		},
+		{
+			name:   "reversed",
+			a:      high,
+			b:      low,
+			first:  low,
+			second: high,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			first, second := teamLockOrder(test.a, test.b)
+			if first != test.first || second != test.second {
+				t.Fatalf("expected %v, %v, got %v, %v", test.first, test.second, first, second)
+			}
+		})
+	}
+}
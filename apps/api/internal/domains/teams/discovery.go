@@ -0,0 +1,368 @@
+package teams
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/swamphacks/core/apps/api/internal/database/sqlc"
+)
+
+// DiscoveryFilter narrows down the team board. Skills and interests match anything that
+// has at least one of them.
+type DiscoveryFilter struct {
+	Search    string
+	Skills    []string
+	Interests []string
+	Limit     int32
+	Offset    int32
+}
+
+func (f DiscoveryFilter) search() *string {
+	if f.Search == "" {
+		return nil
+	}
+	return &f.Search
+}
+
+// normalizeTags lowercases and trims skills and interests and drops blanks and repeats,
+// so "Go" and "go " match. It never returns nil, the columns are not null.
+func normalizeTags(tags []string) []string {
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag != "" && !slices.Contains(normalized, tag) {
+			normalized = append(normalized, tag)
+		}
+	}
+	return normalized
+}
+
+// currentHackathonID returns the active hackathon, the board only shows listings and
+// profiles made for it.
+func (s *TeamService) currentHackathonID(ctx context.Context) (string, error) {
+	hackathon, err := s.db.Query.GetHackathon(ctx)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", ErrNoActiveHackathon
+		}
+		return "", err
+	}
+
+	return hackathon.ID, nil
+}
+
+// ListTeamListings returns the listed teams with at least minOpenSlots open spots.
+func (s *TeamService) ListTeamListings(ctx context.Context, filter DiscoveryFilter, minOpenSlots int64) ([]sqlc.ListTeamListingsRow, error) {
+	hackathonID, err := s.currentHackathonID(ctx)
+
+	if err != nil {
+		if errors.Is(err, ErrNoActiveHackathon) {
+			return nil, err
+		}
+		s.logger.Err(err).Msg("ListTeamListings fail, unable to get hackathon")
+		return nil, ErrGetTeamListings
+	}
+
+	listings, err := s.db.Query.ListTeamListings(ctx, sqlc.ListTeamListingsParams{
+		HackathonID:  hackathonID,
+		Search:       filter.search(),
+		Skills:       normalizeTags(filter.Skills),
+		Interests:    normalizeTags(filter.Interests),
+		MaxMembers:   maxTeamMembers,
+		MinOpenSlots: minOpenSlots,
+		Limit:        filter.Limit,
+		Offset:       filter.Offset,
+	})
+
+	if err != nil {
+		s.logger.Err(err).Msg("ListTeamListings fail")
+		return nil, ErrGetTeamListings
+	}
+
+	return listings, nil
+}
+
+func (s *TeamService) GetTeamListing(ctx context.Context, teamID uuid.UUID) (*sqlc.TeamListing, error) {
+	hackathonID, err := s.currentHackathonID(ctx)
+
+	if err != nil {
+		if errors.Is(err, ErrNoActiveHackathon) {
+			return nil, err
+		}
+		s.logger.Err(err).Msg("GetTeamListing fail, unable to get hackathon")
+		return nil, ErrGetTeamListings
+	}
+
+	listing, err := s.db.Query.GetTeamListing(ctx, sqlc.GetTeamListingParams{
+		TeamID:      teamID,
+		HackathonID: hackathonID,
+	})
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrTeamListingNotFound
+		}
+		s.logger.Err(err).Msg("GetTeamListing fail")
+		return nil, ErrGetTeamListings
+	}
+
+	return &listing, nil
+}
+
+// UpdateTeamListing lists a team on the board for the active hackathon, or changes its
+// listing. Only the owner can perform this action.
+func (s *TeamService) UpdateTeamListing(ctx context.Context, userID, teamID uuid.UUID, description *string, skills, interests []string) (*sqlc.TeamListing, error) {
+	team, err := s.db.Query.GetTeamById(ctx, teamID)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNoTeamFound
+		}
+		s.logger.Err(err).Msg("UpdateTeamListing fail, unable to get team info by id")
+		return nil, ErrUpdateTeamListing
+	}
+
+	if team.OwnerID != userID {
+		return nil, ErrUserNotTeamOwner
+	}
+
+	hackathonID, err := s.currentHackathonID(ctx)
+
+	if err != nil {
+		if errors.Is(err, ErrNoActiveHackathon) {
+			return nil, err
+		}
+		s.logger.Err(err).Msg("UpdateTeamListing fail, unable to get hackathon")
+		return nil, ErrUpdateTeamListing
+	}
+
+	listing, err := s.db.Query.UpsertTeamListing(ctx, sqlc.UpsertTeamListingParams{
+		TeamID:      teamID,
+		HackathonID: hackathonID,
+		Description: description,
+		Skills:      normalizeTags(skills),
+		Interests:   normalizeTags(interests),
+	})
+
+	if err != nil {
+		s.logger.Err(err).Msg("UpdateTeamListing fail")
+		return nil, ErrUpdateTeamListing
+	}
+
+	return &listing, nil
+}
+
+// DeleteTeamListing takes a team off the board. Only the owner can perform this action.
+func (s *TeamService) DeleteTeamListing(ctx context.Context, userID, teamID uuid.UUID) error {
+	team, err := s.db.Query.GetTeamById(ctx, teamID)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrNoTeamFound
+		}
+		s.logger.Err(err).Msg("DeleteTeamListing fail, unable to get team info by id")
+		return ErrUpdateTeamListing
+	}
+
+	if team.OwnerID != userID {
+		return ErrUserNotTeamOwner
+	}
+
+	deleted, err := s.db.Query.DeleteTeamListing(ctx, teamID)
+
+	if err != nil {
+		s.logger.Err(err).Msg("DeleteTeamListing fail")
+		return ErrUpdateTeamListing
+	}
+
+	if deleted == 0 {
+		return ErrTeamListingNotFound
+	}
+
+	return nil
+}
+
+// ListTeamProfiles returns the looking for team profiles of users who aren't on a team.
+func (s *TeamService) ListTeamProfiles(ctx context.Context, filter DiscoveryFilter) ([]sqlc.ListLookingForTeamProfilesRow, error) {
+	hackathonID, err := s.currentHackathonID(ctx)
+
+	if err != nil {
+		if errors.Is(err, ErrNoActiveHackathon) {
+			return nil, err
+		}
+		s.logger.Err(err).Msg("ListTeamProfiles fail, unable to get hackathon")
+		return nil, ErrGetTeamProfiles
+	}
+
+	profiles, err := s.db.Query.ListLookingForTeamProfiles(ctx, sqlc.ListLookingForTeamProfilesParams{
+		HackathonID: hackathonID,
+		Search:      filter.search(),
+		Skills:      normalizeTags(filter.Skills),
+		Interests:   normalizeTags(filter.Interests),
+		Limit:       filter.Limit,
+		Offset:      filter.Offset,
+	})
+
+	if err != nil {
+		s.logger.Err(err).Msg("ListTeamProfiles fail")
+		return nil, ErrGetTeamProfiles
+	}
+
+	return profiles, nil
+}
+
+func (s *TeamService) GetTeamProfile(ctx context.Context, userID uuid.UUID) (*sqlc.LookingForTeamProfile, error) {
+	hackathonID, err := s.currentHackathonID(ctx)
+
+	if err != nil {
+		if errors.Is(err, ErrNoActiveHackathon) {
+			return nil, err
+		}
+		s.logger.Err(err).Msg("GetTeamProfile fail, unable to get hackathon")
+		return nil, ErrGetTeamProfiles
+	}
+
+	profile, err := s.db.Query.GetLookingForTeamProfile(ctx, sqlc.GetLookingForTeamProfileParams{
+		UserID:      userID,
+		HackathonID: hackathonID,
+	})
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrTeamProfileNotFound
+		}
+		s.logger.Err(err).Msg("GetTeamProfile fail")
+		return nil, ErrGetTeamProfiles
+	}
+
+	return &profile, nil
+}
+
+// UpdateTeamProfile puts the user on the board as looking for a team, or changes their
+// profile. Users already on a team can't.
+func (s *TeamService) UpdateTeamProfile(ctx context.Context, userID uuid.UUID, bio *string, skills, interests []string) (*sqlc.LookingForTeamProfile, error) {
+	_, err := s.db.Query.GetTeamByUserId(ctx, userID)
+
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		// User is not on a team, continue.
+
+	case err != nil:
+		s.logger.Err(err).Msg("UpdateTeamProfile fail, unable to get team by user id")
+		return nil, ErrUpdateTeamProfile
+
+	default:
+		return nil, ErrAlreadyHasTeam
+	}
+
+	hackathonID, err := s.currentHackathonID(ctx)
+
+	if err != nil {
+		if errors.Is(err, ErrNoActiveHackathon) {
+			return nil, err
+		}
+		s.logger.Err(err).Msg("UpdateTeamProfile fail, unable to get hackathon")
+		return nil, ErrUpdateTeamProfile
+	}
+
+	profile, err := s.db.Query.UpsertLookingForTeamProfile(ctx, sqlc.UpsertLookingForTeamProfileParams{
+		UserID:      userID,
+		HackathonID: hackathonID,
+		Bio:         bio,
+		Skills:      normalizeTags(skills),
+		Interests:   normalizeTags(interests),
+	})
+
+	if err != nil {
+		s.logger.Err(err).Msg("UpdateTeamProfile fail")
+		return nil, ErrUpdateTeamProfile
+	}
+
+	return &profile, nil
+}
+
+// DeleteTeamProfile takes the user off the board.
+func (s *TeamService) DeleteTeamProfile(ctx context.Context, userID uuid.UUID) error {
+	hackathonID, err := s.currentHackathonID(ctx)
+
+	if err != nil {
+		if errors.Is(err, ErrNoActiveHackathon) {
+			return err
+		}
+		s.logger.Err(err).Msg("DeleteTeamProfile fail, unable to get hackathon")
+		return ErrUpdateTeamProfile
+	}
+
+	deleted, err := s.db.Query.DeleteLookingForTeamProfile(ctx, sqlc.DeleteLookingForTeamProfileParams{
+		UserID:      userID,
+		HackathonID: hackathonID,
+	})
+
+	if err != nil {
+		s.logger.Err(err).Msg("DeleteTeamProfile fail")
+		return ErrUpdateTeamProfile
+	}
+
+	if deleted == 0 {
+		return ErrTeamProfileNotFound
+	}
+
+	return nil
+}
+
+// InviteFromBoard sends a user looking for a team an invitation to the owner's team that
+// only they can use.
+func (s *TeamService) InviteFromBoard(ctx context.Context, ownerID, userID uuid.UUID) error {
+	ownerTeam, err := s.GetTeamByUserId(ctx, ownerID)
+
+	if err != nil {
+		if errors.Is(err, ErrNotInTeam) {
+			return err
+		}
+		return ErrInviteToTeam
+	}
+
+	if ownerTeam.OwnerID != ownerID {
+		return ErrUserNotTeamOwner
+	}
+
+	team, err := s.db.Query.GetTeamById(ctx, ownerTeam.ID)
+
+	if err != nil {
+		s.logger.Err(err).Msg("InviteFromBoard fail, unable to get team info by id")
+		return ErrInviteToTeam
+	}
+
+	// Only users who put themselves on the board can be invited from it.
+	if _, err := s.GetTeamProfile(ctx, userID); err != nil {
+		if errors.Is(err, ErrTeamProfileNotFound) || errors.Is(err, ErrNoActiveHackathon) {
+			return err
+		}
+		return ErrInviteToTeam
+	}
+
+	_, err = s.db.Query.GetTeamByUserId(ctx, userID)
+
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		// User is not on a team, continue inviting.
+
+	case err != nil:
+		s.logger.Err(err).Msg("InviteFromBoard fail, unable to get team by user id")
+		return ErrInviteToTeam
+
+	default:
+		return ErrAlreadyHasTeam
+	}
+
+	if _, err := s.invitePerson(ctx, team, ownerID, &userID, nil, nil); err != nil {
+		return err
+	}
+
+	return nil
+}
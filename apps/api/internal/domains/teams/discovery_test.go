@@ -0,0 +1,44 @@
+package teams
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestNormalizeTags(t *testing.T) {
+	tests := []struct {
+		name     string
+		tags     []string
+		expected []string
+	}{
+		{
+			name:     "no tags",
+			tags:     nil,
+			expected: []string{},
+		},
+		{
+			name:     "lowercased and trimmed",
+			tags:     []string{" Go", "React "},
+			expected: []string{"go", "react"},
+		},
+		{
+			name:     "blanks and repeats dropped",
+			tags:     []string{"go", "", "  ", "GO", "rust"},
+			expected: []string{"go", "rust"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			normalized := normalizeTags(test.tags)
+
+			if normalized == nil {
+				t.Fatal("expected a non-nil slice")
+			}
+
+			if !slices.Equal(normalized, test.expected) {
+				t.Fatalf("expected %v, got %v", test.expected, normalized)
+			}
+		})
+	}
+}
@@ -5,10 +5,12 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http"
+	"time"
 
 	"github.com/danielgtaylor/huma/v2"
 	"github.com/google/uuid"
 	"github.com/swamphacks/core/apps/api/internal/ctxutils"
+	"github.com/swamphacks/core/apps/api/internal/database/sqlc"
 )
 
 type GetMyTeamOutput struct {
@@ -91,7 +93,7 @@ func (h *handler) handleGetTeamByInvitationId(ctx context.Context, input *struct
 	team, err := h.teamService.GetTeamByInvitationId(ctx, input.InviteId)
 
 	if err != nil {
-		return nil, huma.Error500InternalServerError("Fail to get team")
+		return nil, teamHTTPError(err, "Fail to get team")
 	}
 
 	return &GetTeamByInvitationIdOutput{Body: TeamDto{
@@ -191,16 +193,10 @@ func (h *handler) handleJoinTeam(ctx context.Context, input *struct {
 		return nil, huma.Error400BadRequest("Failed to get current user info")
 	}
 
-	invitation, err := h.teamService.GetInvitation(ctx, input.InvitationId)
+	err := h.teamService.JoinTeam(ctx, userCtx.UserID, input.InvitationId)
 
 	if err != nil {
-		return nil, huma.Error500InternalServerError(err.Error())
-	}
-
-	err = h.teamService.JoinTeam(ctx, userCtx.UserID, invitation.TeamID)
-
-	if err != nil {
-		return nil, huma.Error500InternalServerError(err.Error())
+		return nil, teamHTTPError(err, "Failed to join team")
 	}
 
 	return &JoinTeamOutput{Status: http.StatusOK}, nil
@@ -222,12 +218,40 @@ func (h *handler) handleLeaveTeam(ctx context.Context, input *struct {
 	err := h.teamService.LeaveTeam(ctx, userCtx.UserID, input.TeamId)
 
 	if err != nil {
-		return nil, huma.Error500InternalServerError(err.Error())
+		return nil, teamHTTPError(err, "Failed to leave team")
 	}
 
 	return &LeaveTeamOutput{Status: http.StatusOK}, nil
 }
 
+type TransferOwnershipOutput struct {
+	Body TeamDto
+}
+
+func (h *handler) handleTransferOwnership(ctx context.Context, input *struct {
+	Body   TransferOwnershipRequestDto
+	TeamId uuid.UUID `path:"teamId"`
+}) (*TransferOwnershipOutput, error) {
+	userCtx := ctxutils.GetUserFromCtx(ctx)
+
+	if userCtx == nil {
+		return nil, huma.Error400BadRequest("Failed to get current user info")
+	}
+
+	team, err := h.teamService.TransferOwnership(ctx, userCtx.UserID, input.TeamId, input.Body.NewOwnerID)
+
+	if err != nil {
+		return nil, teamHTTPError(err, "Failed to transfer team ownership")
+	}
+
+	return &TransferOwnershipOutput{Body: TeamDto{
+		ID:        team.ID,
+		Name:      team.Name,
+		OwnerID:   team.OwnerID,
+		CreatedAt: team.CreatedAt,
+	}}, nil
+}
+
 type KickMemberFromTeamOutput struct {
 	Status int
 }
@@ -251,24 +275,54 @@ func (h *handler) handleKickMember(ctx context.Context, input *struct {
 	return &KickMemberFromTeamOutput{Status: http.StatusOK}, nil
 }
 
-type CreateInvitationOutput struct {
-	Body uuid.UUID
+type InvitationOutput struct {
+	Body InvitationDto
 }
 
-func (h *handler) handleCreateInvitation(ctx context.Context, input *struct{}) (*CreateInvitationOutput, error) {
+func (h *handler) handleCreateInvitation(ctx context.Context, input *struct {
+	Body   *CreateInvitationRequestDto
+	TeamID uuid.UUID `path:"teamId"`
+}) (*InvitationOutput, error) {
 	userCtx := ctxutils.GetUserFromCtx(ctx)
 
 	if userCtx == nil {
 		return nil, huma.Error400BadRequest("Failed to get current user info")
 	}
 
-	invitation, err := h.teamService.CreateInvitation(ctx, userCtx.UserID)
+	var options InvitationOptions
+	if input.Body != nil {
+		options = InvitationOptions{
+			ExpiresAt: input.Body.ExpiresAt,
+			MaxUses:   input.Body.MaxUses,
+		}
+	}
+
+	invitation, err := h.teamService.CreateInvitation(ctx, userCtx.UserID, input.TeamID, options)
 
 	if err != nil {
-		return nil, huma.Error500InternalServerError(err.Error())
+		return nil, teamHTTPError(err, "Failed to create invitation")
 	}
 
-	return &CreateInvitationOutput{Body: *invitation}, nil
+	return &InvitationOutput{Body: toInvitationDto(invitation)}, nil
+}
+
+func (h *handler) handleInviteByEmail(ctx context.Context, input *struct {
+	Body   InviteByEmailRequestDto
+	TeamID uuid.UUID `path:"teamId"`
+}) (*InvitationOutput, error) {
+	userCtx := ctxutils.GetUserFromCtx(ctx)
+
+	if userCtx == nil {
+		return nil, huma.Error400BadRequest("Failed to get current user info")
+	}
+
+	invitation, err := h.teamService.InviteByEmail(ctx, userCtx.UserID, input.TeamID, input.Body.Email, input.Body.ExpiresAt)
+
+	if err != nil {
+		return nil, teamHTTPError(err, "Failed to invite to team")
+	}
+
+	return &InvitationOutput{Body: toInvitationDto(invitation)}, nil
 }
 
 type GetInvitationOutput struct {
@@ -287,152 +341,525 @@ func (h *handler) handleGetInvitation(ctx context.Context, input *struct {
 	invitation, err := h.teamService.GetInvitationByTeamID(ctx, input.TeamID, userCtx.UserID)
 
 	if err != nil {
-		return nil, huma.Error500InternalServerError(err.Error())
+		return nil, teamHTTPError(err, "Failed to get invitation")
 	}
 
 	return &GetInvitationOutput{Body: invitation.ID}, nil
 }
 
-// type CreateJoinRequest struct {
-// 	Message *string `json:"message"`
-// }
+type ListTeamInvitationsOutput struct {
+	Body []InvitationDto
+}
 
-// type RequestToJoinTeamOutput struct {
-// 	Body *sqlc.TeamJoinRequest
-// }
+func (h *handler) handleListTeamInvitations(ctx context.Context, input *struct {
+	TeamID uuid.UUID `path:"teamId"`
+}) (*ListTeamInvitationsOutput, error) {
+	userCtx := ctxutils.GetUserFromCtx(ctx)
 
-// func (h *handler) handleRequestToJoinTeam(ctx context.Context, input *struct {
-// 	Body   CreateJoinRequest
-// 	TeamId string `path:"teamId"`
-// }) (*RequestToJoinTeamOutput, error) {
-// 	userCtx := ctxutils.GetUserFromCtx(ctx)
+	if userCtx == nil {
+		return nil, huma.Error400BadRequest("Failed to get current user info")
+	}
 
-// 	if userCtx == nil {
-// 		return nil, huma.Error400BadRequest("Failed to get current user info")
-// 	}
+	invitations, err := h.teamService.ListTeamInvitations(ctx, userCtx.UserID, input.TeamID)
 
-// 	teamId, err := uuid.Parse(input.TeamId)
+	if err != nil {
+		return nil, teamHTTPError(err, "Failed to get team invitations")
+	}
 
-// 	if err != nil {
-// 		return nil, huma.Error400BadRequest("Invalid team id")
-// 	}
+	now := time.Now()
 
-// 	request, err := h.teamService.RequestToJoinTeam(ctx, teamId, userCtx.UserID, input.Body.Message)
+	invitationsDto := make([]InvitationDto, len(invitations))
+	for i, invitation := range invitations {
+		var uses []InvitationUseDto
+		if err := json.Unmarshal(invitation.Uses, &uses); err != nil {
+			return nil, huma.Error500InternalServerError("Failed to parse invitation uses")
+		}
 
-// 	if err != nil {
-// 		if errors.Is(err, ErrUserOnTeam) {
-// 			return nil, huma.Error409Conflict("User is already on a team")
-// 		}
+		invitationsDto[i] = InvitationDto{
+			ID:            invitation.ID,
+			InviterID:     invitation.InviterID,
+			InvitedEmail:  invitation.InvitedEmail,
+			InvitedUserID: invitation.InvitedUserID,
+			Status:        invitationStatus(invitation.Status, invitation.ExpiresAt, now),
+			ExpiresAt:     invitation.ExpiresAt,
+			MaxUses:       invitation.MaxUses,
+			UseCount:      invitation.UseCount,
+			CreatedAt:     invitation.CreatedAt,
+			Uses:          uses,
+		}
+	}
 
-// 		return nil, huma.Error500InternalServerError("Fail to create join team request")
-// 	}
+	return &ListTeamInvitationsOutput{Body: invitationsDto}, nil
+}
 
-// 	return &RequestToJoinTeamOutput{Body: request}, nil
-// }
+type GetMyInvitationsOutput struct {
+	Body []MyInvitationDto
+}
 
-// type GetPendingRequestsForTeamOutput struct {
-// 	Body []sqlc.ListJoinRequestsByTeamAndStatusWithUserRow
-// }
+func (h *handler) handleGetMyInvitations(ctx context.Context, input *struct{}) (*GetMyInvitationsOutput, error) {
+	userCtx := ctxutils.GetUserFromCtx(ctx)
 
-// func (h *handler) handleGetPendingRequestsForTeam(ctx context.Context, input *struct {
-// 	TeamId string `path:"teamId"`
-// }) (*GetPendingRequestsForTeamOutput, error) {
-// 	userCtx := ctxutils.GetUserFromCtx(ctx)
+	if userCtx == nil {
+		return nil, huma.Error400BadRequest("Failed to get current user info")
+	}
 
-// 	if userCtx == nil {
-// 		return nil, huma.Error400BadRequest("Failed to get current user info")
-// 	}
+	invitations, err := h.teamService.GetUserInvitations(ctx, userCtx.UserID)
 
-// 	teamId, err := uuid.Parse(input.TeamId)
+	if err != nil {
+		return nil, teamHTTPError(err, "Failed to get user's invitations")
+	}
 
-// 	if err != nil {
-// 		return nil, huma.Error400BadRequest("Invalid team id")
-// 	}
+	invitationsDto := make([]MyInvitationDto, len(invitations))
+	for i, invitation := range invitations {
+		invitationsDto[i] = MyInvitationDto{
+			ID:          invitation.ID,
+			TeamID:      invitation.TeamID,
+			TeamName:    invitation.TeamName,
+			InviterName: invitation.InviterName,
+			ExpiresAt:   invitation.ExpiresAt,
+			CreatedAt:   invitation.CreatedAt,
+		}
+	}
 
-// 	requests, err := h.teamService.GetPendingJoinRequestForTeam(ctx, userCtx.UserID, teamId)
+	return &GetMyInvitationsOutput{Body: invitationsDto}, nil
+}
 
-// 	if err != nil {
-// 		if errors.Is(err, ErrUserNotTeamOwner) {
-// 			return nil, huma.Error403Forbidden("Not authorized to perform this action")
-// 		}
+func (h *handler) handleRevokeInvitation(ctx context.Context, input *struct {
+	InviteId uuid.UUID `path:"inviteId"`
+}) (*struct{}, error) {
+	userCtx := ctxutils.GetUserFromCtx(ctx)
 
-// 		return nil, huma.Error500InternalServerError("Fail to get pending requests for team")
-// 	}
+	if userCtx == nil {
+		return nil, huma.Error400BadRequest("Failed to get current user info")
+	}
 
-// 	return &GetPendingRequestsForTeamOutput{Body: requests}, nil
-// }
+	if err := h.teamService.RevokeInvitation(ctx, userCtx.UserID, input.InviteId); err != nil {
+		return nil, teamHTTPError(err, "Failed to revoke invitation")
+	}
 
-// type GetMyPendingRequestsOutput struct {
-// 	Body []sqlc.TeamJoinRequest
-// }
+	return nil, nil
+}
 
-// func (h *handler) handleGetMyPendingRequests(ctx context.Context, input *struct{}) (*GetMyPendingRequestsOutput, error) {
-// 	userCtx := ctxutils.GetUserFromCtx(ctx)
+func (h *handler) handleDeclineInvitation(ctx context.Context, input *struct {
+	InviteId uuid.UUID `path:"inviteId"`
+}) (*struct{}, error) {
+	userCtx := ctxutils.GetUserFromCtx(ctx)
 
-// 	if userCtx == nil {
-// 		return nil, huma.Error400BadRequest("Failed to get current user info")
-// 	}
-// 	requests, err := h.teamService.GetUserPendingJoinRequests(ctx, userCtx.UserID)
+	if userCtx == nil {
+		return nil, huma.Error400BadRequest("Failed to get current user info")
+	}
 
-// 	if err != nil {
-// 		return nil, huma.Error500InternalServerError("Fail to get user's pending requests")
-// 	}
+	if err := h.teamService.DeclineInvitation(ctx, userCtx.UserID, input.InviteId); err != nil {
+		return nil, teamHTTPError(err, "Failed to decline invitation")
+	}
 
-// 	return &GetMyPendingRequestsOutput{Body: requests}, nil
-// }
+	return nil, nil
+}
 
-// type AcceptTeamJoinRequestOutput struct {
-// 	Status int
-// }
+func toInvitationDto(invitation *sqlc.TeamInvitation) InvitationDto {
+	return InvitationDto{
+		ID:            invitation.ID,
+		InviterID:     invitation.InviterID,
+		InvitedEmail:  invitation.InvitedEmail,
+		InvitedUserID: invitation.InvitedUserID,
+		Status:        invitationStatus(invitation.Status, invitation.ExpiresAt, time.Now()),
+		ExpiresAt:     invitation.ExpiresAt,
+		MaxUses:       invitation.MaxUses,
+		UseCount:      invitation.UseCount,
+		CreatedAt:     invitation.CreatedAt,
+		Uses:          []InvitationUseDto{},
+	}
+}
 
-// func (h *handler) handleAcceptTeamJoinRequest(ctx context.Context, input *struct {
-// 	RequestId string `path:"requestId"`
-// }) (*AcceptTeamJoinRequestOutput, error) {
-// 	userCtx := ctxutils.GetUserFromCtx(ctx)
+type TeamJoinRequestOutput struct {
+	Body TeamJoinRequestDto
+}
 
-// 	if userCtx == nil {
-// 		return nil, huma.Error400BadRequest("Failed to get current user info")
-// 	}
+func (h *handler) handleRequestToJoinTeam(ctx context.Context, input *struct {
+	Body   CreateJoinRequestDto
+	TeamId uuid.UUID `path:"teamId"`
+}) (*TeamJoinRequestOutput, error) {
+	userCtx := ctxutils.GetUserFromCtx(ctx)
 
-// 	requestId, err := uuid.Parse(input.RequestId)
+	if userCtx == nil {
+		return nil, huma.Error400BadRequest("Failed to get current user info")
+	}
 
-// 	if err != nil {
-// 		return nil, huma.Error400BadRequest("Invalid join request id")
-// 	}
+	request, err := h.teamService.RequestToJoinTeam(ctx, input.TeamId, userCtx.UserID, input.Body.Message)
 
-// 	err = h.teamService.RespondToJoinRequest(ctx, userCtx.UserID, requestId, true)
+	if err != nil {
+		return nil, teamHTTPError(err, "Failed to create team join request")
+	}
 
-// 	if err != nil {
-// 		return nil, huma.Error500InternalServerError("Fail to accept team join request")
-// 	}
+	return &TeamJoinRequestOutput{Body: toTeamJoinRequestDto(request)}, nil
+}
 
-// 	return &AcceptTeamJoinRequestOutput{Status: http.StatusOK}, nil
-// }
+type GetPendingRequestsForTeamOutput struct {
+	Body []PendingJoinRequestDto
+}
 
-// type RejectTeamJoinRequestOutput struct {
-// 	Status int
-// }
+func (h *handler) handleGetPendingRequestsForTeam(ctx context.Context, input *struct {
+	TeamId uuid.UUID `path:"teamId"`
+}) (*GetPendingRequestsForTeamOutput, error) {
+	userCtx := ctxutils.GetUserFromCtx(ctx)
 
-// func (h *handler) handleRejectTeamJoinRequest(ctx context.Context, input *struct {
-// 	RequestId string `path:"requestId"`
-// }) (*RejectTeamJoinRequestOutput, error) {
-// 	userCtx := ctxutils.GetUserFromCtx(ctx)
+	if userCtx == nil {
+		return nil, huma.Error400BadRequest("Failed to get current user info")
+	}
 
-// 	if userCtx == nil {
-// 		return nil, huma.Error400BadRequest("Failed to get current user info")
-// 	}
+	requests, err := h.teamService.GetPendingJoinRequestsForTeam(ctx, userCtx.UserID, input.TeamId)
 
-// 	requestId, err := uuid.Parse(input.RequestId)
+	if err != nil {
+		return nil, teamHTTPError(err, "Failed to get pending requests for team")
+	}
 
-// 	if err != nil {
-// 		return nil, huma.Error400BadRequest("Invalid join request id")
-// 	}
+	requestsDto := make([]PendingJoinRequestDto, len(requests))
+	for i, request := range requests {
+		requestsDto[i] = PendingJoinRequestDto{
+			ID:        request.ID,
+			UserID:    request.UserID,
+			Name:      request.Name,
+			Image:     request.Image,
+			Message:   request.RequestMessage,
+			CreatedAt: request.CreatedAt,
+			ExpiresAt: request.ExpiresAt,
+		}
+	}
 
-// 	err = h.teamService.RespondToJoinRequest(ctx, userCtx.UserID, requestId, false)
+	return &GetPendingRequestsForTeamOutput{Body: requestsDto}, nil
+}
 
-// 	if err != nil {
-// 		return nil, huma.Error500InternalServerError("Fail to accept team join request")
-// 	}
+type GetMyPendingRequestsOutput struct {
+	Body []MyJoinRequestDto
+}
 
-// 	return &RejectTeamJoinRequestOutput{Status: http.StatusOK}, nil
-// }
+func (h *handler) handleGetMyPendingRequests(ctx context.Context, input *struct{}) (*GetMyPendingRequestsOutput, error) {
+	userCtx := ctxutils.GetUserFromCtx(ctx)
+
+	if userCtx == nil {
+		return nil, huma.Error400BadRequest("Failed to get current user info")
+	}
+
+	requests, err := h.teamService.GetUserPendingJoinRequests(ctx, userCtx.UserID)
+
+	if err != nil {
+		return nil, teamHTTPError(err, "Failed to get user's pending requests")
+	}
+
+	requestsDto := make([]MyJoinRequestDto, len(requests))
+	for i, request := range requests {
+		requestsDto[i] = MyJoinRequestDto{
+			ID:        request.ID,
+			TeamID:    request.TeamID,
+			TeamName:  request.TeamName,
+			Message:   request.RequestMessage,
+			CreatedAt: request.CreatedAt,
+			ExpiresAt: request.ExpiresAt,
+		}
+	}
+
+	return &GetMyPendingRequestsOutput{Body: requestsDto}, nil
+}
+
+func (h *handler) handleApproveTeamJoinRequest(ctx context.Context, input *struct {
+	RequestId uuid.UUID `path:"requestId"`
+}) (*TeamJoinRequestOutput, error) {
+	return h.respondToJoinRequest(ctx, input.RequestId, true)
+}
+
+func (h *handler) handleRejectTeamJoinRequest(ctx context.Context, input *struct {
+	RequestId uuid.UUID `path:"requestId"`
+}) (*TeamJoinRequestOutput, error) {
+	return h.respondToJoinRequest(ctx, input.RequestId, false)
+}
+
+func (h *handler) respondToJoinRequest(ctx context.Context, requestID uuid.UUID, approve bool) (*TeamJoinRequestOutput, error) {
+	userCtx := ctxutils.GetUserFromCtx(ctx)
+
+	if userCtx == nil {
+		return nil, huma.Error400BadRequest("Failed to get current user info")
+	}
+
+	request, err := h.teamService.RespondToJoinRequest(ctx, userCtx.UserID, requestID, approve)
+
+	if err != nil {
+		return nil, teamHTTPError(err, "Failed to respond to team join request")
+	}
+
+	return &TeamJoinRequestOutput{Body: toTeamJoinRequestDto(request)}, nil
+}
+
+func (h *handler) handleCancelTeamJoinRequest(ctx context.Context, input *struct {
+	RequestId uuid.UUID `path:"requestId"`
+}) (*struct{}, error) {
+	userCtx := ctxutils.GetUserFromCtx(ctx)
+
+	if userCtx == nil {
+		return nil, huma.Error400BadRequest("Failed to get current user info")
+	}
+
+	if err := h.teamService.CancelJoinRequest(ctx, userCtx.UserID, input.RequestId); err != nil {
+		return nil, teamHTTPError(err, "Failed to cancel team join request")
+	}
+
+	return nil, nil
+}
+
+func toTeamJoinRequestDto(request *sqlc.TeamJoinRequest) TeamJoinRequestDto {
+	return TeamJoinRequestDto{
+		ID:        request.ID,
+		TeamID:    request.TeamID,
+		UserID:    request.UserID,
+		Message:   request.RequestMessage,
+		Status:    request.Status,
+		CreatedAt: request.CreatedAt,
+		ExpiresAt: request.ExpiresAt,
+	}
+}
+
+type DiscoverTeamsOutput struct {
+	Body []TeamListingDto
+}
+
+func (h *handler) handleDiscoverTeams(ctx context.Context, input *DiscoverTeamsDto) (*DiscoverTeamsOutput, error) {
+	listings, err := h.teamService.ListTeamListings(ctx, DiscoveryFilter{
+		Search:    input.Search,
+		Skills:    input.Skills,
+		Interests: input.Interests,
+		Limit:     input.Limit,
+		Offset:    input.Offset,
+	}, input.OpenSlots)
+
+	if err != nil {
+		return nil, teamHTTPError(err, "Failed to get team listings")
+	}
+
+	listingsDto := make([]TeamListingDto, len(listings))
+	for i, listing := range listings {
+		var members []TeamMemberDto
+		if err := json.Unmarshal(listing.Members, &members); err != nil {
+			return nil, huma.Error500InternalServerError("Failed to parse team members")
+		}
+
+		listingsDto[i] = TeamListingDto{
+			ID:          listing.ID,
+			Name:        listing.Name,
+			OwnerID:     listing.OwnerID,
+			Description: listing.Description,
+			Skills:      listing.Skills,
+			Interests:   listing.Interests,
+			Members:     members,
+			OpenSlots:   memberLimit(listing.MaxMembers) - listing.MemberCount,
+			UpdatedAt:   listing.UpdatedAt,
+		}
+	}
+
+	return &DiscoverTeamsOutput{Body: listingsDto}, nil
+}
+
+type TeamListingOutput struct {
+	Body TeamListingSettingsDto
+}
+
+func (h *handler) handleGetTeamListing(ctx context.Context, input *struct {
+	TeamId uuid.UUID `path:"teamId"`
+}) (*TeamListingOutput, error) {
+	listing, err := h.teamService.GetTeamListing(ctx, input.TeamId)
+
+	if err != nil {
+		return nil, teamHTTPError(err, "Failed to get team listing")
+	}
+
+	return &TeamListingOutput{Body: toTeamListingSettingsDto(listing)}, nil
+}
+
+func (h *handler) handleUpdateTeamListing(ctx context.Context, input *struct {
+	Body   UpdateTeamListingRequestDto
+	TeamId uuid.UUID `path:"teamId"`
+}) (*TeamListingOutput, error) {
+	userCtx := ctxutils.GetUserFromCtx(ctx)
+
+	if userCtx == nil {
+		return nil, huma.Error400BadRequest("Failed to get current user info")
+	}
+
+	listing, err := h.teamService.UpdateTeamListing(ctx, userCtx.UserID, input.TeamId, input.Body.Description, input.Body.Skills, input.Body.Interests)
+
+	if err != nil {
+		return nil, teamHTTPError(err, "Failed to update team listing")
+	}
+
+	return &TeamListingOutput{Body: toTeamListingSettingsDto(listing)}, nil
+}
+
+func (h *handler) handleDeleteTeamListing(ctx context.Context, input *struct {
+	TeamId uuid.UUID `path:"teamId"`
+}) (*struct{}, error) {
+	userCtx := ctxutils.GetUserFromCtx(ctx)
+
+	if userCtx == nil {
+		return nil, huma.Error400BadRequest("Failed to get current user info")
+	}
+
+	if err := h.teamService.DeleteTeamListing(ctx, userCtx.UserID, input.TeamId); err != nil {
+		return nil, teamHTTPError(err, "Failed to delete team listing")
+	}
+
+	return nil, nil
+}
+
+type DiscoverProfilesOutput struct {
+	Body []TeamProfileDto
+}
+
+func (h *handler) handleDiscoverProfiles(ctx context.Context, input *DiscoverProfilesDto) (*DiscoverProfilesOutput, error) {
+	profiles, err := h.teamService.ListTeamProfiles(ctx, DiscoveryFilter{
+		Search:    input.Search,
+		Skills:    input.Skills,
+		Interests: input.Interests,
+		Limit:     input.Limit,
+		Offset:    input.Offset,
+	})
+
+	if err != nil {
+		return nil, teamHTTPError(err, "Failed to get looking for team profiles")
+	}
+
+	profilesDto := make([]TeamProfileDto, len(profiles))
+	for i, profile := range profiles {
+		profilesDto[i] = TeamProfileDto{
+			UserID:    profile.UserID,
+			Name:      profile.Name,
+			Image:     profile.Image,
+			Bio:       profile.Bio,
+			Skills:    profile.Skills,
+			Interests: profile.Interests,
+			UpdatedAt: profile.UpdatedAt,
+		}
+	}
+
+	return &DiscoverProfilesOutput{Body: profilesDto}, nil
+}
+
+type MyTeamProfileOutput struct {
+	Body MyTeamProfileDto
+}
+
+func (h *handler) handleGetMyTeamProfile(ctx context.Context, input *struct{}) (*MyTeamProfileOutput, error) {
+	userCtx := ctxutils.GetUserFromCtx(ctx)
+
+	if userCtx == nil {
+		return nil, huma.Error400BadRequest("Failed to get current user info")
+	}
+
+	profile, err := h.teamService.GetTeamProfile(ctx, userCtx.UserID)
+
+	if err != nil {
+		return nil, teamHTTPError(err, "Failed to get looking for team profile")
+	}
+
+	return &MyTeamProfileOutput{Body: toMyTeamProfileDto(profile)}, nil
+}
+
+func (h *handler) handleUpdateMyTeamProfile(ctx context.Context, input *struct {
+	Body UpdateTeamProfileRequestDto
+}) (*MyTeamProfileOutput, error) {
+	userCtx := ctxutils.GetUserFromCtx(ctx)
+
+	if userCtx == nil {
+		return nil, huma.Error400BadRequest("Failed to get current user info")
+	}
+
+	profile, err := h.teamService.UpdateTeamProfile(ctx, userCtx.UserID, input.Body.Bio, input.Body.Skills, input.Body.Interests)
+
+	if err != nil {
+		return nil, teamHTTPError(err, "Failed to update looking for team profile")
+	}
+
+	return &MyTeamProfileOutput{Body: toMyTeamProfileDto(profile)}, nil
+}
+
+func (h *handler) handleDeleteMyTeamProfile(ctx context.Context, input *struct{}) (*struct{}, error) {
+	userCtx := ctxutils.GetUserFromCtx(ctx)
+
+	if userCtx == nil {
+		return nil, huma.Error400BadRequest("Failed to get current user info")
+	}
+
+	if err := h.teamService.DeleteTeamProfile(ctx, userCtx.UserID); err != nil {
+		return nil, teamHTTPError(err, "Failed to delete looking for team profile")
+	}
+
+	return nil, nil
+}
+
+func (h *handler) handleInviteFromBoard(ctx context.Context, input *struct {
+	UserId uuid.UUID `path:"userId"`
+}) (*struct{}, error) {
+	userCtx := ctxutils.GetUserFromCtx(ctx)
+
+	if userCtx == nil {
+		return nil, huma.Error400BadRequest("Failed to get current user info")
+	}
+
+	if err := h.teamService.InviteFromBoard(ctx, userCtx.UserID, input.UserId); err != nil {
+		return nil, teamHTTPError(err, "Failed to invite user to team")
+	}
+
+	return nil, nil
+}
+
+func toTeamListingSettingsDto(listing *sqlc.TeamListing) TeamListingSettingsDto {
+	return TeamListingSettingsDto{
+		TeamID:      listing.TeamID,
+		Description: listing.Description,
+		Skills:      listing.Skills,
+		Interests:   listing.Interests,
+		UpdatedAt:   listing.UpdatedAt,
+	}
+}
+
+func toMyTeamProfileDto(profile *sqlc.LookingForTeamProfile) MyTeamProfileDto {
+	return MyTeamProfileDto{
+		Bio:       profile.Bio,
+		Skills:    profile.Skills,
+		Interests: profile.Interests,
+		UpdatedAt: profile.UpdatedAt,
+	}
+}
+
+func teamHTTPError(err error, fallback string) error {
+	if errors.Is(err, ErrNoTeamFound) ||
+		errors.Is(err, ErrNotInTeam) ||
+		errors.Is(err, ErrJoinRequestNotFound) ||
+		errors.Is(err, ErrTeamListingNotFound) ||
+		errors.Is(err, ErrTeamProfileNotFound) ||
+		errors.Is(err, ErrNoActiveHackathon) ||
+		errors.Is(err, ErrInvitationNotFound) {
+		return huma.Error404NotFound(err.Error())
+	}
+
+	if errors.Is(err, ErrUserNotTeamOwner) ||
+		errors.Is(err, ErrInvitationNotForYou) {
+		return huma.Error403Forbidden(err.Error())
+	}
+
+	if errors.Is(err, ErrInvitationExpiry) ||
+		errors.Is(err, ErrTransferToSelf) ||
+		errors.Is(err, ErrNotTeamMember) ||
+		errors.Is(err, ErrMergeSameTeam) {
+		return huma.Error400BadRequest(err.Error())
+	}
+
+	if errors.Is(err, ErrJoinRequestExists) ||
+		errors.Is(err, ErrJoinRequestClosed) ||
+		errors.Is(err, ErrMembersLimitReached) ||
+		errors.Is(err, ErrJoinSameTeam) ||
+		errors.Is(err, ErrAlreadyHasTeam) ||
+		errors.Is(err, ErrInvitationClosed) ||
+		errors.Is(err, ErrInvitationExists) {
+		return huma.Error409Conflict(err.Error())
+	}
+
+	return huma.Error500InternalServerError(fallback)
+}
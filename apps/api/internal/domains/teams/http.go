@@ -92,15 +92,28 @@ func RegisterRoutes(teamHandler *handler, group huma.API, mw *middleware.Middlew
 		OperationID:   "leave-team",
 		Method:        http.MethodPost,
 		Summary:       "Leave Team",
-		Description:   "Leaves a team if the user is on the team.",
+		Description:   "Leaves a team if the user is on the team. If the owner leaves, the member who has been on the team the longest becomes the owner, and the team is deleted if the owner was its last member.",
 		Tags:          []string{"Team"},
 		Path:          "/{teamId}/leave",
 		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma},
-		Errors:        []int{http.StatusUnauthorized, http.StatusBadRequest, http.StatusInternalServerError},
+		Errors:        []int{http.StatusUnauthorized, http.StatusNotFound, http.StatusBadRequest, http.StatusInternalServerError},
 		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
 		DefaultStatus: http.StatusOK,
 	}, teamHandler.handleLeaveTeam)
 
+	huma.Register(group, huma.Operation{
+		OperationID:   "transfer-team-ownership",
+		Method:        http.MethodPost,
+		Summary:       "Transfer Team Ownership",
+		Description:   "Makes another member of the team its owner. Only the team owner can perform this action.",
+		Tags:          []string{"Team"},
+		Path:          "/{teamId}/transfer-ownership",
+		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma},
+		Errors:        []int{http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound, http.StatusBadRequest, http.StatusInternalServerError},
+		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
+		DefaultStatus: http.StatusOK,
+	}, teamHandler.handleTransferOwnership)
+
 	huma.Register(group, huma.Operation{
 		OperationID:   "kick-member",
 		Method:        http.MethodPost,
@@ -118,11 +131,11 @@ func RegisterRoutes(teamHandler *handler, group huma.API, mw *middleware.Middlew
 		OperationID:   "join-team",
 		Method:        http.MethodPost,
 		Summary:       "Join Team",
-		Description:   "Join a team through an invitation link.",
+		Description:   "Join a team through an invitation. Invitations sent to a person can only be used by them, and expired, revoked or used up invitations can't be used.",
 		Tags:          []string{"Team"},
 		Path:          "/join/{id}",
 		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma},
-		Errors:        []int{http.StatusUnauthorized, http.StatusBadRequest, http.StatusInternalServerError},
+		Errors:        []int{http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound, http.StatusConflict, http.StatusBadRequest, http.StatusInternalServerError},
 		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
 		DefaultStatus: http.StatusOK,
 	}, teamHandler.handleJoinTeam)
@@ -131,11 +144,11 @@ func RegisterRoutes(teamHandler *handler, group huma.API, mw *middleware.Middlew
 		OperationID:   "create-invitation",
 		Method:        http.MethodPost,
 		Summary:       "Create Invitation",
-		Description:   "Create an invitation for other users to join the team",
+		Description:   "Create an invite link for other users to join the team. It can expire and be limited to a number of uses. Only the team owner can perform this action.",
 		Tags:          []string{"Team"},
 		Path:          "/{teamId}/invitation",
 		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma},
-		Errors:        []int{http.StatusUnauthorized, http.StatusBadRequest, http.StatusInternalServerError},
+		Errors:        []int{http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound, http.StatusBadRequest, http.StatusInternalServerError},
 		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
 		DefaultStatus: http.StatusOK,
 	}, teamHandler.handleCreateInvitation)
@@ -144,14 +157,274 @@ func RegisterRoutes(teamHandler *handler, group huma.API, mw *middleware.Middlew
 		OperationID:   "get-invitation",
 		Method:        http.MethodGet,
 		Summary:       "Get Invitation",
-		Description:   "Get an invitation id, which can be used to construct a team join link",
+		Description:   "Get the id of the newest invite link of the team that can still be used, which can be used to construct a team join link",
 		Tags:          []string{"Team"},
 		Path:          "/{teamId}/invitation",
 		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma},
-		Errors:        []int{http.StatusUnauthorized, http.StatusBadRequest, http.StatusInternalServerError},
+		Errors:        []int{http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound, http.StatusBadRequest, http.StatusInternalServerError},
 		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
 		DefaultStatus: http.StatusOK,
 	}, teamHandler.handleGetInvitation)
+
+	huma.Register(group, huma.Operation{
+		OperationID:   "list-team-invitations",
+		Method:        http.MethodGet,
+		Summary:       "List Team Invitations",
+		Description:   "Returns every invitation of a team and who joined through them. Only the team owner can perform this action.",
+		Tags:          []string{"Team"},
+		Path:          "/{teamId}/invitations",
+		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma},
+		Errors:        []int{http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound, http.StatusBadRequest, http.StatusInternalServerError},
+		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
+		DefaultStatus: http.StatusOK,
+	}, teamHandler.handleListTeamInvitations)
+
+	huma.Register(group, huma.Operation{
+		OperationID:   "invite-by-email",
+		Method:        http.MethodPost,
+		Summary:       "Invite By Email",
+		Description:   "Emails an invitation that only the person with that email can use, once. They don't need an account yet. Only the team owner can perform this action.",
+		Tags:          []string{"Team"},
+		Path:          "/{teamId}/invitations/email",
+		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma},
+		Errors:        []int{http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound, http.StatusConflict, http.StatusBadRequest, http.StatusInternalServerError},
+		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
+		DefaultStatus: http.StatusCreated,
+	}, teamHandler.handleInviteByEmail)
+
+	huma.Register(group, huma.Operation{
+		OperationID:   "get-my-invitations",
+		Method:        http.MethodGet,
+		Summary:       "Get My Invitations",
+		Description:   "Returns the open invitations sent to the current user",
+		Tags:          []string{"Team"},
+		Path:          "/invitations/me",
+		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma},
+		Errors:        []int{http.StatusUnauthorized, http.StatusBadRequest, http.StatusInternalServerError},
+		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
+		DefaultStatus: http.StatusOK,
+	}, teamHandler.handleGetMyInvitations)
+
+	huma.Register(group, huma.Operation{
+		OperationID:   "revoke-invitation",
+		Method:        http.MethodDelete,
+		Summary:       "Revoke Invitation",
+		Description:   "Stops an invitation from being used. Only the team owner can perform this action.",
+		Tags:          []string{"Team"},
+		Path:          "/invitation/{inviteId}",
+		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma},
+		Errors:        []int{http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound, http.StatusConflict, http.StatusBadRequest, http.StatusInternalServerError},
+		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
+		DefaultStatus: http.StatusNoContent,
+	}, teamHandler.handleRevokeInvitation)
+
+	huma.Register(group, huma.Operation{
+		OperationID:   "decline-invitation",
+		Method:        http.MethodPost,
+		Summary:       "Decline Invitation",
+		Description:   "Turns down an invitation sent to the current user",
+		Tags:          []string{"Team"},
+		Path:          "/invitation/{inviteId}/decline",
+		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma},
+		Errors:        []int{http.StatusUnauthorized, http.StatusNotFound, http.StatusConflict, http.StatusBadRequest, http.StatusInternalServerError},
+		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
+		DefaultStatus: http.StatusNoContent,
+	}, teamHandler.handleDeclineInvitation)
+
+	huma.Register(group, huma.Operation{
+		OperationID:   "request-to-join-team",
+		Method:        http.MethodPost,
+		Summary:       "Request To Join Team",
+		Description:   "Asks the team owner to let the current user join. The owner is emailed, and the request expires if they don't answer in time.",
+		Tags:          []string{"Team"},
+		Path:          "/{teamId}/join-requests",
+		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma},
+		Errors:        []int{http.StatusUnauthorized, http.StatusNotFound, http.StatusConflict, http.StatusBadRequest, http.StatusInternalServerError},
+		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
+		DefaultStatus: http.StatusCreated,
+	}, teamHandler.handleRequestToJoinTeam)
+
+	huma.Register(group, huma.Operation{
+		OperationID:   "get-team-join-requests",
+		Method:        http.MethodGet,
+		Summary:       "Get Team Join Requests",
+		Description:   "Returns the pending join requests of a team. Only the team owner can perform this action.",
+		Tags:          []string{"Team"},
+		Path:          "/{teamId}/join-requests",
+		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma},
+		Errors:        []int{http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound, http.StatusBadRequest, http.StatusInternalServerError},
+		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
+		DefaultStatus: http.StatusOK,
+	}, teamHandler.handleGetPendingRequestsForTeam)
+
+	huma.Register(group, huma.Operation{
+		OperationID:   "get-my-join-requests",
+		Method:        http.MethodGet,
+		Summary:       "Get My Join Requests",
+		Description:   "Returns the pending join requests the current user made.",
+		Tags:          []string{"Team"},
+		Path:          "/join-requests/me",
+		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma},
+		Errors:        []int{http.StatusUnauthorized, http.StatusBadRequest, http.StatusInternalServerError},
+		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
+		DefaultStatus: http.StatusOK,
+	}, teamHandler.handleGetMyPendingRequests)
+
+	huma.Register(group, huma.Operation{
+		OperationID:   "approve-team-join-request",
+		Method:        http.MethodPost,
+		Summary:       "Approve Team Join Request",
+		Description:   "Approves a join request and adds the requester to the team, unless the team is full. Only the team owner can perform this action.",
+		Tags:          []string{"Team"},
+		Path:          "/join-requests/{requestId}/approve",
+		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma},
+		Errors:        []int{http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound, http.StatusConflict, http.StatusBadRequest, http.StatusInternalServerError},
+		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
+		DefaultStatus: http.StatusOK,
+	}, teamHandler.handleApproveTeamJoinRequest)
+
+	huma.Register(group, huma.Operation{
+		OperationID:   "reject-team-join-request",
+		Method:        http.MethodPost,
+		Summary:       "Reject Team Join Request",
+		Description:   "Rejects a join request. Only the team owner can perform this action.",
+		Tags:          []string{"Team"},
+		Path:          "/join-requests/{requestId}/reject",
+		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma},
+		Errors:        []int{http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound, http.StatusConflict, http.StatusBadRequest, http.StatusInternalServerError},
+		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
+		DefaultStatus: http.StatusOK,
+	}, teamHandler.handleRejectTeamJoinRequest)
+
+	huma.Register(group, huma.Operation{
+		OperationID:   "cancel-team-join-request",
+		Method:        http.MethodDelete,
+		Summary:       "Cancel Team Join Request",
+		Description:   "Withdraws one of the current user's pending join requests.",
+		Tags:          []string{"Team"},
+		Path:          "/join-requests/{requestId}",
+		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma},
+		Errors:        []int{http.StatusUnauthorized, http.StatusNotFound, http.StatusConflict, http.StatusBadRequest, http.StatusInternalServerError},
+		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
+		DefaultStatus: http.StatusNoContent,
+	}, teamHandler.handleCancelTeamJoinRequest)
+
+	huma.Register(group, huma.Operation{
+		OperationID:   "discover-teams",
+		Method:        http.MethodGet,
+		Summary:       "Discover Teams",
+		Description:   "Returns the teams listed on the discovery board for the active hackathon. Filters by search text, skills and interests, and by default only returns teams with an open spot.",
+		Tags:          []string{"Team"},
+		Path:          "/discover/teams",
+		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma},
+		Errors:        []int{http.StatusUnauthorized, http.StatusNotFound, http.StatusBadRequest, http.StatusInternalServerError},
+		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
+		DefaultStatus: http.StatusOK,
+	}, teamHandler.handleDiscoverTeams)
+
+	huma.Register(group, huma.Operation{
+		OperationID:   "discover-team-profiles",
+		Method:        http.MethodGet,
+		Summary:       "Discover Hackers Looking For Teams",
+		Description:   "Returns the profiles of hackers looking for a team in the active hackathon. Hackers who joined a team are left out.",
+		Tags:          []string{"Team"},
+		Path:          "/discover/profiles",
+		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma},
+		Errors:        []int{http.StatusUnauthorized, http.StatusNotFound, http.StatusBadRequest, http.StatusInternalServerError},
+		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
+		DefaultStatus: http.StatusOK,
+	}, teamHandler.handleDiscoverProfiles)
+
+	huma.Register(group, huma.Operation{
+		OperationID:   "get-my-team-profile",
+		Method:        http.MethodGet,
+		Summary:       "Get My Looking For Team Profile",
+		Description:   "Returns the current user's looking for team profile for the active hackathon.",
+		Tags:          []string{"Team"},
+		Path:          "/discover/profiles/me",
+		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma},
+		Errors:        []int{http.StatusUnauthorized, http.StatusNotFound, http.StatusBadRequest, http.StatusInternalServerError},
+		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
+		DefaultStatus: http.StatusOK,
+	}, teamHandler.handleGetMyTeamProfile)
+
+	huma.Register(group, huma.Operation{
+		OperationID:   "update-my-team-profile",
+		Method:        http.MethodPut,
+		Summary:       "Update My Looking For Team Profile",
+		Description:   "Puts the current user on the discovery board as looking for a team, or updates their profile. Users already on a team can't.",
+		Tags:          []string{"Team"},
+		Path:          "/discover/profiles/me",
+		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma},
+		Errors:        []int{http.StatusUnauthorized, http.StatusNotFound, http.StatusConflict, http.StatusBadRequest, http.StatusInternalServerError},
+		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
+		DefaultStatus: http.StatusOK,
+	}, teamHandler.handleUpdateMyTeamProfile)
+
+	huma.Register(group, huma.Operation{
+		OperationID:   "delete-my-team-profile",
+		Method:        http.MethodDelete,
+		Summary:       "Delete My Looking For Team Profile",
+		Description:   "Takes the current user off the discovery board.",
+		Tags:          []string{"Team"},
+		Path:          "/discover/profiles/me",
+		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma},
+		Errors:        []int{http.StatusUnauthorized, http.StatusNotFound, http.StatusBadRequest, http.StatusInternalServerError},
+		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
+		DefaultStatus: http.StatusNoContent,
+	}, teamHandler.handleDeleteMyTeamProfile)
+
+	huma.Register(group, huma.Operation{
+		OperationID:   "invite-team-profile",
+		Method:        http.MethodPost,
+		Summary:       "Invite Hacker To Team",
+		Description:   "Emails a hacker on the discovery board an invitation to the current user's team that only they can use. Only the team owner can perform this action.",
+		Tags:          []string{"Team"},
+		Path:          "/discover/profiles/{userId}/invite",
+		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma},
+		Errors:        []int{http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound, http.StatusConflict, http.StatusBadRequest, http.StatusInternalServerError},
+		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
+		DefaultStatus: http.StatusNoContent,
+	}, teamHandler.handleInviteFromBoard)
+
+	huma.Register(group, huma.Operation{
+		OperationID:   "get-team-listing",
+		Method:        http.MethodGet,
+		Summary:       "Get Team Listing",
+		Description:   "Returns a team's listing on the discovery board for the active hackathon.",
+		Tags:          []string{"Team"},
+		Path:          "/{teamId}/listing",
+		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma},
+		Errors:        []int{http.StatusUnauthorized, http.StatusNotFound, http.StatusBadRequest, http.StatusInternalServerError},
+		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
+		DefaultStatus: http.StatusOK,
+	}, teamHandler.handleGetTeamListing)
+
+	huma.Register(group, huma.Operation{
+		OperationID:   "update-team-listing",
+		Method:        http.MethodPut,
+		Summary:       "Update Team Listing",
+		Description:   "Lists a team on the discovery board for the active hackathon, or updates its listing. Only the team owner can perform this action.",
+		Tags:          []string{"Team"},
+		Path:          "/{teamId}/listing",
+		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma},
+		Errors:        []int{http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound, http.StatusBadRequest, http.StatusInternalServerError},
+		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
+		DefaultStatus: http.StatusOK,
+	}, teamHandler.handleUpdateTeamListing)
+
+	huma.Register(group, huma.Operation{
+		OperationID:   "delete-team-listing",
+		Method:        http.MethodDelete,
+		Summary:       "Delete Team Listing",
+		Description:   "Takes a team off the discovery board. Only the team owner can perform this action.",
+		Tags:          []string{"Team"},
+		Path:          "/{teamId}/listing",
+		Middlewares:   huma.Middlewares{mw.Auth.RequireAuthHuma},
+		Errors:        []int{http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound, http.StatusBadRequest, http.StatusInternalServerError},
+		Parameters:    []*huma.Param{cookie.SessionCookieHumaParam},
+		DefaultStatus: http.StatusNoContent,
+	}, teamHandler.handleDeleteTeamListing)
 }
 
 type handler struct {
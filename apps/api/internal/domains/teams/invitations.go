@@ -0,0 +1,402 @@
+package teams
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/swamphacks/core/apps/api/internal/database/sqlc"
+)
+
+// InvitationOptions limits how an invite link is used. Nil fields mean it doesn't expire
+// or anyone can use it.
+type InvitationOptions struct {
+	ExpiresAt *time.Time
+	MaxUses   *int32
+}
+
+// isTargeted reports whether an invitation was sent to a person instead of being an open
+// invite link.
+func isTargeted(invitation sqlc.TeamInvitation) bool {
+	return invitation.InvitedUserID != nil || invitation.InvitedEmail != nil
+}
+
+// isInvitedUser reports whether a user may use an invitation. Open invite links can be
+// used by anyone, invitations sent to a person only by the invited account or an account
+// with the invited email. Only the account email counts, the preferred email is whatever
+// the user typed in and was never verified. GetUserInvitations lists invitations by the
+// same rule.
+func isInvitedUser(invitation sqlc.TeamInvitation, user sqlc.User) bool {
+	if !isTargeted(invitation) {
+		return true
+	}
+
+	if invitation.InvitedUserID != nil && *invitation.InvitedUserID == user.ID {
+		return true
+	}
+
+	return invitation.InvitedEmail != nil &&
+		user.Email != nil &&
+		strings.EqualFold(*user.Email, *invitation.InvitedEmail)
+}
+
+// invitationStatus is the status of an invitation as users see it. Pending invitations
+// past their expiry are expired even though nothing marks them so.
+func invitationStatus(status sqlc.TeamInvitationStatus, expiresAt *time.Time, now time.Time) sqlc.TeamInvitationStatus {
+	if status == sqlc.TeamInvitationStatusPending && expiresAt != nil && !expiresAt.After(now) {
+		return sqlc.TeamInvitationStatusExpired
+	}
+	return status
+}
+
+func (s *TeamService) GetInvitation(ctx context.Context, id uuid.UUID) (*sqlc.TeamInvitation, error) {
+	invitation, err := s.db.Query.GetInvitationByID(ctx, id)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrInvitationNotFound
+		}
+		s.logger.Err(err).Msg("fail to get invitation")
+		return nil, ErrGetInvitation
+	}
+
+	return &invitation, nil
+}
+
+// GetInvitationByTeamID returns the newest invite link of a team that can still be used.
+// Only the owner can perform this action.
+func (s *TeamService) GetInvitationByTeamID(ctx context.Context, teamID, userID uuid.UUID) (*sqlc.TeamInvitation, error) {
+	team, err := s.db.Query.GetTeamById(ctx, teamID)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNoTeamFound
+		}
+		s.logger.Err(err).Msg("fail to get team by id")
+		return nil, ErrGetInvitation
+	}
+
+	if team.OwnerID != userID {
+		return nil, ErrUserNotTeamOwner
+	}
+
+	invitation, err := s.db.Query.GetActiveInviteLinkByTeamID(ctx, teamID)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrInvitationNotFound
+		}
+		s.logger.Err(err).Msg("fail to get invitation by team id")
+		return nil, ErrGetInvitation
+	}
+
+	return &invitation, nil
+}
+
+// ListTeamInvitations returns every invitation of a team and who used them. Only the
+// owner can perform this action.
+func (s *TeamService) ListTeamInvitations(ctx context.Context, userID, teamID uuid.UUID) ([]sqlc.ListTeamInvitationsRow, error) {
+	team, err := s.db.Query.GetTeamById(ctx, teamID)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNoTeamFound
+		}
+		s.logger.Err(err).Msg("ListTeamInvitations fail, unable to get team info by id")
+		return nil, ErrGetInvitation
+	}
+
+	if team.OwnerID != userID {
+		return nil, ErrUserNotTeamOwner
+	}
+
+	invitations, err := s.db.Query.ListTeamInvitations(ctx, teamID)
+
+	if err != nil {
+		s.logger.Err(err).Msg("ListTeamInvitations fail")
+		return nil, ErrGetInvitation
+	}
+
+	return invitations, nil
+}
+
+// CreateInvitation creates an invite link for a team. Only the owner can perform this
+// action.
+func (s *TeamService) CreateInvitation(ctx context.Context, userID, teamID uuid.UUID, options InvitationOptions) (*sqlc.TeamInvitation, error) {
+	team, err := s.db.Query.GetTeamById(ctx, teamID)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNoTeamFound
+		}
+		s.logger.Err(err).Msg("CreateInvitation fail, unable to get team info by id")
+		return nil, ErrCreateInvitation
+	}
+
+	if team.OwnerID != userID {
+		return nil, ErrUserNotTeamOwner
+	}
+
+	if options.ExpiresAt != nil && !options.ExpiresAt.After(time.Now()) {
+		return nil, ErrInvitationExpiry
+	}
+
+	invitation, err := s.db.Query.CreateInvitation(ctx, sqlc.CreateInvitationParams{
+		TeamID:    teamID,
+		InviterID: userID,
+		ExpiresAt: options.ExpiresAt,
+		MaxUses:   options.MaxUses,
+	})
+
+	if err != nil {
+		s.logger.Err(err).Msg("fail to create invitation")
+		return nil, ErrCreateInvitation
+	}
+
+	return &invitation, nil
+}
+
+// InviteByEmail sends an invitation that only the person with that email can use, once.
+// They don't need an account yet. Only the owner can perform this action.
+func (s *TeamService) InviteByEmail(ctx context.Context, ownerID, teamID uuid.UUID, email string, expiresAt *time.Time) (*sqlc.TeamInvitation, error) {
+	team, err := s.db.Query.GetTeamById(ctx, teamID)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNoTeamFound
+		}
+		s.logger.Err(err).Msg("InviteByEmail fail, unable to get team info by id")
+		return nil, ErrInviteToTeam
+	}
+
+	if team.OwnerID != ownerID {
+		return nil, ErrUserNotTeamOwner
+	}
+
+	if expiresAt != nil && !expiresAt.After(time.Now()) {
+		return nil, ErrInvitationExpiry
+	}
+
+	email = strings.ToLower(strings.TrimSpace(email))
+
+	var invitedUserID *uuid.UUID
+
+	user, err := s.db.Query.GetUserByEmail(ctx, &email)
+
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		// No account with this email yet, the invitation is matched by email when they join.
+
+	case err != nil:
+		s.logger.Err(err).Msg("InviteByEmail fail, unable to get user by email")
+		return nil, ErrInviteToTeam
+
+	default:
+		invitedUserID = &user.ID
+	}
+
+	return s.invitePerson(ctx, team, ownerID, invitedUserID, &email, expiresAt)
+}
+
+// invitePerson creates a single use invitation for a user or an email and emails it to
+// them. Members of the team can't be invited, and neither can people who already have
+// an open invitation to it.
+func (s *TeamService) invitePerson(ctx context.Context, team sqlc.Team, inviterID uuid.UUID, userID *uuid.UUID, email *string, expiresAt *time.Time) (*sqlc.TeamInvitation, error) {
+	if userID != nil {
+		currentTeam, err := s.db.Query.GetTeamByUserId(ctx, *userID)
+
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			// User is not on a team, continue inviting.
+
+		case err != nil:
+			s.logger.Err(err).Msg("invitePerson fail, unable to get team by user id")
+			return nil, ErrInviteToTeam
+
+		case currentTeam.ID == team.ID:
+			return nil, ErrJoinSameTeam
+		}
+	}
+
+	count, err := s.db.Query.CountTeamMembers(ctx, team.ID)
+
+	if err != nil {
+		s.logger.Err(err).Msg("invitePerson fail, unable to count team members")
+		return nil, ErrInviteToTeam
+	}
+
+	if count >= memberLimit(team.MaxMembers) {
+		return nil, ErrMembersLimitReached
+	}
+
+	_, err = s.db.Query.GetPendingInvitationTo(ctx, sqlc.GetPendingInvitationToParams{
+		TeamID: team.ID,
+		UserID: userID,
+		Email:  email,
+	})
+
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		// No open invitation yet, continue inviting.
+
+	case err != nil:
+		s.logger.Err(err).Msg("invitePerson fail, unable to get pending invitation")
+		return nil, ErrInviteToTeam
+
+	default:
+		return nil, ErrInvitationExists
+	}
+
+	maxUses := int32(1)
+
+	invitation, err := s.db.Query.CreateInvitation(ctx, sqlc.CreateInvitationParams{
+		TeamID:        team.ID,
+		InviterID:     inviterID,
+		ExpiresAt:     expiresAt,
+		MaxUses:       &maxUses,
+		InvitedEmail:  email,
+		InvitedUserID: userID,
+	})
+
+	if err != nil {
+		s.logger.Err(err).Msg("invitePerson fail")
+		return nil, ErrInviteToTeam
+	}
+
+	// The invitation stands even if it can't be emailed, users see it in the portal.
+	if err := s.emailInvitation(ctx, team.Name, invitation); err != nil {
+		s.logger.Err(err).Msg("invitePerson: unable to email invitation")
+	}
+
+	return &invitation, nil
+}
+
+// emailInvitation emails an invitation to the person it was sent to. Users with an
+// account get it at their contact email.
+func (s *TeamService) emailInvitation(ctx context.Context, teamName string, invitation sqlc.TeamInvitation) error {
+	inviter, err := s.db.Query.GetUserEmailInfoById(ctx, invitation.InviterID)
+
+	if err != nil {
+		return err
+	}
+
+	var recipient, name string
+
+	if invitation.InvitedEmail != nil {
+		recipient = *invitation.InvitedEmail
+	}
+
+	if invitation.InvitedUserID != nil {
+		invitee, err := s.db.Query.GetUserEmailInfoById(ctx, *invitation.InvitedUserID)
+
+		if err != nil {
+			return err
+		}
+
+		if contactEmail, ok := invitee.ContactEmail.(string); ok {
+			recipient = contactEmail
+		}
+		name = invitee.Name
+	}
+
+	if recipient == "" {
+		return errors.New("invitee has no email")
+	}
+
+	inviteLink := strings.TrimRight(s.config.ClientUrl, "/") + "/team/join/" + invitation.ID.String()
+
+	return s.emailService.QueueTeamInvitationEmail(ctx, recipient, name, invitation.ID, inviter.Name, teamName, inviteLink)
+}
+
+// GetUserInvitations returns the open invitations sent to a user.
+func (s *TeamService) GetUserInvitations(ctx context.Context, userID uuid.UUID) ([]sqlc.ListPendingInvitationsForUserRow, error) {
+	user, err := s.db.Query.GetUserByID(ctx, userID)
+
+	if err != nil {
+		s.logger.Err(err).Msg("GetUserInvitations fail, unable to get user")
+		return nil, ErrGetInvitation
+	}
+
+	var email string
+	if user.Email != nil {
+		email = *user.Email
+	}
+
+	invitations, err := s.db.Query.ListPendingInvitationsForUser(ctx, sqlc.ListPendingInvitationsForUserParams{
+		UserID: userID,
+		Email:  email,
+	})
+
+	if err != nil {
+		s.logger.Err(err).Msg("GetUserInvitations fail")
+		return nil, ErrGetInvitation
+	}
+
+	return invitations, nil
+}
+
+// RevokeInvitation stops an invitation from being used. Only the owner can perform this
+// action.
+func (s *TeamService) RevokeInvitation(ctx context.Context, userID, invitationID uuid.UUID) error {
+	invitation, err := s.GetInvitation(ctx, invitationID)
+
+	if err != nil {
+		return err
+	}
+
+	team, err := s.db.Query.GetTeamById(ctx, invitation.TeamID)
+
+	if err != nil {
+		s.logger.Err(err).Msg("RevokeInvitation fail, unable to get team info by id")
+		return ErrUpdateInvitation
+	}
+
+	if team.OwnerID != userID {
+		return ErrUserNotTeamOwner
+	}
+
+	return s.closeInvitation(ctx, invitationID, sqlc.TeamInvitationStatusRevoked)
+}
+
+// DeclineInvitation turns down an invitation sent to the user. Other users' invitations
+// and open invite links are reported as missing.
+func (s *TeamService) DeclineInvitation(ctx context.Context, userID, invitationID uuid.UUID) error {
+	invitation, err := s.GetInvitation(ctx, invitationID)
+
+	if err != nil {
+		return err
+	}
+
+	user, err := s.db.Query.GetUserByID(ctx, userID)
+
+	if err != nil {
+		s.logger.Err(err).Msg("DeclineInvitation fail, unable to get user")
+		return ErrUpdateInvitation
+	}
+
+	if !isTargeted(*invitation) || !isInvitedUser(*invitation, user) {
+		return ErrInvitationNotFound
+	}
+
+	return s.closeInvitation(ctx, invitationID, sqlc.TeamInvitationStatusRejected)
+}
+
+func (s *TeamService) closeInvitation(ctx context.Context, invitationID uuid.UUID, status sqlc.TeamInvitationStatus) error {
+	_, err := s.db.Query.UpdateInvitationStatus(ctx, sqlc.UpdateInvitationStatusParams{
+		Status: status,
+		ID:     invitationID,
+	})
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrInvitationClosed
+		}
+		s.logger.Err(err).Msg("closeInvitation fail")
+		return ErrUpdateInvitation
+	}
+
+	return nil
+}
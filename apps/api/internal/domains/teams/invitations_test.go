@@ -0,0 +1,115 @@
+package teams
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/swamphacks/core/apps/api/internal/database/sqlc"
+)
+
+func TestIsInvitedUser(t *testing.T) {
+	userID := uuid.New()
+	email := "Gator@ufl.edu"
+	otherEmail := "albert@ufl.edu"
+	invitedEmail := "gator@ufl.edu"
+	otherID := uuid.New()
+
+	tests := []struct {
+		name       string
+		invitation sqlc.TeamInvitation
+		user       sqlc.User
+		expected   bool
+	}{
+		{
+			name:       "open invite link",
+			invitation: sqlc.TeamInvitation{},
+			user:       sqlc.User{ID: userID},
+			expected:   true,
+		},
+		{
+			name:       "invited account",
+			invitation: sqlc.TeamInvitation{InvitedUserID: &userID},
+			user:       sqlc.User{ID: userID},
+			expected:   true,
+		},
+		{
+			name:       "other account",
+			invitation: sqlc.TeamInvitation{InvitedUserID: &otherID},
+			user:       sqlc.User{ID: userID, Email: &email},
+			expected:   false,
+		},
+		{
+			name:       "invited email in another case",
+			invitation: sqlc.TeamInvitation{InvitedEmail: &invitedEmail},
+			user:       sqlc.User{ID: userID, Email: &email},
+			expected:   true,
+		},
+		{
+			name:       "unverified preferred email",
+			invitation: sqlc.TeamInvitation{InvitedEmail: &invitedEmail},
+			user:       sqlc.User{ID: userID, Email: &otherEmail, PreferredEmail: &email},
+			expected:   false,
+		},
+		{
+			name:       "other email",
+			invitation: sqlc.TeamInvitation{InvitedEmail: &invitedEmail},
+			user:       sqlc.User{ID: userID, Email: &otherEmail},
+			expected:   false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if invited := isInvitedUser(test.invitation, test.user); invited != test.expected {
+				t.Fatalf("expected %v, got %v", test.expected, invited)
+			}
+		})
+	}
+}
+
+func TestInvitationStatus(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+
+	tests := []struct {
+		name      string
+		status    sqlc.TeamInvitationStatus
+		expiresAt *time.Time
+		expected  sqlc.TeamInvitationStatus
+	}{
+		{
+			name:      "pending without expiry",
+			status:    sqlc.TeamInvitationStatusPending,
+			expiresAt: nil,
+			expected:  sqlc.TeamInvitationStatusPending,
+		},
+		{
+			name:      "pending before expiry",
+			status:    sqlc.TeamInvitationStatusPending,
+			expiresAt: &future,
+			expected:  sqlc.TeamInvitationStatusPending,
+		},
+		{
+			name:      "pending past expiry",
+			status:    sqlc.TeamInvitationStatusPending,
+			expiresAt: &past,
+			expected:  sqlc.TeamInvitationStatusExpired,
+		},
+		{
+			name:      "revoked past expiry",
+			status:    sqlc.TeamInvitationStatusRevoked,
+			expiresAt: &past,
+			expected:  sqlc.TeamInvitationStatusRevoked,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if status := invitationStatus(test.status, test.expiresAt, now); status != test.expected {
+				t.Fatalf("expected %v, got %v", test.expected, status)
+			}
+		})
+	}
+}
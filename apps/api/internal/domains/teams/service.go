@@ -3,28 +3,49 @@ package teams
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/rs/zerolog"
+	"github.com/swamphacks/core/apps/api/internal/config"
 	"github.com/swamphacks/core/apps/api/internal/database"
 	"github.com/swamphacks/core/apps/api/internal/database/sqlc"
+	"github.com/swamphacks/core/apps/api/internal/domains/email"
 )
 
+// maxTeamMembers is how many members a team can have, owner included, unless staff
+// set a different limit for it.
+const maxTeamMembers = 4
+
+// memberLimit returns how many members a team with the given max_members can have.
+func memberLimit(maxMembers *int32) int64 {
+	if maxMembers == nil {
+		return maxTeamMembers
+	}
+	return int64(*maxMembers)
+}
+
 type TeamService struct {
-	db     *database.DB
-	txm    *database.TransactionManager
-	logger zerolog.Logger
+	db           *database.DB
+	txm          *database.TransactionManager
+	emailService *email.EmailService
+	config       *config.Config
+	logger       zerolog.Logger
 }
 
 func NewService(
 	db *database.DB,
 	txm *database.TransactionManager,
+	emailService *email.EmailService,
+	config *config.Config,
 	logger zerolog.Logger) *TeamService {
 	return &TeamService{
-		db:     db,
-		txm:    txm,
-		logger: logger.With().Str("service", "TeamService").Str("component", "team").Logger(),
+		db:           db,
+		txm:          txm,
+		emailService: emailService,
+		config:       config,
+		logger:       logger.With().Str("service", "TeamService").Str("component", "team").Logger(),
 	}
 }
 
@@ -51,10 +72,10 @@ func (s *TeamService) CreateTeam(ctx context.Context, name string, userID uuid.U
 			return err
 		}
 
+		// Every team starts with an open invite link that doesn't expire.
 		_, err = txDB.Query.CreateInvitation(ctx, sqlc.CreateInvitationParams{
 			TeamID:    team.ID,
 			InviterID: userID,
-			ExpiresAt: nil,
 		})
 
 		if err != nil {
@@ -121,46 +142,106 @@ func (s *TeamService) GetTeamDetails(ctx context.Context, teamID uuid.UUID) (*sq
 	return &teamDetails, nil
 }
 
-func (s *TeamService) JoinTeam(ctx context.Context, userID, teamID uuid.UUID) error {
-	members, err := s.GetTeamMembers(ctx, teamID)
+// JoinTeam adds a user to the team of an invitation and records that they used it.
+// Invitations sent to a person can only be used by them.
+func (s *TeamService) JoinTeam(ctx context.Context, userID, invitationID uuid.UUID) error {
+	invitation, err := s.GetInvitation(ctx, invitationID)
 
 	if err != nil {
-		s.logger.Err(err).Msg("fail to get team members")
+		return err
+	}
+
+	if isTargeted(*invitation) {
+		user, err := s.db.Query.GetUserByID(ctx, userID)
+
+		if err != nil {
+			s.logger.Err(err).Msg("Join fail, unable to get user")
+			return ErrJoinTeam
+		}
+
+		if !isInvitedUser(*invitation, user) {
+			return ErrInvitationNotForYou
+		}
+	}
+
+	err = s.txm.WithTx(ctx, func(tx pgx.Tx) error {
+		txDB := s.db.NewTX(tx)
+
+		team, err := txDB.Query.GetTeamByIdForUpdate(ctx, invitation.TeamID)
+
+		if err != nil {
+			return err
+		}
+
+		if _, err := txDB.Query.UseInvitation(ctx, invitationID); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrInvitationClosed
+			}
+			return err
+		}
+
+		if err := s.addMember(ctx, txDB, team, userID); err != nil {
+			return err
+		}
+
+		return txDB.Query.CreateInvitationUse(ctx, sqlc.CreateInvitationUseParams{
+			InvitationID: invitationID,
+			UserID:       userID,
+		})
+	})
+
+	if err != nil {
+		if errors.Is(err, ErrInvitationClosed) ||
+			errors.Is(err, ErrMembersLimitReached) ||
+			errors.Is(err, ErrJoinSameTeam) ||
+			errors.Is(err, ErrAlreadyHasTeam) {
+			return err
+		}
+		s.logger.Err(err).Msg("Join fail")
 		return ErrJoinTeam
 	}
 
-	if len(members) >= 4 {
+	return nil
+}
+
+// addMember adds a user to a team the transaction holds the lock of, so the member limit
+// can't be passed by joins running at the same time. The user's pending join requests
+// to other teams are cancelled.
+func (s *TeamService) addMember(ctx context.Context, txDB *database.DB, team sqlc.Team, userID uuid.UUID) error {
+	count, err := txDB.Query.CountTeamMembers(ctx, team.ID)
+
+	if err != nil {
+		return err
+	}
+
+	if count >= memberLimit(team.MaxMembers) {
 		return ErrMembersLimitReached
 	}
 
-	team, err := s.db.Query.GetTeamByUserId(ctx, userID)
+	currentTeam, err := txDB.Query.GetTeamByUserId(ctx, userID)
 
 	switch {
 	case errors.Is(err, pgx.ErrNoRows):
 		// User is not on a team, continue joining.
 
-	case err != nil && !errors.Is(err, pgx.ErrNoRows):
-		s.logger.Err(err).Msg("JoinTeam failed: GetTeamByUserId")
-		return ErrJoinTeam
+	case err != nil:
+		return err
 
-	case team.ID == teamID:
+	case currentTeam.ID == team.ID:
 		return ErrJoinSameTeam
 
 	default:
 		return ErrAlreadyHasTeam
 	}
 
-	_, err = s.db.Query.AddUserToTeam(ctx, sqlc.AddUserToTeamParams{
-		TeamID: teamID,
+	if _, err = txDB.Query.AddUserToTeam(ctx, sqlc.AddUserToTeamParams{
+		TeamID: team.ID,
 		UserID: userID,
-	})
-
-	if err != nil {
-		s.logger.Err(err).Msg("Join fail")
-		return ErrJoinTeam
+	}); err != nil {
+		return err
 	}
 
-	return nil
+	return txDB.Query.CancelPendingTeamJoinRequestsByUser(ctx, userID)
 }
 
 func (s *TeamService) KickMember(ctx context.Context, memberID, teamID, userID uuid.UUID) error {
@@ -214,91 +295,343 @@ func (s *TeamService) DeleteTeam(ctx context.Context, userID, teamID uuid.UUID)
 	return nil
 }
 
+// LeaveTeam removes a user from their team. When the owner leaves, the member who has
+// been on the team the longest becomes the owner, and the team is deleted if the owner
+// was its last member.
 func (s *TeamService) LeaveTeam(ctx context.Context, userID, teamID uuid.UUID) error {
-	team, err := s.db.Query.GetTeamById(ctx, teamID)
+	err := s.txm.WithTx(ctx, func(tx pgx.Tx) error {
+		txDB := s.db.NewTX(tx)
+
+		team, err := txDB.Query.GetTeamByIdForUpdate(ctx, teamID)
+
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrNoTeamFound
+			}
+			return err
+		}
+
+		if team.OwnerID == userID {
+			nextOwnerID, err := txDB.Query.GetLongestTenuredTeamMember(ctx, sqlc.GetLongestTenuredTeamMemberParams{
+				TeamID: teamID,
+				UserID: userID,
+			})
+
+			if errors.Is(err, pgx.ErrNoRows) {
+				return txDB.Query.DeleteTeamById(ctx, teamID)
+			}
+
+			if err != nil {
+				return err
+			}
+
+			if _, err := txDB.Query.UpdateTeamById(ctx, sqlc.UpdateTeamByIdParams{
+				OwnerIDDoUpdate: true,
+				OwnerID:         nextOwnerID,
+				ID:              teamID,
+			}); err != nil {
+				return err
+			}
+		}
+
+		return txDB.Query.RemoveUserFromTeam(ctx, sqlc.RemoveUserFromTeamParams{
+			TeamID: teamID,
+			UserID: userID,
+		})
+	})
 
 	if err != nil {
-		s.logger.Err(err).Msg("LeaveTeam fail, unable to get team info by id")
+		if errors.Is(err, ErrNoTeamFound) {
+			return err
+		}
+		s.logger.Err(err).Msg("LeaveTeam fail")
 		return ErrLeaveTeam
 	}
 
-	if team.OwnerID == userID {
-		return ErrOwnerLeaveTeam
+	return nil
+}
+
+// TransferOwnership hands a team over to another of its members. Only the owner can
+// perform this action.
+func (s *TeamService) TransferOwnership(ctx context.Context, ownerID, teamID, newOwnerID uuid.UUID) (*sqlc.Team, error) {
+	if newOwnerID == ownerID {
+		return nil, ErrTransferToSelf
 	}
 
-	err = s.db.Query.RemoveUserFromTeam(ctx, sqlc.RemoveUserFromTeamParams{
-		TeamID: teamID,
-		UserID: userID,
+	var updated sqlc.Team
+
+	err := s.txm.WithTx(ctx, func(tx pgx.Tx) error {
+		txDB := s.db.NewTX(tx)
+
+		team, err := txDB.Query.GetTeamByIdForUpdate(ctx, teamID)
+
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrNoTeamFound
+			}
+			return err
+		}
+
+		if team.OwnerID != ownerID {
+			return ErrUserNotTeamOwner
+		}
+
+		newOwnerTeam, err := txDB.Query.GetTeamByUserId(ctx, newOwnerID)
+
+		if errors.Is(err, pgx.ErrNoRows) || (err == nil && newOwnerTeam.ID != teamID) {
+			return ErrNotTeamMember
+		}
+
+		if err != nil {
+			return err
+		}
+
+		updated, err = txDB.Query.UpdateTeamById(ctx, sqlc.UpdateTeamByIdParams{
+			OwnerIDDoUpdate: true,
+			OwnerID:         newOwnerID,
+			ID:              teamID,
+		})
+
+		return err
 	})
 
 	if err != nil {
-		s.logger.Err(err).Msg("LeaveTeam fail")
-		return ErrLeaveTeam
+		if errors.Is(err, ErrNoTeamFound) ||
+			errors.Is(err, ErrUserNotTeamOwner) ||
+			errors.Is(err, ErrNotTeamMember) {
+			return nil, err
+		}
+		s.logger.Err(err).Msg("TransferOwnership fail")
+		return nil, ErrTransferOwnership
 	}
 
-	return nil
+	return &updated, nil
 }
 
-func (s *TeamService) CreateInvitation(ctx context.Context, userID uuid.UUID) (*uuid.UUID, error) {
-	team, err := s.GetTeamByUserId(ctx, userID)
+// RequestToJoinTeam asks the owner of a team to let the user in. The owner is emailed
+// about it, and the request expires if they don't answer within TeamJoinRequestTTL.
+func (s *TeamService) RequestToJoinTeam(ctx context.Context, teamID, userID uuid.UUID, message *string) (*sqlc.TeamJoinRequest, error) {
+	team, err := s.db.Query.GetTeamById(ctx, teamID)
 
 	if err != nil {
-		if errors.Is(err, ErrNotInTeam) {
-			return nil, ErrNotInTeam
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNoTeamFound
 		}
-		s.logger.Err(err).Msg("fail to create invitation")
-		return nil, ErrGetTeam
+		s.logger.Err(err).Msg("RequestToJoinTeam fail, unable to get team info by id")
+		return nil, ErrRequestJoinTeam
+	}
+
+	// Users can't request to join a team if they are already on one.
+	currentTeam, err := s.db.Query.GetTeamByUserId(ctx, userID)
+
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		// User is not on a team, continue requesting.
+
+	case err != nil:
+		s.logger.Err(err).Msg("RequestToJoinTeam fail, unable to get team by user id")
+		return nil, ErrRequestJoinTeam
+
+	case currentTeam.ID == teamID:
+		return nil, ErrJoinSameTeam
+
+	default:
+		return nil, ErrAlreadyHasTeam
+	}
+
+	count, err := s.db.Query.CountTeamMembers(ctx, teamID)
+
+	if err != nil {
+		s.logger.Err(err).Msg("RequestToJoinTeam fail, unable to count team members")
+		return nil, ErrRequestJoinTeam
+	}
+
+	if count >= memberLimit(team.MaxMembers) {
+		return nil, ErrMembersLimitReached
+	}
+
+	request, err := s.db.Query.CreateTeamJoinRequest(ctx, sqlc.CreateTeamJoinRequestParams{
+		TeamID:         teamID,
+		UserID:         userID,
+		RequestMessage: message,
+		ExpiresAt:      time.Now().Add(s.config.TeamJoinRequestTTL),
+	})
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrJoinRequestExists
+		}
+		s.logger.Err(err).Msg("RequestToJoinTeam fail")
+		return nil, ErrRequestJoinTeam
+	}
+
+	// The request stands even if the owner can't be emailed, they still see it in the portal.
+	requester, err := s.db.Query.GetUserEmailInfoById(ctx, userID)
+
+	if err != nil {
+		s.logger.Err(err).Msg("RequestToJoinTeam: unable to get requester info for the owner email")
+	} else if err := s.emailService.QueueTeamJoinRequestEmail(ctx, team.OwnerID, request, requester.Name, team.Name); err != nil {
+		s.logger.Err(err).Msg("RequestToJoinTeam: unable to email team owner")
+	}
+
+	return &request, nil
+}
+
+// GetPendingJoinRequestsForTeam returns the open join requests of a team. Only the
+// owner can see them.
+func (s *TeamService) GetPendingJoinRequestsForTeam(ctx context.Context, userID, teamID uuid.UUID) ([]sqlc.ListPendingTeamJoinRequestsByTeamRow, error) {
+	team, err := s.db.Query.GetTeamById(ctx, teamID)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNoTeamFound
+		}
+		s.logger.Err(err).Msg("GetPendingJoinRequestsForTeam fail, unable to get team info by id")
+		return nil, ErrGetJoinRequests
 	}
 
 	if team.OwnerID != userID {
 		return nil, ErrUserNotTeamOwner
 	}
 
-	invitation, err := s.db.Query.CreateInvitation(ctx, sqlc.CreateInvitationParams{
-		TeamID:    team.ID,
-		InviterID: userID,
-		ExpiresAt: nil,
-	})
+	requests, err := s.db.Query.ListPendingTeamJoinRequestsByTeam(ctx, teamID)
 
 	if err != nil {
-		s.logger.Err(err).Msg("fail to create invitation")
-		return nil, ErrCreateInvitation
+		s.logger.Err(err).Msg("GetPendingJoinRequestsForTeam fail")
+		return nil, ErrGetJoinRequests
 	}
 
-	return &invitation.ID, nil
+	return requests, nil
 }
 
-func (s *TeamService) GetInvitation(ctx context.Context, id uuid.UUID) (*sqlc.TeamInvitation, error) {
-	invitation, err := s.db.Query.GetInvitationByID(ctx, id)
+func (s *TeamService) GetUserPendingJoinRequests(ctx context.Context, userID uuid.UUID) ([]sqlc.ListPendingTeamJoinRequestsByUserRow, error) {
+	requests, err := s.db.Query.ListPendingTeamJoinRequestsByUser(ctx, userID)
 
 	if err != nil {
-		s.logger.Err(err).Msg("fail to get invitation")
-		return nil, ErrGetInvitation
+		s.logger.Err(err).Msg("GetUserPendingJoinRequests fail")
+		return nil, ErrGetJoinRequests
 	}
 
-	return &invitation, nil
+	return requests, nil
 }
 
-func (s *TeamService) GetInvitationByTeamID(ctx context.Context, teamID, userID uuid.UUID) (*sqlc.TeamInvitation, error) {
-	team, err := s.GetTeamByUserId(ctx, userID)
+// RespondToJoinRequest approves or rejects a join request. The member limit is checked
+// again on approval, since the team may have filled up after the request was made.
+func (s *TeamService) RespondToJoinRequest(ctx context.Context, ownerID, requestID uuid.UUID, approve bool) (*sqlc.TeamJoinRequest, error) {
+	request, err := s.db.Query.GetTeamJoinRequestById(ctx, requestID)
 
 	if err != nil {
-		s.logger.Err(err).Msg("fail to get team by user id")
-		return nil, ErrGetInvitation
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrJoinRequestNotFound
+		}
+		s.logger.Err(err).Msg("RespondToJoinRequest fail, unable to get join request")
+		return nil, ErrRespondJoinRequest
 	}
 
-	if team.OwnerID != userID {
-		return nil, ErrUserNotTeamOwner
+	status := sqlc.TeamJoinRequestStatusRejected
+	if approve {
+		status = sqlc.TeamJoinRequestStatusApproved
 	}
 
-	invitation, err := s.db.Query.GetInvitationByTeamID(ctx, teamID)
+	var updated sqlc.TeamJoinRequest
+
+	err = s.txm.WithTx(ctx, func(tx pgx.Tx) error {
+		txDB := s.db.NewTX(tx)
+
+		team, err := txDB.Query.GetTeamByIdForUpdate(ctx, request.TeamID)
+
+		if err != nil {
+			return err
+		}
+
+		if team.OwnerID != ownerID {
+			return ErrUserNotTeamOwner
+		}
+
+		updated, err = txDB.Query.UpdateTeamJoinRequestStatus(ctx, sqlc.UpdateTeamJoinRequestStatusParams{
+			Status:            status,
+			ProcessedByUserID: &ownerID,
+			ID:                requestID,
+		})
+
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrJoinRequestClosed
+			}
+			return err
+		}
+
+		if !approve {
+			return nil
+		}
+
+		return s.addMember(ctx, txDB, team, request.UserID)
+	})
+
+	if err != nil {
+		if errors.Is(err, ErrUserNotTeamOwner) ||
+			errors.Is(err, ErrJoinRequestClosed) ||
+			errors.Is(err, ErrMembersLimitReached) ||
+			errors.Is(err, ErrJoinSameTeam) ||
+			errors.Is(err, ErrAlreadyHasTeam) {
+			return nil, err
+		}
+		s.logger.Err(err).Msg("RespondToJoinRequest fail")
+		return nil, ErrRespondJoinRequest
+	}
+
+	return &updated, nil
+}
+
+// CancelJoinRequest withdraws one of the user's own pending join requests.
+func (s *TeamService) CancelJoinRequest(ctx context.Context, userID, requestID uuid.UUID) error {
+	request, err := s.db.Query.GetTeamJoinRequestById(ctx, requestID)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrJoinRequestNotFound
+		}
+		s.logger.Err(err).Msg("CancelJoinRequest fail, unable to get join request")
+		return ErrCancelJoinRequest
+	}
+
+	// Other users' requests are reported as missing rather than forbidden.
+	if request.UserID != userID {
+		return ErrJoinRequestNotFound
+	}
+
+	_, err = s.db.Query.UpdateTeamJoinRequestStatus(ctx, sqlc.UpdateTeamJoinRequestStatusParams{
+		Status:            sqlc.TeamJoinRequestStatusCancelled,
+		ProcessedByUserID: &userID,
+		ID:                requestID,
+	})
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrJoinRequestClosed
+		}
+		s.logger.Err(err).Msg("CancelJoinRequest fail")
+		return ErrCancelJoinRequest
+	}
+
+	return nil
+}
+
+// ExpireJoinRequests closes the pending join requests past their expiry and returns
+// how many. They are already hidden from the team endpoints, this only records it.
+func (s *TeamService) ExpireJoinRequests(ctx context.Context) (int64, error) {
+	expired, err := s.db.Query.ExpireTeamJoinRequests(ctx)
 
 	if err != nil {
-		s.logger.Err(err).Msg("fail to get invitation by team id")
-		return nil, ErrGetInvitation
+		s.logger.Err(err).Msg("ExpireJoinRequests fail")
+		return 0, err
+	}
+
+	if expired > 0 {
+		s.logger.Info().Int64("Expired", expired).Msg("Expired team join requests")
 	}
 
-	return &invitation, nil
+	return expired, nil
 }
 
 // type MemberWithUserInfo struct {
@@ -352,36 +685,6 @@ func (s *TeamService) GetInvitationByTeamID(ctx context.Context, teamID, userID
 // 	return &teamWithMembers, nil
 // }
 
-// func (s *TeamService) GetTeamsWithMembers(ctx context.Context, limit, offset int32) ([]TeamWithMembers, error) {
-// 	teams, err := s.teamRepo.GetTeamsWithMembers(ctx, sqlc.ListTeamsWithMembersParams{
-// 		Limit:  limit,
-// 		Offset: offset,
-// 	})
-
-// 	if err != nil {
-// 		return []TeamWithMembers{}, err
-// 	}
-
-// 	var result []TeamWithMembers
-
-// 	for _, team := range teams {
-// 		var parsedMembers []MemberWithUserInfo
-
-// 		if err := json.Unmarshal(team.Members, &parsedMembers); err != nil {
-// 			return []TeamWithMembers{}, err
-// 		}
-
-// 		result = append(result, TeamWithMembers{
-// 			ID:      team.ID,
-// 			OwnerId: team.OwnerID,
-// 			Name:    team.Name,
-// 			Members: parsedMembers,
-// 		})
-// 	}
-
-// 	return result, nil
-// }
-
 // func (s *TeamService) GetTeamWithMembersByTeamId(ctx context.Context, teamID uuid.UUID) (*TeamWithMembers, error) {
 // 	team, err := s.teamRepo.GetByID(ctx, teamID)
 // 	if err != nil {
@@ -417,234 +720,3 @@ func (s *TeamService) GetInvitationByTeamID(ctx context.Context, teamID, userID
 
 // 	return &teamWithMembers, nil
 // }
-
-// func (s *TeamService) LeaveTeam(ctx context.Context, userID, teamID uuid.UUID) error {
-// 	team, err := s.teamRepo.GetByID(ctx, teamID)
-// 	if err != nil {
-// 		if errors.Is(err, repository.ErrTeamNotFound) {
-// 			return ErrTeamNotFound
-// 		}
-// 		return err
-// 	}
-
-// 	// Check if user is NOT the owner
-// 	if team.OwnerID == nil || *team.OwnerID != userID {
-// 		return s.teamMemberRepo.Delete(ctx, sqlc.RemoveTeamMemberParams{
-// 			TeamID: teamID,
-// 			UserID: userID,
-// 		})
-// 	}
-
-// 	// User IS the owner
-// 	members, err := s.teamMemberRepo.GetTeamMembers(ctx, team.ID)
-// 	if err != nil {
-// 		return err
-// 	}
-
-// 	// Confirm that team has members. This should not run unless there are inconsistencies.
-// 	if len(members) == 0 {
-// 		s.logger.Warn().Msg("Team has no members but owner exists — inconsistent state.")
-// 		return s.teamRepo.Delete(ctx, team.ID)
-// 	}
-
-// 	// If current user is the last member → delete both team + membership
-// 	if len(members) == 1 {
-// 		return s.txm.WithTx(ctx, func(tx pgx.Tx) error {
-// 			txTeamRepo := s.teamRepo.NewTx(tx)
-// 			txTeamMemberRepo := s.teamMemberRepo.NewTx(tx)
-
-// 			if err := txTeamMemberRepo.Delete(ctx, sqlc.RemoveTeamMemberParams{
-// 				TeamID: teamID,
-// 				UserID: userID,
-// 			}); err != nil {
-// 				return err
-// 			}
-// 			return txTeamRepo.Delete(ctx, team.ID)
-// 		})
-// 	}
-
-// 	// Choose the next owner deterministically
-// 	var nextOwner sqlc.GetTeamMembersRow
-// 	for _, m := range members {
-// 		if m.UserID != userID {
-// 			nextOwner = m
-// 			break
-// 		}
-// 	}
-
-// 	if nextOwner.UserID == uuid.Nil {
-// 		// Should not happen unless data is inconsistent
-// 		s.logger.Error().Msg("No eligible next owner found.")
-// 		return ErrNoEligibleNextOwner
-// 	}
-
-// 	return s.txm.WithTx(ctx, func(tx pgx.Tx) error {
-// 		txTeamRepo := s.teamRepo.NewTx(tx)
-// 		txTeamMemberRepo := s.teamMemberRepo.NewTx(tx)
-
-// 		if err := txTeamMemberRepo.Delete(ctx, sqlc.RemoveTeamMemberParams{
-// 			TeamID: teamID,
-// 			UserID: userID,
-// 		}); err != nil {
-// 			return err
-// 		}
-
-// 		// _, err := txTeamRepo.Update(ctx, team.ID, nil, &nextOwner.UserID)
-// 		_, err := txTeamRepo.Update(ctx, sqlc.UpdateTeamByIdParams{
-// 			ID:              team.ID,
-// 			OwnerIDDoUpdate: true,
-// 			OwnerID:         &nextOwner.UserID,
-// 		})
-// 		return err
-// 	})
-// }
-
-// func (s *TeamService) RequestToJoinTeam(ctx context.Context, teamID, userID uuid.UUID, message *string) (*sqlc.TeamJoinRequest, error) {
-// 	// Ensure user is not already on a team (User's can't request to join a team if they are already on a team)
-// 	_, err := s.teamMemberRepo.GetTeamMemberByUser(ctx, userID)
-// 	if err != nil && !errors.Is(err, repository.ErrTeamMemberNotFound) {
-// 		return nil, err
-// 	}
-
-// 	if err == nil {
-// 		return nil, ErrUserOnTeam
-// 	}
-
-// 	request, err := s.teamJoinRequestRepo.Create(ctx, sqlc.CreateTeamJoinRequestParams{
-// 		TeamID:         teamID,
-// 		UserID:         userID,
-// 		RequestMessage: message,
-// 	})
-
-// 	if err != nil {
-// 		return nil, err
-// 	}
-
-// 	return request, nil
-// }
-
-// func (s *TeamService) GetPendingJoinRequestForTeam(ctx context.Context, userID, teamID uuid.UUID) ([]sqlc.ListJoinRequestsByTeamAndStatusWithUserRow, error) {
-// 	// Get team and check if user is the owner of the tema
-// 	team, err := s.teamRepo.GetByID(ctx, teamID)
-// 	if err != nil {
-// 		return []sqlc.ListJoinRequestsByTeamAndStatusWithUserRow{}, err
-// 	}
-
-// 	if *team.OwnerID != userID {
-// 		return []sqlc.ListJoinRequestsByTeamAndStatusWithUserRow{}, ErrUserNotTeamOwner
-// 	}
-
-// 	requests, err := s.teamJoinRequestRepo.ListJoinRequestsByTeamWithUser(ctx, sqlc.ListJoinRequestsByTeamAndStatusWithUserParams{
-// 		TeamID: teamID,
-// 		Status: sqlc.TeamJoinRequestStatusPending,
-// 	})
-
-// 	if err != nil {
-// 		return []sqlc.ListJoinRequestsByTeamAndStatusWithUserRow{}, err
-// 	}
-
-// 	return requests, nil
-// }
-
-// func (s *TeamService) GetUserPendingJoinRequests(ctx context.Context, userID uuid.UUID) ([]sqlc.TeamJoinRequest, error) {
-// 	requests, err := s.teamJoinRequestRepo.ListJoinRequestsByUserAndStatus(ctx, sqlc.ListTeamJoinRequestsByUserAndStatusParams{
-// 		UserID: userID,
-// 		Status: sqlc.TeamJoinRequestStatusPending,
-// 	})
-
-// 	if err != nil {
-// 		return []sqlc.TeamJoinRequest{}, err
-// 	}
-
-// 	return requests, nil
-// }
-
-// func (s *TeamService) RespondToJoinRequest(ctx context.Context, ownerID, requestID uuid.UUID, accept bool) error {
-// 	// Retrieve the join request
-// 	oldRequest, err := s.teamJoinRequestRepo.GetById(ctx, requestID)
-// 	if err != nil {
-// 		return err
-// 	}
-
-// 	// Get team and check if user is the owner of the team
-// 	team, err := s.teamRepo.GetByID(ctx, oldRequest.TeamID)
-// 	if err != nil {
-// 		return err
-// 	}
-// 	if *team.OwnerID != ownerID {
-// 		return ErrUserNotTeamOwner
-// 	}
-
-// 	// Also ensure user is actually an applicant or attendee
-// 	user, err := s.userRepo.GetUserByID(ctx, oldRequest.UserID)
-// 	if err != nil {
-// 		if errors.Is(err, database.ErrEntityNotFound) {
-// 			return ErrUserNotApplicantOrAttendee
-// 		}
-
-// 		return err
-// 	}
-// 	if user == nil {
-// 		return ErrUserNotApplicantOrAttendee
-// 	}
-// 	if user.Role != sqlc.UserRoleAttendee && user.Role != sqlc.UserRoleApplicant {
-// 		return ErrUserNotApplicantOrAttendee
-// 	}
-
-// 	// Ensure user is not already on a team
-// 	_, err = s.teamMemberRepo.GetTeamMemberByUser(ctx, oldRequest.UserID)
-// 	if err != nil && !errors.Is(err, repository.ErrTeamMemberNotFound) {
-// 		return err
-// 	}
-
-// 	if err == nil {
-// 		return ErrUserOnTeam
-// 	}
-
-// 	if accept {
-// 		// Check if team is already full
-// 		members, err := s.teamMemberRepo.GetTeamMembers(ctx, team.ID)
-// 		if err != nil {
-// 			return err
-// 		}
-
-// 		if len(members) >= 4 {
-// 			return ErrTeamFull
-// 		}
-
-// 		// Accepting the request: add user to team and update request status
-// 		return s.txm.WithTx(ctx, func(tx pgx.Tx) error {
-// 			txTeamMemberRepo := s.teamMemberRepo.NewTx(tx)
-// 			txTeamJoinRequestRepo := s.teamJoinRequestRepo.NewTx(tx)
-
-// 			if _, err := txTeamMemberRepo.Create(ctx, sqlc.CreateTeamMemberParams{
-// 				TeamID: oldRequest.TeamID,
-// 				UserID: oldRequest.UserID,
-// 			}); err != nil {
-// 				return err
-// 			}
-
-// 			if _, err := txTeamJoinRequestRepo.UpdateStatus(ctx, sqlc.UpdateTeamJoinRequestParams{
-// 				ID:             requestID,
-// 				StatusDoUpdate: true,
-// 				Status:         sqlc.TeamJoinRequestStatusApproved,
-// 			}); err != nil {
-// 				return err
-// 			}
-
-// 			// Delete all other pending requests by the user for this event
-// 			return txTeamJoinRequestRepo.DeleteByUserAndStatus(ctx, sqlc.DeleteJoinRequestsByUserAndStatusParams{
-// 				UserID: oldRequest.UserID,
-// 				Status: sqlc.TeamJoinRequestStatusPending,
-// 			})
-// 		})
-// 	} else {
-// 		// Rejecting the request: just update request status
-// 		_, err := s.teamJoinRequestRepo.UpdateStatus(ctx, sqlc.UpdateTeamJoinRequestParams{
-// 			ID:             requestID,
-// 			StatusDoUpdate: true,
-// 			Status:         sqlc.TeamJoinRequestStatusApproved,
-// 		})
-// 		return err
-// 	}
-// }
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/swamphacks/core/apps/api/internal/database/sqlc"
 )
 
 var (
@@ -20,12 +21,41 @@ var (
 	ErrJoinTeam            = errors.New("unable to join team")
 	ErrJoinSameTeam        = errors.New("cannot join the same team again")
 	ErrLeaveTeam           = errors.New("unable to leave team")
-	ErrOwnerLeaveTeam      = errors.New("owner cannot leave team")
 	ErrCreateInvitation    = errors.New("unable to create invitation")
 	ErrGetInvitation       = errors.New("unable to get invitation")
 	ErrMembersLimitReached = errors.New("members limit exceeded")
 	ErrNoTeamFound         = errors.New("no team found")
 	ErrAlreadyHasTeam      = errors.New("user is already in a team")
+	ErrRequestJoinTeam     = errors.New("unable to request to join team")
+	ErrJoinRequestExists   = errors.New("a request to join this team is already pending")
+	ErrJoinRequestNotFound = errors.New("join request not found")
+	ErrJoinRequestClosed   = errors.New("join request is no longer pending")
+	ErrGetJoinRequests     = errors.New("unable to get join requests")
+	ErrRespondJoinRequest  = errors.New("unable to respond to join request")
+	ErrCancelJoinRequest   = errors.New("unable to cancel join request")
+	ErrNoActiveHackathon   = errors.New("no hackathon is active")
+	ErrGetTeamListings     = errors.New("unable to get team listings")
+	ErrTeamListingNotFound = errors.New("team is not listed")
+	ErrUpdateTeamListing   = errors.New("unable to update team listing")
+	ErrGetTeamProfiles     = errors.New("unable to get looking for team profiles")
+	ErrTeamProfileNotFound = errors.New("looking for team profile not found")
+	ErrUpdateTeamProfile   = errors.New("unable to update looking for team profile")
+	ErrInviteToTeam        = errors.New("unable to invite user to team")
+	ErrInvitationNotFound  = errors.New("invitation not found")
+	ErrInvitationClosed    = errors.New("invitation is no longer valid")
+	ErrInvitationNotForYou = errors.New("invitation was sent to someone else")
+	ErrInvitationExists    = errors.New("an invitation to this person is already pending")
+	ErrInvitationExpiry    = errors.New("invitation expiry must be in the future")
+	ErrUpdateInvitation    = errors.New("unable to update invitation")
+	ErrTransferOwnership   = errors.New("unable to transfer team ownership")
+	ErrTransferToSelf      = errors.New("cannot transfer ownership to yourself")
+	ErrNotTeamMember       = errors.New("user is not a member of the team")
+	ErrGetTeams            = errors.New("unable to get teams")
+	ErrUpdateTeam          = errors.New("unable to update team")
+	ErrMergeTeams          = errors.New("unable to merge teams")
+	ErrMergeSameTeam       = errors.New("cannot merge a team into itself")
+	ErrMoveMember          = errors.New("unable to move member")
+	ErrGetAuditLogs        = errors.New("unable to get team audit logs")
 )
 
 type TeamDto struct {
@@ -61,3 +91,212 @@ type DeleteTeamRequestDto struct {
 type KickMemberRequestDto struct {
 	MemberId uuid.UUID `json:"memberId"`
 }
+
+type TransferOwnershipRequestDto struct {
+	NewOwnerID uuid.UUID `json:"newOwnerId" doc:"A member of the team"`
+}
+
+type CreateJoinRequestDto struct {
+	Message *string `json:"message,omitempty" maxLength:"500"`
+}
+
+type TeamJoinRequestDto struct {
+	ID        uuid.UUID                  `json:"id"`
+	TeamID    uuid.UUID                  `json:"teamId"`
+	UserID    uuid.UUID                  `json:"userId"`
+	Message   *string                    `json:"message"`
+	Status    sqlc.TeamJoinRequestStatus `json:"status"`
+	CreatedAt time.Time                  `json:"createdAt"`
+	ExpiresAt time.Time                  `json:"expiresAt"`
+}
+
+// PendingJoinRequestDto is a join request as the team owner sees it.
+type PendingJoinRequestDto struct {
+	ID        uuid.UUID `json:"id"`
+	UserID    uuid.UUID `json:"userId"`
+	Name      string    `json:"name"`
+	Image     *string   `json:"image"`
+	Message   *string   `json:"message"`
+	CreatedAt time.Time `json:"createdAt"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// MyJoinRequestDto is a join request as the requester sees it.
+type MyJoinRequestDto struct {
+	ID        uuid.UUID `json:"id"`
+	TeamID    uuid.UUID `json:"teamId"`
+	TeamName  string    `json:"teamName"`
+	Message   *string   `json:"message"`
+	CreatedAt time.Time `json:"createdAt"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+type CreateInvitationRequestDto struct {
+	ExpiresAt *time.Time `json:"expiresAt,omitempty" doc:"Leave out for an invitation that doesn't expire"`
+	MaxUses   *int32     `json:"maxUses,omitempty" minimum:"1" maximum:"100" doc:"Leave out for an invitation anyone can use"`
+}
+
+type InviteByEmailRequestDto struct {
+	Email     string     `json:"email" format:"email" maxLength:"320"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
+type InvitationUseDto struct {
+	UserID uuid.UUID `json:"userId"`
+	Name   string    `json:"name"`
+	Image  *string   `json:"image"`
+	UsedAt time.Time `json:"usedAt"`
+}
+
+// InvitationDto is an invitation as the team owner sees it. Invitations sent to a person
+// have an invited email or user, open invite links have neither.
+type InvitationDto struct {
+	ID            uuid.UUID                 `json:"id"`
+	InviterID     uuid.UUID                 `json:"inviterId"`
+	InvitedEmail  *string                   `json:"invitedEmail"`
+	InvitedUserID *uuid.UUID                `json:"invitedUserId"`
+	Status        sqlc.TeamInvitationStatus `json:"status"`
+	ExpiresAt     *time.Time                `json:"expiresAt"`
+	MaxUses       *int32                    `json:"maxUses"`
+	UseCount      int32                     `json:"useCount"`
+	CreatedAt     time.Time                 `json:"createdAt"`
+	Uses          []InvitationUseDto        `json:"uses"`
+}
+
+// MyInvitationDto is an invitation as the invited user sees it.
+type MyInvitationDto struct {
+	ID          uuid.UUID  `json:"id"`
+	TeamID      uuid.UUID  `json:"teamId"`
+	TeamName    string     `json:"teamName"`
+	InviterName string     `json:"inviterName"`
+	ExpiresAt   *time.Time `json:"expiresAt"`
+	CreatedAt   time.Time  `json:"createdAt"`
+}
+
+type DiscoverTeamsDto struct {
+	Search    string   `query:"search"`
+	Skills    []string `query:"skills" doc:"Teams looking for any of these skills"`
+	Interests []string `query:"interests"`
+	OpenSlots int64    `query:"openSlots" minimum:"0" maximum:"3" default:"1" doc:"Minimum number of open spots"`
+	Limit     int32    `query:"limit" minimum:"1" maximum:"100" default:"20"`
+	Offset    int32    `query:"offset" minimum:"0" default:"0"`
+}
+
+type DiscoverProfilesDto struct {
+	Search    string   `query:"search"`
+	Skills    []string `query:"skills" doc:"Hackers with any of these skills"`
+	Interests []string `query:"interests"`
+	Limit     int32    `query:"limit" minimum:"1" maximum:"100" default:"20"`
+	Offset    int32    `query:"offset" minimum:"0" default:"0"`
+}
+
+// TeamListingDto is a team on the discovery board.
+type TeamListingDto struct {
+	ID          uuid.UUID       `json:"id"`
+	Name        string          `json:"name"`
+	OwnerID     uuid.UUID       `json:"ownerId"`
+	Description *string         `json:"description"`
+	Skills      []string        `json:"skills"`
+	Interests   []string        `json:"interests"`
+	Members     []TeamMemberDto `json:"members"`
+	OpenSlots   int64           `json:"openSlots"`
+	UpdatedAt   time.Time       `json:"updatedAt"`
+}
+
+type TeamListingSettingsDto struct {
+	TeamID      uuid.UUID `json:"teamId"`
+	Description *string   `json:"description"`
+	Skills      []string  `json:"skills"`
+	Interests   []string  `json:"interests"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+type UpdateTeamListingRequestDto struct {
+	Description *string  `json:"description,omitempty" maxLength:"500"`
+	Skills      []string `json:"skills" maxItems:"20" doc:"Skills the team is looking for"`
+	Interests   []string `json:"interests" maxItems:"20"`
+}
+
+// TeamProfileDto is a hacker looking for a team on the discovery board.
+type TeamProfileDto struct {
+	UserID    uuid.UUID `json:"userId"`
+	Name      string    `json:"name"`
+	Image     *string   `json:"image"`
+	Bio       *string   `json:"bio"`
+	Skills    []string  `json:"skills"`
+	Interests []string  `json:"interests"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type MyTeamProfileDto struct {
+	Bio       *string   `json:"bio"`
+	Skills    []string  `json:"skills"`
+	Interests []string  `json:"interests"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type UpdateTeamProfileRequestDto struct {
+	Bio       *string  `json:"bio,omitempty" maxLength:"500"`
+	Skills    []string `json:"skills" maxItems:"20"`
+	Interests []string `json:"interests" maxItems:"20"`
+}
+
+type AdminTeamsDto struct {
+	Search string `query:"search" doc:"Matches the team name or the name or email of a member"`
+	Limit  int32  `query:"limit" minimum:"1" maximum:"100" default:"20"`
+	Offset int32  `query:"offset" minimum:"0" default:"0"`
+}
+
+type AdminTeamMemberDto struct {
+	ID                uuid.UUID               `json:"id"`
+	Name              string                  `json:"name"`
+	Email             *string                 `json:"email"`
+	Image             *string                 `json:"image"`
+	JoinedAt          time.Time               `json:"joinedAt"`
+	ApplicationStatus *sqlc.ApplicationStatus `json:"applicationStatus" doc:"Status of their application to the active hackathon"`
+}
+
+// AdminTeamDto is a team as staff see it. MaxMembersOverride is the limit staff set for
+// the team, if any, and MaxMembers the limit that applies.
+type AdminTeamDto struct {
+	ID                 uuid.UUID            `json:"id"`
+	Name               string               `json:"name"`
+	OwnerID            uuid.UUID            `json:"ownerId"`
+	MaxMembers         int64                `json:"maxMembers"`
+	MaxMembersOverride *int32               `json:"maxMembersOverride"`
+	MemberCount        int64                `json:"memberCount"`
+	CreatedAt          time.Time            `json:"createdAt"`
+	Members            []AdminTeamMemberDto `json:"members"`
+}
+
+type RenameTeamRequestDto struct {
+	Name string `json:"name" minLength:"1" maxLength:"100"`
+}
+
+type MergeTeamsRequestDto struct {
+	SourceTeamID uuid.UUID `json:"sourceTeamId" doc:"Team whose members join this one, it is deleted afterwards"`
+}
+
+type MoveMemberRequestDto struct {
+	TeamID uuid.UUID `json:"teamId" doc:"Team the member is moved to"`
+}
+
+type SetMemberLimitRequestDto struct {
+	MaxMembers *int32 `json:"maxMembers,omitempty" minimum:"1" maximum:"100" doc:"Leave out to go back to the default limit"`
+}
+
+type TeamAuditLogsDto struct {
+	TeamID uuid.UUID `query:"teamId" doc:"Only show the log of this team"`
+	Limit  int32     `query:"limit" minimum:"1" maximum:"100" default:"50"`
+	Offset int32     `query:"offset" minimum:"0" default:"0"`
+}
+
+type TeamAuditLogDto struct {
+	ID        uuid.UUID            `json:"id"`
+	TeamID    uuid.UUID            `json:"teamId"`
+	ActorID   *uuid.UUID           `json:"actorId"`
+	ActorName *string              `json:"actorName"`
+	Action    sqlc.TeamAuditAction `json:"action"`
+	Details   map[string]any       `json:"details"`
+	CreatedAt time.Time            `json:"createdAt"`
+}
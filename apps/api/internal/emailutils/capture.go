@@ -0,0 +1,79 @@
+package emailutils
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// CapturedEmail is an email the capture backend kept instead of sending.
+type CapturedEmail struct {
+	MessageID string
+	Email     Email
+	Message   []byte
+}
+
+// CaptureSender keeps emails in memory instead of sending them, for local development
+// and tests. When dir is set, every email is also written to it as an .eml file.
+type CaptureSender struct {
+	dir    string
+	logger zerolog.Logger
+
+	mu     sync.Mutex
+	emails []CapturedEmail
+}
+
+func NewCaptureSender(dir string, logger zerolog.Logger) (*CaptureSender, error) {
+	if dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create email capture directory: %w", err)
+		}
+	}
+
+	return &CaptureSender{
+		dir:    dir,
+		logger: logger.With().Str("component", "capture_sender").Logger(),
+	}, nil
+}
+
+func (c *CaptureSender) Send(ctx context.Context, email Email) (string, error) {
+	messageID := newMessageID(email.From)
+	message, err := buildMessage(email, messageID, time.Now())
+	if err != nil {
+		return "", err
+	}
+
+	if c.dir != "" {
+		name := strings.ReplaceAll(messageID, "@", "_") + ".eml"
+		if err := os.WriteFile(filepath.Join(c.dir, name), message, 0o644); err != nil {
+			c.logger.Err(err).Msg("Failed to write captured email")
+			return "", err
+		}
+	}
+
+	c.mu.Lock()
+	c.emails = append(c.emails, CapturedEmail{
+		MessageID: messageID,
+		Email:     email,
+		Message:   message,
+	})
+	c.mu.Unlock()
+
+	c.logger.Info().Strs("to", email.To).Str("subject", email.Subject).Str("message_id", messageID).Msg("Captured email")
+
+	return messageID, nil
+}
+
+// Emails returns every email captured so far, oldest first.
+func (c *CaptureSender) Emails() []CapturedEmail {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]CapturedEmail(nil), c.emails...)
+}
@@ -0,0 +1,211 @@
+package emailutils
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrEmptyEmail    = errors.New("email has no body")
+	ErrInvalidHeader = errors.New("email header contains a line break")
+)
+
+// newMessageID returns a unique Message-ID, without angle brackets, on the domain of the
+// sender.
+func newMessageID(from string) string {
+	domain := "localhost"
+	if address, err := mail.ParseAddress(from); err == nil {
+		if at := strings.LastIndex(address.Address, "@"); at != -1 {
+			domain = address.Address[at+1:]
+		}
+	}
+
+	return fmt.Sprintf("%s@%s", uuid.NewString(), domain)
+}
+
+// mimeEntity is a MIME header and its encoded body, either a whole message body or one
+// part of a multipart body.
+type mimeEntity struct {
+	header textproto.MIMEHeader
+	body   []byte
+}
+
+// buildMessage encodes an email as a MIME message. The text and HTML are sent as
+// multipart/alternative, and attachments wrap them in multipart/mixed. The Message-ID
+// header is left out when messageID is empty, for backends that set their own.
+func buildMessage(email Email, messageID string, date time.Time) ([]byte, error) {
+	if email.Html == "" && email.Text == "" {
+		return nil, fmt.Errorf("%w: to %v", ErrEmptyEmail, email.To)
+	}
+	if email.Html != "" && email.Text == "" {
+		email.Text = HTMLToText(email.Html)
+	}
+
+	headers := [][2]string{
+		{"From", email.From},
+		{"To", strings.Join(email.To, ", ")},
+	}
+	if len(email.ReplyTo) > 0 {
+		headers = append(headers, [2]string{"Reply-To", strings.Join(email.ReplyTo, ", ")})
+	}
+	headers = append(headers,
+		[2]string{"Subject", mime.QEncoding.Encode("utf-8", email.Subject)},
+		[2]string{"Date", date.Format(time.RFC1123Z)},
+	)
+	if messageID != "" {
+		headers = append(headers, [2]string{"Message-ID", "<" + messageID + ">"})
+	}
+	if len(email.ListUnsubscribe) > 0 {
+		urls := make([]string, len(email.ListUnsubscribe))
+		for i, url := range email.ListUnsubscribe {
+			urls[i] = "<" + url + ">"
+		}
+		headers = append(headers, [2]string{"List-Unsubscribe", strings.Join(urls, ", ")})
+
+		if email.ListUnsubscribePost {
+			headers = append(headers, [2]string{"List-Unsubscribe-Post", "List-Unsubscribe=One-Click"})
+		}
+	}
+	headers = append(headers, [2]string{"MIME-Version", "1.0"})
+
+	body, err := textEntity("text/plain", email.Text)
+	if err != nil {
+		return nil, err
+	}
+
+	if email.Html != "" {
+		html, err := textEntity("text/html", email.Html)
+		if err != nil {
+			return nil, err
+		}
+
+		// Clients show the last alternative they support, so HTML goes last.
+		body, err = multipartEntity("alternative", []mimeEntity{body, html})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(email.Attachments) > 0 {
+		parts := []mimeEntity{body}
+		for _, attachment := range email.Attachments {
+			part, err := attachmentEntity(attachment)
+			if err != nil {
+				return nil, err
+			}
+			parts = append(parts, part)
+		}
+
+		body, err = multipartEntity("mixed", parts)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var buf bytes.Buffer
+	for _, header := range headers {
+		if strings.ContainsAny(header[1], "\r\n") {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidHeader, header[0])
+		}
+		fmt.Fprintf(&buf, "%s: %s\r\n", header[0], header[1])
+	}
+	for _, key := range []string{"Content-Type", "Content-Transfer-Encoding"} {
+		if value := body.header.Get(key); value != "" {
+			fmt.Fprintf(&buf, "%s: %s\r\n", key, value)
+		}
+	}
+	buf.WriteString("\r\n")
+	buf.Write(body.body)
+
+	return buf.Bytes(), nil
+}
+
+func textEntity(contentType string, text string) (mimeEntity, error) {
+	var body bytes.Buffer
+
+	encoder := quotedprintable.NewWriter(&body)
+	if _, err := encoder.Write([]byte(text)); err != nil {
+		return mimeEntity{}, err
+	}
+	if err := encoder.Close(); err != nil {
+		return mimeEntity{}, err
+	}
+
+	return mimeEntity{
+		header: textproto.MIMEHeader{
+			"Content-Type":              {contentType + "; charset=UTF-8"},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		},
+		body: body.Bytes(),
+	}, nil
+}
+
+func attachmentEntity(attachment Attachment) (mimeEntity, error) {
+	contentType := attachment.ContentType
+	if contentType == "" {
+		contentType = mime.TypeByExtension(filepath.Ext(attachment.Filename))
+	}
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	disposition := mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename})
+	if disposition == "" || strings.ContainsAny(contentType, "\r\n") {
+		return mimeEntity{}, fmt.Errorf("%w: attachment %q", ErrInvalidHeader, attachment.Filename)
+	}
+
+	var body bytes.Buffer
+	encoded := base64.StdEncoding.EncodeToString(attachment.Data)
+	for len(encoded) > 76 {
+		body.WriteString(encoded[:76] + "\r\n")
+		encoded = encoded[76:]
+	}
+	body.WriteString(encoded)
+
+	return mimeEntity{
+		header: textproto.MIMEHeader{
+			"Content-Type":              {contentType},
+			"Content-Disposition":       {disposition},
+			"Content-Transfer-Encoding": {"base64"},
+		},
+		body: body.Bytes(),
+	}, nil
+}
+
+func multipartEntity(subtype string, parts []mimeEntity) (mimeEntity, error) {
+	var body bytes.Buffer
+
+	writer := multipart.NewWriter(&body)
+	for _, part := range parts {
+		partWriter, err := writer.CreatePart(part.header)
+		if err != nil {
+			return mimeEntity{}, err
+		}
+		if _, err := io.Copy(partWriter, bytes.NewReader(part.body)); err != nil {
+			return mimeEntity{}, err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return mimeEntity{}, err
+	}
+
+	return mimeEntity{
+		header: textproto.MIMEHeader{
+			"Content-Type": {"multipart/" + subtype + "; boundary=" + writer.Boundary()},
+		},
+		body: body.Bytes(),
+	}, nil
+}
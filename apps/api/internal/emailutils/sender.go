@@ -0,0 +1,71 @@
+package emailutils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/rs/zerolog"
+	"github.com/swamphacks/core/apps/api/internal/config"
+)
+
+const (
+	EmailBackendSES     = "ses"
+	EmailBackendSMTP    = "smtp"
+	EmailBackendCapture = "capture"
+)
+
+var ErrUnknownEmailBackend = errors.New("unknown email backend")
+
+// Email is a message to send. At least one of Html and Text must be set. When only Html
+// is, the plain text alternative is generated from it.
+type Email struct {
+	To      []string
+	From    string
+	ReplyTo []string
+	Subject string
+	Html    string
+	Text    string
+
+	// ListUnsubscribe are the mailto: and https: URLs the recipient's mail client can
+	// unsubscribe with. ListUnsubscribePost marks the https: URL as one-click (RFC 8058).
+	ListUnsubscribe     []string
+	ListUnsubscribePost bool
+
+	Attachments []Attachment
+}
+
+// Attachment is a file sent with an email. ContentType is guessed from the filename
+// when it is empty.
+type Attachment struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+// EmailSender delivers emails. Send returns the ID the backend gave the message, which SES
+// bounce and complaint notifications refer to.
+type EmailSender interface {
+	Send(ctx context.Context, email Email) (string, error)
+}
+
+// NewEmailSender creates the sender for the backend picked in the config.
+func NewEmailSender(cfg *config.Config, logger zerolog.Logger) (EmailSender, error) {
+	switch cfg.EmailBackend {
+	case EmailBackendSES, "":
+		client := NewSESClient(cfg.AWS.AccessKey, cfg.AWS.AccessKeySecret, cfg.AWS.Region, logger)
+		if client == nil {
+			return nil, errors.New("failed to create SES client")
+		}
+		return client, nil
+	case EmailBackendSMTP:
+		if cfg.Smtp.Host == "" {
+			return nil, errors.New("SMTP_HOST is required for the smtp email backend")
+		}
+		return NewSMTPSender(cfg.Smtp.Host, cfg.Smtp.Port, cfg.Smtp.Username, cfg.Smtp.Password, logger), nil
+	case EmailBackendCapture:
+		return NewCaptureSender(cfg.EmailCaptureDirectory, logger)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownEmailBackend, cfg.EmailBackend)
+	}
+}
@@ -0,0 +1,295 @@
+package emailutils
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"io"
+	"maps"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+func TestBuildMessage(t *testing.T) {
+	tests := []struct {
+		name     string
+		email    Email
+		expected map[string]string
+	}{
+		{
+			name: "html with generated text",
+			email: Email{
+				Html: "<p>Hi Albert</p>",
+			},
+			expected: map[string]string{
+				"text/html":  "<p>Hi Albert</p>",
+				"text/plain": "Hi Albert",
+			},
+		},
+		{
+			name: "text only",
+			email: Email{
+				Text: "Hi Albert",
+			},
+			expected: map[string]string{"text/plain": "Hi Albert"},
+		},
+		{
+			name: "html with text alternative",
+			email: Email{
+				Html: "<p>Hi Albert, this line is long enough that quoted-printable has to wrap it somewhere</p>",
+				Text: "Hi Albert",
+			},
+			expected: map[string]string{
+				"text/html":  "<p>Hi Albert, this line is long enough that quoted-printable has to wrap it somewhere</p>",
+				"text/plain": "Hi Albert",
+			},
+		},
+		{
+			name: "attachments",
+			email: Email{
+				Html: "<p>See you there</p>",
+				Attachments: []Attachment{
+					{Filename: "invite.ics", ContentType: "text/calendar; method=REQUEST", Data: []byte("BEGIN:VCALENDAR")},
+					{Filename: "itinerary.pdf", Data: bytes.Repeat([]byte("%PDF"), 40)},
+				},
+			},
+			expected: map[string]string{
+				"text/html":       "<p>See you there</p>",
+				"text/plain":      "See you there",
+				"text/calendar":   "BEGIN:VCALENDAR",
+				"application/pdf": strings.Repeat("%PDF", 40),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.email.To = []string{"albert@ufl.edu", "alberta@ufl.edu"}
+			test.email.From = "SwampHacks <no-reply@swamphacks.com>"
+			test.email.Subject = "Welcome to SwampHacks ⚡"
+
+			raw, err := buildMessage(test.email, "id@swamphacks.com", time.Now())
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			message, err := mail.ReadMessage(bytes.NewReader(raw))
+			if err != nil {
+				t.Fatalf("failed to parse message: %v", err)
+			}
+
+			subject, err := new(mime.WordDecoder).DecodeHeader(message.Header.Get("Subject"))
+			if err != nil || subject != test.email.Subject {
+				t.Fatalf("expected subject %q, got %q (%v)", test.email.Subject, subject, err)
+			}
+			if to := message.Header.Get("To"); to != "albert@ufl.edu, alberta@ufl.edu" {
+				t.Fatalf("unexpected To header %q", to)
+			}
+			if id := message.Header.Get("Message-ID"); id != "<id@swamphacks.com>" {
+				t.Fatalf("unexpected Message-ID header %q", id)
+			}
+
+			parts := readParts(t, message.Header.Get("Content-Type"), message.Body)
+			if len(parts) != len(test.expected) {
+				t.Fatalf("expected %d parts, got %d", len(test.expected), len(parts))
+			}
+			for contentType, body := range test.expected {
+				if parts[contentType] != body {
+					t.Fatalf("expected %s part %q, got %q", contentType, body, parts[contentType])
+				}
+			}
+		})
+	}
+}
+
+func TestBuildMessageHeaders(t *testing.T) {
+	raw, err := buildMessage(Email{
+		To:                  []string{"albert@ufl.edu"},
+		From:                "SwampHacks <no-reply@swamphacks.com>",
+		ReplyTo:             []string{"sponsors@swamphacks.com"},
+		Subject:             "Welcome",
+		Text:                "Hi Albert",
+		ListUnsubscribe:     []string{"https://api.swamphacks.com/email/unsubscribe?token=abc", "mailto:unsubscribe@swamphacks.com"},
+		ListUnsubscribePost: true,
+	}, "", time.Now())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	message, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("failed to parse message: %v", err)
+	}
+
+	expected := map[string]string{
+		"Reply-To":              "sponsors@swamphacks.com",
+		"List-Unsubscribe":      "<https://api.swamphacks.com/email/unsubscribe?token=abc>, <mailto:unsubscribe@swamphacks.com>",
+		"List-Unsubscribe-Post": "List-Unsubscribe=One-Click",
+		"Message-ID":            "",
+	}
+	for key, value := range expected {
+		if got := message.Header.Get(key); got != value {
+			t.Fatalf("expected %s header %q, got %q", key, value, got)
+		}
+	}
+}
+
+func TestBuildMessageErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		email         Email
+		expectedError error
+	}{
+		{
+			name:          "no body",
+			email:         Email{To: []string{"albert@ufl.edu"}},
+			expectedError: ErrEmptyEmail,
+		},
+		{
+			name:          "line break in reply-to",
+			email:         Email{To: []string{"albert@ufl.edu"}, ReplyTo: []string{"a@ufl.edu\r\nBcc: b@ufl.edu"}, Text: "Hi"},
+			expectedError: ErrInvalidHeader,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := buildMessage(test.email, "id@swamphacks.com", time.Now())
+			if !errors.Is(err, test.expectedError) {
+				t.Fatalf("expected error %v, got %v", test.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestHTMLToText(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		expected string
+	}{
+		{
+			name:     "paragraphs and breaks",
+			html:     "<html><head><title>Hi</title><style>p { color: red; }</style></head><body><h1>Welcome!</h1>\n<p>Hi   Albert,<br>see you\nsoon</p></body></html>",
+			expected: "Welcome!\n\nHi Albert,\nsee you soon",
+		},
+		{
+			name:     "links",
+			html:     `<p><a href="https://swamphacks.com">our site</a>, <a href="https://swamphacks.com/faq">https://swamphacks.com/faq</a> or <a href="mailto:contact@swamphacks.com">contact@swamphacks.com</a></p>`,
+			expected: "our site (https://swamphacks.com), https://swamphacks.com/faq or contact@swamphacks.com",
+		},
+		{
+			name:     "lists and entities",
+			html:     "<ul><li>Food &amp; drinks</li><li>Swag&nbsp;&lt;3</li></ul>",
+			expected: "- Food & drinks\n\n- Swag <3",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if result := HTMLToText(test.html); result != test.expected {
+				t.Fatalf("expected %q, got %q", test.expected, result)
+			}
+		})
+	}
+}
+
+func TestCaptureSender(t *testing.T) {
+	dir := t.TempDir()
+
+	sender, err := NewCaptureSender(dir, zerolog.Nop())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	email := Email{
+		To:      []string{"albert@ufl.edu"},
+		From:    "SwampHacks <no-reply@swamphacks.com>",
+		Subject: "Welcome",
+		Text:    "Hi Albert",
+	}
+
+	messageID, err := sender.Send(context.Background(), email)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !strings.HasSuffix(messageID, "@swamphacks.com") {
+		t.Fatalf("expected a message ID on the sender's domain, got %q", messageID)
+	}
+
+	emails := sender.Emails()
+	if len(emails) != 1 || emails[0].MessageID != messageID || emails[0].Email.Subject != email.Subject {
+		t.Fatalf("expected the sent email to be captured, got %+v", emails)
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+	if err != nil || len(files) != 1 {
+		t.Fatalf("expected one .eml file, got %v (%v)", files, err)
+	}
+
+	written, err := os.ReadFile(files[0])
+	if err != nil {
+		t.Fatalf("failed to read captured email: %v", err)
+	}
+	if !bytes.Equal(written, emails[0].Message) {
+		t.Fatal("expected the .eml file to hold the captured message")
+	}
+}
+
+// readParts returns the decoded body of every part of a message by content type.
+func readParts(t *testing.T, contentType string, body io.Reader) map[string]string {
+	t.Helper()
+
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		t.Fatalf("invalid Content-Type %q: %v", contentType, err)
+	}
+
+	if !strings.HasPrefix(mediaType, "multipart/") {
+		decoded, err := io.ReadAll(quotedprintable.NewReader(body))
+		if err != nil {
+			t.Fatalf("failed to decode body: %v", err)
+		}
+		return map[string]string{mediaType: string(decoded)}
+	}
+
+	parts := map[string]string{}
+	reader := multipart.NewReader(body, params["boundary"])
+	for {
+		// NextPart decodes quoted-printable parts itself.
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return parts
+		}
+		if err != nil {
+			t.Fatalf("failed to read part: %v", err)
+		}
+
+		partType, _, _ := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		if strings.HasPrefix(partType, "multipart/") {
+			maps.Copy(parts, readParts(t, part.Header.Get("Content-Type"), part))
+			continue
+		}
+
+		var partBody io.Reader = part
+		if part.Header.Get("Content-Transfer-Encoding") == "base64" {
+			partBody = base64.NewDecoder(base64.StdEncoding, part)
+		}
+
+		decoded, err := io.ReadAll(partBody)
+		if err != nil {
+			t.Fatalf("failed to read part: %v", err)
+		}
+		parts[partType] = string(decoded)
+	}
+}
@@ -2,7 +2,9 @@ package emailutils
 
 import (
 	"context"
+	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	awsConfig "github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/ses"
@@ -34,52 +36,24 @@ func NewSESClient(accessKey, secretkey, region string, logger zerolog.Logger) *S
 	}
 }
 
-func (c *SESClient) SendEmail(to []string, from, subject string, body string) error {
-	_, err := c.client.SendEmail(context.TODO(), &ses.SendEmailInput{
-		Destination: &types.Destination{
-			ToAddresses: to,
-		},
-		Source: &from,
-		Message: &types.Message{
-			Subject: &types.Content{
-				Data: &subject,
-			},
-			Body: &types.Body{
-				Text: &types.Content{
-					Data: &body,
-				},
-			},
-		},
-	})
+// Send sends an email through SES as a raw MIME message and returns its SES message ID.
+// SES sets the Message-ID header itself and takes the sender from the From header.
+func (c *SESClient) Send(ctx context.Context, email Email) (string, error) {
+	message, err := buildMessage(email, "", time.Now())
 	if err != nil {
-		c.logger.Err(err).Msg("Failed to send email")
-		return err
+		return "", err
 	}
 
-	return nil
-}
-
-func (c *SESClient) SendHTMLEmail(to []string, from, subject string, htmlBody string) error {
-	_, err := c.client.SendEmail(context.TODO(), &ses.SendEmailInput{
-		Destination: &types.Destination{
-			ToAddresses: to,
-		},
-		Source: &from,
-		Message: &types.Message{
-			Subject: &types.Content{
-				Data: &subject,
-			},
-			Body: &types.Body{
-				Html: &types.Content{
-					Data: &htmlBody,
-				},
-			},
+	output, err := c.client.SendRawEmail(ctx, &ses.SendRawEmailInput{
+		Destinations: email.To,
+		RawMessage: &types.RawMessage{
+			Data: message,
 		},
 	})
 	if err != nil {
-		c.logger.Err(err).Msg("Failed to send HTML email")
-		return err
+		c.logger.Err(err).Msg("Failed to send email")
+		return "", err
 	}
 
-	return nil
+	return aws.ToString(output.MessageId), nil
 }
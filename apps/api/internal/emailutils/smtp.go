@@ -0,0 +1,71 @@
+package emailutils
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+type SMTPSender struct {
+	addr     string
+	host     string
+	username string
+	password string
+	logger   zerolog.Logger
+}
+
+// NewSMTPSender creates a sender for an SMTP server. Emails are sent without auth when
+// username is empty.
+func NewSMTPSender(host, port, username, password string, logger zerolog.Logger) *SMTPSender {
+	if port == "" {
+		port = "25"
+	}
+
+	return &SMTPSender{
+		addr:     net.JoinHostPort(host, port),
+		host:     host,
+		username: username,
+		password: password,
+		logger:   logger.With().Str("component", "smtp_sender").Logger(),
+	}
+}
+
+// Send sends an email through the SMTP server and returns the Message-ID it was sent with.
+func (s *SMTPSender) Send(ctx context.Context, email Email) (string, error) {
+	from, err := mail.ParseAddress(email.From)
+	if err != nil {
+		return "", fmt.Errorf("invalid sender %q: %w", email.From, err)
+	}
+
+	to := make([]string, 0, len(email.To))
+	for _, recipient := range email.To {
+		address, err := mail.ParseAddress(recipient)
+		if err != nil {
+			return "", fmt.Errorf("invalid recipient %q: %w", recipient, err)
+		}
+		to = append(to, address.Address)
+	}
+
+	messageID := newMessageID(email.From)
+	message, err := buildMessage(email, messageID, time.Now())
+	if err != nil {
+		return "", err
+	}
+
+	var auth smtp.Auth
+	if s.username != "" {
+		auth = smtp.PlainAuth("", s.username, s.password, s.host)
+	}
+
+	if err := smtp.SendMail(s.addr, auth, from.Address, to, message); err != nil {
+		s.logger.Err(err).Msg("Failed to send email")
+		return "", err
+	}
+
+	return messageID, nil
+}
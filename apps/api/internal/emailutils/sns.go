@@ -0,0 +1,245 @@
+package emailutils
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+var (
+	ErrInvalidSNSMessage        = errors.New("invalid SNS message")
+	ErrInvalidSNSSignature      = errors.New("SNS message signature does not match")
+	ErrUntrustedSNSCertificate  = errors.New("SNS signing certificate is not from AWS")
+	ErrUnsupportedSNSSignature  = errors.New("unsupported SNS signature version")
+	ErrUntrustedSNSSubscribeURL = errors.New("SNS subscribe URL is not from AWS")
+)
+
+const (
+	SNSTypeNotification             = "Notification"
+	SNSTypeSubscriptionConfirmation = "SubscriptionConfirmation"
+	SNSTypeUnsubscribeConfirmation  = "UnsubscribeConfirmation"
+)
+
+// snsHost matches the hosts SNS signs certificates and subscription links from.
+var snsHost = regexp.MustCompile(`^sns\.[a-z0-9-]+\.amazonaws\.com(\.cn)?$`)
+
+// SNSMessage is a message SNS posts to an HTTPS subscription.
+type SNSMessage struct {
+	Type             string `json:"Type"`
+	MessageId        string `json:"MessageId"`
+	Token            string `json:"Token,omitempty"`
+	TopicArn         string `json:"TopicArn"`
+	Subject          string `json:"Subject,omitempty"`
+	Message          string `json:"Message"`
+	Timestamp        string `json:"Timestamp"`
+	SignatureVersion string `json:"SignatureVersion"`
+	Signature        string `json:"Signature"`
+	SigningCertURL   string `json:"SigningCertURL"`
+	SubscribeURL     string `json:"SubscribeURL,omitempty"`
+	UnsubscribeURL   string `json:"UnsubscribeURL,omitempty"`
+}
+
+func ParseSNSMessage(body []byte) (*SNSMessage, error) {
+	var message SNSMessage
+	if err := json.Unmarshal(body, &message); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSNSMessage, err)
+	}
+
+	if message.Type == "" || message.MessageId == "" || message.Signature == "" || message.SigningCertURL == "" {
+		return nil, ErrInvalidSNSMessage
+	}
+
+	return &message, nil
+}
+
+// stringToSign builds the canonical string SNS signs, which depends on the message type.
+func (m *SNSMessage) stringToSign() (string, error) {
+	var fields [][2]string
+
+	switch m.Type {
+	case SNSTypeNotification:
+		fields = [][2]string{{"Message", m.Message}, {"MessageId", m.MessageId}}
+		if m.Subject != "" {
+			fields = append(fields, [2]string{"Subject", m.Subject})
+		}
+		fields = append(fields, [][2]string{{"Timestamp", m.Timestamp}, {"TopicArn", m.TopicArn}, {"Type", m.Type}}...)
+	case SNSTypeSubscriptionConfirmation, SNSTypeUnsubscribeConfirmation:
+		fields = [][2]string{
+			{"Message", m.Message},
+			{"MessageId", m.MessageId},
+			{"SubscribeURL", m.SubscribeURL},
+			{"Timestamp", m.Timestamp},
+			{"Token", m.Token},
+			{"TopicArn", m.TopicArn},
+			{"Type", m.Type},
+		}
+	default:
+		return "", fmt.Errorf("%w: unknown type %q", ErrInvalidSNSMessage, m.Type)
+	}
+
+	var b strings.Builder
+	for _, field := range fields {
+		b.WriteString(field[0])
+		b.WriteByte('\n')
+		b.WriteString(field[1])
+		b.WriteByte('\n')
+	}
+
+	return b.String(), nil
+}
+
+// SNSVerifier checks that SNS messages were signed by AWS. Signing certificates are
+// fetched once and cached.
+type SNSVerifier struct {
+	client *http.Client
+	certs  sync.Map
+}
+
+func NewSNSVerifier(client *http.Client) *SNSVerifier {
+	return &SNSVerifier{client: client}
+}
+
+// Verify checks the signature of a message against its AWS signing certificate.
+func (v *SNSVerifier) Verify(ctx context.Context, message *SNSMessage) error {
+	if err := checkSNSURL(message.SigningCertURL); err != nil {
+		return ErrUntrustedSNSCertificate
+	}
+
+	var hash crypto.Hash
+	switch message.SignatureVersion {
+	case "1":
+		hash = crypto.SHA1
+	case "2":
+		hash = crypto.SHA256
+	default:
+		return ErrUnsupportedSNSSignature
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(message.Signature)
+	if err != nil {
+		return ErrInvalidSNSSignature
+	}
+
+	toSign, err := message.stringToSign()
+	if err != nil {
+		return err
+	}
+
+	cert, err := v.certificate(ctx, message.SigningCertURL)
+	if err != nil {
+		return err
+	}
+
+	publicKey, ok := cert.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return ErrUntrustedSNSCertificate
+	}
+
+	var digest []byte
+	if hash == crypto.SHA1 {
+		sum := sha1.Sum([]byte(toSign))
+		digest = sum[:]
+	} else {
+		sum := sha256.Sum256([]byte(toSign))
+		digest = sum[:]
+	}
+
+	if err := rsa.VerifyPKCS1v15(publicKey, hash, digest, signature); err != nil {
+		return ErrInvalidSNSSignature
+	}
+
+	return nil
+}
+
+// ConfirmSubscription visits the subscribe URL of a verified subscription confirmation.
+func (v *SNSVerifier) ConfirmSubscription(ctx context.Context, message *SNSMessage) error {
+	if err := checkSNSURL(message.SubscribeURL); err != nil {
+		return ErrUntrustedSNSSubscribeURL
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, message.SubscribeURL, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("confirming SNS subscription returned %s", resp.Status)
+	}
+
+	return nil
+}
+
+func (v *SNSVerifier) certificate(ctx context.Context, certURL string) (*x509.Certificate, error) {
+	if cert, ok := v.certs.Load(certURL); ok {
+		return cert.(*x509.Certificate), nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, certURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching SNS signing certificate returned %s", resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, ErrUntrustedSNSCertificate
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, ErrUntrustedSNSCertificate
+	}
+
+	v.certs.Store(certURL, cert)
+
+	return cert, nil
+}
+
+// checkSNSURL only accepts HTTPS links to an SNS endpoint, so a forged message can't make
+// us trust or call an arbitrary host.
+func checkSNSURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+
+	if u.Scheme != "https" || !snsHost.MatchString(u.Hostname()) || u.Port() != "" {
+		return fmt.Errorf("untrusted SNS URL %q", rawURL)
+	}
+
+	return nil
+}
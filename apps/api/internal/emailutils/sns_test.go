@@ -0,0 +1,209 @@
+package emailutils
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"errors"
+	"math/big"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+)
+
+const testCertURL = "https://sns.us-east-1.amazonaws.com/SimpleNotificationService-test.pem"
+
+// newTestVerifier returns a verifier that already trusts a freshly generated certificate
+// for testCertURL, together with the key to sign messages with.
+func newTestVerifier(t *testing.T) (*SNSVerifier, *rsa.PrivateKey) {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "sns.amazonaws.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	verifier := NewSNSVerifier(http.DefaultClient)
+	verifier.certs.Store(testCertURL, cert)
+
+	return verifier, key
+}
+
+func signTestMessage(t *testing.T, key *rsa.PrivateKey, message *SNSMessage) {
+	t.Helper()
+
+	toSign, err := message.stringToSign()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var hash crypto.Hash
+	var digest []byte
+	if message.SignatureVersion == "1" {
+		hash = crypto.SHA1
+		sum := sha1.Sum([]byte(toSign))
+		digest = sum[:]
+	} else {
+		hash = crypto.SHA256
+		sum := sha256.Sum256([]byte(toSign))
+		digest = sum[:]
+	}
+
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, hash, digest)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	message.Signature = base64.StdEncoding.EncodeToString(signature)
+}
+
+func testNotification(t *testing.T, fixture string, signatureVersion string) *SNSMessage {
+	t.Helper()
+
+	body, err := os.ReadFile(fixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &SNSMessage{
+		Type:             SNSTypeNotification,
+		MessageId:        "22b80b92-fdea-4c2c-8f9d-bdfb0c7bf324",
+		TopicArn:         "arn:aws:sns:us-east-1:123456789012:ses-notifications",
+		Message:          string(body),
+		Timestamp:        "2026-08-01T12:00:01.000Z",
+		SignatureVersion: signatureVersion,
+		SigningCertURL:   testCertURL,
+	}
+}
+
+func TestSNSVerifierVerify(t *testing.T) {
+	verifier, key := newTestVerifier(t)
+
+	tests := []struct {
+		name          string
+		message       func() *SNSMessage
+		expectedError error
+	}{
+		{
+			name: "bounce signed with sha1",
+			message: func() *SNSMessage {
+				message := testNotification(t, "testdata/bounce.json", "1")
+				signTestMessage(t, key, message)
+				return message
+			},
+		},
+		{
+			name: "complaint signed with sha256",
+			message: func() *SNSMessage {
+				message := testNotification(t, "testdata/complaint.json", "2")
+				signTestMessage(t, key, message)
+				return message
+			},
+		},
+		{
+			name: "subscription confirmation",
+			message: func() *SNSMessage {
+				message := &SNSMessage{
+					Type:             SNSTypeSubscriptionConfirmation,
+					MessageId:        "165545c9-2a5c-472c-8df2-7ff2be2b3b1b",
+					Token:            "2336412f37",
+					TopicArn:         "arn:aws:sns:us-east-1:123456789012:ses-notifications",
+					Message:          "You have chosen to subscribe to the topic.",
+					SubscribeURL:     "https://sns.us-east-1.amazonaws.com/?Action=ConfirmSubscription&Token=2336412f37",
+					Timestamp:        "2026-08-01T12:00:01.000Z",
+					SignatureVersion: "1",
+					SigningCertURL:   testCertURL,
+				}
+				signTestMessage(t, key, message)
+				return message
+			},
+		},
+		{
+			name: "tampered message",
+			message: func() *SNSMessage {
+				message := testNotification(t, "testdata/bounce.json", "1")
+				signTestMessage(t, key, message)
+				message.Message = `{"notificationType":"Complaint"}`
+				return message
+			},
+			expectedError: ErrInvalidSNSSignature,
+		},
+		{
+			name: "certificate not from aws",
+			message: func() *SNSMessage {
+				message := testNotification(t, "testdata/bounce.json", "1")
+				message.SigningCertURL = "https://sns.us-east-1.amazonaws.com.evil.com/cert.pem"
+				signTestMessage(t, key, message)
+				return message
+			},
+			expectedError: ErrUntrustedSNSCertificate,
+		},
+		{
+			name: "unsupported signature version",
+			message: func() *SNSMessage {
+				message := testNotification(t, "testdata/bounce.json", "3")
+				message.Signature = "c2lnbmF0dXJl"
+				return message
+			},
+			expectedError: ErrUnsupportedSNSSignature,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := verifier.Verify(context.Background(), test.message())
+
+			if !errors.Is(err, test.expectedError) {
+				t.Fatalf("expected error %v, got %v", test.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestCheckSNSURL(t *testing.T) {
+	tests := []struct {
+		url      string
+		expected bool
+	}{
+		{url: "https://sns.us-east-1.amazonaws.com/SimpleNotificationService.pem", expected: true},
+		{url: "https://sns.cn-north-1.amazonaws.com.cn/SimpleNotificationService.pem", expected: true},
+		{url: "http://sns.us-east-1.amazonaws.com/SimpleNotificationService.pem", expected: false},
+		{url: "https://sns.us-east-1.amazonaws.com:8443/SimpleNotificationService.pem", expected: false},
+		{url: "https://sns.us-east-1.amazonaws.com.evil.com/SimpleNotificationService.pem", expected: false},
+		{url: "https://evil.com/sns.us-east-1.amazonaws.com", expected: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.url, func(t *testing.T) {
+			result := checkSNSURL(test.url) == nil
+
+			if result != test.expected {
+				t.Fatalf("expected %v, got %v", test.expected, result)
+			}
+		})
+	}
+}
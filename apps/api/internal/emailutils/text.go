@@ -0,0 +1,58 @@
+package emailutils
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	htmlSkippedRegex   = regexp.MustCompile(`(?is)<(head|style|script|title)\b.*?</(head|style|script|title)>|<!--.*?-->`)
+	htmlLinkRegex      = regexp.MustCompile(`(?is)<a\b[^>]*?\bhref\s*=\s*["']([^"']*)["'][^>]*>(.*?)</a>`)
+	htmlBreakRegex     = regexp.MustCompile(`(?i)<br\s*/?>`)
+	htmlListItemRegex  = regexp.MustCompile(`(?i)<li\b[^>]*>`)
+	htmlBlockEndRegex  = regexp.MustCompile(`(?i)</(p|div|h[1-6]|tr|table|ul|ol|li|blockquote)>|<hr\b[^>]*>`)
+	htmlTagRegex       = regexp.MustCompile(`(?s)<[^>]*>`)
+	inlineSpaceRegex   = regexp.MustCompile(`[ \t\x{a0}]+`)
+	extraNewlinesRegex = regexp.MustCompile(`\n{3,}`)
+)
+
+// HTMLToText turns an HTML email into its plain text alternative. Links keep their URL
+// after the link text and block elements become line breaks.
+func HTMLToText(body string) string {
+	text := htmlSkippedRegex.ReplaceAllString(body, "")
+	text = strings.NewReplacer("\r\n", " ", "\n", " ", "\r", " ").Replace(text)
+
+	text = htmlLinkRegex.ReplaceAllStringFunc(text, func(link string) string {
+		match := htmlLinkRegex.FindStringSubmatch(link)
+		// Both stay escaped until the whole text is unescaped below.
+		href := strings.TrimSpace(match[1])
+		label := strings.TrimSpace(htmlTagRegex.ReplaceAllString(match[2], ""))
+
+		switch {
+		case href == "" || strings.HasPrefix(href, "#"):
+			return label
+		case label == "" || label == href:
+			return href
+		case "mailto:"+label == href:
+			return label
+		default:
+			return label + " (" + href + ")"
+		}
+	})
+
+	text = htmlBreakRegex.ReplaceAllString(text, "\n")
+	text = htmlListItemRegex.ReplaceAllString(text, "\n- ")
+	text = htmlBlockEndRegex.ReplaceAllString(text, "\n\n")
+	text = htmlTagRegex.ReplaceAllString(text, "")
+	text = html.UnescapeString(text)
+
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(inlineSpaceRegex.ReplaceAllString(line, " "))
+	}
+	text = strings.Join(lines, "\n")
+	text = extraNewlinesRegex.ReplaceAllString(text, "\n\n")
+
+	return strings.TrimSpace(text)
+}
@@ -8,20 +8,14 @@ import (
 )
 
 const (
-	TypeCalculateAdmissions        = "admissions:calculate"
-	TypeScheduleTransitionWaitlist = "waitlist:scheduletransition"
-	TypeTransitionWaitlist         = "waitlist:transition"
-	TypeShutdownScheduler          = "waitlist:shutdownscheduler"
+	TypeCalculateAdmissions = "admissions:calculate"
+	TypeTransitionWaitlist  = "waitlist:transition"
 )
 
 type CalculateAdmissionsPayload struct {
 	BatRunID uuid.UUID
 }
 
-type ScheduleTransitionWaitlistPayload struct {
-	Period string
-}
-
 type TransitionWaitlistPayload struct {
 	AcceptFromWaitlistCount uint32
 	MaxAcceptedApplications uint32
@@ -36,15 +30,6 @@ func NewTaskCalculateAdmissions(payload CalculateAdmissionsPayload) (*asynq.Task
 	return asynq.NewTask(TypeCalculateAdmissions, data), nil
 }
 
-func NewTaskScheduleTransitionWaitlist(payload ScheduleTransitionWaitlistPayload) (*asynq.Task, error) {
-	data, err := json.Marshal(payload)
-	if err != nil {
-		return nil, err
-	}
-
-	return asynq.NewTask(TypeScheduleTransitionWaitlist, data), nil
-}
-
 func NewTaskTransitionWaitlist(payload TransitionWaitlistPayload) (*asynq.Task, error) {
 	data, err := json.Marshal(payload)
 	if err != nil {
@@ -53,7 +38,3 @@ func NewTaskTransitionWaitlist(payload TransitionWaitlistPayload) (*asynq.Task,
 
 	return asynq.NewTask(TypeTransitionWaitlist, data), nil
 }
-
-func NewTaskShutdownScheduler() (*asynq.Task, error) {
-	return asynq.NewTask(TypeShutdownScheduler, nil), nil
-}
@@ -0,0 +1,35 @@
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+)
+
+const (
+	TypeApplyDecisionRelease = "decisions:apply"
+	TypeSendDecisionEmails   = "decisions:sendemails"
+)
+
+type DecisionReleasePayload struct {
+	ReleaseID uuid.UUID
+}
+
+func NewTaskApplyDecisionRelease(payload DecisionReleasePayload) (*asynq.Task, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return asynq.NewTask(TypeApplyDecisionRelease, data), nil
+}
+
+func NewTaskSendDecisionEmails(payload DecisionReleasePayload) (*asynq.Task, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return asynq.NewTask(TypeSendDecisionEmails, data), nil
+}
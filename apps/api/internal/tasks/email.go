@@ -3,6 +3,7 @@ package tasks
 import (
 	"encoding/json"
 
+	"github.com/google/uuid"
 	"github.com/hibiken/asynq"
 )
 
@@ -11,17 +12,42 @@ const (
 	TypeSendHtmlEmail = "htmlemail:send"
 )
 
+// EmailOptions are the parts of a queued email besides its content.
+type EmailOptions struct {
+	// Category is the email preference category the email belongs to. The worker skips
+	// recipients that unsubscribed from it. Empty for emails everyone gets.
+	Category string
+	ReplyTo  []string
+	// ListUnsubscribe are mailto: and https: URLs. ListUnsubscribePost marks the https:
+	// URL as one-click.
+	ListUnsubscribe     []string
+	ListUnsubscribePost bool
+	Attachments         []EmailAttachment
+}
+
+type EmailAttachment struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
 type SendTextEmailPayload struct {
 	To      []string
 	Subject string
 	Body    string
+	Options EmailOptions
 }
 
 type SendHtmlEmailPayload struct {
-	To               string
-	Subject          string
-	TemplateData     interface{}
-	TemplateFilePath string
+	DeliveryID *uuid.UUID
+	// TemplateVersionID is set when the hackathon overrides the template. Its subject is
+	// already rendered into Subject.
+	TemplateVersionID *uuid.UUID
+	To                string
+	Subject           string
+	TemplateData      interface{}
+	TemplateFilePath  string
+	Options           EmailOptions
 }
 
 func NewTaskSendTextEmail(payload SendTextEmailPayload) (*asynq.Task, error) {
@@ -41,3 +67,49 @@ func NewTaskSendHtmlEmail(payload SendHtmlEmailPayload) (*asynq.Task, error) {
 
 	return asynq.NewTask(TypeSendHtmlEmail, data), nil
 }
+
+const (
+	TypeSendCampaign      = "campaign:send"
+	TypeSendCampaignBatch = "campaign:sendbatch"
+)
+
+type SendCampaignPayload struct {
+	CampaignID  uuid.UUID
+	HackathonID string
+}
+
+type CampaignRecipient struct {
+	DeliveryID uuid.UUID
+	Name       string
+	Email      string
+}
+
+type SendCampaignBatchPayload struct {
+	CampaignID  uuid.UUID
+	HackathonID string
+	Recipients  []CampaignRecipient
+}
+
+func NewTaskSendCampaign(payload SendCampaignPayload) (*asynq.Task, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return asynq.NewTask(TypeSendCampaign, data), nil
+}
+
+func NewTaskSendCampaignBatch(payload SendCampaignBatchPayload) (*asynq.Task, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return asynq.NewTask(TypeSendCampaignBatch, data), nil
+}
+
+const TypeDispatchCampaigns = "campaign:dispatch"
+
+func NewTaskDispatchCampaigns() *asynq.Task {
+	return asynq.NewTask(TypeDispatchCampaigns, nil)
+}
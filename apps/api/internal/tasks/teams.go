@@ -0,0 +1,9 @@
+package tasks
+
+import "github.com/hibiken/asynq"
+
+const TypeExpireTeamJoinRequests = "team:expire_join_requests"
+
+func NewTaskExpireTeamJoinRequests() *asynq.Task {
+	return asynq.NewTask(TypeExpireTeamJoinRequests, nil)
+}
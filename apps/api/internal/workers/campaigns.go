@@ -0,0 +1,93 @@
+package workers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog"
+	"github.com/swamphacks/core/apps/api/internal/domains/email"
+	"github.com/swamphacks/core/apps/api/internal/tasks"
+)
+
+// Campaign Worker
+// The campaign worker starts scheduled email campaigns once they are due, resolves
+// the recipients of a sending campaign into batches, and sends every batch at a
+// limited rate.
+type CampaignWorker struct {
+	emailCampaignService *email.EmailCampaignService
+	logger               zerolog.Logger
+}
+
+func NewCampaignWorker(emailCampaignService *email.EmailCampaignService, logger zerolog.Logger) *CampaignWorker {
+	return &CampaignWorker{
+		emailCampaignService: emailCampaignService,
+		logger:               logger.With().Str("worker", "CampaignWorker").Logger(),
+	}
+}
+
+func (w *CampaignWorker) HandleDispatchCampaignsTask(ctx context.Context, t *asynq.Task) error {
+	dispatched, err := w.emailCampaignService.DispatchDueCampaigns(ctx)
+	if dispatched > 0 {
+		w.logger.Info().Int("Campaigns", dispatched).Msg("Started sending scheduled email campaigns.")
+	}
+	if err != nil {
+		// The next dispatch picks up whatever this one missed.
+		return fmt.Errorf("HandleDispatchCampaignsTask: %v: %w", err, asynq.SkipRetry)
+	}
+
+	return nil
+}
+
+func (w *CampaignWorker) HandleSendCampaignTask(ctx context.Context, t *asynq.Task) error {
+	var p tasks.SendCampaignPayload
+	if err := json.Unmarshal(t.Payload(), &p); err != nil {
+		w.logger.Err(err).Msg("Failed to unmarshal payload.")
+		return fmt.Errorf("HandleSendCampaignTask: json.Unmarshal failed: %v: %w", err, asynq.SkipRetry)
+	}
+
+	err := w.emailCampaignService.PrepareCampaign(ctx, p.CampaignID, p.HackathonID)
+	if err == nil {
+		return nil
+	}
+
+	if errors.Is(err, email.ErrEmailCampaignNotSending) || errors.Is(err, email.ErrEmailCampaignNotFound) {
+		w.logger.Info().Str("CampaignID", p.CampaignID.String()).Msg("Email campaign is no longer sending, skipping.")
+		return nil
+	}
+
+	// The campaign was marked as failed, it has to be sent again.
+	w.logger.Err(err).Str("CampaignID", p.CampaignID.String()).Msg("Failed to prepare email campaign.")
+	return fmt.Errorf("HandleSendCampaignTask: %v: %w", err, asynq.SkipRetry)
+}
+
+func (w *CampaignWorker) HandleSendCampaignBatchTask(ctx context.Context, t *asynq.Task) error {
+	var p tasks.SendCampaignBatchPayload
+	if err := json.Unmarshal(t.Payload(), &p); err != nil {
+		w.logger.Err(err).Msg("Failed to unmarshal payload.")
+		return fmt.Errorf("HandleSendCampaignBatchTask: json.Unmarshal failed: %v: %w", err, asynq.SkipRetry)
+	}
+
+	err := w.emailCampaignService.SendCampaignBatch(ctx, p)
+	if err == nil {
+		return nil
+	}
+
+	if errors.Is(err, email.ErrEmailCampaignNotSending) || errors.Is(err, email.ErrEmailCampaignNotFound) {
+		w.logger.Info().Str("CampaignID", p.CampaignID.String()).Msg("Email campaign is no longer sending, skipping batch.")
+		return nil
+	}
+
+	// The worker is shutting down, the batch runs again and skips the recipients that
+	// were already sent to.
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		w.logger.Warn().Str("CampaignID", p.CampaignID.String()).Msg("Email campaign batch was interrupted, retrying.")
+		return fmt.Errorf("HandleSendCampaignBatchTask: %w", err)
+	}
+
+	// The campaign was marked as failed, it has to be sent again.
+	w.logger.Err(err).Str("CampaignID", p.CampaignID.String()).Msg("Failed to send email campaign batch.")
+	return fmt.Errorf("HandleSendCampaignBatchTask: %v: %w", err, asynq.SkipRetry)
+}
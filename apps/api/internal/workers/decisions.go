@@ -0,0 +1,85 @@
+package workers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog"
+	"github.com/swamphacks/core/apps/api/internal/domains/decisions"
+	"github.com/swamphacks/core/apps/api/internal/tasks"
+)
+
+// Decision Worker
+// The decision worker applies scheduled decision releases once their
+// release time comes, and queues the decision emails of applied releases
+// once the rollback window has passed.
+type DecisionWorker struct {
+	decisionService *decisions.DecisionService
+	logger          zerolog.Logger
+}
+
+func NewDecisionWorker(decisionService *decisions.DecisionService, logger zerolog.Logger) *DecisionWorker {
+	return &DecisionWorker{
+		decisionService: decisionService,
+		logger:          logger.With().Str("worker", "DecisionWorker").Logger(),
+	}
+}
+
+func (w *DecisionWorker) HandleApplyDecisionReleaseTask(ctx context.Context, t *asynq.Task) error {
+	var p tasks.DecisionReleasePayload
+	if err := json.Unmarshal(t.Payload(), &p); err != nil {
+		w.logger.Err(err).Msg("Failed to unmarshal payload.")
+		return fmt.Errorf("HandleApplyDecisionReleaseTask: json.Unmarshal failed: %v: %w", err, asynq.SkipRetry)
+	}
+
+	err := w.decisionService.ApplyRelease(ctx, p.ReleaseID)
+	if err == nil {
+		return nil
+	}
+
+	// The release was rolled back before its release time.
+	if errors.Is(err, decisions.ErrReleaseNotScheduled) || errors.Is(err, decisions.ErrReleaseNotFound) {
+		w.logger.Info().Str("ReleaseID", p.ReleaseID.String()).Msg("Decision release is no longer scheduled, skipping.")
+		return nil
+	}
+
+	// The decisions were applied, only the emails failed to queue.
+	if errors.Is(err, decisions.ErrQueueReleaseEmails) {
+		return fmt.Errorf("HandleApplyDecisionReleaseTask: %v: %w", err, asynq.SkipRetry)
+	}
+
+	w.logger.Err(err).Str("ReleaseID", p.ReleaseID.String()).Msg("Failed to apply decision release.")
+
+	if markErr := w.decisionService.MarkReleaseFailed(ctx, p.ReleaseID, err); markErr != nil {
+		w.logger.Err(markErr).Str("ReleaseID", p.ReleaseID.String()).Msg("Failed to mark decision release as failed.")
+	}
+
+	return fmt.Errorf("HandleApplyDecisionReleaseTask: %v: %w", err, asynq.SkipRetry)
+}
+
+func (w *DecisionWorker) HandleSendDecisionEmailsTask(ctx context.Context, t *asynq.Task) error {
+	var p tasks.DecisionReleasePayload
+	if err := json.Unmarshal(t.Payload(), &p); err != nil {
+		w.logger.Err(err).Msg("Failed to unmarshal payload.")
+		return fmt.Errorf("HandleSendDecisionEmailsTask: json.Unmarshal failed: %v: %w", err, asynq.SkipRetry)
+	}
+
+	err := w.decisionService.SendReleaseEmails(ctx, p.ReleaseID)
+	if err == nil {
+		return nil
+	}
+
+	// The release was rolled back during the email delay.
+	if errors.Is(err, decisions.ErrReleaseNotApplied) || errors.Is(err, decisions.ErrReleaseNotFound) {
+		w.logger.Info().Str("ReleaseID", p.ReleaseID.String()).Msg("Decision release is not applied, skipping its emails.")
+		return nil
+	}
+
+	w.logger.Err(err).Str("ReleaseID", p.ReleaseID.String()).Msg("Failed to queue decision emails, retrying.")
+
+	// Retries resume from the last saved batch.
+	return fmt.Errorf("HandleSendDecisionEmailsTask: %w", err)
+}
@@ -3,6 +3,7 @@ package workers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	"github.com/hibiken/asynq"
@@ -23,6 +24,20 @@ func NewEmailWorker(emailService *email.EmailService, logger zerolog.Logger) *Em
 	}
 }
 
+func (w *EmailWorker) HandleSendTextEmailTask(ctx context.Context, t *asynq.Task) error {
+	var p tasks.SendTextEmailPayload
+	if err := json.Unmarshal(t.Payload(), &p); err != nil {
+		w.logger.Err(err)
+		return fmt.Errorf("HandleSendTextEmailTask: json.Unmarshal failed: %v: %w", err, asynq.SkipRetry)
+	}
+
+	if err := w.emailService.SendTextEmail(ctx, p.To, p.Subject, p.Body, p.Options); err != nil {
+		w.logger.Err(err).Msg("Failed to send text email from worker")
+		return err
+	}
+	return nil
+}
+
 func (w *EmailWorker) HandleSendHtmlEmailTask(ctx context.Context, t *asynq.Task) error {
 	var p tasks.SendHtmlEmailPayload
 	if err := json.Unmarshal(t.Payload(), &p); err != nil {
@@ -30,9 +45,24 @@ func (w *EmailWorker) HandleSendHtmlEmailTask(ctx context.Context, t *asynq.Task
 		return fmt.Errorf("HandleSendHtmlEmailTask: json.Unmarshal failed: %v: %w", err, asynq.SkipRetry)
 	}
 
-	if err := w.emailService.SendHtmlEmail(p.To, p.Subject, p.TemplateData, p.TemplateFilePath); err != nil {
+	if err := w.emailService.SendHtmlEmail(ctx, p.DeliveryID, p.TemplateVersionID, p.To, p.Subject, p.TemplateData, p.TemplateFilePath, p.Options); err != nil {
 		w.logger.Err(err).Msg("Failed to send ConfirmationEmail from worker")
 		return err
 	}
 	return nil
 }
+
+// HandleError is called by the server whenever an email queue task fails. After the last
+// retry asynq archives the task, where admins can retry or discard it.
+func (w *EmailWorker) HandleError(ctx context.Context, t *asynq.Task, err error) {
+	retried, _ := asynq.GetRetryCount(ctx)
+	maxRetry, _ := asynq.GetMaxRetry(ctx)
+	taskID, _ := asynq.GetTaskID(ctx)
+
+	if retried < maxRetry && !errors.Is(err, asynq.SkipRetry) {
+		w.logger.Warn().Err(err).Str("TaskID", taskID).Str("Task Type", t.Type()).Int("Retried", retried).Int("MaxRetry", maxRetry).Msg("Email task failed, it will be retried")
+		return
+	}
+
+	w.logger.Error().Err(err).Str("TaskID", taskID).Str("Task Type", t.Type()).Int("Retried", retried).Msg("Email task failed for good and was archived")
+}
@@ -0,0 +1,33 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog"
+	"github.com/swamphacks/core/apps/api/internal/domains/teams"
+)
+
+// Team Worker
+// The team worker expires join requests team owners didn't answer in time.
+type TeamWorker struct {
+	teamService *teams.TeamService
+	logger      zerolog.Logger
+}
+
+func NewTeamWorker(teamService *teams.TeamService, logger zerolog.Logger) *TeamWorker {
+	return &TeamWorker{
+		teamService: teamService,
+		logger:      logger.With().Str("worker", "TeamWorker").Logger(),
+	}
+}
+
+func (w *TeamWorker) HandleExpireTeamJoinRequestsTask(ctx context.Context, t *asynq.Task) error {
+	if _, err := w.teamService.ExpireJoinRequests(ctx); err != nil {
+		// The next scheduled round expires whatever this one missed.
+		return fmt.Errorf("HandleExpireTeamJoinRequestsTask: %v: %w", err, asynq.SkipRetry)
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package workers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog"
+	"github.com/swamphacks/core/apps/api/internal/domains/application"
+	"github.com/swamphacks/core/apps/api/internal/tasks"
+)
+
+// Waitlist Worker
+// The waitlist worker runs the rolling waitlist. Every scheduled round
+// sends unconfirmed waitlist acceptances past their RSVP deadline back to
+// the waitlist and accepts the longest waiting applicants into the freed seats.
+type WaitlistWorker struct {
+	applicationService *application.ApplicationService
+	logger             zerolog.Logger
+}
+
+func NewWaitlistWorker(applicationService *application.ApplicationService, logger zerolog.Logger) *WaitlistWorker {
+	return &WaitlistWorker{
+		applicationService: applicationService,
+		logger:             logger.With().Str("worker", "WaitlistWorker").Logger(),
+	}
+}
+
+func (w *WaitlistWorker) HandleTransitionWaitlistTask(ctx context.Context, t *asynq.Task) error {
+	var p tasks.TransitionWaitlistPayload
+	if err := json.Unmarshal(t.Payload(), &p); err != nil {
+		w.logger.Err(err).Msg("Failed to unmarshal payload.")
+		return fmt.Errorf("HandleTransitionWaitlistTask: json.Unmarshal failed: %v: %w", err, asynq.SkipRetry)
+	}
+
+	err := w.applicationService.TransitionWaitlistedApplications(ctx, p.AcceptFromWaitlistCount, p.MaxAcceptedApplications)
+	if err == nil {
+		return nil
+	}
+
+	// The scheduler has been shut down, so there is nothing left to do.
+	if errors.Is(err, application.ErrEventAlreadyStarted) {
+		return nil
+	}
+
+	w.logger.Err(err).Msg("Something went wrong transitioning the waitlist.")
+
+	// The next scheduled round picks up where this one left off.
+	return fmt.Errorf("HandleTransitionWaitlistTask: %v: %w", err, asynq.SkipRetry)
+}
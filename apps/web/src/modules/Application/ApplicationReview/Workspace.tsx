@@ -24,6 +24,7 @@ import TablerArrowRight from "~icons/tabler/arrow-right";
 import TablerRefresh from "~icons/tabler/refresh";
 import { Button } from "@/components/ui/Button";
 import type { ApplicationFields } from "@/modules/Application/hooks/useApplication";
+import config from "@/config";
 
 interface ApplicationReviewWorkspaceProps {
   user: UserContext;
@@ -150,19 +151,24 @@ function ApplicationViewer({
   }
 
   const appFields = applicationReview.data.application;
-  const resume = applicationReview.data.resumeUrl;
+  const blind = applicationReview.data.blind;
+  const resume = resolveResumeUrl(applicationReview.data.resumeUrl);
 
   return (
     <div className="grid lg:grid-cols-2 gap-6 mb-8">
       <div className="space-y-4 mt-4">
-        <ApplicantInfo appFields={appFields} />
+        <ApplicantInfo appFields={appFields} blind={blind} />
         <Essays appFields={appFields} />
       </div>
 
       <div className="space-y-4 mt-4">
         <div className="p-2 rounded-md border border-input-border h-[63vh]">
           {resume === "" ? (
-            <p>No resume provided.</p>
+            <p>
+              {blind
+                ? "The resume is hidden during blind review until you rate this application."
+                : "No resume provided."}
+            </p>
           ) : (
             <object
               className="w-full h-full"
@@ -187,11 +193,20 @@ function ApplicationViewer({
   );
 }
 
+// In blind review the API serves resumes itself and returns a path relative to the API.
+function resolveResumeUrl(resumeUrl: string) {
+  if (resumeUrl === "" || /^https?:\/\//.test(resumeUrl)) {
+    return resumeUrl;
+  }
+  return `${config.BASE_API_URL.replace(/\/$/, "")}/${resumeUrl}`;
+}
+
 interface ApplicantInfoProps {
   appFields: ApplicationFields;
+  blind: boolean;
 }
 
-function ApplicantInfo({ appFields }: ApplicantInfoProps) {
+function ApplicantInfo({ appFields, blind }: ApplicantInfoProps) {
   const getHackathonExperienceText = (experience: string) => {
     switch (experience) {
       case "first_time":
@@ -230,7 +245,9 @@ function ApplicantInfo({ appFields }: ApplicantInfoProps) {
           <div>
             <div className="text-text-secondary">Name</div>
             <div className="font-medium">
-              {appFields.firstName + " " + appFields.lastName}
+              {blind && !appFields.firstName
+                ? "Hidden for blind review"
+                : appFields.firstName + " " + appFields.lastName}
             </div>
           </div>
 
@@ -25,7 +25,12 @@ export async function fetchApplicationReview(
     .get<ParsedApplicationReview>(`application/review/${reviewId}`)
     .json();
 
-  const parsedApplication = ApplicationFieldsSchema.safeParse(
+  // Blind review strips identifying answers, so required fields may be missing.
+  const schema = result.blind
+    ? ApplicationFieldsSchema.partial()
+    : ApplicationFieldsSchema;
+
+  const parsedApplication = schema.safeParse(
     JSON.parse(atob(result.application as unknown as string)),
   );
 
@@ -36,7 +41,7 @@ export async function fetchApplicationReview(
 
   return {
     ...result,
-    application: parsedApplication.data,
+    application: parsedApplication.data as ApplicationFields,
   };
 }
 
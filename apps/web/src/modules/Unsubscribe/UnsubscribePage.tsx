@@ -0,0 +1,103 @@
+import { Heading, Text } from "react-aria-components";
+import { Button } from "@/components/ui/Button";
+import { Switch } from "@/components/ui/Switch";
+import { showToast } from "@/lib/toast/toast";
+import {
+  type EmailCategory,
+  useTokenPreferences,
+} from "./hooks/useTokenPreferences";
+
+interface UnsubscribePageProps {
+  token: string;
+}
+
+export function UnsubscribePage({ token }: UnsubscribePageProps) {
+  const { preferences, updatePreferences, unsubscribe } =
+    useTokenPreferences(token);
+
+  const showError = () =>
+    showToast({
+      title: "Something went wrong :(",
+      message: "Unable to save changes.",
+      type: "error",
+    });
+
+  const handleUnsubscribe = async () => {
+    try {
+      await unsubscribe.mutateAsync();
+    } catch {
+      showError();
+    }
+  };
+
+  const handleToggle = async (category: string, subscribed: boolean) => {
+    try {
+      await updatePreferences.mutateAsync({
+        categories: [{ category: category as EmailCategory, subscribed }],
+      });
+    } catch {
+      showError();
+    }
+  };
+
+  if (!token || preferences.isError) {
+    return (
+      <div className="h-full flex items-center justify-center pb-15">
+        <p>This unsubscribe link is invalid.</p>
+      </div>
+    );
+  }
+
+  if (preferences.isPending) {
+    return (
+      <div className="h-full flex items-center justify-center pb-15">
+        <p>Loading your email preferences...</p>
+      </div>
+    );
+  }
+
+  const isSaving = updatePreferences.isPending || unsubscribe.isPending;
+
+  return (
+    <div className="max-w-xl mx-auto p-6 font-figtree">
+      <Heading className="text-3xl mb-2">Email Preferences</Heading>
+      <Text elementType="p" className="mb-6 text-text-secondary">
+        Choose which emails SwampHacks sends to {preferences.data.email}.
+      </Text>
+
+      <div className="flex flex-col gap-4">
+        {preferences.data.categories.map((preference) => (
+          <div key={preference.category}>
+            <Switch
+              isSelected={preference.subscribed}
+              isDisabled={isSaving}
+              onChange={(selected) =>
+                handleToggle(preference.category, selected)
+              }
+            >
+              {preference.name}
+            </Switch>
+            <Text elementType="p" className="text-sm text-text-secondary">
+              {preference.description}
+            </Text>
+          </div>
+        ))}
+      </div>
+
+      {unsubscribe.isSuccess ? (
+        <Text elementType="p" className="mt-6 text-text-main">
+          You have been unsubscribed.
+        </Text>
+      ) : (
+        <Button
+          variant="danger"
+          className="mt-6"
+          isDisabled={isSaving}
+          onPress={handleUnsubscribe}
+        >
+          Unsubscribe
+        </Button>
+      )}
+    </div>
+  );
+}
@@ -0,0 +1,74 @@
+import { api } from "@/lib/ky";
+import type { operations } from "@/lib/openapi/schema";
+import { useMutation, useQuery, useQueryClient } from "@tanstack/react-query";
+
+export type TokenPreferencesResponse =
+  operations["get-email-preferences-by-token"]["responses"]["200"]["content"]["application/json"];
+
+type UpdateTokenPreferencesRequest =
+  operations["update-email-preferences-by-token"]["requestBody"]["content"]["application/json"];
+
+export type EmailCategory =
+  UpdateTokenPreferencesRequest["categories"][number]["category"];
+
+export const tokenPreferencesQueryKey = (token: string) => [
+  "email-preferences",
+  token,
+];
+
+export async function fetchTokenPreferences(
+  token: string,
+): Promise<TokenPreferencesResponse> {
+  return api
+    .get<TokenPreferencesResponse>("email/unsubscribe", {
+      searchParams: { token },
+      retry: 0,
+    })
+    .json();
+}
+
+async function updateTokenPreferencesFn(
+  token: string,
+  req: UpdateTokenPreferencesRequest,
+): Promise<TokenPreferencesResponse> {
+  return api
+    .put<TokenPreferencesResponse>("email/unsubscribe", {
+      searchParams: { token },
+      json: req,
+    })
+    .json();
+}
+
+// Unsubscribes from the category the link was sent for, the same as a mail client's
+// one-click unsubscribe.
+async function unsubscribeFn(token: string) {
+  await api.post("email/unsubscribe", { searchParams: { token } });
+}
+
+export function useTokenPreferences(token: string) {
+  const queryClient = useQueryClient();
+  const queryKey = tokenPreferencesQueryKey(token);
+
+  const preferences = useQuery({
+    queryKey,
+    queryFn: () => fetchTokenPreferences(token),
+    retry: 1,
+  });
+
+  const updatePreferences = useMutation({
+    mutationFn: (req: UpdateTokenPreferencesRequest) =>
+      updateTokenPreferencesFn(token, req),
+    onSuccess: (data) => {
+      queryClient.setQueryData(queryKey, data);
+    },
+  });
+
+  const unsubscribe = useMutation({
+    mutationFn: () => unsubscribeFn(token),
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey });
+    },
+  });
+
+  return { preferences, updatePreferences, unsubscribe };
+}
@@ -0,0 +1,16 @@
+import { createFileRoute } from "@tanstack/react-router";
+import { z } from "zod";
+import { UnsubscribePage } from "@/modules/Unsubscribe/UnsubscribePage";
+
+// Unsubscribe links in emails open this page, it needs no login.
+export const Route = createFileRoute("/unsubscribe")({
+  validateSearch: z.object({
+    token: z.string().optional().catch(""),
+  }),
+  component: RouteComponent,
+});
+
+function RouteComponent() {
+  const { token } = Route.useSearch();
+  return <UnsubscribePage token={token ?? ""} />;
+}